@@ -0,0 +1,43 @@
+# AD Object Data Source Example
+
+terraform {
+  required_providers {
+    ad = {
+      source = "isometry/ad"
+    }
+  }
+}
+
+provider "ad" {
+  domain   = "example.com"
+  username = "admin@example.com"
+  password = "secure_password"
+}
+
+# Lookup a printer queue by Distinguished Name
+data "ad_object" "printer" {
+  dn = "CN=PRN-LON-01,OU=Printers,DC=example,DC=com"
+
+  attributes = [
+    "location",
+    "portName",
+    "printColor",
+  ]
+}
+
+# Lookup an object by GUID
+data "ad_object" "by_guid" {
+  id = "12345678-1234-5678-9012-123456789012"
+
+  attributes = ["description", "thumbnailPhoto"]
+}
+
+# Output object information
+output "printer_details" {
+  value = {
+    object_class = data.ad_object.printer.object_class
+    container    = data.ad_object.printer.container
+    location     = try(data.ad_object.printer.values["location"][0], null)
+    ports        = try(data.ad_object.printer.values["portName"], [])
+  }
+}
@@ -0,0 +1,54 @@
+# AD Search Data Source Example
+
+terraform {
+  required_providers {
+    ad = {
+      source = "isometry/ad"
+    }
+  }
+}
+
+provider "ad" {
+  domain   = "example.com"
+  username = "admin@example.com"
+  password = "secure_password"
+}
+
+# Find contractor accounts whose password never expires
+data "ad_search" "contractors" {
+  base_dn = "OU=Users,DC=example,DC=com"
+  filter  = "(&(objectClass=user)(employeeType=contractor)(userAccountControl:1.2.840.113556.1.4.803:=65536))"
+
+  attributes = ["sAMAccountName", "objectSid", "manager"]
+}
+
+# Find all printer queues, without a size limit
+data "ad_search" "printers" {
+  filter     = "(objectClass=printQueue)"
+  attributes = ["printerName", "location"]
+  size_limit = 0
+}
+
+# Read a single object with a base search
+data "ad_search" "domain" {
+  base_dn    = "DC=example,DC=com"
+  scope      = "base"
+  filter     = "(objectClass=*)"
+  attributes = ["msDS-Behavior-Version", "ms-DS-MachineAccountQuota"]
+}
+
+# Output search results
+output "contractors" {
+  value = {
+    for entry in data.ad_search.contractors.entries :
+    entry.dn => {
+      username = entry.values["sAMAccountName"][0]
+      sid      = entry.values["objectSid"][0]
+      manager  = try(entry.values["manager"][0], null)
+    }
+  }
+}
+
+output "printer_count" {
+  value = data.ad_search.printers.entry_count
+}
@@ -116,6 +116,16 @@ data "ad_users" "department_users" {
   }
 }
 
+# Forest-wide search via the Global Catalog (partial attribute set only)
+data "ad_users" "forest_service_accounts" {
+  global_catalog = true
+
+  filter = {
+    name_prefix = "svc-"
+    enabled     = true
+  }
+}
+
 # Create department-specific groups based on user search results
 resource "ad_group" "department_groups" {
   for_each = data.ad_users.department_users
@@ -0,0 +1,17 @@
+# Current password of a gMSA, read over LDAPS
+ephemeral "ad_gmsa_password" "web" {
+  account = "svc-web"
+}
+
+# Hand the NT hash to a Linux service that authenticates as the gMSA
+resource "vault_kv_secret_v2" "svc_web" {
+  mount = "secret"
+  name  = "gmsa/svc-web"
+
+  data_json_wo = jsonencode({
+    principal = ephemeral.ad_gmsa_password.web.sam_account_name
+    nt_hash   = ephemeral.ad_gmsa_password.web.nt_hash
+    expires   = ephemeral.ad_gmsa_password.web.expiration_time
+  })
+  data_json_wo_version = 1
+}
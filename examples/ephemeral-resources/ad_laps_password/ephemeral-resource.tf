@@ -0,0 +1,21 @@
+# Local administrator password of a LAPS-managed workstation
+ephemeral "ad_laps_password" "ws01" {
+  computer = "WS01"
+}
+
+# Computers can also be identified by DNS host name, DN, GUID or SID
+ephemeral "ad_laps_password" "server" {
+  computer = "srv01.example.com"
+}
+
+# Pass the password to a write-only argument of another provider
+resource "vault_kv_secret_v2" "ws01_local_admin" {
+  mount = "secret"
+  name  = "laps/ws01"
+
+  data_json_wo = jsonencode({
+    username = ephemeral.ad_laps_password.ws01.account_name
+    password = ephemeral.ad_laps_password.ws01.password
+  })
+  data_json_wo_version = 1
+}
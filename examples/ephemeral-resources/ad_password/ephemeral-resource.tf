@@ -0,0 +1,20 @@
+# Password satisfying the policy of the OU new users are created in
+ephemeral "ad_password" "new_user" {
+  target = "OU=Users,DC=example,DC=com"
+}
+
+resource "ad_user" "jdoe" {
+  name             = "John Doe"
+  sam_account_name = "jdoe"
+  principal_name   = "jdoe@example.com"
+  container        = "OU=Users,DC=example,DC=com"
+
+  password         = ephemeral.ad_password.new_user.result
+  password_version = 1
+}
+
+# Password satisfying the fine-grained policy (PSO) of an existing user
+ephemeral "ad_password" "admin" {
+  target = "adm-jdoe@example.com"
+  length = 32
+}
@@ -29,6 +29,10 @@ resource "ad_group" "distribution_group" {
   scope            = "universal"
   category         = "distribution"
   description      = "Marketing team email distribution list"
+
+  extra_attributes = {
+    mail = ["marketing@example.com"]
+  }
 }
 
 # Domain local group for resource access
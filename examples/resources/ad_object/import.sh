@@ -0,0 +1,11 @@
+#!/bin/bash
+
+# Import examples for ad_object resource
+# Objects can be imported by GUID or Distinguished Name. Only the attributes
+# listed in the configuration are managed, so attributes are not imported.
+
+# Import by Distinguished Name
+terraform import ad_object.printer "CN=PRN-LON-01,OU=Printers,DC=example,DC=com"
+
+# Import by GUID (ObjectGUID)
+terraform import ad_object.contact "12345678-1234-5678-9012-123456789012"
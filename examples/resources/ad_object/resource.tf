@@ -0,0 +1,52 @@
+# AD Object Examples
+
+terraform {
+  required_providers {
+    ad = {
+      source = "isometry/ad"
+    }
+  }
+}
+
+provider "ad" {
+  domain   = "example.com"
+  username = "admin@example.com"
+  password = "secure_password"
+}
+
+resource "ad_ou" "printers" {
+  name = "Printers"
+  path = "dc=example,dc=com"
+}
+
+# Published printer
+resource "ad_object" "printer" {
+  object_class = "printQueue"
+  name         = "PRN-LON-01"
+  container    = ad_ou.printers.dn
+
+  attributes = {
+    uNCName         = ["\\\\print01.example.com\\PRN-LON-01"]
+    serverName      = ["print01.example.com"]
+    shortServerName = ["print01"]
+    printerName     = ["PRN-LON-01"]
+    versionNumber   = ["4"]
+    location        = ["London/Floor 2"]
+    printBinNames   = ["Tray 1", "Tray 2"]
+  }
+}
+
+# Mail contact with a binary attribute (base64-encoded)
+resource "ad_object" "contact" {
+  object_class = "contact"
+  name         = "Jane Supplier"
+  container    = "ou=Contacts,dc=example,dc=com"
+
+  attributes = {
+    displayName    = ["Jane Supplier"]
+    mail           = ["jane@supplier.example"]
+    managedBy      = ["CN=Procurement,OU=Groups,DC=example,DC=com"]
+    thumbnailPhoto = [filebase64("${path.module}/jane.jpg")]
+  }
+}
+
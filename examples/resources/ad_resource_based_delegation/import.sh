@@ -0,0 +1,8 @@
+#!/bin/bash
+
+# Import examples for ad_resource_based_delegation resource
+# Delegation is imported by the objectGUID of the account delegated to. The
+# principals are imported as SIDs.
+
+# Import by GUID (ObjectGUID)
+terraform import ad_resource_based_delegation.sql "12345678-1234-5678-9012-123456789012"
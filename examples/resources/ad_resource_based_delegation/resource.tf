@@ -0,0 +1,34 @@
+# AD Resource-Based Constrained Delegation Examples
+
+terraform {
+  required_providers {
+    ad = {
+      source = "isometry/ad"
+    }
+  }
+}
+
+provider "ad" {
+  domain   = "example.com"
+  username = "admin@example.com"
+  password = "secure_password"
+}
+
+# Service account running the SQL Server instance that is delegated to
+resource "ad_user" "sql_service" {
+  name           = "svc-sql"
+  principal_name = "svc-sql@example.com"
+  container      = "OU=Service Accounts,DC=example,DC=com"
+
+  service_principal_names = ["MSSQLSvc/db.example.com:1433"]
+}
+
+# Allow the web front end to access SQL Server on behalf of its users
+resource "ad_resource_based_delegation" "sql" {
+  account_id = ad_user.sql_service.id
+
+  principals = [
+    "WEB01$",                                           # Computer account by SAM account name
+    "CN=svc-web,OU=Service Accounts,DC=example,DC=com", # Service account by DN
+  ]
+}
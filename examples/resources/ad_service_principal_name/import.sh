@@ -0,0 +1,8 @@
+#!/bin/bash
+
+# Import examples for ad_service_principal_name resource
+# SPNs are imported by the objectGUID of the account and the SPN, separated
+# by a slash.
+
+# Import by <account_id>/<spn>
+terraform import ad_service_principal_name.sql "12345678-1234-5678-9012-123456789012/MSSQLSvc/db.example.com:1433"
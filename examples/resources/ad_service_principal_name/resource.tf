@@ -0,0 +1,34 @@
+# AD Service Principal Name Examples
+
+terraform {
+  required_providers {
+    ad = {
+      source = "isometry/ad"
+    }
+  }
+}
+
+provider "ad" {
+  domain   = "example.com"
+  username = "admin@example.com"
+  password = "secure_password"
+}
+
+# Service account whose SPNs are registered by the application configuration
+data "ad_user" "sql_service" {
+  sam_account_name = "svc-sql"
+}
+
+# SQL Server default instance
+resource "ad_service_principal_name" "sql" {
+  account_id = data.ad_user.sql_service.id
+  spn        = "MSSQLSvc/db.example.com:1433"
+}
+
+# One SPN per web host alias
+resource "ad_service_principal_name" "web" {
+  for_each = toset(["web.example.com", "www.example.com"])
+
+  account_id = data.ad_user.sql_service.id
+  spn        = "HTTP/${each.value}"
+}
@@ -63,3 +63,90 @@ resource "ad_user" "service_account" {
   enabled                = true
   password_never_expires = true
 }
+
+# Contractor account with an end date
+resource "ad_user" "contractor" {
+  name            = "c.smith"
+  principal_name  = "c.smith@example.com"
+  container       = "OU=Contractors,OU=Users,DC=example,DC=com"
+  account_expires = "2027-06-30T23:59:59Z"
+
+  display_name = "Chris Smith"
+  company      = "Example Contracting Ltd"
+
+  extra_attributes = {
+    employeeType        = ["Contractor"]
+    extensionAttribute1 = ["PO-2027-0042"]
+  }
+}
+
+# Shift worker restricted to weekday hours on two kiosks
+resource "ad_user" "shift_worker" {
+  name           = "kiosk.operator"
+  principal_name = "kiosk.operator@example.com"
+  container      = "OU=Kiosk,OU=Users,DC=example,DC=com"
+
+  logon_hours = {
+    utc_offset = "+01:00"
+    monday     = ["06-14"]
+    tuesday    = ["06-14"]
+    wednesday  = ["06-14"]
+    thursday   = ["06-14"]
+    friday     = ["06-14"]
+  }
+  logon_workstations = ["KIOSK01", "KIOSK02"]
+}
+
+# Service account with SPNs, checked for forest-wide duplicates at plan time
+resource "ad_user" "sql_service" {
+  name           = "svc-sql"
+  principal_name = "svc-sql@example.com"
+  container      = "OU=Service Accounts,DC=example,DC=com"
+
+  service_principal_names = [
+    "MSSQLSvc/db.example.com",
+    "MSSQLSvc/db.example.com:1433",
+  ]
+}
+
+# Service account with constrained delegation and protocol transition
+resource "ad_user" "web_service" {
+  name           = "svc-web"
+  principal_name = "svc-web@example.com"
+  container      = "OU=Service Accounts,DC=example,DC=com"
+
+  service_principal_names        = ["HTTP/web.example.com"]
+  allowed_to_delegate_to         = ["MSSQLSvc/db.example.com:1433"]
+  trusted_to_auth_for_delegation = true
+}
+
+# Shared kiosk account that cannot change its password
+resource "ad_user" "kiosk" {
+  name           = "kiosk.shared"
+  principal_name = "kiosk.shared@example.com"
+  container      = "OU=Kiosk,OU=Users,DC=example,DC=com"
+
+  cannot_change_password = true
+  password_never_expires = true
+}
+
+# Administrator account that cannot be delegated and only uses AES for Kerberos,
+# with plans failing if it is privileged but drifts from the tier 0 baseline
+resource "ad_user" "admin" {
+  name           = "adm.jsmith"
+  principal_name = "adm.jsmith@example.com"
+  container      = "OU=Admins,DC=example,DC=com"
+
+  account_not_delegated     = true
+  kerberos_encryption_types = ["aes128", "aes256"]
+  tier0_hardening           = "error"
+}
+
+# Linux user whose primary group is not Domain Users
+resource "ad_user" "linux" {
+  name           = "jdoe.linux"
+  principal_name = "jdoe.linux@example.com"
+  container      = "OU=Linux,OU=Users,DC=example,DC=com"
+
+  primary_group = "linux-users"
+}
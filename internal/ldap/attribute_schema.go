@@ -0,0 +1,98 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// systemFlagAttrIsConstructed marks an attributeSchema whose values are
+// computed by the directory rather than stored (FLAG_ATTR_IS_CONSTRUCTED).
+const systemFlagAttrIsConstructed = 0x4
+
+// Attribute syntaxes (attributeSyntax OIDs) that need special handling.
+const (
+	syntaxDN                 = "2.5.5.1"
+	syntaxOctetString        = "2.5.5.10"
+	syntaxSecurityDescriptor = "2.5.5.15"
+	syntaxSID                = "2.5.5.17"
+)
+
+// AttributeSchema describes an attribute as defined by its attributeSchema
+// object in the schema naming context.
+type AttributeSchema struct {
+	LDAPDisplayName string // Canonical attribute name
+	Syntax          string // attributeSyntax OID
+	SystemOnly      bool   // Only the directory itself may write the attribute
+	SingleValued    bool   // The attribute holds at most one value
+	Constructed     bool   // The attribute is computed and cannot be written
+}
+
+// IsBinary reports whether the attribute holds binary values.
+func (s AttributeSchema) IsBinary() bool {
+	switch s.Syntax {
+	case syntaxOctetString, syntaxSecurityDescriptor, syntaxSID:
+		return true
+	}
+	return false
+}
+
+// IsDN reports whether the attribute holds distinguished names.
+func (s AttributeSchema) IsDN() bool {
+	return s.Syntax == syntaxDN
+}
+
+// LookupAttributeSchema reads the attributeSchema objects of the named
+// attributes. The result is keyed by lowercased attribute name; names that
+// are not defined in the schema are absent.
+func LookupAttributeSchema(ctx context.Context, client Client, names []string) (map[string]AttributeSchema, error) {
+	schemas := make(map[string]AttributeSchema, len(names))
+	if len(names) == 0 {
+		return schemas, nil
+	}
+
+	rootDSE, err := client.GetRootDSE(ctx)
+	if err != nil {
+		return nil, WrapError("get_root_dse", err)
+	}
+	if rootDSE.SchemaNamingContext == "" {
+		return nil, fmt.Errorf("RootDSE has no schemaNamingContext")
+	}
+
+	var filter strings.Builder
+	filter.WriteString("(&(objectClass=attributeSchema)(|")
+	for _, name := range names {
+		fmt.Fprintf(&filter, "(lDAPDisplayName=%s)", ldap.EscapeFilter(name))
+	}
+	filter.WriteString("))")
+
+	result, err := client.SearchWithPaging(ctx, &SearchRequest{
+		BaseDN:     rootDSE.SchemaNamingContext,
+		Scope:      ScopeSingleLevel,
+		Filter:     filter.String(),
+		Attributes: []string{"lDAPDisplayName", "attributeSyntax", "systemOnly", "isSingleValued", "systemFlags"},
+	})
+	if err != nil {
+		return nil, WrapError("search_attribute_schema", err)
+	}
+
+	for _, entry := range result.Entries {
+		name := entry.GetAttributeValue("lDAPDisplayName")
+		if name == "" {
+			continue
+		}
+		systemFlags, _ := strconv.ParseInt(entry.GetAttributeValue("systemFlags"), 10, 32)
+		schemas[strings.ToLower(name)] = AttributeSchema{
+			LDAPDisplayName: name,
+			Syntax:          entry.GetAttributeValue("attributeSyntax"),
+			SystemOnly:      strings.EqualFold(entry.GetAttributeValue("systemOnly"), "TRUE"),
+			SingleValued:    strings.EqualFold(entry.GetAttributeValue("isSingleValued"), "TRUE"),
+			Constructed:     systemFlags&systemFlagAttrIsConstructed != 0,
+		}
+	}
+
+	return schemas, nil
+}
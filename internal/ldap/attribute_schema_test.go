@@ -0,0 +1,59 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupAttributeSchema(t *testing.T) {
+	const schemaDN = "CN=Schema,CN=Configuration,DC=test,DC=local"
+
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{SchemaNamingContext: schemaDN}, nil)
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == schemaDN &&
+			req.Filter == "(&(objectClass=attributeSchema)(|(lDAPDisplayName=employeetype)(lDAPDisplayName=objectSid)(lDAPDisplayName=tokenGroups)(lDAPDisplayName=noSuchAttribute)))"
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("CN=Employee-Type,"+schemaDN, map[string][]string{
+			"lDAPDisplayName": {"employeeType"},
+			"systemOnly":      {"FALSE"},
+			"isSingleValued":  {"TRUE"},
+			"systemFlags":     {"0"},
+		}),
+		ldap.NewEntry("CN=Object-Sid,"+schemaDN, map[string][]string{
+			"lDAPDisplayName": {"objectSid"},
+			"systemOnly":      {"TRUE"},
+			"isSingleValued":  {"TRUE"},
+			"systemFlags":     {"18"},
+		}),
+		ldap.NewEntry("CN=Token-Groups,"+schemaDN, map[string][]string{
+			"lDAPDisplayName": {"tokenGroups"},
+			"systemOnly":      {"FALSE"},
+			"isSingleValued":  {"FALSE"},
+			"systemFlags":     {"134217748"},
+		}),
+	}}, nil)
+
+	schemas, err := LookupAttributeSchema(t.Context(), client, []string{"employeetype", "objectSid", "tokenGroups", "noSuchAttribute"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]AttributeSchema{
+		"employeetype": {LDAPDisplayName: "employeeType", SingleValued: true},
+		"objectsid":    {LDAPDisplayName: "objectSid", SystemOnly: true, SingleValued: true},
+		"tokengroups":  {LDAPDisplayName: "tokenGroups", Constructed: true},
+	}, schemas)
+	client.AssertExpectations(t)
+}
+
+func TestLookupAttributeSchema_NoNames(t *testing.T) {
+	client := &MockClient{}
+
+	schemas, err := LookupAttributeSchema(t.Context(), client, nil)
+	require.NoError(t, err)
+	assert.Empty(t, schemas)
+	client.AssertNotCalled(t, "GetRootDSE", mock.Anything)
+}
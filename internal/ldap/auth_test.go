@@ -43,6 +43,33 @@ func TestConnectionConfig_GetAuthMethod(t *testing.T) {
 			},
 			expected: AuthMethodExternal,
 		},
+		{
+			name: "ntlm with domain and password",
+			config: &ConnectionConfig{
+				Username:   "testuser",
+				Password:   "testpass",
+				NTLMDomain: "EXAMPLE",
+			},
+			expected: AuthMethodNTLM,
+		},
+		{
+			name: "ntlm with hash",
+			config: &ConnectionConfig{
+				Username: "testuser",
+				NTLMHash: "8846f7eaee8fb117ad06bdd830b7586c",
+			},
+			expected: AuthMethodNTLM,
+		},
+		{
+			name: "kerberos takes precedence over ntlm",
+			config: &ConnectionConfig{
+				Username:      "testuser",
+				Password:      "testpass",
+				NTLMDomain:    "EXAMPLE",
+				KerberosRealm: "EXAMPLE.COM",
+			},
+			expected: AuthMethodKerberos,
+		},
 		{
 			name: "username only defaults to simple bind",
 			config: &ConnectionConfig{
@@ -105,6 +132,14 @@ func TestConnectionConfig_HasAuthentication(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "has ntlm hash authentication",
+			config: &ConnectionConfig{
+				Username: "testuser",
+				NTLMHash: "8846f7eaee8fb117ad06bdd830b7586c",
+			},
+			expected: true,
+		},
 		{
 			name: "username without password",
 			config: &ConnectionConfig{
@@ -136,6 +171,67 @@ func TestConnectionConfig_HasAuthentication(t *testing.T) {
 	}
 }
 
+func TestConnectionConfig_IsEncrypted(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *ConnectionConfig
+		expected bool
+	}{
+		{
+			name:     "TLS",
+			config:   &ConnectionConfig{UseTLS: true},
+			expected: true,
+		},
+		{
+			name:     "TLS skipped",
+			config:   &ConnectionConfig{UseTLS: true, SkipTLS: true, LDAPURLs: []string{"ldap://dc1.example.com"}},
+			expected: false,
+		},
+		{
+			name:     "LDAPS URLs without use_tls",
+			config:   &ConnectionConfig{LDAPURLs: []string{"LDAPS://dc1.example.com", "ldaps://dc2.example.com"}},
+			expected: true,
+		},
+		{
+			name:     "mixed URLs without use_tls",
+			config:   &ConnectionConfig{LDAPURLs: []string{"ldaps://dc1.example.com", "ldap://dc2.example.com"}},
+			expected: false,
+		},
+		{
+			name: "Kerberos with seal layer",
+			config: &ConnectionConfig{
+				Username:          "testuser",
+				KerberosRealm:     "EXAMPLE.COM",
+				SASLSecurityLayer: SASLSecurityLayerSeal,
+			},
+			expected: true,
+		},
+		{
+			name: "Kerberos with sign layer",
+			config: &ConnectionConfig{
+				Username:          "testuser",
+				KerberosRealm:     "EXAMPLE.COM",
+				SASLSecurityLayer: SASLSecurityLayerSign,
+			},
+			expected: false,
+		},
+		{
+			name:     "seal layer without Kerberos",
+			config:   &ConnectionConfig{Username: "testuser", Password: "testpass", SASLSecurityLayer: SASLSecurityLayerSeal},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.config.IsEncrypted()
+			if result != tt.expected {
+				t.Errorf("IsEncrypted() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestAuthMethod_String(t *testing.T) {
 	tests := []struct {
 		method   AuthMethod
@@ -144,6 +240,7 @@ func TestAuthMethod_String(t *testing.T) {
 		{AuthMethodSimpleBind, "simple"},
 		{AuthMethodKerberos, "kerberos"},
 		{AuthMethodExternal, "external"},
+		{AuthMethodNTLM, "ntlm"},
 		{AuthMethod(999), "unknown"}, // Invalid method
 	}
 
@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// bindState is the runtime state a pool authenticates its connections with:
+// credentials refreshed from a credential command or password file, the
+// Kerberos ticket cache and the TLS material read from files. Each pool owns
+// its own, so the ConnectionConfig shared by the main and Global Catalog
+// pools is only read once the pools exist.
+type bindState struct {
+	config *ConnectionConfig
+
+	credentials *credentialRefresher // nil unless CredentialCommand or PasswordFile is set
+	tickets     *kerberosTicketCache // nil unless Kerberos is used
+	tlsFiles    *tlsFiles            // nil without a TLS configuration
+}
+
+// newBindState reads the password file and TLS files configured in config
+// and prepares the Kerberos ticket cache.
+func newBindState(ctx context.Context, config *ConnectionConfig) (*bindState, error) {
+	s := &bindState{config: config}
+
+	if len(config.CredentialCommand) > 0 || config.PasswordFile != "" {
+		s.credentials = newCredentialRefresher(config)
+	}
+	if err := s.reloadPasswordFile(ctx); err != nil {
+		return nil, err
+	}
+	if config.GetAuthMethod() == AuthMethodKerberos {
+		s.tickets = newKerberosTicketCache(config)
+		// Log in with rotated credentials once the old tickets are discarded
+		s.tickets.config = s.bindConfig
+	}
+
+	if config.TLSConfig != nil {
+		files, err := newTLSFiles(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build certificate pool: %w", err)
+		}
+		s.tlsFiles = files
+		tflog.SubsystemDebug(ctx, "ldap", "Certificate pool configured", map[string]any{
+			"has_custom_ca":   config.TLSCACertFile != "" || config.TLSCACert != "",
+			"has_client_cert": files.hasClientCert(),
+		})
+	}
+	return s, nil
+}
+
+// kerberosTicketExpiry returns when the TGT held for binds expires, or the
+// zero time when none is held.
+func (s *bindState) kerberosTicketExpiry() time.Time {
+	if s == nil || s.tickets == nil {
+		return time.Time{}
+	}
+	return s.tickets.expiry()
+}
+
+// bindConfig returns the configuration to bind with: the current credentials
+// when they can be refreshed, and otherwise the pool's configuration. It is
+// shared and must not be modified.
+func (s *bindState) bindConfig() *ConnectionConfig {
+	if s.credentials == nil {
+		return s.config
+	}
+	return s.credentials.snapshot()
+}
+
+// credentialsForBind reloads a changed password file, runs the credential
+// command again when its credentials are older than the refresh interval,
+// and returns the configuration to bind with.
+func (s *bindState) credentialsForBind(ctx context.Context) *ConnectionConfig {
+	if err := s.reloadPasswordFile(ctx); err != nil {
+		tflog.SubsystemWarn(ctx, "ldap", "Failed to reload password file, keeping current password", map[string]any{
+			"error": err.Error(),
+		})
+	}
+	if s.credentialsStale() {
+		s.refreshCredentials(ctx)
+	}
+	return s.bindConfig()
+}
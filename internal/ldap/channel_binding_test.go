@@ -0,0 +1,212 @@
+package ldap
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSelfSignedCert returns a self-signed certificate for dc1.example.com
+// signed with the given key and algorithm.
+func newSelfSignedCert(t *testing.T, key crypto.Signer, alg x509.SignatureAlgorithm) tls.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:       big.NewInt(1),
+		Subject:            pkix.Name{CommonName: "dc1.example.com"},
+		DNSNames:           []string{"dc1.example.com"},
+		IPAddresses:        []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:          time.Now().Add(-time.Hour),
+		NotAfter:           time.Now().Add(time.Hour),
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		SignatureAlgorithm: alg,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func newECDSACert(t *testing.T, curve elliptic.Curve, alg x509.SignatureAlgorithm) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+	return newSelfSignedCert(t, key, alg)
+}
+
+// startTestLDAPServer accepts connections, completing the TLS handshake when
+// cert is non-nil, and holds them open until the test ends.
+func startTestLDAPServer(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var conns []net.Conn
+	t.Cleanup(func() {
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			if cert != nil {
+				tlsConn := tls.Server(conn, &tls.Config{Certificates: []tls.Certificate{*cert}})
+				go func() { _ = tlsConn.Handshake() }()
+			}
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestTLSServerEndPoint(t *testing.T) {
+	t.Run("SHA-256 signature", func(t *testing.T) {
+		cert := newECDSACert(t, elliptic.P256(), x509.ECDSAWithSHA256)
+		got, err := tlsServerEndPoint(cert.Leaf)
+		require.NoError(t, err)
+
+		hash := crypto.SHA256.New()
+		hash.Write(cert.Leaf.Raw)
+		assert.Equal(t, append([]byte("tls-server-end-point:"), hash.Sum(nil)...), got)
+	})
+
+	t.Run("SHA-384 signature", func(t *testing.T) {
+		cert := newECDSACert(t, elliptic.P384(), x509.ECDSAWithSHA384)
+		got, err := tlsServerEndPoint(cert.Leaf)
+		require.NoError(t, err)
+		assert.Len(t, got, len("tls-server-end-point:")+48)
+	})
+
+	t.Run("undefined for Ed25519", func(t *testing.T) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		cert := newSelfSignedCert(t, key, x509.PureEd25519)
+		_, err = tlsServerEndPoint(cert.Leaf)
+		assert.Error(t, err)
+	})
+}
+
+func TestChannelBindingHash(t *testing.T) {
+	data := []byte("tls-server-end-point:abc")
+
+	bindings := make([]byte, 20+len(data))
+	binary.LittleEndian.PutUint32(bindings[16:], uint32(len(data)))
+	copy(bindings[20:], data)
+	sum := md5.Sum(bindings)
+
+	assert.Equal(t, sum[:], channelBindingHash(data))
+}
+
+func TestConnectionChannelBinding(t *testing.T) {
+	cert := newECDSACert(t, elliptic.P256(), x509.ECDSAWithSHA256)
+	expected, err := tlsServerEndPoint(cert.Leaf)
+	require.NoError(t, err)
+
+	tlsAddr := startTestLDAPServer(t, &cert)
+	plainAddr := startTestLDAPServer(t, nil)
+
+	dialTLS := func(t *testing.T) *ldap.Conn {
+		conn, err := ldap.DialURL("ldaps://"+tlsAddr, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	dialPlain := func(t *testing.T) *ldap.Conn {
+		conn, err := ldap.DialURL("ldap://" + plainAddr)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+
+	t.Run("auto over TLS", func(t *testing.T) {
+		got, err := connectionChannelBinding(dialTLS(t), ChannelBindingAuto)
+		require.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("empty mode defaults to auto", func(t *testing.T) {
+		got, err := connectionChannelBinding(dialTLS(t), "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		got, err := connectionChannelBinding(dialTLS(t), ChannelBindingDisabled)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("auto without TLS", func(t *testing.T) {
+		got, err := connectionChannelBinding(dialPlain(t), ChannelBindingAuto)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("required without TLS", func(t *testing.T) {
+		_, err := connectionChannelBinding(dialPlain(t), ChannelBindingRequired)
+		assert.ErrorContains(t, err, "does not use TLS")
+	})
+}
+
+func TestAddNTLMChannelBinding(t *testing.T) {
+	binding := []byte("tls-server-end-point:test")
+	challenge := testNTLMChallenge()
+
+	got, err := addNTLMChannelBinding(challenge, binding)
+	require.NoError(t, err)
+	assert.Equal(t, challenge[:40], got[:40], "fields before the target info are unchanged")
+
+	length := int(binary.LittleEndian.Uint16(got[40:]))
+	offset := int(binary.LittleEndian.Uint32(got[44:]))
+	info := got[offset : offset+length]
+
+	want := []byte{0x02, 0x00, 0x04, 0x00, 'E', 0, 'X', 0, 0x0A, 0x00, 0x10, 0x00}
+	want = append(want, channelBindingHash(binding)...)
+	want = append(want, 0, 0, 0, 0)
+	assert.Equal(t, want, info)
+
+	// Adding a binding again replaces rather than duplicates it.
+	again, err := addNTLMChannelBinding(got, binding)
+	require.NoError(t, err)
+	length = int(binary.LittleEndian.Uint16(again[40:]))
+	offset = int(binary.LittleEndian.Uint32(again[44:]))
+	assert.Equal(t, want, again[offset:offset+length])
+
+	_, err = addNTLMChannelBinding(challenge[:20], binding)
+	assert.Error(t, err)
+}
+
+func TestValidateChannelBinding(t *testing.T) {
+	for _, mode := range []string{"", "auto", "required", "disabled"} {
+		assert.NoError(t, ValidateChannelBinding(mode), mode)
+	}
+	assert.Error(t, ValidateChannelBinding("optional"))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-ldap/ldap/v3"
@@ -15,6 +16,11 @@ type client struct {
 	ctx    context.Context // Logging context with LDAP subsystem
 	pool   ConnectionPool
 	config *ConnectionConfig
+
+	// Global Catalog pool and partial attribute set, created on first use.
+	gcMu   sync.Mutex
+	gcPool ConnectionPool
+	pas    map[string]struct{}
 }
 
 // ldapOps is the subset of *ldap.Conn operations invoked by the client
@@ -123,6 +129,19 @@ func (c *client) Connect(ctx context.Context) error {
 
 // Close closes the client and all its connections.
 func (c *client) Close() error {
+	c.gcMu.Lock()
+	gcPool := c.gcPool
+	c.gcPool = nil
+	c.gcMu.Unlock()
+
+	if gcPool != nil {
+		if err := gcPool.Close(); err != nil {
+			tflog.SubsystemWarn(c.ctx, "ldap", "Failed to close Global Catalog connection pool", map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	return c.pool.Close()
 }
 
@@ -169,13 +188,23 @@ func (c *client) BindWithConfig(ctx context.Context) error {
 	}
 	defer conn.Close()
 
+	// Connections with a SASL security layer were bound when they were
+	// created and cannot be re-bound in place.
+	if conn.hasSASLSecurityLayer() {
+		tflog.SubsystemDebug(c.ctx, "ldap", "Connection already authenticated with a SASL security layer", map[string]any{
+			"auth_method":         authMethod.String(),
+			"sasl_security_layer": c.config.SASLSecurityLayer,
+		})
+		return nil
+	}
+
 	tflog.SubsystemDebug(c.ctx, "ldap", "Starting authentication", map[string]any{
 		"auth_method": authMethod.String(),
 	})
 
 	// Perform authentication based on configured method
 	err = c.withRetry(ctx, func() error {
-		return c.authenticate(ctx, conn.Conn())
+		return c.authenticate(ctx, conn)
 	})
 
 	// Log operation completion
@@ -196,26 +225,43 @@ func (c *client) BindWithConfig(ctx context.Context) error {
 	return err
 }
 
-// authenticate performs authentication based on the configured method.
-func (c *client) authenticate(ctx context.Context, conn *ldap.Conn) error {
-	authMethod := c.config.GetAuthMethod()
+// authenticate performs authentication based on the configured method, with
+// the credentials and Kerberos tickets of the pool the connection came from.
+// The connection's channel binding is passed to NTLM and Kerberos binds.
+func (c *client) authenticate(ctx context.Context, pooled *PooledConnection) error {
+	bind := pooled.bind
+	if bind == nil {
+		bind = &bindState{config: c.config}
+	}
+	cfg := bind.credentialsForBind(ctx)
+
+	conn, channelBinding := pooled.Conn(), pooled.channelBinding
+	authMethod := cfg.GetAuthMethod()
 
 	tflog.SubsystemDebug(c.ctx, "ldap", "Performing authentication", map[string]any{
 		"auth_method": authMethod.String(),
 	})
 
 	start := time.Now()
-	var err error
+	bindWith := func(cfg *ConnectionConfig) error {
+		switch authMethod {
+		case AuthMethodSimpleBind:
+			return c.authenticateSimple(conn, cfg)
+		case AuthMethodKerberos:
+			return c.authenticateKerberos(ctx, conn, cfg, bind.tickets, channelBinding)
+		case AuthMethodExternal:
+			return c.authenticateExternal(ctx, conn)
+		case AuthMethodNTLM:
+			return performNTLMBind(c.ctx, conn, cfg, channelBinding)
+		default:
+			return fmt.Errorf("unsupported authentication method: %s", authMethod.String())
+		}
+	}
 
-	switch authMethod {
-	case AuthMethodSimpleBind:
-		err = c.authenticateSimple(conn)
-	case AuthMethodKerberos:
-		err = c.authenticateKerberos(ctx, conn)
-	case AuthMethodExternal:
-		err = c.authenticateExternal(ctx, conn)
-	default:
-		err = fmt.Errorf("unsupported authentication method: %s", authMethod.String())
+	err := bindWith(cfg)
+	// Retry once with rotated credentials, as the pool does for new connections
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) && bind.refreshCredentials(ctx) {
+		err = bindWith(bind.bindConfig())
 	}
 
 	if err != nil {
@@ -236,18 +282,18 @@ func (c *client) authenticate(ctx context.Context, conn *ldap.Conn) error {
 }
 
 // authenticateSimple performs simple bind authentication.
-func (c *client) authenticateSimple(conn *ldap.Conn) error {
-	if c.config.Username == "" {
+func (c *client) authenticateSimple(conn *ldap.Conn, cfg *ConnectionConfig) error {
+	if cfg.Username == "" {
 		tflog.SubsystemError(c.ctx, "ldap", "Username is required for simple bind authentication")
 		return fmt.Errorf("username is required for simple bind authentication")
 	}
 
 	// Handle anonymous bind (empty password)
-	password := c.config.Password
-	isAnonymousBind := password == "" && c.config.Username != ""
+	password := cfg.Password
+	isAnonymousBind := password == "" && cfg.Username != ""
 
 	fields := map[string]any{
-		"username":       c.config.Username,
+		"username":       cfg.Username,
 		"anonymous_bind": isAnonymousBind,
 	}
 
@@ -257,10 +303,10 @@ func (c *client) authenticateSimple(conn *ldap.Conn) error {
 	if isAnonymousBind {
 		// Allow anonymous bind attempt with username only
 		tflog.SubsystemDebug(c.ctx, "ldap", "Attempting anonymous bind with username")
-		err = conn.Bind(c.config.Username, "")
+		err = conn.Bind(cfg.Username, "")
 	} else {
 		tflog.SubsystemDebug(c.ctx, "ldap", "Attempting authenticated bind")
-		err = conn.Bind(c.config.Username, password)
+		err = conn.Bind(cfg.Username, password)
 	}
 
 	if err != nil {
@@ -287,7 +333,7 @@ func (c *client) authenticateSimple(conn *ldap.Conn) error {
 }
 
 // authenticateKerberos performs GSSAPI/Kerberos authentication.
-func (c *client) authenticateKerberos(ctx context.Context, conn *ldap.Conn) error {
+func (c *client) authenticateKerberos(ctx context.Context, conn *ldap.Conn, cfg *ConnectionConfig, tickets *kerberosTicketCache, channelBinding []byte) error {
 	// For client connections, we need to extract server info from the config
 	// Since client doesn't have direct access to ServerInfo like pool does,
 	// we'll create it from the first available server or derive from connection
@@ -314,7 +360,10 @@ func (c *client) authenticateKerberos(ctx context.Context, conn *ldap.Conn) erro
 		return fmt.Errorf("insufficient connection information for Kerberos authentication")
 	}
 
-	return performKerberosAuthWithContext(ctx, conn, c.config, serverInfo)
+	return performKerberosAuthWithOptions(ctx, conn, cfg, serverInfo, kerberosBindOptions{
+		channelBinding: channelBinding,
+		tickets:        tickets,
+	})
 }
 
 // authenticateExternal performs external/certificate authentication.
@@ -376,9 +425,17 @@ func (c *client) Search(ctx context.Context, req *SearchRequest) (*SearchResult,
 		"size_limit": req.SizeLimit,
 		"time_limit": req.TimeLimit.String(),
 	}
+	if req.GlobalCatalog {
+		searchFields["global_catalog"] = true
+	}
 
 	return c.performSearch("search", searchFields, func() (*SearchResult, error) {
-		conn, err := c.pool.Get(ctx)
+		pool, attributes, err := c.searchPool(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		conn, err := pool.Get(ctx)
 		if err != nil {
 			tflog.SubsystemError(c.ctx, "ldap", "Failed to get connection for search", map[string]any{
 				"error": err.Error(),
@@ -396,7 +453,7 @@ func (c *client) Search(ctx context.Context, req *SearchRequest) (*SearchResult,
 			int(req.TimeLimit.Seconds()),
 			false, // TypesOnly
 			req.Filter,
-			req.Attributes,
+			attributes,
 			req.Controls,
 		)
 
@@ -460,9 +517,18 @@ func (c *client) SearchWithPaging(ctx context.Context, req *SearchRequest) (*Sea
 		"time_limit": req.TimeLimit.String(),
 	}
 
+	if req.GlobalCatalog {
+		fields["global_catalog"] = true
+	}
+
 	tflog.SubsystemDebug(c.ctx, "ldap", "Starting paged search", fields)
 
-	conn, err := c.pool.Get(ctx)
+	pool, attributes, err := c.searchPool(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pool.Get(ctx)
 	if err != nil {
 		fields["operation"] = "get_connection"
 		fields["error"] = err.Error()
@@ -583,7 +649,7 @@ func (c *client) SearchWithPaging(ctx context.Context, req *SearchRequest) (*Sea
 			int(req.TimeLimit.Seconds()),
 			false,
 			req.Filter,
-			req.Attributes,
+			attributes,
 			pagedControls,
 		)
 
@@ -643,6 +709,27 @@ func (c *client) SearchWithPaging(ctx context.Context, req *SearchRequest) (*Sea
 			lastProgressTime = currentTime
 		}
 
+		// Stop once the size limit is reached; the limit is not sent to the
+		// server because it would apply to each page rather than the search
+		if req.SizeLimit > 0 && len(allEntries) >= req.SizeLimit {
+			hasMore := len(allEntries) > req.SizeLimit
+			if responseControl, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging); ok && len(responseControl.Cookie) > 0 {
+				hasMore = true
+			}
+
+			tflog.SubsystemDebug(c.ctx, "ldap", "Paged search reached size limit", map[string]any{
+				"size_limit":      req.SizeLimit,
+				"pages_completed": pageNum,
+				"has_more":        hasMore,
+			})
+
+			return &SearchResult{
+				Entries: allEntries[:req.SizeLimit],
+				Total:   req.SizeLimit,
+				HasMore: hasMore,
+			}, nil
+		}
+
 		// Check for more pages
 		pagingResult := ldap.FindControl(result.Controls, ldap.ControlTypePaging)
 		if responseControl, ok := pagingResult.(*ldap.ControlPaging); ok {
@@ -739,6 +826,11 @@ func (c *client) Modify(ctx context.Context, req *ModifyRequest) error {
 		ldapReq.Delete(attr, []string{})
 	}
 
+	// Delete individual values
+	for attr, values := range req.DeleteValues {
+		ldapReq.Delete(attr, values)
+	}
+
 	return c.withRetry(ctx, func() error {
 		return connOps(conn).Modify(ldapReq)
 	})
@@ -950,6 +1042,9 @@ func (c *client) WhoAmI(ctx context.Context) (*WhoAmIResult, error) {
 	whoAmIResult := &WhoAmIResult{
 		AuthzID: result.AuthzID,
 	}
+	if expiry := conn.bind.kerberosTicketExpiry(); !expiry.IsZero() {
+		whoAmIResult.KerberosTicketExpiry = &expiry
+	}
 
 	return whoAmIResult, nil
 }
@@ -989,6 +1084,27 @@ func parseADBool(s string) bool {
 	return strings.EqualFold(s, "TRUE")
 }
 
+// siteFromServerName extracts the site name from a DC's serverName DN, e.g.
+// CN=DC1,CN=Servers,CN=London,CN=Sites,CN=Configuration,DC=example,DC=com.
+func siteFromServerName(serverName string) string {
+	dn, err := ldap.ParseDN(serverName)
+	if err != nil {
+		return ""
+	}
+
+	for i := 1; i < len(dn.RDNs); i++ {
+		rdn := dn.RDNs[i]
+		if len(rdn.Attributes) != 1 || !strings.EqualFold(rdn.Attributes[0].Value, "Sites") {
+			continue
+		}
+		site := dn.RDNs[i-1]
+		if len(site.Attributes) == 1 {
+			return site.Attributes[0].Value
+		}
+	}
+	return ""
+}
+
 // GetRootDSE retrieves RootDSE attributes and forest configuration from the Configuration partition.
 func (c *client) GetRootDSE(ctx context.Context) (*RootDSEInfo, error) {
 	// Query RootDSE
@@ -1066,6 +1182,9 @@ func (c *client) GetRootDSE(ctx context.Context) (*RootDSEInfo, error) {
 
 		IsGlobalCatalogReady: parseADBool(entry.GetAttributeValue("isGlobalCatalogReady")),
 		IsSynchronized:       parseADBool(entry.GetAttributeValue("isSynchronized")),
+
+		ServerSiteName: siteFromServerName(entry.GetAttributeValue("serverName")),
+		ClientSiteName: c.pool.Stats().Site,
 	}
 
 	// Derive DNS names from naming contexts
@@ -268,6 +268,43 @@ func TestSearchRequest_Validation(t *testing.T) {
 	})
 }
 
+// TestSearchWithPaging_SizeLimit verifies that SearchWithPaging stops at the
+// size limit across pages rather than sending it to the server per page.
+func TestSearchWithPaging_SizeLimit(t *testing.T) {
+	pool := &mockPool{}
+	ops := &mockLDAPOps{}
+	swapConnOps(t, ops)
+
+	pool.On("Get", mock.Anything).Return(&PooledConnection{}, nil).Once()
+
+	pagingControl := ldap.NewControlPaging(1000)
+	pagingControl.SetCookie([]byte("next"))
+	ops.On("Search", mock.MatchedBy(func(req *ldap.SearchRequest) bool {
+		return req.SizeLimit == 0
+	})).Return(&ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{DN: "cn=a,dc=example,dc=com"},
+			{DN: "cn=b,dc=example,dc=com"},
+			{DN: "cn=c,dc=example,dc=com"},
+		},
+		Controls: []ldap.Control{pagingControl},
+	}, nil).Once()
+
+	c := newTestClient(pool, nil)
+	got, err := c.SearchWithPaging(t.Context(), &SearchRequest{
+		BaseDN:    "dc=example,dc=com",
+		Scope:     ScopeWholeSubtree,
+		Filter:    "(objectClass=*)",
+		SizeLimit: 2,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Total)
+	assert.Len(t, got.Entries, 2)
+	assert.True(t, got.HasMore)
+	ops.AssertExpectations(t)
+}
+
 // TestAddRequest_Validation verifies that client.Add rejects nil requests
 // and that it converts a *AddRequest into a *ldap.AddRequest carrying the
 // expected DN and attribute set.
@@ -339,7 +376,7 @@ func TestAddRequest_Validation(t *testing.T) {
 }
 
 // TestModifyRequest_Validation verifies that client.Modify rejects nil
-// requests and that Add/Replace/Delete attribute sets translate into the
+// requests and that Add/Replace/Delete attribute and value sets translate into the
 // expected set of ldap.Change operations in the outgoing request.
 func TestModifyRequest_Validation(t *testing.T) {
 	t.Run("nil request", func(t *testing.T) {
@@ -374,6 +411,9 @@ func TestModifyRequest_Validation(t *testing.T) {
 				"mail": {"test@example.com"},
 			},
 			DeleteAttributes: []string{"telephoneNumber"},
+			DeleteValues: map[string][]string{
+				"servicePrincipalName": {"HTTP/web.example.com"},
+			},
 		}
 
 		c := newTestClient(pool, nil)
@@ -381,7 +421,7 @@ func TestModifyRequest_Validation(t *testing.T) {
 
 		require.NotNil(t, captured)
 		assert.Equal(t, "cn=test,dc=example,dc=com", captured.DN)
-		require.Len(t, captured.Changes, 3, "one change per Add/Replace/Delete entry")
+		require.Len(t, captured.Changes, 4, "one change per Add/Replace/Delete entry")
 
 		type op struct {
 			Op   uint
@@ -406,6 +446,9 @@ func TestModifyRequest_Validation(t *testing.T) {
 		assert.Contains(t, gotOps, op{
 			Op: ldap.DeleteAttribute, Type: "telephoneNumber", Vals: []string{},
 		})
+		assert.Contains(t, gotOps, op{
+			Op: ldap.DeleteAttribute, Type: "servicePrincipalName", Vals: []string{"HTTP/web.example.com"},
+		})
 
 		pool.AssertExpectations(t)
 		ops.AssertExpectations(t)
@@ -799,3 +842,30 @@ func TestClient_WithRetry_Logic(t *testing.T) {
 		t.Errorf("Expected 1 attempt for non-retryable error, got %d", attempts)
 	}
 }
+
+func TestSiteFromServerName(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverName string
+		want       string
+	}{
+		{
+			name:       "default site",
+			serverName: "CN=DC01,CN=Servers,CN=Default-First-Site-Name,CN=Sites,CN=Configuration,DC=example,DC=com",
+			want:       "Default-First-Site-Name",
+		},
+		{
+			name:       "case insensitive",
+			serverName: "cn=DC02,cn=Servers,cn=London,cn=sites,cn=Configuration,dc=example,dc=com",
+			want:       "London",
+		},
+		{name: "empty", serverName: "", want: ""},
+		{name: "not a server object", serverName: "CN=Users,DC=example,DC=com", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, siteFromServerName(tt.serverName))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// computerLocator builds searches for computer accounts, including managed
+// service accounts, which are named by a SAM account name ending in "$".
+type computerLocator struct {
+	guidHandler *GUIDHandler
+	sidHandler  *SIDHandler
+	normalizer  *MemberNormalizer
+	baseDN      string
+	timeout     time.Duration
+}
+
+func newComputerLocator(client Client, baseDN string, cacheManager *CacheManager) *computerLocator {
+	return &computerLocator{
+		guidHandler: NewGUIDHandler(),
+		sidHandler:  NewSIDHandler(),
+		normalizer:  NewMemberNormalizer(client, baseDN, cacheManager),
+		baseDN:      baseDN,
+		timeout:     30 * time.Second,
+	}
+}
+
+// searchRequest builds a search for an object of objectClass identified by
+// DN, GUID, SID, DNS host name or SAM account name (with or without the
+// trailing "$" and an optional DOMAIN\ prefix).
+func (l *computerLocator) searchRequest(objectClass, identifier string, attributes []string) (*SearchRequest, error) {
+	identifier = strings.TrimSpace(identifier)
+	classFilter := fmt.Sprintf("(objectClass=%s)", ldap.EscapeFilter(objectClass))
+	req := &SearchRequest{
+		BaseDN:     l.baseDN,
+		Scope:      ScopeWholeSubtree,
+		Attributes: attributes,
+		SizeLimit:  1,
+		TimeLimit:  l.timeout,
+	}
+
+	switch l.normalizer.DetectIdentifierType(identifier) {
+	case IdentifierTypeDN:
+		req.BaseDN = identifier
+		req.Scope = ScopeBaseObject
+		req.Filter = classFilter
+	case IdentifierTypeGUID:
+		filter, err := l.guidHandler.GUIDToSearchFilter(identifier)
+		if err != nil {
+			return nil, WrapError("guid_to_search_filter", err)
+		}
+		req.Filter = fmt.Sprintf("(&%s%s)", classFilter, filter)
+	case IdentifierTypeSID:
+		filter, err := l.sidHandler.SIDToSearchFilter(identifier)
+		if err != nil {
+			return nil, WrapError("sid_to_search_filter", err)
+		}
+		req.Filter = fmt.Sprintf("(&%s%s)", classFilter, filter)
+	default:
+		if strings.Contains(identifier, ".") && !strings.Contains(identifier, "\\") {
+			req.Filter = fmt.Sprintf("(&%s(dNSHostName=%s))", classFilter, ldap.EscapeFilter(identifier))
+			break
+		}
+		if _, name, found := strings.Cut(identifier, "\\"); found {
+			identifier = name
+		}
+		if !strings.HasSuffix(identifier, "$") {
+			identifier += "$"
+		}
+		req.Filter = fmt.Sprintf("(&%s(sAMAccountName=%s))", classFilter, ldap.EscapeFilter(identifier))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,57 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testComputerDN = "CN=WS01,OU=Workstations,DC=example,DC=com"
+
+func TestComputerLocator_SearchRequest(t *testing.T) {
+	l := newComputerLocator(&MockClient{}, "DC=example,DC=com", nil)
+
+	testCases := []struct {
+		name     string
+		computer string
+		baseDN   string
+		scope    SearchScope
+		filter   string
+	}{
+		{"DN", testComputerDN, testComputerDN, ScopeBaseObject, "(objectClass=computer)"},
+		{"SAM without $", "WS01", "DC=example,DC=com", ScopeWholeSubtree, "(&(objectClass=computer)(sAMAccountName=WS01$))"},
+		{"SAM with $", "WS01$", "DC=example,DC=com", ScopeWholeSubtree, "(&(objectClass=computer)(sAMAccountName=WS01$))"},
+		{"SAM with domain", "EXAMPLE\\WS01", "DC=example,DC=com", ScopeWholeSubtree, "(&(objectClass=computer)(sAMAccountName=WS01$))"},
+		{"DNS host name", " ws01.example.com ", "DC=example,DC=com", ScopeWholeSubtree, "(&(objectClass=computer)(dNSHostName=ws01.example.com))"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := l.searchRequest("computer", tc.computer, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.baseDN, req.BaseDN)
+			assert.Equal(t, tc.scope, req.Scope)
+			assert.Equal(t, tc.filter, req.Filter)
+		})
+	}
+
+	t.Run("SID", func(t *testing.T) {
+		sid := "S-1-5-21-1004336348-1177238915-682003330-1105"
+		sidFilter, err := NewSIDHandler().SIDToSearchFilter(sid)
+		require.NoError(t, err)
+
+		req, err := l.searchRequest("computer", sid, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "(&(objectClass=computer)"+sidFilter+")", req.Filter)
+	})
+}
+
+func TestComputerLocator_ObjectClass(t *testing.T) {
+	l := newComputerLocator(&MockClient{}, "DC=example,DC=com", nil)
+
+	req, err := l.searchRequest("msDS-GroupManagedServiceAccount", "svc-web", []string{"msDS-ManagedPassword"})
+	require.NoError(t, err)
+	assert.Equal(t, "(&(objectClass=msDS-GroupManagedServiceAccount)(sAMAccountName=svc-web$))", req.Filter)
+	assert.Equal(t, []string{"msDS-ManagedPassword"}, req.Attributes)
+}
@@ -0,0 +1,204 @@
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// credentialCommandTimeout bounds a single run of the credential command.
+	credentialCommandTimeout = 30 * time.Second
+
+	// credentialCommandMinInterval lets the re-authentication of several
+	// pooled connections share one run of the credential command.
+	credentialCommandMinInterval = time.Minute
+
+	// defaultCredentialRefreshInterval is how old the credentials from the
+	// command may be before a new connection runs it again.
+	defaultCredentialRefreshInterval = 5 * time.Minute
+
+	// credentialCommandMaxStderr limits how much of the command's error
+	// output is included in error messages.
+	credentialCommandMaxStderr = 512
+)
+
+// CredentialRequest describes the credentials wanted from a credential
+// command. It is written to the command's standard input as JSON, much as git
+// passes the protocol and host to a credential helper.
+type CredentialRequest struct {
+	Action        string `json:"action"` // Always "get"
+	Domain        string `json:"domain,omitempty"`
+	Username      string `json:"username,omitempty"`
+	KerberosRealm string `json:"kerberos_realm,omitempty"`
+}
+
+// CredentialResult is the JSON document a credential command prints on
+// standard output. It must contain a password or a keytab path.
+type CredentialResult struct {
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	KerberosKeytab string `json:"keytab,omitempty"`
+}
+
+// RunCredentialCommand runs a credential command and parses its output.
+// command is the executable followed by its arguments; no shell is involved.
+func RunCredentialCommand(ctx context.Context, command []string, req CredentialRequest) (*CredentialResult, error) {
+	if len(command) == 0 || command[0] == "" {
+		return nil, errors.New("credential command is empty")
+	}
+	req.Action = "get"
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credential request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if len(message) > credentialCommandMaxStderr {
+			message = message[:credentialCommandMaxStderr] + "..."
+		}
+		if message != "" {
+			return nil, fmt.Errorf("credential command %s failed: %w: %s", command[0], err, message)
+		}
+		return nil, fmt.Errorf("credential command %s failed: %w", command[0], err)
+	}
+
+	var result CredentialResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("credential command %s did not print a JSON object: %w", command[0], err)
+	}
+	if result.Password == "" && result.KerberosKeytab == "" {
+		return nil, fmt.Errorf("credential command %s returned neither a password nor a keytab", command[0])
+	}
+	return &result, nil
+}
+
+// Apply replaces the credentials in cfg with the command's result. The
+// configured username is kept when the command does not return one.
+func (r *CredentialResult) Apply(cfg *ConnectionConfig) {
+	if r.Username != "" {
+		cfg.Username = r.Username
+	}
+	cfg.Password = r.Password
+	if r.KerberosKeytab != "" {
+		cfg.KerberosKeytab = r.KerberosKeytab
+	}
+}
+
+// credentialRefresher holds the credentials a pool binds with while a
+// credential command or password file can replace them. The configuration
+// the pool was created with is never modified: each change publishes a new
+// copy, and a bind uses the copy that was current when it started.
+type credentialRefresher struct {
+	current atomic.Pointer[ConnectionConfig]
+
+	mu           sync.Mutex // Serialises credential command runs and password file reloads
+	lastRun      time.Time
+	fetchedAt    time.Time // When the credential command last succeeded
+	passwordFile fileStamp
+}
+
+// newCredentialRefresher starts from the credentials in cfg, which the
+// provider fetched when it was configured.
+func newCredentialRefresher(cfg *ConnectionConfig) *credentialRefresher {
+	r := &credentialRefresher{fetchedAt: time.Now()}
+	r.current.Store(cfg)
+	return r
+}
+
+// snapshot returns the current credentials. The configuration returned is
+// shared and must not be modified.
+func (r *credentialRefresher) snapshot() *ConnectionConfig {
+	return r.current.Load()
+}
+
+// update publishes a copy of the current configuration modified by apply and
+// reports whether any credential changed. The caller holds r.mu.
+func (r *credentialRefresher) update(apply func(*ConnectionConfig)) bool {
+	current := r.current.Load()
+	next := *current
+	apply(&next)
+	if next.Username == current.Username && next.Password == current.Password && next.KerberosKeytab == current.KerberosKeytab {
+		return false
+	}
+	r.current.Store(&next)
+	return true
+}
+
+// credentialsStale reports whether the credentials from the credential
+// command are older than the configured refresh interval.
+func (s *bindState) credentialsStale() bool {
+	refresher := s.credentials
+	if refresher == nil || len(s.config.CredentialCommand) == 0 {
+		return false
+	}
+	interval := s.config.CredentialRefreshInterval
+	if interval <= 0 {
+		interval = defaultCredentialRefreshInterval
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+	return time.Since(refresher.fetchedAt) >= interval
+}
+
+// refreshCredentials runs the credential command again so that rotated
+// credentials are used by the next bind, and reports whether they changed.
+// On failure the current credentials are kept.
+func (s *bindState) refreshCredentials(ctx context.Context) bool {
+	refresher := s.credentials
+	if refresher == nil || len(s.config.CredentialCommand) == 0 {
+		return false
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+
+	if time.Since(refresher.lastRun) < credentialCommandMinInterval {
+		return false
+	}
+	refresher.lastRun = time.Now()
+
+	current := refresher.snapshot()
+	result, err := RunCredentialCommand(ctx, current.CredentialCommand, CredentialRequest{
+		Domain:        current.Domain,
+		Username:      current.Username,
+		KerberosRealm: current.KerberosRealm,
+	})
+	if err != nil {
+		tflog.SubsystemWarn(ctx, "ldap", "Credential command failed, keeping current credentials", map[string]any{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	refresher.fetchedAt = time.Now()
+	changed := refresher.update(result.Apply)
+	if changed && s.tickets != nil {
+		s.tickets.invalidate()
+	}
+
+	tflog.SubsystemDebug(ctx, "ldap", "Credentials refreshed from credential command", map[string]any{
+		"changed": changed,
+	})
+	return changed
+}
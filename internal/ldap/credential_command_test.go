@@ -0,0 +1,171 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	krb5client "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCredentialHelper writes an executable shell script to a temporary
+// directory and returns its path.
+func writeCredentialHelper(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "helper.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o700))
+	return path
+}
+
+func TestRunCredentialCommand(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns credentials and receives the request", func(t *testing.T) {
+		dir := t.TempDir()
+		requestPath := filepath.Join(dir, "request.json")
+		helper := writeCredentialHelper(t, `cat > "$1"
+echo '{"username":"svc-terraform","password":"rotated"}'
+`)
+
+		result, err := RunCredentialCommand(ctx, []string{helper, requestPath}, CredentialRequest{
+			Domain:   "example.com",
+			Username: "svc-terraform",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &CredentialResult{Username: "svc-terraform", Password: "rotated"}, result)
+
+		data, err := os.ReadFile(requestPath)
+		require.NoError(t, err)
+		var req CredentialRequest
+		require.NoError(t, json.Unmarshal(data, &req))
+		assert.Equal(t, CredentialRequest{Action: "get", Domain: "example.com", Username: "svc-terraform"}, req)
+	})
+
+	t.Run("keytab only", func(t *testing.T) {
+		helper := writeCredentialHelper(t, `echo '{"keytab":"/run/secrets/svc.keytab"}'`)
+		result, err := RunCredentialCommand(ctx, []string{helper}, CredentialRequest{KerberosRealm: "EXAMPLE.COM"})
+		require.NoError(t, err)
+		assert.Equal(t, "/run/secrets/svc.keytab", result.KerberosKeytab)
+	})
+
+	t.Run("failure includes stderr", func(t *testing.T) {
+		helper := writeCredentialHelper(t, `echo "vault: permission denied" >&2
+exit 3
+`)
+		_, err := RunCredentialCommand(ctx, []string{helper}, CredentialRequest{})
+		assert.ErrorContains(t, err, "vault: permission denied")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		helper := writeCredentialHelper(t, `echo "password=secret"`)
+		_, err := RunCredentialCommand(ctx, []string{helper}, CredentialRequest{})
+		assert.ErrorContains(t, err, "did not print a JSON object")
+	})
+
+	t.Run("no password or keytab", func(t *testing.T) {
+		helper := writeCredentialHelper(t, `echo '{"username":"svc-terraform"}'`)
+		_, err := RunCredentialCommand(ctx, []string{helper}, CredentialRequest{})
+		assert.ErrorContains(t, err, "neither a password nor a keytab")
+	})
+
+	t.Run("empty command", func(t *testing.T) {
+		_, err := RunCredentialCommand(ctx, nil, CredentialRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCredentialResult_Apply(t *testing.T) {
+	cfg := &ConnectionConfig{Username: "configured", Password: "old", KerberosKeytab: "/etc/old.keytab"}
+
+	(&CredentialResult{Password: "new"}).Apply(cfg)
+	assert.Equal(t, "configured", cfg.Username, "the configured username is kept")
+	assert.Equal(t, "new", cfg.Password)
+	assert.Equal(t, "/etc/old.keytab", cfg.KerberosKeytab)
+
+	(&CredentialResult{Username: "returned", KerberosKeytab: "/etc/new.keytab"}).Apply(cfg)
+	assert.Equal(t, "returned", cfg.Username)
+	assert.Empty(t, cfg.Password)
+	assert.Equal(t, "/etc/new.keytab", cfg.KerberosKeytab)
+}
+
+func TestBindState_RefreshCredentials(t *testing.T) {
+	ctx := context.Background()
+	passwordPath := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordPath, []byte("first"), 0o600))
+	helper := writeCredentialHelper(t, `printf '{"password":"%s"}' "$(cat "$1")"`)
+
+	cfg := &ConnectionConfig{
+		Username:          "svc-terraform",
+		Password:          "first",
+		KerberosRealm:     "EXAMPLE.COM",
+		CredentialCommand: []string{helper, passwordPath},
+	}
+	bind := &bindState{config: cfg, credentials: newCredentialRefresher(cfg), tickets: newKerberosTicketCache(cfg)}
+	bind.tickets.client = krb5client.NewWithPassword("svc-terraform", "EXAMPLE.COM", "first", config.New())
+
+	t.Run("unchanged credentials keep the tickets", func(t *testing.T) {
+		bind.refreshCredentials(ctx)
+		assert.Same(t, cfg, bind.bindConfig())
+		assert.NotNil(t, bind.tickets.client)
+	})
+
+	t.Run("runs at most once a minute", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(passwordPath, []byte("second"), 0o600))
+		bind.refreshCredentials(ctx)
+		assert.Equal(t, "first", bind.bindConfig().Password)
+	})
+
+	t.Run("rotated credentials replace the tickets", func(t *testing.T) {
+		inFlight := bind.bindConfig()
+		bind.credentials.lastRun = time.Now().Add(-credentialCommandMinInterval)
+		bind.refreshCredentials(ctx)
+		assert.Equal(t, "second", bind.bindConfig().Password)
+		assert.Equal(t, "svc-terraform", bind.bindConfig().Username)
+		assert.Equal(t, "first", inFlight.Password, "a bind in progress keeps its credentials")
+		assert.Equal(t, "first", cfg.Password, "the pool's configuration is not modified")
+		assert.Nil(t, bind.tickets.client, "the next bind logs in with the new password")
+	})
+
+	t.Run("failure keeps the current credentials", func(t *testing.T) {
+		require.NoError(t, os.Remove(passwordPath))
+		bind.credentials.lastRun = time.Time{}
+		bind.refreshCredentials(ctx)
+		assert.Equal(t, "second", bind.bindConfig().Password)
+	})
+
+	t.Run("no command", func(t *testing.T) {
+		plain := &bindState{config: &ConnectionConfig{Password: "static"}}
+		plain.refreshCredentials(ctx)
+		assert.Equal(t, "static", plain.bindConfig().Password)
+	})
+}
+
+func TestBindState_ConcurrentRefresh(t *testing.T) {
+	// Run with -race: binds read the credentials while they are refreshed.
+	ctx := context.Background()
+	helper := writeCredentialHelper(t, `echo '{"password":"rotated"}'`)
+	cfg := &ConnectionConfig{Username: "svc-terraform", Password: "first", CredentialCommand: []string{helper}}
+	bind := &bindState{config: cfg, credentials: newCredentialRefresher(cfg)}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg := bind.credentialsForBind(ctx)
+			_ = cfg.Username + cfg.Password + cfg.KerberosKeytab
+			_ = cfg.HasAuthentication()
+		}()
+	}
+	bind.refreshCredentials(ctx)
+	wg.Wait()
+
+	assert.Equal(t, "rotated", bind.bindConfig().Password)
+}
@@ -0,0 +1,231 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// fileStamp identifies the contents of a file by its modification time and
+// size, so that a file rewritten by a secrets agent can be read again.
+type fileStamp struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// statFile returns the current stamp of the file at path.
+func statFile(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{path: path, modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// changed reports whether the file has been rewritten since it was stamped.
+// A file that has disappeared is not treated as a change.
+func (s fileStamp) changed() bool {
+	if s.path == "" {
+		return false
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+// ReadPasswordFile reads a password from a file, ignoring trailing line breaks.
+func ReadPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file %s: %w", path, err)
+	}
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", path)
+	}
+	return password, nil
+}
+
+// reloadPasswordFile reads PasswordFile again when it has changed since it was
+// last read, publishing the new password for the next bind and discarding
+// Kerberos tickets obtained with the old one.
+func (s *bindState) reloadPasswordFile(ctx context.Context) error {
+	refresher, path := s.credentials, s.config.PasswordFile
+	if refresher == nil || path == "" {
+		return nil
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+	if refresher.passwordFile.path != "" && !refresher.passwordFile.changed() {
+		return nil
+	}
+
+	// Stamp before reading so that a rewrite during the read is seen next time.
+	stamp, err := statFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read password file %s: %w", path, err)
+	}
+	password, err := ReadPasswordFile(path)
+	if err != nil {
+		return err
+	}
+	refresher.passwordFile = stamp
+
+	if !refresher.update(func(cfg *ConnectionConfig) { cfg.Password = password }) {
+		return nil
+	}
+	if s.tickets != nil {
+		s.tickets.invalidate()
+	}
+	tflog.SubsystemInfo(ctx, "ldap", "Password file changed, using new password", map[string]any{
+		"path": path,
+	})
+	return nil
+}
+
+// tlsFiles holds the CA bundle and client certificate read from the
+// configured files, reading them again when they change on disk so that
+// certificate rotation does not break long-running pools.
+type tlsFiles struct {
+	caFile    string
+	caContent string
+	certFile  string
+	keyFile   string
+
+	mu        sync.Mutex
+	caStamp   fileStamp
+	rootCAs   *x509.CertPool
+	certStamp fileStamp
+	keyStamp  fileStamp
+	cert      *tls.Certificate
+}
+
+// newTLSFiles reads the CA bundle and client certificate configured in cfg.
+func newTLSFiles(cfg *ConnectionConfig) (*tlsFiles, error) {
+	f := &tlsFiles{
+		caFile:    cfg.TLSCACertFile,
+		caContent: cfg.TLSCACert,
+		certFile:  cfg.TLSClientCertFile,
+		keyFile:   cfg.TLSClientKeyFile,
+	}
+	if err := f.loadCA(); err != nil {
+		return nil, err
+	}
+	if f.hasClientCert() {
+		if err := f.loadClientCert(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// hasClientCert reports whether a client certificate is configured.
+func (f *tlsFiles) hasClientCert() bool {
+	return f.certFile != "" && f.keyFile != ""
+}
+
+// loadCA builds the certificate pool, stamping the CA file first.
+func (f *tlsFiles) loadCA() error {
+	var stamp fileStamp
+	if f.caFile != "" {
+		var err error
+		if stamp, err = statFile(f.caFile); err != nil {
+			return fmt.Errorf("failed to read CA certificate file %s: %w", f.caFile, err)
+		}
+	}
+	pool, err := buildCertPool(f.caFile, f.caContent)
+	if err != nil {
+		return err
+	}
+	f.caStamp = stamp
+	f.rootCAs = pool
+	return nil
+}
+
+// loadClientCert reads the client certificate and key, stamping both first.
+func (f *tlsFiles) loadClientCert() error {
+	certStamp, err := statFile(f.certFile)
+	if err != nil {
+		return fmt.Errorf("failed to read client certificate file %s: %w", f.certFile, err)
+	}
+	keyStamp, err := statFile(f.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read client key file %s: %w", f.keyFile, err)
+	}
+	cert, err := tls.LoadX509KeyPair(f.certFile, f.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	f.certStamp, f.keyStamp = certStamp, keyStamp
+	f.cert = &cert
+	return nil
+}
+
+// roots returns the current CA pool, reading the CA file again if it has
+// changed. A file that cannot be read keeps the previous pool in use.
+func (f *tlsFiles) roots(ctx context.Context) *x509.CertPool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.caStamp.changed() {
+		if err := f.loadCA(); err != nil {
+			tflog.SubsystemWarn(ctx, "ldap", "Failed to reload CA certificate file, keeping previous certificates", map[string]any{
+				"path":  f.caFile,
+				"error": err.Error(),
+			})
+		} else {
+			tflog.SubsystemInfo(ctx, "ldap", "CA certificate file changed, reloaded", map[string]any{
+				"path": f.caFile,
+			})
+		}
+	}
+	return f.rootCAs
+}
+
+// clientCertificate returns the current client certificate, reading the
+// files again if either has changed. A certificate whose new key has not been
+// written yet fails to load and the previous certificate stays in use.
+func (f *tlsFiles) clientCertificate(ctx context.Context) (*tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.certStamp.changed() || f.keyStamp.changed() {
+		if err := f.loadClientCert(); err != nil {
+			tflog.SubsystemWarn(ctx, "ldap", "Failed to reload client certificate, keeping previous certificate", map[string]any{
+				"cert_file": f.certFile,
+				"error":     err.Error(),
+			})
+		} else {
+			tflog.SubsystemInfo(ctx, "ldap", "Client certificate changed, reloaded", map[string]any{
+				"cert_file": f.certFile,
+			})
+		}
+	}
+	if f.cert == nil {
+		return nil, errors.New("no client certificate loaded")
+	}
+	return f.cert, nil
+}
+
+// configure points a TLS configuration at the current CA pool and client
+// certificate.
+func (f *tlsFiles) configure(ctx context.Context, tlsConfig *tls.Config) {
+	tlsConfig.RootCAs = f.roots(ctx)
+	if f.hasClientCert() {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return f.clientCertificate(ctx)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package ldap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	krb5client "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rewriteFile replaces the contents of path and moves its modification time
+// forward, so that the change is seen even on coarse-grained filesystems.
+func rewriteFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	later := info.ModTime().Add(time.Second)
+	require.NoError(t, os.Chtimes(path, later, later))
+}
+
+// writeCertificatePEM writes a new self-signed certificate and its key as PEM
+// files and returns the certificate.
+func writeCertificatePEM(t *testing.T, certPath, keyPath string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	cert := newSelfSignedCert(t, key, x509.ECDSAWithSHA256)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	for path, data := range map[string][]byte{certPath: certPEM, keyPath: keyPEM} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			rewriteFile(t, path, data)
+		} else {
+			require.NoError(t, os.WriteFile(path, data, 0o600))
+		}
+	}
+	return cert.Leaf
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "password")
+
+	require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0o600))
+	password, err := ReadPasswordFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", password)
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	_, err = ReadPasswordFile(path)
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = ReadPasswordFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestBindState_ReloadPasswordFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	cfg := &ConnectionConfig{
+		Username:      "svc-terraform",
+		KerberosRealm: "EXAMPLE.COM",
+		PasswordFile:  path,
+	}
+	bind := &bindState{config: cfg, credentials: newCredentialRefresher(cfg)}
+	require.NoError(t, bind.reloadPasswordFile(ctx))
+	assert.Equal(t, "first", bind.bindConfig().Password)
+	assert.Empty(t, cfg.Password, "the pool's configuration is not modified")
+
+	bind.tickets = newKerberosTicketCache(cfg)
+	bind.tickets.client = krb5client.NewWithPassword("svc-terraform", "EXAMPLE.COM", "first", config.New())
+
+	t.Run("unchanged file", func(t *testing.T) {
+		require.NoError(t, bind.reloadPasswordFile(ctx))
+		assert.NotNil(t, bind.tickets.client)
+	})
+
+	t.Run("rotated password", func(t *testing.T) {
+		inFlight := bind.bindConfig()
+		rewriteFile(t, path, []byte("second\n"))
+		require.NoError(t, bind.reloadPasswordFile(ctx))
+		assert.Equal(t, "second", bind.bindConfig().Password)
+		assert.Equal(t, "first", inFlight.Password, "a bind in progress keeps its password")
+		assert.Nil(t, bind.tickets.client, "tickets from the old password are discarded")
+	})
+
+	t.Run("unreadable file keeps the password", func(t *testing.T) {
+		rewriteFile(t, path, []byte(""))
+		assert.Error(t, bind.reloadPasswordFile(ctx))
+		assert.Equal(t, "second", bind.bindConfig().Password)
+	})
+}
+
+func TestTLSFiles_ReloadsCABundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	first := writeCertificatePEM(t, caPath, "")
+
+	files, err := newTLSFiles(&ConnectionConfig{TLSCACertFile: caPath})
+	require.NoError(t, err)
+	assert.False(t, files.hasClientCert())
+
+	tlsConfig := &tls.Config{}
+	files.configure(ctx, tlsConfig)
+	assert.True(t, certificateTrusted(tlsConfig.RootCAs, first))
+	assert.Nil(t, tlsConfig.GetClientCertificate)
+
+	second := writeCertificatePEM(t, caPath, "")
+	roots := files.roots(ctx)
+	assert.True(t, certificateTrusted(roots, second), "the rotated CA is trusted")
+	assert.False(t, certificateTrusted(roots, first))
+
+	rewriteFile(t, caPath, []byte("not a certificate"))
+	assert.Same(t, roots, files.roots(ctx), "an invalid bundle keeps the previous pool")
+}
+
+func TestTLSFiles_ReloadsClientCertificate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "client.pem")
+	keyPath := filepath.Join(dir, "client-key.pem")
+	first := writeCertificatePEM(t, certPath, keyPath)
+
+	files, err := newTLSFiles(&ConnectionConfig{TLSClientCertFile: certPath, TLSClientKeyFile: keyPath})
+	require.NoError(t, err)
+
+	tlsConfig := &tls.Config{}
+	files.configure(ctx, tlsConfig)
+	require.NotNil(t, tlsConfig.GetClientCertificate)
+	cert, err := tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.Raw, cert.Certificate[0])
+
+	second := writeCertificatePEM(t, certPath, keyPath)
+	cert, err = tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.Raw, cert.Certificate[0])
+
+	// A certificate written before its key does not match and is not used yet.
+	writeCertificatePEM(t, certPath, "")
+	cert, err = tlsConfig.GetClientCertificate(&tls.CertificateRequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, second.Raw, cert.Certificate[0])
+}
+
+func TestNewTLSFiles_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := newTLSFiles(&ConnectionConfig{TLSCACertFile: filepath.Join(dir, "missing.pem")})
+	assert.Error(t, err)
+
+	_, err = newTLSFiles(&ConnectionConfig{
+		TLSClientCertFile: filepath.Join(dir, "client.pem"),
+		TLSClientKeyFile:  filepath.Join(dir, "client-key.pem"),
+	})
+	assert.Error(t, err)
+}
+
+// certificateTrusted reports whether a self-signed certificate verifies
+// against roots.
+func certificateTrusted(roots *x509.CertPool, cert *x509.Certificate) bool {
+	_, err := cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "dc1.example.com"})
+	return err == nil
+}
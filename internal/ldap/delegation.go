@@ -0,0 +1,275 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// allowedToActAttribute holds the security descriptor whose DACL lists the
+// principals allowed to delegate to an account (resource-based constrained
+// delegation).
+const allowedToActAttribute = "msDS-AllowedToActOnBehalfOfOtherIdentity"
+
+// AccessMaskAllowedToAct is the access mask Windows grants each principal in
+// msDS-AllowedToActOnBehalfOfOtherIdentity (standard rights required plus all
+// directory service rights), as written by Set-ADComputer
+// -PrincipalsAllowedToDelegateToAccount.
+const AccessMaskAllowedToAct uint32 = 0x000F01FF
+
+// builtinAdministratorsSID is the well-known S-1-5-32-544 (BUILTIN\Administrators)
+// SID, the owner of the security descriptors Windows writes for RBCD.
+var builtinAdministratorsSID = SID{
+	RevisionLevel:  1,
+	Authority:      5,
+	SubAuthorities: []uint32{32, 544},
+}
+
+// DelegationAccount is an account (user, computer or managed service
+// account) and the SIDs of the principals allowed to delegate to it.
+type DelegationAccount struct {
+	ObjectGUID        string   `json:"objectGUID"`
+	DistinguishedName string   `json:"distinguishedName"`
+	AllowedPrincipals []string `json:"allowedPrincipals,omitempty"` // SIDs from msDS-AllowedToActOnBehalfOfOtherIdentity
+}
+
+// DelegationManager manages resource-based constrained delegation, which is
+// configured on the account being delegated to rather than on the account
+// performing the delegation.
+type DelegationManager struct {
+	ctx         context.Context
+	client      Client
+	guidHandler *GUIDHandler
+	sidHandler  *SIDHandler
+	normalizer  *MemberNormalizer
+	baseDN      string
+	timeout     time.Duration
+}
+
+// NewDelegationManager creates a new delegation manager instance.
+func NewDelegationManager(ctx context.Context, client Client, baseDN string, cacheManager *CacheManager) *DelegationManager {
+	return &DelegationManager{
+		ctx:         ctx,
+		client:      client,
+		guidHandler: NewGUIDHandler(),
+		sidHandler:  NewSIDHandler(),
+		normalizer:  NewMemberNormalizer(client, baseDN, cacheManager),
+		baseDN:      baseDN,
+		timeout:     30 * time.Second,
+	}
+}
+
+// SetTimeout sets the LDAP operation timeout.
+func (dm *DelegationManager) SetTimeout(timeout time.Duration) {
+	dm.timeout = timeout
+	dm.normalizer.SetTimeout(timeout)
+}
+
+// NewAllowedToActSecurityDescriptor builds the security descriptor stored in
+// msDS-AllowedToActOnBehalfOfOtherIdentity: owned by BUILTIN\Administrators,
+// with a DACL granting AccessMaskAllowedToAct to each of sids.
+func NewAllowedToActSecurityDescriptor(sids []string) (*SecurityDescriptor, error) {
+	aces := make([]ACE, 0, len(sids))
+	for _, s := range sids {
+		sid, err := ParseSID(s)
+		if err != nil {
+			return nil, err
+		}
+		aces = append(aces, ACE{
+			AceType:    AccessAllowedACEType,
+			AccessMask: AccessMaskAllowedToAct,
+			SID:        sid,
+		})
+	}
+
+	owner := builtinAdministratorsSID
+	return &SecurityDescriptor{
+		Revision: 1,
+		Control:  SEDACLPresent,
+		Owner:    &owner,
+		DACL:     &ACL{AclRevision: 4, ACEs: aces},
+	}, nil
+}
+
+// AllowedToActSIDs returns the SIDs granted access by the DACL of a
+// msDS-AllowedToActOnBehalfOfOtherIdentity security descriptor.
+func AllowedToActSIDs(sd *SecurityDescriptor) []string {
+	if sd == nil || sd.DACL == nil {
+		return nil
+	}
+	var sids []string
+	for _, ace := range sd.DACL.ACEs {
+		if ace.RawBody != nil || ace.AceType != AccessAllowedACEType {
+			continue
+		}
+		if sid := ace.SID.String(); !slices.Contains(sids, sid) {
+			sids = append(sids, sid)
+		}
+	}
+	return sids
+}
+
+// SIDsEqual reports whether two SID lists hold the same SIDs, ignoring order
+// and case.
+func SIDsEqual(a, b []string) bool {
+	normalize := func(sids []string) []string {
+		out := make([]string, len(sids))
+		for i, sid := range sids {
+			out[i] = strings.ToUpper(sid)
+		}
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+// GetAccount retrieves an account and the principals allowed to delegate to
+// it by objectGUID.
+func (dm *DelegationManager) GetAccount(guid string) (*DelegationAccount, error) {
+	if guid == "" {
+		return nil, fmt.Errorf("account GUID cannot be empty")
+	}
+
+	if !dm.guidHandler.IsValidGUID(guid) {
+		return nil, fmt.Errorf("invalid GUID format: %s", guid)
+	}
+
+	searchReq, err := dm.guidHandler.GenerateGUIDSearchRequest(dm.baseDN, guid)
+	if err != nil {
+		return nil, WrapError("generate_guid_search", err)
+	}
+	searchReq.Attributes = []string{"objectGUID", "distinguishedName", allowedToActAttribute}
+	searchReq.TimeLimit = dm.timeout
+
+	result, err := dm.client.Search(dm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_account_by_guid", err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_account", "account with GUID %s not found", guid)
+	}
+
+	entry := result.Entries[0]
+	account := &DelegationAccount{
+		DistinguishedName: entry.DN,
+	}
+	account.ObjectGUID, err = dm.guidHandler.ExtractGUID(entry)
+	if err != nil {
+		return nil, WrapError("extract_account_guid", err)
+	}
+
+	if raw := entry.GetRawAttributeValue(allowedToActAttribute); len(raw) > 0 {
+		sd, err := UnmarshalSecurityDescriptor(raw)
+		if err != nil {
+			return nil, WrapError("parse_allowed_to_act", err)
+		}
+		account.AllowedPrincipals = AllowedToActSIDs(sd)
+	}
+
+	return account, nil
+}
+
+// ResolvePrincipalSIDs resolves principal identifiers in any format supported
+// by MemberNormalizer (DN, GUID, SID, UPN or SAM account name) to their
+// objectSid. Results and failures are keyed by the identifier as given.
+func (dm *DelegationManager) ResolvePrincipalSIDs(identifiers []string) (map[string]string, map[string]error) {
+	dns, failures := dm.normalizer.NormalizeToDNBatch(identifiers)
+
+	sids := make(map[string]string, len(dns))
+	for identifier, dn := range dns {
+		sid, err := dm.getObjectSID(dn)
+		if err != nil {
+			failures[identifier] = err
+			continue
+		}
+		sids[identifier] = sid
+	}
+
+	return sids, failures
+}
+
+// getObjectSID reads the objectSid of the object at dn.
+func (dm *DelegationManager) getObjectSID(dn string) (string, error) {
+	searchReq := &SearchRequest{
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=*)",
+		Attributes: []string{"objectSid"},
+		SizeLimit:  1,
+		TimeLimit:  dm.timeout,
+	}
+
+	result, err := dm.client.Search(dm.ctx, searchReq)
+	if err != nil {
+		return "", WrapError("search_object_sid", err)
+	}
+	if len(result.Entries) == 0 {
+		return "", NewNotFoundError("get_object_sid", "object not found at DN: %s", dn)
+	}
+
+	sid := dm.sidHandler.ExtractSIDSafe(result.Entries[0])
+	if sid == "" {
+		return "", fmt.Errorf("%s has no objectSid and cannot be granted delegation", dn)
+	}
+	return sid, nil
+}
+
+// SetAllowedPrincipals replaces the principals allowed to delegate to the
+// account with the given objectGUID. An empty list removes
+// msDS-AllowedToActOnBehalfOfOtherIdentity.
+func (dm *DelegationManager) SetAllowedPrincipals(guid string, sids []string) error {
+	account, err := dm.GetAccount(guid)
+	if err != nil {
+		return WrapError("get_account_for_delegation", err)
+	}
+
+	if SIDsEqual(sids, account.AllowedPrincipals) {
+		return nil
+	}
+
+	tflog.SubsystemDebug(dm.ctx, "ldap", "Setting resource-based constrained delegation", map[string]any{
+		"account_dn":      account.DistinguishedName,
+		"principal_count": len(sids),
+	})
+
+	modReq := &ModifyRequest{DN: account.DistinguishedName}
+	if len(sids) == 0 {
+		modReq.DeleteAttributes = []string{allowedToActAttribute}
+	} else {
+		sd, err := NewAllowedToActSecurityDescriptor(sids)
+		if err != nil {
+			return WrapError("build_allowed_to_act", err)
+		}
+		raw, err := sd.Marshal()
+		if err != nil {
+			return WrapError("marshal_allowed_to_act", err)
+		}
+		modReq.ReplaceAttributes = map[string][]string{allowedToActAttribute: {string(raw)}}
+	}
+
+	if err := dm.client.Modify(dm.ctx, modReq); err != nil {
+		if len(sids) == 0 && ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return nil
+		}
+		return WrapError("set_allowed_to_act", err)
+	}
+
+	return nil
+}
+
+// ClearAllowedPrincipals removes every principal allowed to delegate to the
+// account with the given objectGUID. Clearing an account that no longer
+// exists succeeds.
+func (dm *DelegationManager) ClearAllowedPrincipals(guid string) error {
+	err := dm.SetAllowedPrincipals(guid, nil)
+	if IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
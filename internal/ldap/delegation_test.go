@@ -0,0 +1,167 @@
+package ldap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockDelegationEntry returns an account entry whose
+// msDS-AllowedToActOnBehalfOfOtherIdentity grants the given SIDs.
+func createMockDelegationEntry(t *testing.T, guid, dn string, sids ...string) *ldap.Entry {
+	t.Helper()
+
+	guidBytes, err := NewGUIDHandler().StringToGUIDBytes(guid)
+	require.NoError(t, err)
+
+	entry := &ldap.Entry{
+		DN: dn,
+		Attributes: []*ldap.EntryAttribute{
+			{Name: "objectGUID", ByteValues: [][]byte{guidBytes}},
+			{Name: "distinguishedName", Values: []string{dn}},
+		},
+	}
+	if len(sids) > 0 {
+		sd, err := NewAllowedToActSecurityDescriptor(sids)
+		require.NoError(t, err)
+		raw, err := sd.Marshal()
+		require.NoError(t, err)
+		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{
+			Name: allowedToActAttribute, ByteValues: [][]byte{raw},
+		})
+	}
+	return entry
+}
+
+func TestAllowedToActSecurityDescriptor_RoundTrip(t *testing.T) {
+	sids := []string{
+		"S-1-5-21-1004336348-1177238915-682003330-1105",
+		"S-1-5-21-1004336348-1177238915-682003330-1106",
+	}
+
+	sd, err := NewAllowedToActSecurityDescriptor(sids)
+	require.NoError(t, err)
+	raw, err := sd.Marshal()
+	require.NoError(t, err)
+
+	parsed, err := UnmarshalSecurityDescriptor(raw)
+	require.NoError(t, err)
+	require.NotNil(t, parsed.Owner)
+	assert.Equal(t, "S-1-5-32-544", parsed.Owner.String())
+	assert.NotZero(t, parsed.Control&SEDACLPresent)
+	for _, ace := range parsed.DACL.ACEs {
+		assert.Equal(t, AccessAllowedACEType, ace.AceType)
+		assert.Equal(t, AccessMaskAllowedToAct, ace.AccessMask)
+	}
+	assert.Equal(t, sids, AllowedToActSIDs(parsed))
+
+	_, err = NewAllowedToActSecurityDescriptor([]string{"not-a-sid"})
+	assert.Error(t, err)
+}
+
+func TestAllowedToActSIDs_IgnoresOtherACEs(t *testing.T) {
+	sid, err := ParseSID("S-1-5-21-1-2-3-1105")
+	require.NoError(t, err)
+
+	sd := &SecurityDescriptor{DACL: &ACL{ACEs: []ACE{
+		{AceType: AccessDeniedACEType, AccessMask: AccessMaskAllowedToAct, SID: everyoneSIDValue},
+		{AceType: 0x05, RawBody: []byte{0, 0, 0, 0}},
+		{AceType: AccessAllowedACEType, AccessMask: AccessMaskAllowedToAct, SID: sid},
+		{AceType: AccessAllowedACEType, AccessMask: AccessMaskAllowedToAct, SID: sid},
+	}}}
+
+	assert.Equal(t, []string{"S-1-5-21-1-2-3-1105"}, AllowedToActSIDs(sd))
+	assert.Nil(t, AllowedToActSIDs(&SecurityDescriptor{}))
+}
+
+func TestResolvePrincipalSIDs(t *testing.T) {
+	webDN := "CN=WEB01,OU=Servers,DC=test,DC=local"
+	webSID := "S-1-5-21-1-2-3-1105"
+	webSIDBytes, err := NewSIDHandler().StringToSIDBytes(webSID)
+	require.NoError(t, err)
+
+	client := &MockClient{}
+	// DN validation by the normalizer
+	client.On("Search", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == webDN && slices.Equal(req.Attributes, []string{"distinguishedName"})
+	})).Return(&SearchResult{Entries: []*ldap.Entry{{
+		DN:         webDN,
+		Attributes: []*ldap.EntryAttribute{{Name: "distinguishedName", Values: []string{webDN}}},
+	}}}, nil)
+	client.On("Search", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == webDN && slices.Equal(req.Attributes, []string{"objectSid"})
+	})).Return(&SearchResult{Entries: []*ldap.Entry{{
+		DN:         webDN,
+		Attributes: []*ldap.EntryAttribute{{Name: "objectSid", ByteValues: [][]byte{webSIDBytes}}},
+	}}}, nil)
+	client.On("Search", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == "CN=Missing,DC=test,DC=local"
+	})).Return(&SearchResult{}, nil)
+
+	dm := NewDelegationManager(t.Context(), client, "DC=test,DC=local", nil)
+	sids, failures := dm.ResolvePrincipalSIDs([]string{webDN, "CN=Missing,DC=test,DC=local"})
+
+	assert.Equal(t, map[string]string{webDN: webSID}, sids)
+	assert.Len(t, failures, 1)
+	assert.Contains(t, failures, "CN=Missing,DC=test,DC=local")
+}
+
+func TestSetAllowedPrincipals(t *testing.T) {
+	guid := "12345678-1234-1234-1234-123456789012"
+	dn := "CN=SQL01,OU=Servers,DC=test,DC=local"
+	sid := "S-1-5-21-1-2-3-1105"
+
+	t.Run("writes a security descriptor", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockDelegationEntry(t, guid, dn)}}, nil).Once()
+		client.On("Modify", mock.Anything, mock.MatchedBy(func(req *ModifyRequest) bool {
+			values := req.ReplaceAttributes[allowedToActAttribute]
+			if req.DN != dn || len(values) != 1 {
+				return false
+			}
+			sd, err := UnmarshalSecurityDescriptor([]byte(values[0]))
+			return err == nil && slices.Equal(AllowedToActSIDs(sd), []string{sid})
+		})).Return(nil).Once()
+
+		dm := NewDelegationManager(t.Context(), client, "DC=test,DC=local", nil)
+		require.NoError(t, dm.SetAllowedPrincipals(guid, []string{sid}))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("unchanged principals are not written", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockDelegationEntry(t, guid, dn, sid)}}, nil).Once()
+
+		dm := NewDelegationManager(t.Context(), client, "DC=test,DC=local", nil)
+		require.NoError(t, dm.SetAllowedPrincipals(guid, []string{sid}))
+		client.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty list removes the attribute", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockDelegationEntry(t, guid, dn, sid)}}, nil).Once()
+		client.On("Modify", mock.Anything, mock.MatchedBy(func(req *ModifyRequest) bool {
+			return req.DN == dn && slices.Equal(req.DeleteAttributes, []string{allowedToActAttribute})
+		})).Return(nil).Once()
+
+		dm := NewDelegationManager(t.Context(), client, "DC=test,DC=local", nil)
+		require.NoError(t, dm.SetAllowedPrincipals(guid, nil))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("clearing a missing account succeeds", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{}, nil).Once()
+
+		dm := NewDelegationManager(t.Context(), client, "DC=test,DC=local", nil)
+		require.NoError(t, dm.ClearAllowedPrincipals(guid))
+		client.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+	})
+}
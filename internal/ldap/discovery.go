@@ -2,6 +2,7 @@ package ldap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sort"
@@ -15,14 +16,54 @@ import (
 // SRVDiscovery handles DNS SRV record discovery for domain controllers.
 type SRVDiscovery struct {
 	ctx      context.Context // Logging context with LDAP subsystem
+	resolver SRVResolver
+	ping     func(ctx context.Context, host, domain string, timeout time.Duration) (*NetlogonResponse, error)
+}
+
+// DefaultSRVTTL is used when the resolver cannot report a record's TTL. It
+// matches the TTL with which domain controllers register their locator
+// records.
+const DefaultSRVTTL = 10 * time.Minute
+
+// SRVResolver looks up DNS SRV records. The returned TTL is the time-to-live
+// of the record set; resolvers that cannot report it return zero.
+type SRVResolver interface {
+	LookupSRV(ctx context.Context, name string) ([]*net.SRV, time.Duration, error)
+}
+
+// netSRVResolver adapts net.Resolver to SRVResolver. The standard library
+// does not expose TTLs, so it always reports zero.
+type netSRVResolver struct {
 	resolver *net.Resolver
 }
 
+func (r netSRVResolver) LookupSRV(ctx context.Context, name string) ([]*net.SRV, time.Duration, error) {
+	_, records, err := r.resolver.LookupSRV(ctx, "", "", name)
+	return records, 0, err
+}
+
+// newSRVResolver returns the resolver used by NewSRVDiscovery. It is a
+// package-level variable so tests can substitute a fake DNS.
+var newSRVResolver = func() SRVResolver {
+	return newDNSSRVResolver()
+}
+
+// Site detection limits.
+const (
+	// siteDetectionMaxServers bounds how many DCs are pinged concurrently.
+	siteDetectionMaxServers = 3
+
+	// siteDetectionTimeout bounds the CLDAP ping so that networks which
+	// drop UDP 389 only delay startup briefly.
+	siteDetectionTimeout = 2 * time.Second
+)
+
 // NewSRVDiscovery creates a new SRV discovery instance.
 func NewSRVDiscovery(ctx context.Context) *SRVDiscovery {
 	return &SRVDiscovery{
 		ctx:      ctx,
-		resolver: net.DefaultResolver,
+		resolver: newSRVResolver(),
+		ping:     NetlogonPing,
 	}
 }
 
@@ -31,17 +72,146 @@ func NewSRVDiscovery(ctx context.Context) *SRVDiscovery {
 // Returns error if no SRV records found. Use ldap_url provider configuration
 // to specify servers directly when SRV records are not available.
 func (d *SRVDiscovery) DiscoverServers(ctx context.Context, domain string) ([]*ServerInfo, error) {
-	start := time.Now()
-	tflog.SubsystemDebug(d.ctx, "ldap", "Starting server discovery for domain", map[string]any{
-		"domain": domain,
-	})
-
 	if domain == "" {
 		return nil, fmt.Errorf("domain cannot be empty")
 	}
 
-	// Query standard Active Directory SRV record
-	service := "_ldap._tcp." + domain
+	return d.discoverService(ctx, domain, "_ldap._tcp."+domain)
+}
+
+// DiscoverSiteServers discovers LDAP servers for a domain, preferring the
+// site-specific SRV record _ldap._tcp.<site>._sites.<domain>. When site is
+// empty or the site record yields no servers, it falls back to the
+// domain-wide record. The returned bool reports whether site-specific
+// servers were used.
+func (d *SRVDiscovery) DiscoverSiteServers(ctx context.Context, domain, site string) ([]*ServerInfo, bool, error) {
+	if domain == "" {
+		return nil, false, fmt.Errorf("domain cannot be empty")
+	}
+
+	if site != "" {
+		if err := ValidateSiteName(site); err != nil {
+			return nil, false, err
+		}
+
+		servers, err := d.discoverService(ctx, domain, "_ldap._tcp."+site+"._sites."+domain)
+		if err == nil {
+			return servers, true, nil
+		}
+		tflog.SubsystemWarn(d.ctx, "ldap", "No domain controllers found for site, falling back to domain-wide discovery", map[string]any{
+			"domain": domain,
+			"site":   site,
+			"error":  err.Error(),
+		})
+	}
+
+	servers, err := d.DiscoverServers(ctx, domain)
+	return servers, false, err
+}
+
+// DetectSite determines the AD site of this client by sending CLDAP netlogon
+// pings to up to three of the given servers concurrently and returning the
+// first ClientSiteName reported. An empty site with a nil error means the
+// DCs answered but the client's subnet is not mapped to a site.
+func (d *SRVDiscovery) DetectSite(ctx context.Context, domain string, servers []*ServerInfo) (string, error) {
+	if len(servers) == 0 {
+		return "", fmt.Errorf("no servers available for site detection")
+	}
+	if len(servers) > siteDetectionMaxServers {
+		servers = servers[:siteDetectionMaxServers]
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, siteDetectionTimeout)
+	defer cancel()
+
+	type result struct {
+		host string
+		resp *NetlogonResponse
+		err  error
+	}
+	results := make(chan result, len(servers))
+	for _, server := range servers {
+		go func(host string) {
+			resp, err := d.ping(ctx, host, domain, siteDetectionTimeout)
+			results <- result{host: host, resp: resp, err: err}
+		}(server.Host)
+	}
+
+	var errs []error
+	for range servers {
+		r := <-results
+		if r.err != nil {
+			tflog.SubsystemDebug(d.ctx, "ldap", "CLDAP netlogon ping failed", map[string]any{
+				"server": r.host,
+				"error":  r.err.Error(),
+			})
+			errs = append(errs, r.err)
+			continue
+		}
+
+		if r.resp.ClientSiteName != "" {
+			if err := ValidateSiteName(r.resp.ClientSiteName); err != nil {
+				tflog.SubsystemWarn(d.ctx, "ldap", "Ignoring invalid client site name from CLDAP netlogon ping", map[string]any{
+					"server": r.host,
+					"error":  err.Error(),
+				})
+				errs = append(errs, fmt.Errorf("%s: %w", r.host, err))
+				continue
+			}
+		}
+
+		tflog.SubsystemInfo(d.ctx, "ldap", "Detected client site via CLDAP netlogon ping", map[string]any{
+			"server":      r.host,
+			"client_site": r.resp.ClientSiteName,
+			"dc_site":     r.resp.DCSiteName,
+		})
+		return r.resp.ClientSiteName, nil
+	}
+
+	return "", fmt.Errorf("site detection failed: %w", errors.Join(errs...))
+}
+
+// ValidateSiteName checks that site can be used as the <site> label of
+// _ldap._tcp.<site>._sites.<domain>: a single DNS label of at most 63
+// letters, digits, hyphens or underscores that does not start or end with a
+// hyphen.
+func ValidateSiteName(site string) error {
+	if site == "" || len(site) > 63 {
+		return fmt.Errorf("invalid site name %q: must be 1 to 63 characters", site)
+	}
+	if site[0] == '-' || site[len(site)-1] == '-' {
+		return fmt.Errorf("invalid site name %q: must not start or end with a hyphen", site)
+	}
+	for _, c := range site {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("invalid site name %q: must contain only letters, digits, hyphens and underscores", site)
+		}
+	}
+	return nil
+}
+
+// DiscoverGlobalCatalogServers discovers Global Catalog servers for a forest
+// using the _gc._tcp.<forest> SRV record. The returned servers point at the
+// GC LDAP port (3268); callers still verify each server's
+// isGlobalCatalogReady RootDSE attribute before trusting it.
+func (d *SRVDiscovery) DiscoverGlobalCatalogServers(ctx context.Context, forest string) ([]*ServerInfo, error) {
+	if forest == "" {
+		return nil, fmt.Errorf("forest cannot be empty")
+	}
+
+	return d.discoverService(ctx, forest, "_gc._tcp."+forest)
+}
+
+// discoverService performs SRV discovery for a single service name and
+// returns the servers sorted by priority and weight.
+func (d *SRVDiscovery) discoverService(ctx context.Context, domain, service string) ([]*ServerInfo, error) {
+	start := time.Now()
+	tflog.SubsystemDebug(d.ctx, "ldap", "Starting server discovery for domain", map[string]any{
+		"domain":  domain,
+		"service": service,
+	})
 
 	tflog.SubsystemDebug(d.ctx, "ldap", "Attempting SRV lookup", map[string]any{
 		"service": service,
@@ -91,7 +261,7 @@ func (d *SRVDiscovery) lookupSRV(ctx context.Context, service string) ([]*Server
 		"service": service,
 	})
 
-	_, srvRecords, err := d.resolver.LookupSRV(ctx, "", "", service)
+	srvRecords, ttl, err := d.resolver.LookupSRV(ctx, service)
 	duration := time.Since(start)
 
 	if err != nil {
@@ -107,12 +277,17 @@ func (d *SRVDiscovery) lookupSRV(ctx context.Context, service string) ([]*Server
 		"service":      service,
 		"duration":     duration.String(),
 		"record_count": len(srvRecords),
+		"ttl":          ttl.String(),
 	})
 
 	if len(srvRecords) == 0 {
 		return nil, fmt.Errorf("no SRV records found for %s", service)
 	}
 
+	if ttl <= 0 {
+		ttl = DefaultSRVTTL
+	}
+
 	var servers []*ServerInfo
 	for _, srv := range srvRecords {
 		// Remove trailing dot from hostname if present
@@ -125,6 +300,7 @@ func (d *SRVDiscovery) lookupSRV(ctx context.Context, service string) ([]*Server
 			Priority: int(srv.Priority),
 			Weight:   int(srv.Weight),
 			Source:   "srv", // This function is specifically for SRV discovery
+			TTL:      ttl,
 		}
 		servers = append(servers, server)
 	}
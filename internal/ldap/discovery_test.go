@@ -2,6 +2,9 @@ package ldap
 
 import (
 	"context"
+	"errors"
+	"net"
+	"strings"
 	"testing"
 	"time"
 )
@@ -318,3 +321,144 @@ func TestSortServersByPriority(t *testing.T) {
 		t.Errorf("Last server priority = %d, want 2", servers[len(servers)-1].Priority)
 	}
 }
+
+func TestSRVDiscovery_DetectSite(t *testing.T) {
+	servers := []*ServerInfo{
+		{Host: "dc1.example.com", Port: 389},
+		{Host: "dc2.example.com", Port: 389},
+	}
+
+	t.Run("first successful ping wins", func(t *testing.T) {
+		discovery := NewSRVDiscovery(t.Context())
+		discovery.ping = func(_ context.Context, host, domain string, _ time.Duration) (*NetlogonResponse, error) {
+			if domain != "example.com" {
+				t.Errorf("ping domain = %q, want example.com", domain)
+			}
+			if host == "dc1.example.com" {
+				return nil, errors.New("timeout")
+			}
+			return &NetlogonResponse{DCSiteName: "Hub", ClientSiteName: "London"}, nil
+		}
+
+		site, err := discovery.DetectSite(t.Context(), "example.com", servers)
+		if err != nil {
+			t.Fatalf("DetectSite() unexpected error: %v", err)
+		}
+		if site != "London" {
+			t.Errorf("DetectSite() = %q, want London", site)
+		}
+	})
+
+	t.Run("all pings fail", func(t *testing.T) {
+		discovery := NewSRVDiscovery(t.Context())
+		discovery.ping = func(context.Context, string, string, time.Duration) (*NetlogonResponse, error) {
+			return nil, errors.New("udp blocked")
+		}
+
+		if _, err := discovery.DetectSite(t.Context(), "example.com", servers); err == nil {
+			t.Error("DetectSite() expected error but got none")
+		}
+	})
+
+	t.Run("pings at most three servers", func(t *testing.T) {
+		discovery := NewSRVDiscovery(t.Context())
+		pinged := make(chan string, 10)
+		discovery.ping = func(_ context.Context, host, _ string, _ time.Duration) (*NetlogonResponse, error) {
+			pinged <- host
+			return nil, errors.New("no answer")
+		}
+
+		many := []*ServerInfo{{Host: "a"}, {Host: "b"}, {Host: "c"}, {Host: "d"}, {Host: "e"}}
+		_, _ = discovery.DetectSite(t.Context(), "example.com", many)
+		if got := len(pinged); got != siteDetectionMaxServers {
+			t.Errorf("pinged %d servers, want %d", got, siteDetectionMaxServers)
+		}
+	})
+
+	t.Run("invalid site name ignored", func(t *testing.T) {
+		discovery := NewSRVDiscovery(t.Context())
+		discovery.ping = func(context.Context, string, string, time.Duration) (*NetlogonResponse, error) {
+			return &NetlogonResponse{ClientSiteName: "evil.example.net"}, nil
+		}
+
+		if _, err := discovery.DetectSite(t.Context(), "example.com", servers); err == nil {
+			t.Error("DetectSite() expected error for invalid site name")
+		}
+	})
+
+	t.Run("no servers", func(t *testing.T) {
+		discovery := NewSRVDiscovery(t.Context())
+		if _, err := discovery.DetectSite(t.Context(), "example.com", nil); err == nil {
+			t.Error("DetectSite() expected error but got none")
+		}
+	})
+}
+
+func TestSRVDiscovery_DiscoverSiteServers(t *testing.T) {
+	discovery := NewSRVDiscovery(t.Context())
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, _, err := discovery.DiscoverSiteServers(ctx, "", "London"); err == nil {
+		t.Error("DiscoverSiteServers() expected error for empty domain")
+	}
+
+	if _, _, err := discovery.DiscoverSiteServers(ctx, "example.com", "a.b"); err == nil {
+		t.Error("DiscoverSiteServers() expected error for invalid site name")
+	}
+
+	// Both the site record and the domain-wide fallback are missing.
+	_, siteMatched, err := discovery.DiscoverSiteServers(ctx, "nonexistent.invalid.domain.test", "London")
+	if err == nil {
+		t.Error("DiscoverSiteServers() expected error but got none")
+	}
+	if siteMatched {
+		t.Error("DiscoverSiteServers() reported site match on failure")
+	}
+}
+
+func TestValidateSiteName(t *testing.T) {
+	for _, site := range []string{"London", "Default-First-Site-Name", "site_1", "A"} {
+		if err := ValidateSiteName(site); err != nil {
+			t.Errorf("ValidateSiteName(%q) unexpected error: %v", site, err)
+		}
+	}
+	for _, site := range []string{"", "a.b", "-site", "site-", "with space", "s\x00", strings.Repeat("a", 64)} {
+		if err := ValidateSiteName(site); err == nil {
+			t.Errorf("ValidateSiteName(%q) expected error", site)
+		}
+	}
+}
+
+func TestSRVDiscovery_LookupSRVTTL(t *testing.T) {
+	resolver := &fakeSRVResolver{}
+	resolver.set("_ldap._tcp.example.com",
+		&net.SRV{Target: "dc2.example.com.", Port: 389, Priority: 0, Weight: 50},
+		&net.SRV{Target: "dc1.example.com.", Port: 389, Priority: 0, Weight: 100},
+	)
+	swapSRVResolver(t, resolver)
+	discovery := NewSRVDiscovery(t.Context())
+
+	servers, err := discovery.DiscoverServers(t.Context(), "example.com")
+	if err != nil {
+		t.Fatalf("DiscoverServers() unexpected error: %v", err)
+	}
+	if len(servers) != 2 || servers[0].Host != "dc1.example.com" {
+		t.Fatalf("DiscoverServers() = %v, want dc1 first", servers)
+	}
+	for _, server := range servers {
+		if server.TTL != DefaultSRVTTL {
+			t.Errorf("server %s TTL = %v, want default %v when resolver reports none", server.Host, server.TTL, DefaultSRVTTL)
+		}
+	}
+
+	resolver.ttl = 90 * time.Second
+	servers, err = discovery.DiscoverServers(t.Context(), "example.com")
+	if err != nil {
+		t.Fatalf("DiscoverServers() unexpected error: %v", err)
+	}
+	if servers[0].TTL != 90*time.Second {
+		t.Errorf("TTL = %v, want 90s", servers[0].TTL)
+	}
+}
@@ -0,0 +1,76 @@
+package ldap
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// readExtraAttributes returns the values of the named attributes from an
+// entry. Names are matched case-insensitively because AD returns attributes
+// under their schema lDAPDisplayName regardless of how they were requested.
+// The result is keyed by the requested names and omits attributes without
+// values.
+func readExtraAttributes(entry *ldap.Entry, names []string) map[string][]string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	values := make(map[string][]string, len(names))
+	for _, name := range names {
+		for _, attr := range entry.Attributes {
+			if strings.EqualFold(attr.Name, name) && len(attr.Values) > 0 {
+				values[name] = attr.Values
+				break
+			}
+		}
+	}
+	return values
+}
+
+// addExtraAttributes adds the non-empty extra attributes to the attributes of
+// an add request.
+func addExtraAttributes(attributes map[string][]string, extra map[string][]string) {
+	for name, values := range extra {
+		if len(values) > 0 {
+			attributes[name] = values
+		}
+	}
+}
+
+// calculateExtraAttributeChanges adds a modification for each requested
+// extra attribute whose values differ from the current ones, following the
+// semantics of addModifyAttribute: values replace the attribute and an empty
+// list deletes it. Values are compared without regard to order, as AD does
+// not preserve the order of multi-valued attributes. Returns true if any
+// change was added.
+func calculateExtraAttributeChanges(modReq *ModifyRequest, requested, current map[string][]string) bool {
+	hasChanges := false
+	for name, values := range requested {
+		currentValues := current[name]
+		if SameAttributeValues(values, currentValues) {
+			continue
+		}
+
+		if len(values) == 0 {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, name)
+		} else {
+			modReq.ReplaceAttributes[name] = values
+		}
+		hasChanges = true
+	}
+	return hasChanges
+}
+
+// SameAttributeValues reports whether two attribute value lists hold the
+// same values, ignoring order, as AD does not preserve value order.
+func SameAttributeValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA, sortedB := slices.Clone(a), slices.Clone(b)
+	slices.Sort(sortedA)
+	slices.Sort(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
@@ -0,0 +1,55 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadExtraAttributes(t *testing.T) {
+	entry := ldap.NewEntry("CN=jdoe,DC=test,DC=local", map[string][]string{
+		"extensionAttribute1": {"finance"},
+		"employeeType":        {"contractor"},
+		"otherTelephone":      {"+1 555 0100", "+1 555 0101"},
+	})
+
+	values := readExtraAttributes(entry, []string{"extensionattribute1", "otherTelephone", "costCenter"})
+
+	assert.Equal(t, map[string][]string{
+		"extensionattribute1": {"finance"},
+		"otherTelephone":      {"+1 555 0100", "+1 555 0101"},
+	}, values)
+	assert.Nil(t, readExtraAttributes(entry, nil))
+}
+
+func TestCalculateExtraAttributeChanges(t *testing.T) {
+	current := map[string][]string{
+		"employeeType":   {"contractor"},
+		"otherTelephone": {"+1 555 0100", "+1 555 0101"},
+		"costCenter":     {"4711"},
+	}
+	requested := map[string][]string{
+		"employeeType":        {"employee"},
+		"otherTelephone":      {"+1 555 0101", "+1 555 0100"},
+		"costCenter":          {},
+		"extensionAttribute1": {},
+		"extensionAttribute2": {"finance"},
+	}
+
+	modReq := &ModifyRequest{ReplaceAttributes: make(map[string][]string)}
+	require.True(t, calculateExtraAttributeChanges(modReq, requested, current))
+
+	assert.Equal(t, map[string][]string{
+		"employeeType":        {"employee"},
+		"extensionAttribute2": {"finance"},
+	}, modReq.ReplaceAttributes)
+	assert.Equal(t, []string{"costCenter"}, modReq.DeleteAttributes)
+
+	unchanged := &ModifyRequest{ReplaceAttributes: make(map[string][]string)}
+	assert.False(t, calculateExtraAttributeChanges(unchanged, map[string][]string{"costCenter": {"4711"}}, current))
+	assert.False(t, calculateExtraAttributeChanges(unchanged, nil, current))
+	assert.Empty(t, unchanged.ReplaceAttributes)
+	assert.Empty(t, unchanged.DeleteAttributes)
+}
@@ -0,0 +1,240 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Global Catalog ports.
+const (
+	// GlobalCatalogPort is the plain LDAP (StartTLS capable) GC port.
+	GlobalCatalogPort = 3268
+	// GlobalCatalogTLSPort is the LDAPS GC port.
+	GlobalCatalogTLSPort = 3269
+)
+
+// defaultPartialAttributeSet contains the attributes that a default AD schema
+// replicates to the Global Catalog and that this provider reads. It is used
+// when the live partial attribute set cannot be read from the schema, and for
+// plan-time validation where no connection is available.
+var defaultPartialAttributeSet = map[string]struct{}{
+	"cn":                         {},
+	"description":                {},
+	"displayname":                {},
+	"distinguishedname":          {},
+	"givenname":                  {},
+	"grouptype":                  {},
+	"mail":                       {},
+	"managedby":                  {},
+	"manager":                    {},
+	"member":                     {},
+	"memberof":                   {},
+	"name":                       {},
+	"objectcategory":             {},
+	"objectclass":                {},
+	"objectguid":                 {},
+	"objectsid":                  {},
+	"physicaldeliveryofficename": {},
+	"primarygroupid":             {},
+	"samaccountname":             {},
+	"samaccounttype":             {},
+	"serviceprincipalname":       {},
+	"sn":                         {},
+	"telephonenumber":            {},
+	"useraccountcontrol":         {},
+	"userprincipalname":          {},
+	"whenchanged":                {},
+	"whencreated":                {},
+}
+
+// IsPartialAttribute reports whether the attribute is part of the default
+// Global Catalog partial attribute set. The comparison is case-insensitive.
+func IsPartialAttribute(name string) bool {
+	_, ok := defaultPartialAttributeSet[strings.ToLower(name)]
+	return ok
+}
+
+// NonPartialAttributes returns the attributes that are not replicated to the
+// Global Catalog by default, preserving order and removing duplicates.
+func NonPartialAttributes(attributes []string) []string {
+	var result []string
+	seen := make(map[string]struct{})
+	for _, attr := range attributes {
+		key := strings.ToLower(attr)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		if !IsPartialAttribute(attr) {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// filterPartialAttributes keeps only the attributes present in the partial
+// attribute set. An empty request is translated to the full set so that a GC
+// search never asks for "all attributes" and silently receives fewer.
+func filterPartialAttributes(attributes []string, pas map[string]struct{}) []string {
+	if len(attributes) == 0 {
+		result := make([]string, 0, len(pas))
+		for attr := range pas {
+			result = append(result, attr)
+		}
+		return result
+	}
+
+	result := make([]string, 0, len(attributes))
+	for _, attr := range attributes {
+		if _, ok := pas[strings.ToLower(attr)]; ok {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// globalCatalogServer maps a configured domain controller endpoint to its
+// Global Catalog endpoint: LDAPS to 3269, everything else to 3268.
+func globalCatalogServer(server *ServerInfo) *ServerInfo {
+	gc := *server
+	if server.UseTLS {
+		gc.Port = GlobalCatalogTLSPort
+	} else {
+		gc.Port = GlobalCatalogPort
+	}
+	return &gc
+}
+
+// verifyGlobalCatalogReady reads isGlobalCatalogReady from the RootDSE and
+// returns an error unless the server advertises itself as a ready GC.
+func verifyGlobalCatalogReady(ops ldapOps) error {
+	req := ldap.NewSearchRequest(
+		"",
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		1,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"isGlobalCatalogReady"},
+		nil,
+	)
+
+	result, err := ops.Search(req)
+	if err != nil {
+		return fmt.Errorf("failed to read RootDSE: %w", err)
+	}
+	if len(result.Entries) == 0 {
+		return fmt.Errorf("RootDSE returned no entries")
+	}
+	if !parseADBool(result.Entries[0].GetAttributeValue("isGlobalCatalogReady")) {
+		return fmt.Errorf("isGlobalCatalogReady is not TRUE")
+	}
+	return nil
+}
+
+// searchPool returns the connection pool and attribute list to use for a
+// search request, switching to the Global Catalog pool when requested.
+func (c *client) searchPool(ctx context.Context, req *SearchRequest) (ConnectionPool, []string, error) {
+	if !req.GlobalCatalog {
+		return c.pool, req.Attributes, nil
+	}
+
+	pool, err := c.globalCatalogPool(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pool, filterPartialAttributes(req.Attributes, c.partialAttributeSet(ctx)), nil
+}
+
+// globalCatalogPool lazily creates the Global Catalog connection pool for the
+// forest of the configured domain.
+func (c *client) globalCatalogPool(ctx context.Context) (ConnectionPool, error) {
+	c.gcMu.Lock()
+	defer c.gcMu.Unlock()
+
+	if c.gcPool != nil {
+		return c.gcPool, nil
+	}
+
+	forest := c.config.Domain
+	if rootDSE, err := c.GetRootDSE(ctx); err == nil && rootDSE.Forest.Name != "" {
+		forest = rootDSE.Forest.Name
+	}
+
+	tflog.SubsystemDebug(c.ctx, "ldap", "Creating Global Catalog connection pool", map[string]any{
+		"forest": forest,
+	})
+
+	pool, err := NewGlobalCatalogPool(c.ctx, c.config, forest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create global catalog connection pool: %w", err)
+	}
+	c.gcPool = pool
+	return pool, nil
+}
+
+// partialAttributeSet returns the forest's partial attribute set, read once
+// from the schema naming context. The default set is used if the schema
+// cannot be read.
+func (c *client) partialAttributeSet(ctx context.Context) map[string]struct{} {
+	c.gcMu.Lock()
+	defer c.gcMu.Unlock()
+
+	if c.pas != nil {
+		return c.pas
+	}
+
+	pas, err := c.loadPartialAttributeSet(ctx)
+	if err != nil {
+		tflog.SubsystemWarn(c.ctx, "ldap", "Failed to read partial attribute set from schema, using defaults", map[string]any{
+			"error": err.Error(),
+		})
+		pas = defaultPartialAttributeSet
+	}
+	c.pas = pas
+	return pas
+}
+
+func (c *client) loadPartialAttributeSet(ctx context.Context) (map[string]struct{}, error) {
+	rootDSE, err := c.GetRootDSE(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rootDSE.SchemaNamingContext == "" {
+		return nil, fmt.Errorf("RootDSE has no schemaNamingContext")
+	}
+
+	result, err := c.SearchWithPaging(ctx, &SearchRequest{
+		BaseDN:     rootDSE.SchemaNamingContext,
+		Scope:      ScopeSingleLevel,
+		Filter:     "(&(objectClass=attributeSchema)(isMemberOfPartialAttributeSet=TRUE))",
+		Attributes: []string{"lDAPDisplayName"},
+		TimeLimit:  c.config.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schema for partial attribute set: %w", err)
+	}
+
+	pas := make(map[string]struct{}, len(result.Entries)+2)
+	for _, entry := range result.Entries {
+		if name := entry.GetAttributeValue("lDAPDisplayName"); name != "" {
+			pas[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	if len(pas) == 0 {
+		return nil, fmt.Errorf("schema returned no partial attribute set members")
+	}
+
+	// distinguishedName is constructed and memberOf is a back-link; every GC
+	// returns both regardless of how the schema flags them.
+	pas["distinguishedname"] = struct{}{}
+	pas["memberof"] = struct{}{}
+
+	return pas, nil
+}
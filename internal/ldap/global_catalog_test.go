@@ -0,0 +1,197 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalCatalogServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   *ServerInfo
+		wantPort int
+		wantTLS  bool
+	}{
+		{
+			name:     "ldaps maps to 3269",
+			server:   &ServerInfo{Host: "dc1.example.com", Port: 636, UseTLS: true},
+			wantPort: GlobalCatalogTLSPort,
+			wantTLS:  true,
+		},
+		{
+			name:     "ldap maps to 3268",
+			server:   &ServerInfo{Host: "dc1.example.com", Port: 389},
+			wantPort: GlobalCatalogPort,
+		},
+		{
+			name:     "custom port maps to 3268",
+			server:   &ServerInfo{Host: "dc1.example.com", Port: 1389},
+			wantPort: GlobalCatalogPort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := globalCatalogServer(tt.server)
+			assert.Equal(t, tt.server.Host, gc.Host)
+			assert.Equal(t, tt.wantPort, gc.Port)
+			assert.Equal(t, tt.wantTLS, gc.UseTLS)
+			assert.NotSame(t, tt.server, gc, "input must not be modified")
+		})
+	}
+}
+
+func TestNewGlobalCatalogPool_MapsConfiguredURLs(t *testing.T) {
+	config := DefaultConfig()
+	config.LDAPURLs = []string{"ldaps://dc1.example.com:636", "ldap://dc2.example.com"}
+	config.HealthCheck = 0
+
+	pool, err := NewGlobalCatalogPool(context.Background(), config, "example.com")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pool.Close() })
+
+	cp, ok := pool.(*connectionPool)
+	require.True(t, ok)
+	require.Len(t, cp.servers, 2)
+	assert.True(t, cp.globalCatalog)
+	assert.Equal(t, GlobalCatalogTLSPort, cp.servers[0].Port)
+	assert.Equal(t, GlobalCatalogPort, cp.servers[1].Port)
+}
+
+func TestIsPartialAttribute(t *testing.T) {
+	assert.True(t, IsPartialAttribute("sAMAccountName"))
+	assert.True(t, IsPartialAttribute("samaccountname"))
+	assert.True(t, IsPartialAttribute("memberOf"))
+	assert.False(t, IsPartialAttribute("department"))
+	assert.False(t, IsPartialAttribute("lastLogon"))
+}
+
+func TestNonPartialAttributes(t *testing.T) {
+	got := NonPartialAttributes([]string{"cn", "department", "title", "Department", "mail", "company"})
+	assert.Equal(t, []string{"department", "title", "company"}, got)
+	assert.Empty(t, NonPartialAttributes([]string{"cn", "memberOf", "userAccountControl"}))
+	assert.Empty(t, NonPartialAttributes(nil))
+}
+
+func TestFilterPartialAttributes(t *testing.T) {
+	pas := map[string]struct{}{"cn": {}, "mail": {}, "objectguid": {}}
+
+	t.Run("drops non-PAS attributes", func(t *testing.T) {
+		got := filterPartialAttributes([]string{"cn", "department", "objectGUID", "title"}, pas)
+		assert.Equal(t, []string{"cn", "objectGUID"}, got)
+	})
+
+	t.Run("empty request expands to the full set", func(t *testing.T) {
+		got := filterPartialAttributes(nil, pas)
+		assert.ElementsMatch(t, []string{"cn", "mail", "objectguid"}, got)
+	})
+}
+
+func TestVerifyGlobalCatalogReady(t *testing.T) {
+	rootDSE := func(value string) *ldap.SearchResult {
+		return &ldap.SearchResult{Entries: []*ldap.Entry{
+			ldap.NewEntry("", map[string][]string{"isGlobalCatalogReady": {value}}),
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		result  *ldap.SearchResult
+		err     error
+		wantErr string
+	}{
+		{name: "ready", result: rootDSE("TRUE")},
+		{name: "not ready", result: rootDSE("FALSE"), wantErr: "isGlobalCatalogReady is not TRUE"},
+		{name: "no entries", result: &ldap.SearchResult{}, wantErr: "no entries"},
+		{name: "search error", err: errors.New("boom"), wantErr: "failed to read RootDSE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := &mockLDAPOps{}
+			var captured *ldap.SearchRequest
+			ops.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+				Run(captureArg(t, &captured)).
+				Return(tt.result, tt.err)
+
+			err := verifyGlobalCatalogReady(ops)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NotNil(t, captured)
+			assert.Empty(t, captured.BaseDN)
+			assert.Equal(t, ldap.ScopeBaseObject, captured.Scope)
+			assert.Equal(t, []string{"isGlobalCatalogReady"}, captured.Attributes)
+		})
+	}
+}
+
+func TestClientSearch_GlobalCatalog(t *testing.T) {
+	domainPool := &mockPool{}
+	gcPool := &mockPool{}
+	gcPool.On("Get", mock.Anything).Return(&PooledConnection{}, nil)
+
+	c := newTestClient(domainPool, nil)
+	c.gcPool = gcPool
+	c.pas = map[string]struct{}{"cn": {}, "objectguid": {}}
+
+	ops := &mockLDAPOps{}
+	var captured *ldap.SearchRequest
+	ops.On("Search", mock.AnythingOfType("*ldap.SearchRequest")).
+		Run(captureArg(t, &captured)).
+		Return(&ldap.SearchResult{}, nil)
+	swapConnOps(t, ops)
+
+	_, err := c.Search(context.Background(), &SearchRequest{
+		BaseDN:        "",
+		Scope:         ScopeWholeSubtree,
+		Filter:        "(objectClass=user)",
+		Attributes:    []string{"cn", "department", "objectGUID"},
+		GlobalCatalog: true,
+	})
+	require.NoError(t, err)
+
+	gcPool.AssertCalled(t, "Get", mock.Anything)
+	domainPool.AssertNotCalled(t, "Get", mock.Anything)
+	require.NotNil(t, captured)
+	assert.Equal(t, []string{"cn", "objectGUID"}, captured.Attributes)
+}
+
+func TestUserSearchFilter_FilterAttributes(t *testing.T) {
+	enabled := true
+	filter := &UserSearchFilter{
+		NamePrefix:  "svc-",
+		Department:  "IT",
+		Office:      "London",
+		Enabled:     &enabled,
+		EmailDomain: "example.com",
+		MemberOf:    "CN=Admins,DC=example,DC=com",
+	}
+
+	assert.Equal(t,
+		[]string{"cn", "department", "physicalDeliveryOfficeName", "userAccountControl", "mail", "memberOf"},
+		filter.FilterAttributes(),
+	)
+	assert.Empty(t, (&UserSearchFilter{Container: "OU=Users,DC=example,DC=com"}).FilterAttributes())
+}
+
+func TestGroupSearchFilter_FilterAttributes(t *testing.T) {
+	hasMembers := true
+	filter := &GroupSearchFilter{
+		NameContains: "app",
+		Scope:        "universal",
+		HasMembers:   &hasMembers,
+	}
+
+	assert.Equal(t, []string{"cn", "groupType", "member"}, filter.FilterAttributes())
+}
@@ -0,0 +1,86 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GMSAPassword is the managed password of a group managed service account.
+type GMSAPassword struct {
+	DN             string
+	SAMAccountName string
+	RetrievedAt    time.Time
+	*ManagedPassword
+}
+
+// ExpiresAt returns when the current password will have been changed.
+func (p *GMSAPassword) ExpiresAt() time.Time {
+	return p.RetrievedAt.Add(p.QueryPasswordInterval)
+}
+
+// UnchangedUntil returns the time before which the password will not change.
+func (p *GMSAPassword) UnchangedUntil() time.Time {
+	return p.RetrievedAt.Add(p.UnchangedPasswordInterval)
+}
+
+// GMSAManager reads the managed passwords of group managed service accounts.
+type GMSAManager struct {
+	ctx     context.Context
+	client  Client
+	locator *computerLocator
+}
+
+// NewGMSAManager creates a new gMSA manager instance.
+func NewGMSAManager(ctx context.Context, client Client, baseDN string, cacheManager *CacheManager) *GMSAManager {
+	return &GMSAManager{
+		ctx:     ctx,
+		client:  client,
+		locator: newComputerLocator(client, baseDN, cacheManager),
+	}
+}
+
+// GetManagedPassword reads msDS-ManagedPassword from a gMSA identified by DN,
+// GUID, SID or SAM account name (with or without the trailing "$"). AD only
+// returns the attribute over an encrypted connection to principals listed in
+// msDS-GroupMSAMembership, and silently omits it otherwise, so a missing
+// attribute returns a not found error.
+func (gm *GMSAManager) GetManagedPassword(account string) (*GMSAPassword, error) {
+	if account == "" {
+		return nil, fmt.Errorf("gMSA identifier cannot be empty")
+	}
+
+	searchReq, err := gm.locator.searchRequest("msDS-GroupManagedServiceAccount", account,
+		[]string{"distinguishedName", "sAMAccountName", "msDS-ManagedPassword"})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := gm.client.Search(gm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_gmsa", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_gmsa_password", "gMSA %s not found", account)
+	}
+	entry := result.Entries[0]
+	retrievedAt := time.Now()
+
+	blob := entry.GetRawAttributeValue("msDS-ManagedPassword")
+	if len(blob) == 0 {
+		return nil, NewNotFoundError("get_gmsa_password",
+			"msDS-ManagedPassword of %s is not readable: the connection must be encrypted and the bind identity allowed to retrieve the password", entry.DN)
+	}
+
+	password, err := ParseManagedPasswordBlob(blob)
+	if err != nil {
+		return nil, WrapError("parse_managed_password", err)
+	}
+
+	return &GMSAPassword{
+		DN:              entry.DN,
+		SAMAccountName:  entry.GetAttributeValue("sAMAccountName"),
+		RetrievedAt:     retrievedAt,
+		ManagedPassword: password,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGMSAManager_GetManagedPassword(t *testing.T) {
+	gmsaDN := "CN=svc-web,CN=Managed Service Accounts,DC=example,DC=com"
+	blob := buildManagedPasswordBlob(utf16LE("current"), nil, 30*24*time.Hour, 29*24*time.Hour)
+
+	readable := ldap.NewEntry(gmsaDN, map[string][]string{"sAMAccountName": {"svc-web$"}})
+	readable.Attributes = append(readable.Attributes, &ldap.EntryAttribute{
+		Name:       "msDS-ManagedPassword",
+		Values:     []string{string(blob)},
+		ByteValues: [][]byte{blob},
+	})
+	withheld := ldap.NewEntry(gmsaDN, map[string][]string{"sAMAccountName": {"svc-app$"}})
+
+	filterFor := func(sam string) any {
+		return mock.MatchedBy(func(r *SearchRequest) bool {
+			return r.Filter == "(&(objectClass=msDS-GroupManagedServiceAccount)(sAMAccountName="+sam+"))"
+		})
+	}
+	client := &MockClient{}
+	client.On("Search", mock.Anything, filterFor("svc-web$")).Return(&SearchResult{Entries: []*ldap.Entry{readable}}, nil)
+	client.On("Search", mock.Anything, filterFor("svc-app$")).Return(&SearchResult{Entries: []*ldap.Entry{withheld}}, nil)
+	client.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{}, nil)
+	gm := NewGMSAManager(t.Context(), client, "DC=example,DC=com", nil)
+
+	t.Run("readable password", func(t *testing.T) {
+		before := time.Now()
+		password, err := gm.GetManagedPassword("svc-web")
+		require.NoError(t, err)
+		assert.Equal(t, gmsaDN, password.DN)
+		assert.Equal(t, "svc-web$", password.SAMAccountName)
+		assert.Equal(t, utf16LE("current"), password.CurrentPassword)
+		assert.False(t, password.RetrievedAt.Before(before))
+		assert.Equal(t, password.RetrievedAt.Add(30*24*time.Hour), password.ExpiresAt())
+		assert.Equal(t, password.RetrievedAt.Add(29*24*time.Hour), password.UnchangedUntil())
+	})
+
+	t.Run("password withheld", func(t *testing.T) {
+		_, err := gm.GetManagedPassword("svc-app$")
+		assert.True(t, IsNotFoundError(err), "got %v", err)
+		assert.ErrorContains(t, err, "not readable")
+	})
+
+	t.Run("gMSA not found", func(t *testing.T) {
+		_, err := gm.GetManagedPassword("svc-missing")
+		assert.True(t, IsNotFoundError(err), "got %v", err)
+	})
+
+	t.Run("empty identifier", func(t *testing.T) {
+		_, err := gm.GetManagedPassword("")
+		assert.Error(t, err)
+	})
+}
@@ -111,6 +111,11 @@ type GroupSearchFilter struct {
 	// set this field. A pointer is required because the zero value of
 	// SearchScope (ScopeBaseObject) is itself a legal and distinct scope.
 	SearchScope *SearchScope `json:"searchScope,omitempty"`
+
+	// GlobalCatalog searches a Global Catalog server instead of the domain.
+	// With no Container the whole forest is searched. Only attributes in the
+	// partial attribute set are returned.
+	GlobalCatalog bool `json:"globalCatalog,omitempty"`
 }
 
 // Group represents an Active Directory group.
@@ -141,6 +146,9 @@ type Group struct {
 	// Timestamps
 	WhenCreated time.Time `json:"whenCreated"`
 	WhenChanged time.Time `json:"whenChanged"`
+
+	// Attributes not otherwise modeled, as requested via SetExtraAttributes
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"`
 }
 
 // CreateGroupRequest represents a request to create a new group.
@@ -154,6 +162,8 @@ type CreateGroupRequest struct {
 	Mail           string        `json:"mail,omitempty"`         // Optional: Email for distribution groups
 	MailNickname   string        `json:"mailNickname,omitempty"` // Optional: Exchange nickname
 	ManagedBy      string        `json:"managedBy,omitempty"`    // Optional: DN of manager
+
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"` // Optional: Attributes not otherwise modeled
 }
 
 // UpdateGroupRequest represents a request to update an existing group.
@@ -167,6 +177,8 @@ type UpdateGroupRequest struct {
 	Scope          *GroupScope    `json:"scope,omitempty"`          // Optional: New scope (limited conversions)
 	Category       *GroupCategory `json:"category,omitempty"`       // Optional: New category
 	Container      *string        `json:"container,omitempty"`      // Optional: New container DN (triggers move)
+
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"` // Optional: Attributes not otherwise modeled (empty list = delete)
 }
 
 // GroupManager handles Active Directory group operations.
@@ -179,6 +191,9 @@ type GroupManager struct {
 	baseDN       string
 	timeout      time.Duration
 	cacheManager *CacheManager // Reference to shared cache
+
+	// extraAttributes are additional attributes read into Group.ExtraAttributes
+	extraAttributes []string
 }
 
 // NewGroupManager creates a new group manager instance.
@@ -201,14 +216,19 @@ func (gm *GroupManager) SetTimeout(timeout time.Duration) {
 	gm.normalizer.SetTimeout(timeout)
 }
 
+// SetExtraAttributes sets the additional attributes read into Group.ExtraAttributes.
+func (gm *GroupManager) SetExtraAttributes(names []string) {
+	gm.extraAttributes = names
+}
+
 // getAllGroupAttributes returns the standard set of LDAP attributes to retrieve for groups.
 // This ensures consistency across all group search and retrieval operations.
 func (gm *GroupManager) getAllGroupAttributes() []string {
-	return []string{
+	return append([]string{
 		"objectGUID", "distinguishedName", "objectSid", "cn", "sAMAccountName",
 		"description", "groupType", "mail", "mailNickname", "managedBy",
 		"member", "memberOf", "whenCreated", "whenChanged",
-	}
+	}, gm.extraAttributes...)
 }
 
 // CalculateGroupType calculates the Active Directory groupType value from scope and category.
@@ -350,6 +370,8 @@ func (gm *GroupManager) CreateGroup(req *CreateGroupRequest) (*Group, error) {
 		attributes["managedBy"] = []string{req.ManagedBy}
 	}
 
+	addExtraAttributes(attributes, req.ExtraAttributes)
+
 	// Create the group
 	addReq := &AddRequest{
 		DN:         groupDN,
@@ -387,11 +409,7 @@ func (gm *GroupManager) GetGroup(guid string) (*Group, error) {
 	}
 
 	// Expand attributes to include all group-relevant fields
-	searchReq.Attributes = []string{
-		"objectGUID", "distinguishedName", "objectSid", "cn", "sAMAccountName",
-		"description", "groupType", "mail", "mailNickname", "member", "memberOf",
-		"whenCreated", "whenChanged", "managedBy",
-	}
+	searchReq.Attributes = gm.getAllGroupAttributes()
 	searchReq.TimeLimit = gm.timeout
 
 	result, err := gm.client.Search(gm.ctx, searchReq)
@@ -423,16 +441,12 @@ func (gm *GroupManager) GetGroupByDN(dn string) (*Group, error) {
 // getGroupByDN is the internal implementation for DN-based group retrieval.
 func (gm *GroupManager) getGroupByDN(dn string) (*Group, error) {
 	searchReq := &SearchRequest{
-		BaseDN: dn,
-		Scope:  ScopeBaseObject,
-		Filter: "(objectClass=group)",
-		Attributes: []string{
-			"objectGUID", "distinguishedName", "objectSid", "cn", "sAMAccountName",
-			"description", "groupType", "mail", "mailNickname", "member", "memberOf",
-			"whenCreated", "whenChanged", "managedBy",
-		},
-		SizeLimit: 1,
-		TimeLimit: gm.timeout,
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=group)",
+		Attributes: gm.getAllGroupAttributes(),
+		SizeLimit:  1,
+		TimeLimit:  gm.timeout,
 	}
 
 	result, err := gm.client.Search(gm.ctx, searchReq)
@@ -581,6 +595,11 @@ func (gm *GroupManager) UpdateGroup(guid string, req *UpdateGroupRequest) (*Grou
 		}
 	}
 
+	// Handle extra attribute changes
+	if calculateExtraAttributeChanges(modReq, req.ExtraAttributes, currentGroup.ExtraAttributes) {
+		hasAttributeChanges = true
+	}
+
 	// Check if we have any changes at all
 	if !hasAttributeChanges && renamedOrMovedGroup == nil {
 		// No changes at all, return current group
@@ -938,6 +957,9 @@ func (gm *GroupManager) SearchGroupsWithFilter(filter *GroupSearchFilter) ([]*Gr
 	if filter.HasMembers != nil {
 		filterFields["has_members"] = *filter.HasMembers
 	}
+	if filter.GlobalCatalog {
+		filterFields["global_catalog"] = true
+	}
 
 	tflog.SubsystemDebug(gm.ctx, "ldap", "Starting SearchGroupsWithFilter", filterFields)
 
@@ -966,8 +988,12 @@ func (gm *GroupManager) SearchGroupsWithFilter(filter *GroupSearchFilter) ([]*Gr
 	ldapFilter := gm.buildLDAPFilter(filter)
 	filterFields["ldap_filter"] = ldapFilter
 
-	// Determine search base DN (container or baseDN)
+	// Determine search base DN (container or baseDN). A Global Catalog search
+	// without a container starts at the empty DN to cover the whole forest.
 	searchBaseDN := gm.baseDN
+	if filter.GlobalCatalog {
+		searchBaseDN = ""
+	}
 	if filter.Container != "" {
 		searchBaseDN = filter.Container
 	}
@@ -985,7 +1011,7 @@ func (gm *GroupManager) SearchGroupsWithFilter(filter *GroupSearchFilter) ([]*Gr
 	tflog.SubsystemDebug(gm.ctx, "ldap", "Filter validation complete, executing search", filterFields)
 
 	// Perform search using existing SearchGroups method with custom base DN
-	groups, err := gm.searchGroupsInContainer(searchBaseDN, ldapFilter, nil, searchScope)
+	groups, err := gm.searchGroupsInContainer(searchBaseDN, ldapFilter, nil, searchScope, filter.GlobalCatalog)
 
 	duration := time.Since(start)
 	filterFields["duration_ms"] = duration.Milliseconds()
@@ -1158,6 +1184,8 @@ func (gm *GroupManager) entryToGroup(entry *ldap.Entry) (*Group, error) {
 	// Extract member of
 	group.MemberOf = entry.GetAttributeValues("memberOf")
 
+	group.ExtraAttributes = readExtraAttributes(entry, gm.extraAttributes)
+
 	// Parse timestamps
 	if whenCreated := entry.GetAttributeValue("whenCreated"); whenCreated != "" {
 		if t, err := time.Parse("20060102150405.0Z", whenCreated); err == nil {
@@ -1318,6 +1346,26 @@ func (gm *GroupManager) validateSearchFilter(filter *GroupSearchFilter) error {
 	return nil
 }
 
+// FilterAttributes returns the LDAP attributes referenced by the filter
+// criteria. It is used to warn about Global Catalog searches on attributes
+// outside the partial attribute set.
+func (f *GroupSearchFilter) FilterAttributes() []string {
+	var attrs []string
+	if f.NamePrefix != "" || f.NameSuffix != "" || f.NameContains != "" {
+		attrs = append(attrs, "cn")
+	}
+	if f.Category != "" || f.Scope != "" {
+		attrs = append(attrs, "groupType")
+	}
+	if f.HasMembers != nil || f.HasMember != "" {
+		attrs = append(attrs, "member")
+	}
+	if f.MemberOf != "" {
+		attrs = append(attrs, "memberOf")
+	}
+	return attrs
+}
+
 // buildLDAPFilter converts a user-friendly filter to an LDAP filter string.
 func (gm *GroupManager) buildLDAPFilter(filter *GroupSearchFilter) string {
 	if filter == nil {
@@ -1398,7 +1446,7 @@ func (gm *GroupManager) buildLDAPFilter(filter *GroupSearchFilter) string {
 // searchGroupsInContainer searches for groups in a specific container using LDAP filter.
 // The searchScope argument is passed verbatim; callers are responsible for
 // defaulting an unset scope before calling this helper.
-func (gm *GroupManager) searchGroupsInContainer(baseDN, filter string, attributes []string, searchScope SearchScope) ([]*Group, error) {
+func (gm *GroupManager) searchGroupsInContainer(baseDN, filter string, attributes []string, searchScope SearchScope, globalCatalog bool) ([]*Group, error) {
 	start := time.Now()
 
 	originalFilter := filter
@@ -1428,11 +1476,12 @@ func (gm *GroupManager) searchGroupsInContainer(baseDN, filter string, attribute
 	}
 
 	searchReq := &SearchRequest{
-		BaseDN:     baseDN,
-		Scope:      searchScope,
-		Filter:     filter,
-		Attributes: attributes,
-		TimeLimit:  gm.timeout,
+		BaseDN:        baseDN,
+		Scope:         searchScope,
+		Filter:        filter,
+		Attributes:    attributes,
+		TimeLimit:     gm.timeout,
+		GlobalCatalog: globalCatalog,
 	}
 
 	tflog.SubsystemDebug(gm.ctx, "ldap", "Executing paged search in container", searchFields)
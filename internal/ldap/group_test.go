@@ -3,6 +3,7 @@ package ldap
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -1898,7 +1899,7 @@ func TestSearchGroupsInContainer(t *testing.T) {
 				len(searchReq.Attributes) == 2
 		})).Return(searchResult, nil)
 
-		groups, err := gm.searchGroupsInContainer(customBaseDN, filter, attributes, ScopeWholeSubtree)
+		groups, err := gm.searchGroupsInContainer(customBaseDN, filter, attributes, ScopeWholeSubtree, false)
 
 		require.NoError(t, err)
 		assert.Len(t, groups, 1)
@@ -1916,7 +1917,7 @@ func TestSearchGroupsInContainer(t *testing.T) {
 			return searchReq.Filter == "(objectClass=group)"
 		})).Return(searchResult, nil)
 
-		groups, err := gm.searchGroupsInContainer("DC=test,DC=local", "", nil, ScopeWholeSubtree)
+		groups, err := gm.searchGroupsInContainer("DC=test,DC=local", "", nil, ScopeWholeSubtree, false)
 
 		require.NoError(t, err)
 		assert.Len(t, groups, 1)
@@ -1933,7 +1934,7 @@ func TestSearchGroupsInContainer(t *testing.T) {
 			return len(searchReq.Attributes) > 5 // Should have default attributes
 		})).Return(searchResult, nil)
 
-		groups, err := gm.searchGroupsInContainer("DC=test,DC=local", "", nil, ScopeWholeSubtree)
+		groups, err := gm.searchGroupsInContainer("DC=test,DC=local", "", nil, ScopeWholeSubtree, false)
 
 		require.NoError(t, err)
 		assert.Len(t, groups, 1)
@@ -2595,3 +2596,41 @@ func TestUpdateGroupRenameAndMove(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestUpdateGroupExtraAttributes(t *testing.T) {
+	gm, mockClient := createTestGroupManager(t)
+	gm.SetExtraAttributes([]string{"extensionattribute1", "info"})
+
+	testGUID := "12345678-1234-1234-1234-123456789012"
+	testDN := "CN=TestGroup,CN=Users,DC=test,DC=local"
+
+	entry := createMockGroupEntry("TestGroup", testGUID, testDN, CalculateGroupType(GroupScopeGlobal, GroupCategorySecurity))
+	entry.Attributes = append(entry.Attributes,
+		&ldap.EntryAttribute{Name: "extensionAttribute1", Values: []string{"finance"}},
+		&ldap.EntryAttribute{Name: "info", Values: []string{"legacy"}},
+	)
+
+	mockClient.On("Search", mock.Anything, mock.MatchedBy(func(searchReq *SearchRequest) bool {
+		return slices.Contains(searchReq.Attributes, "extensionattribute1") && slices.Contains(searchReq.Attributes, "info")
+	})).Return(&SearchResult{Entries: []*ldap.Entry{entry}, Total: 1}, nil)
+
+	mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(modReq *ModifyRequest) bool {
+		return modReq.DN == testDN &&
+			slices.Equal(modReq.ReplaceAttributes["extensionattribute1"], []string{"sales"}) &&
+			slices.Equal(modReq.DeleteAttributes, []string{"info"})
+	})).Return(nil).Once()
+
+	group, err := gm.UpdateGroup(testGUID, &UpdateGroupRequest{
+		ExtraAttributes: map[string][]string{
+			"extensionattribute1": {"sales"},
+			"info":                {},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"extensionattribute1": {"finance"},
+		"info":                {"legacy"},
+	}, group.ExtraAttributes)
+	mockClient.AssertExpectations(t)
+}
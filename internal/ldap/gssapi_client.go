@@ -0,0 +1,188 @@
+package ldap
+
+import (
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/jcmturner/gokrb5/v8/asn1tools"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	krb5gssapi "github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/chksumtype"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+// gssapiBindClient is an ldap.GSSAPIClient that supports channel bindings
+// and SASL security layers, neither of which the go-ldap client offers. It
+// binds with a service ticket obtained from the kerberosTicketCache.
+type gssapiBindClient struct {
+	creds  *credentials.Credentials
+	ticket messages.Ticket
+
+	channelBinding []byte // tls-server-end-point application data, if any
+	layer          string // SASL security layer to negotiate
+
+	ekey    types.EncryptionKey  // Service ticket session key
+	subkey  types.EncryptionKey  // Acceptor subkey from the AP-REP
+	context *saslSecurityContext // Set when a security layer is negotiated
+}
+
+// InitSecContext initiates the GSS-API security context (RFC 4752 section 3.1).
+func (c *gssapiBindClient) InitSecContext(target string, token []byte) ([]byte, bool, error) {
+	return c.InitSecContextWithOptions(target, token, nil)
+}
+
+// InitSecContextWithOptions sends an AP-REQ carrying the channel binding on
+// the first call and processes the server's AP-REP on the second. The
+// service ticket must be set beforehand.
+func (c *gssapiBindClient) InitSecContextWithOptions(target string, token []byte, options []int) ([]byte, bool, error) {
+	if token == nil {
+		if c.creds == nil || c.ekey.KeyType == 0 {
+			return nil, false, fmt.Errorf("no service ticket for %s", target)
+		}
+		flags := []int{krb5gssapi.ContextFlagInteg, krb5gssapi.ContextFlagConf, krb5gssapi.ContextFlagMutual}
+		output, err := newAPReqToken(c.creds, c.ticket, c.ekey, flags, options, c.channelBinding)
+		if err != nil {
+			return nil, false, err
+		}
+		return output, true, nil
+	}
+
+	var reply spnego.KRB5Token
+	if err := reply.Unmarshal(token); err != nil {
+		return nil, false, err
+	}
+	if reply.IsKRBError() {
+		return nil, false, reply.KRBError
+	}
+	if !reply.IsAPRep() {
+		return []byte{}, true, nil
+	}
+
+	encPart, err := crypto.DecryptEncPart(reply.APRep.EncPart, c.ekey, keyusage.AP_REP_ENCPART)
+	if err != nil {
+		return nil, false, err
+	}
+	part := &messages.EncAPRepPart{}
+	if err := part.Unmarshal(encPart); err != nil {
+		return nil, false, err
+	}
+	c.subkey = part.Subkey
+	return []byte{}, false, nil
+}
+
+// NegotiateSaslAuth performs the final step of the SASL GSSAPI handshake
+// (RFC 4752 section 3.1), selecting the configured security layer.
+func (c *gssapiBindClient) NegotiateSaslAuth(input []byte, authzid string) ([]byte, error) {
+	if len(input) < 3 {
+		return nil, errors.New("server sent bad final token for SASL GSSAPI handshake")
+	}
+	key, flags := c.ekey, byte(0)
+	if input[2]&wrapFlagAcceptorSubkey != 0 {
+		key, flags = c.subkey, wrapFlagAcceptorSubkey
+	}
+	if key.KeyType == 0 {
+		return nil, errors.New("no session key established for SASL GSSAPI handshake")
+	}
+
+	want := saslLayerMask(c.layer)
+	if want != saslLayerMaskNone && !isAESEnctype(key.KeyType) {
+		return nil, fmt.Errorf("SASL security layer requires an AES session key, got encryption type %d", key.KeyType)
+	}
+
+	payload, _, err := unwrapGSSToken(key, input, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != 4 {
+		return nil, errors.New("server sent bad final token for SASL GSSAPI handshake")
+	}
+	if payload[0]&want == 0 {
+		return nil, fmt.Errorf("server does not offer the SASL %s security layer (offered 0x%02x)", c.layer, payload[0])
+	}
+
+	reply := []byte{want, 0, 0, 0}
+	if want != saslLayerMaskNone {
+		maxSend := int(payload[1])<<16 | int(payload[2])<<8 | int(payload[3])
+		if maxSend == 0 {
+			maxSend = saslMaxReceiveSize
+		}
+		c.context = &saslSecurityContext{
+			key:     key,
+			subkey:  flags&wrapFlagAcceptorSubkey != 0,
+			seal:    c.layer == SASLSecurityLayerSeal,
+			maxSend: maxSend,
+			sendSeq: 2,
+			// The server numbers its messages on from this token
+			recvSeq: wrapTokenSequence(input) + 1,
+		}
+		reply = []byte{want, saslMaxReceiveSize >> 16 & 0xFF, saslMaxReceiveSize >> 8 & 0xFF, saslMaxReceiveSize & 0xFF}
+	}
+	reply = append(reply, authzid...)
+
+	return wrapGSSToken(key, reply, flags, 1)
+}
+
+// DeleteSecContext discards the session keys. A negotiated security layer
+// keeps its own copy.
+func (c *gssapiBindClient) DeleteSecContext() error {
+	c.ekey = types.EncryptionKey{}
+	c.subkey = types.EncryptionKey{}
+	return nil
+}
+
+// newAPReqToken builds a GSS-API KRB5 AP-REQ token whose authenticator
+// checksum carries the channel binding (RFC 4121 section 4.1.1).
+func newAPReqToken(creds *credentials.Credentials, tkt messages.Ticket, key types.EncryptionKey, flags, options []int, channelBinding []byte) ([]byte, error) {
+	auth, err := types.NewAuthenticator(creds.Domain(), creds.CName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+	auth.Cksum = types.Checksum{
+		CksumType: chksumtype.GSSAPI,
+		Checksum:  authenticatorChecksum(flags, channelBinding),
+	}
+
+	apReq, err := messages.NewAPReq(tkt, key, auth)
+	if err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		types.SetFlag(&apReq.APOptions, option)
+	}
+	body, err := apReq.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal AP-REQ: %w", err)
+	}
+
+	// InitialContextToken: mech OID, TOK_ID 01 00 and the AP-REQ.
+	oid, err := asn1.Marshal(asn1.ObjectIdentifier(krb5gssapi.OIDKRB5.OID()))
+	if err != nil {
+		return nil, err
+	}
+	token := append(oid, 0x01, 0x00)
+	token = append(token, body...)
+	return asn1tools.AddASNAppTag(token, 0), nil
+}
+
+// authenticatorChecksum returns the 0x8003 checksum value: the length of
+// the channel binding hash, the hash itself (zero when unbound) and the
+// context flags.
+func authenticatorChecksum(flags []int, channelBinding []byte) []byte {
+	checksum := make([]byte, 24)
+	binary.LittleEndian.PutUint32(checksum[:4], 16)
+	if len(channelBinding) > 0 {
+		copy(checksum[4:20], channelBindingHash(channelBinding))
+	}
+	var contextFlags uint32
+	for _, flag := range flags {
+		contextFlags |= uint32(flag)
+	}
+	binary.LittleEndian.PutUint32(checksum[20:24], contextFlags)
+	return checksum
+}
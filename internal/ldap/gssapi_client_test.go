@@ -0,0 +1,122 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	krb5gssapi "github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGSSAPIBindClient_NegotiateSaslAuth(t *testing.T) {
+	key := testSessionKey(t)
+	serverToken := func(t *testing.T, offered byte) []byte {
+		token, err := wrapGSSToken(key, []byte{offered, 0x00, 0x10, 0x00}, wrapFlagSentByAcceptor|wrapFlagAcceptorSubkey, 41)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("selects requested layer", func(t *testing.T) {
+		client := &gssapiBindClient{subkey: key, layer: SASLSecurityLayerSeal}
+
+		reply, err := client.NegotiateSaslAuth(serverToken(t, saslLayerMaskNone|saslLayerMaskSign|saslLayerMaskSeal), "u:admin")
+		require.NoError(t, err)
+
+		payload, flags, err := unwrapGSSToken(key, reply, false)
+		require.NoError(t, err)
+		assert.Zero(t, flags&wrapFlagSealed, "negotiation reply is integrity protected only")
+		assert.Equal(t, append([]byte{saslLayerMaskSeal, 0xFF, 0xFF, 0xFF}, "u:admin"...), payload)
+
+		require.NotNil(t, client.context)
+		assert.True(t, client.context.seal)
+		assert.True(t, client.context.subkey)
+		assert.Equal(t, 0x1000, client.context.maxSend)
+		assert.Equal(t, uint64(42), client.context.recvSeq, "server messages continue from the negotiation token")
+	})
+
+	t.Run("no security layer", func(t *testing.T) {
+		client := &gssapiBindClient{ekey: key, layer: SASLSecurityLayerNone}
+		token, err := wrapGSSToken(key, []byte{saslLayerMaskNone, 0, 0, 0}, wrapFlagSentByAcceptor, 0)
+		require.NoError(t, err)
+
+		reply, err := client.NegotiateSaslAuth(token, "")
+		require.NoError(t, err)
+
+		payload, _, err := unwrapGSSToken(key, reply, false)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{saslLayerMaskNone, 0, 0, 0}, payload)
+		assert.Nil(t, client.context)
+	})
+
+	t.Run("server does not offer layer", func(t *testing.T) {
+		client := &gssapiBindClient{subkey: key, layer: SASLSecurityLayerSeal}
+
+		_, err := client.NegotiateSaslAuth(serverToken(t, saslLayerMaskNone|saslLayerMaskSign), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not offer the SASL seal security layer")
+		assert.Nil(t, client.context)
+	})
+
+	t.Run("requires session key", func(t *testing.T) {
+		client := &gssapiBindClient{layer: SASLSecurityLayerSign}
+		_, err := client.NegotiateSaslAuth(serverToken(t, saslLayerMaskSign), "")
+		assert.ErrorContains(t, err, "no session key")
+	})
+
+	t.Run("requires AES key", func(t *testing.T) {
+		rc4 := types.EncryptionKey{KeyType: etypeID.RC4_HMAC, KeyValue: make([]byte, 16)}
+		client := &gssapiBindClient{subkey: rc4, layer: SASLSecurityLayerSign}
+		_, err := client.NegotiateSaslAuth(serverToken(t, saslLayerMaskSign), "")
+		assert.ErrorContains(t, err, "AES")
+	})
+}
+
+func TestAuthenticatorChecksum(t *testing.T) {
+	flags := []int{krb5gssapi.ContextFlagInteg, krb5gssapi.ContextFlagConf, krb5gssapi.ContextFlagMutual}
+
+	unbound := authenticatorChecksum(flags, nil)
+	require.Len(t, unbound, 24)
+	assert.Equal(t, uint32(16), binary.LittleEndian.Uint32(unbound[:4]))
+	assert.Equal(t, make([]byte, 16), unbound[4:20])
+	assert.Equal(t, uint32(krb5gssapi.ContextFlagInteg|krb5gssapi.ContextFlagConf|krb5gssapi.ContextFlagMutual),
+		binary.LittleEndian.Uint32(unbound[20:]))
+
+	binding := []byte("tls-server-end-point:test")
+	bound := authenticatorChecksum(flags, binding)
+	assert.Equal(t, channelBindingHash(binding), bound[4:20])
+	assert.Equal(t, unbound[20:], bound[20:])
+}
+
+func TestNewAPReqToken_ChannelBinding(t *testing.T) {
+	key := testSessionKey(t)
+	tkt := messages.Ticket{
+		TktVNO: 5,
+		Realm:  "EXAMPLE.COM",
+		SName:  types.NewPrincipalName(nametype.KRB_NT_SRV_INST, "ldap/dc1.example.com"),
+		EncPart: types.EncryptedData{
+			EType:  etypeID.AES256_CTS_HMAC_SHA1_96,
+			Cipher: []byte("opaque"),
+		},
+	}
+	binding := []byte("tls-server-end-point:test")
+
+	token, err := newAPReqToken(credentials.New("admin", "EXAMPLE.COM"), tkt, key, []int{krb5gssapi.ContextFlagMutual}, nil, binding)
+	require.NoError(t, err)
+
+	var parsed spnego.KRB5Token
+	require.NoError(t, parsed.Unmarshal(token))
+	require.True(t, parsed.IsAPReq())
+
+	require.NoError(t, parsed.APReq.DecryptAuthenticator(key))
+	checksum := parsed.APReq.Authenticator.Cksum.Checksum
+	require.Len(t, checksum, 24)
+	assert.Equal(t, channelBindingHash(binding), checksum[4:20])
+	assert.Equal(t, "admin", parsed.APReq.Authenticator.CName.PrincipalNameString())
+}
@@ -2,6 +2,7 @@ package ldap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -12,16 +13,30 @@ import (
 	"github.com/go-ldap/ldap/v3/gssapi"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	krb5client "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
 )
 
-// performKerberosAuth performs Kerberos authentication on an LDAP connection.
-// This can be shared by both client and pool implementations.
-func performKerberosAuth(conn *ldap.Conn, cfg *ConnectionConfig, serverInfo *ServerInfo) error {
-	return performKerberosAuthWithContext(context.Background(), conn, cfg, serverInfo)
+// kerberosBindOptions carries per-connection state for a GSSAPI bind.
+type kerberosBindOptions struct {
+	// layer, when non-nil, receives the negotiated SASL security layer
+	// once the bind has succeeded.
+	layer *saslConn
+
+	// channelBinding is the tls-server-end-point application data to bind
+	// the authenticator to, if any.
+	channelBinding []byte
+
+	// tickets is the ticket cache of the pool making the bind. A cache for
+	// this bind alone is used when nil.
+	tickets *kerberosTicketCache
 }
 
-// performKerberosAuthWithContext performs Kerberos authentication with logging context.
-func performKerberosAuthWithContext(ctx context.Context, conn *ldap.Conn, cfg *ConnectionConfig, serverInfo *ServerInfo) error {
+// performKerberosAuthWithOptions performs Kerberos authentication on an LDAP
+// connection with an optional channel binding and SASL security layer. It is
+// shared by the client and the pool.
+func performKerberosAuthWithOptions(ctx context.Context, conn *ldap.Conn, cfg *ConnectionConfig, serverInfo *ServerInfo, opts kerberosBindOptions) error {
 	start := time.Now()
 
 	kerberosFields := map[string]any{
@@ -41,45 +56,62 @@ func performKerberosAuthWithContext(ctx context.Context, conn *ldap.Conn, cfg *C
 		return fmt.Errorf("kerberos configuration error: %w", err)
 	}
 
-	// Create GSSAPI client based on available credentials
-	tflog.SubsystemDebug(ctx, "ldap", "Creating GSSAPI client", kerberosFields)
-	gssapiClient, err := createGSSAPIClientWithContext(ctx, cfg)
+	// Build service principal name from connection info
+	spn, err := buildServicePrincipalWithContext(ctx, cfg, serverInfo)
 	if err != nil {
-		tflog.SubsystemError(ctx, "ldap", "Kerberos GSSAPI client creation failed", map[string]any{
+		tflog.SubsystemError(ctx, "ldap", "Kerberos SPN build failed", map[string]any{
 			"error":       err.Error(),
 			"duration_ms": time.Since(start).Milliseconds(),
 		})
-		return fmt.Errorf("failed to create GSSAPI client: %w", err)
+		return fmt.Errorf("failed to build service principal: %w", err)
+	}
+	kerberosFields["spn"] = spn
+
+	// Obtain a service ticket, renewing or re-acquiring the TGT as needed
+	tickets := opts.tickets
+	if tickets == nil {
+		tickets = newKerberosTicketCache(cfg)
+	}
+	tflog.SubsystemDebug(ctx, "ldap", "Obtaining Kerberos service ticket", kerberosFields)
+	creds, ticket, err := tickets.serviceTicket(ctx, spn)
+	if err != nil {
+		tflog.SubsystemError(ctx, "ldap", "Kerberos service ticket acquisition failed", map[string]any{
+			"error":       err.Error(),
+			"duration_ms": time.Since(start).Milliseconds(),
+		})
+		return fmt.Errorf("failed to obtain Kerberos service ticket: %w", err)
+	}
+
+	tflog.SubsystemDebug(ctx, "ldap", "Kerberos service ticket obtained", map[string]any{
+		"expires":     ticket.endTime,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+
+	bindClient := &gssapiBindClient{
+		creds:          creds,
+		ticket:         ticket.ticket,
+		ekey:           ticket.key,
+		channelBinding: opts.channelBinding,
 	}
 	defer func() {
 		tflog.SubsystemDebug(ctx, "ldap", "Cleaning up GSSAPI security context")
-		if deleteErr := gssapiClient.DeleteSecContext(); deleteErr != nil {
+		if deleteErr := bindClient.DeleteSecContext(); deleteErr != nil {
 			tflog.SubsystemWarn(ctx, "ldap", "Failed to delete security context", map[string]any{
 				"error": deleteErr.Error(),
 			})
 		}
 	}()
-
-	tflog.SubsystemDebug(ctx, "ldap", "Kerberos GSSAPI client created", map[string]any{
-		"duration_ms": time.Since(start).Milliseconds(),
-	})
-
-	// Build service principal name from connection info
-	spn, err := buildServicePrincipalWithContext(ctx, cfg, serverInfo)
-	if err != nil {
-		tflog.SubsystemError(ctx, "ldap", "Kerberos SPN build failed", map[string]any{
-			"error":       err.Error(),
-			"duration_ms": time.Since(start).Milliseconds(),
-		})
-		return fmt.Errorf("failed to build service principal: %w", err)
+	if opts.layer != nil {
+		bindClient.layer = cfg.SASLSecurityLayer
+		kerberosFields["sasl_security_layer"] = cfg.SASLSecurityLayer
 	}
+	kerberosFields["channel_binding"] = len(opts.channelBinding) > 0
 
-	kerberosFields["spn"] = spn
 	tflog.SubsystemInfo(ctx, "ldap", "Attempting GSSAPI bind", kerberosFields)
 
 	// Perform the GSSAPI bind
 	bindStart := time.Now()
-	err = conn.GSSAPIBind(gssapiClient, spn, "")
+	err = conn.GSSAPIBind(bindClient, spn, "")
 	bindDuration := time.Since(bindStart)
 
 	kerberosFields["bind_duration_ms"] = bindDuration.Milliseconds()
@@ -91,6 +123,13 @@ func performKerberosAuthWithContext(ctx context.Context, conn *ldap.Conn, cfg *C
 		return fmt.Errorf("GSSAPI bind failed: %w", err)
 	}
 
+	if opts.layer != nil {
+		if bindClient.context == nil {
+			return fmt.Errorf("GSSAPI bind completed without negotiating the SASL %s security layer", cfg.SASLSecurityLayer)
+		}
+		opts.layer.enable(bindClient.context)
+	}
+
 	tflog.SubsystemInfo(ctx, "ldap", "Kerberos authentication successful", kerberosFields)
 	return nil
 }
@@ -98,11 +137,26 @@ func performKerberosAuthWithContext(ctx context.Context, conn *ldap.Conn, cfg *C
 // createGSSAPIClient creates a GSSAPI client based on the configuration.
 // Priority order: credential cache → keytab → password.
 func createGSSAPIClient(cfg *ConnectionConfig) (ldap.GSSAPIClient, error) {
-	return createGSSAPIClientWithContext(context.Background(), cfg)
+	creds, err := loadKerberosCredentials(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &gssapi.Client{Client: creds.client}, nil
 }
 
-// createGSSAPIClientWithContext creates a GSSAPI client with logging context.
-func createGSSAPIClientWithContext(ctx context.Context, cfg *ConnectionConfig) (ldap.GSSAPIClient, error) {
+// kerberosCredentials is a Kerberos client together with where its
+// credentials came from.
+type kerberosCredentials struct {
+	client *krb5client.Client
+	source kerberosSource
+
+	// tgt is the TGT read from a credential cache. It is zero for keytab
+	// and password credentials until the first login.
+	tgt kerberosTicket
+}
+
+// loadKerberosCredentials loads Kerberos credentials with logging context.
+func loadKerberosCredentials(ctx context.Context, cfg *ConnectionConfig) (*kerberosCredentials, error) {
 	var krb5confPath string
 	var useAutoDiscovery bool
 
@@ -147,8 +201,8 @@ func createGSSAPIClientWithContext(ctx context.Context, cfg *ConnectionConfig) (
 			"dns_lookup_realm": cfg.KerberosDNSLookupRealm,
 		})
 
-		// Create GSSAPI client using the runtime configuration
-		return createGSSAPIClientWithRuntimeConfig(ctx, cfg, runtimeConfig)
+		// Load credentials using the runtime configuration
+		return loadKerberosCredentialsWithRuntimeConfig(ctx, cfg, runtimeConfig)
 	} else {
 		// Use traditional file-based configuration
 		tflog.SubsystemDebug(ctx, "ldap", "Checking Kerberos configuration file", map[string]any{
@@ -172,13 +226,13 @@ func createGSSAPIClientWithContext(ctx context.Context, cfg *ConnectionConfig) (
 			"path": krb5confPath,
 		})
 
-		// Create GSSAPI client using the file-based configuration
-		return createGSSAPIClientWithFile(ctx, cfg, krb5confPath)
+		// Load credentials using the file-based configuration
+		return loadKerberosCredentialsWithConfigPath(ctx, cfg, krb5confPath)
 	}
 }
 
-// createGSSAPIClientWithRuntimeConfig creates a GSSAPI client using runtime-generated krb5.conf.
-func createGSSAPIClientWithRuntimeConfig(ctx context.Context, cfg *ConnectionConfig, runtimeConfig string) (ldap.GSSAPIClient, error) {
+// loadKerberosCredentialsWithRuntimeConfig loads credentials using runtime-generated krb5.conf.
+func loadKerberosCredentialsWithRuntimeConfig(ctx context.Context, cfg *ConnectionConfig, runtimeConfig string) (*kerberosCredentials, error) {
 	// Create a temporary file with the runtime configuration
 	tempFile, err := createTempKrb5Conf(runtimeConfig)
 	if err != nil {
@@ -189,102 +243,90 @@ func createGSSAPIClientWithRuntimeConfig(ctx context.Context, cfg *ConnectionCon
 	}
 	defer removeTempFile(tempFile)
 
-	return createGSSAPIClientWithConfigPath(ctx, cfg, tempFile)
+	return loadKerberosCredentialsWithConfigPath(ctx, cfg, tempFile)
 }
 
-// createGSSAPIClientWithFile creates a GSSAPI client using a krb5.conf file.
-func createGSSAPIClientWithFile(ctx context.Context, cfg *ConnectionConfig, krb5confPath string) (ldap.GSSAPIClient, error) {
-	return createGSSAPIClientWithConfigPath(ctx, cfg, krb5confPath)
-}
+// loadKerberosCredentialsWithConfigPath loads credentials using a krb5 configuration file path.
+// A credential cache whose TGT has expired is skipped in favour of the next
+// source, so a keytab or password can take over from a lapsed kinit.
+func loadKerberosCredentialsWithConfigPath(ctx context.Context, cfg *ConnectionConfig, krb5confPath string) (*kerberosCredentials, error) {
+	krb5conf, err := config.Load(krb5confPath)
+	if err != nil {
+		tflog.SubsystemError(ctx, "ldap", "Kerberos configuration could not be loaded", map[string]any{
+			"path":  krb5confPath,
+			"error": err.Error(),
+		})
+		return nil, err
+	}
 
-// createGSSAPIClientWithConfigPath creates a GSSAPI client using a krb5 configuration file path.
-func createGSSAPIClientWithConfigPath(ctx context.Context, cfg *ConnectionConfig, krb5confPath string) (ldap.GSSAPIClient, error) {
-	// Priority 1: Explicit credential cache
-	if cfg.KerberosCCache != "" && fileExists(cfg.KerberosCCache) {
+	// Priority 1 and 2: Explicit credential cache, then default credential cache (if exists)
+	var expiredErr error
+	ccaches := []struct{ method, path string }{
+		{"explicit_ccache", cfg.KerberosCCache},
+		{"default_ccache", getDefaultCCachePath()},
+	}
+	for _, ccache := range ccaches {
+		if !fileExists(ccache.path) {
+			continue
+		}
 		tflog.SubsystemDebug(ctx, "ldap", "Kerberos credentials selected", map[string]any{
-			"method": "explicit_ccache",
-			"path":   cfg.KerberosCCache,
+			"method": ccache.method,
+			"path":   ccache.path,
 		})
-		client, err := gssapi.NewClientFromCCache(cfg.KerberosCCache, krb5confPath, krb5client.DisablePAFXFAST(true))
-		if err != nil {
-			tflog.SubsystemError(ctx, "ldap", "Kerberos credential cache failed", map[string]any{
-				"path":  cfg.KerberosCCache,
+		creds, err := loadCCacheCredentials(ccache.path, ccache.method, krb5conf)
+		if errors.Is(err, errCCacheExpired) {
+			tflog.SubsystemWarn(ctx, "ldap", "Kerberos credential cache has expired", map[string]any{
+				"path":  ccache.path,
 				"error": err.Error(),
 			})
-			return nil, err
+			if expiredErr == nil {
+				expiredErr = err
+			}
+			continue
 		}
-		tflog.SubsystemDebug(ctx, "ldap", "Kerberos credential cache loaded", map[string]any{
-			"path": cfg.KerberosCCache,
-		})
-		return client, nil
-	}
-
-	// Priority 2: Default credential cache (if exists)
-	defaultCCache := getDefaultCCachePath()
-	if fileExists(defaultCCache) {
-		tflog.SubsystemDebug(ctx, "ldap", "Kerberos credentials selected", map[string]any{
-			"method": "default_ccache",
-			"path":   defaultCCache,
-		})
-		client, err := gssapi.NewClientFromCCache(defaultCCache, krb5confPath, krb5client.DisablePAFXFAST(true))
 		if err != nil {
 			tflog.SubsystemError(ctx, "ldap", "Kerberos credential cache failed", map[string]any{
-				"path":  defaultCCache,
+				"path":  ccache.path,
 				"error": err.Error(),
 			})
 			return nil, err
 		}
 		tflog.SubsystemDebug(ctx, "ldap", "Kerberos credential cache loaded", map[string]any{
-			"path": defaultCCache,
+			"path":        ccache.path,
+			"tgt_expires": creds.tgt.endTime,
 		})
-		return client, nil
+		return creds, nil
 	}
 
-	// Priority 3: Explicit keytab
-	if cfg.KerberosKeytab != "" && fileExists(cfg.KerberosKeytab) {
+	// Priority 3 and 4: Explicit keytab, then default keytab (if exists and username provided)
+	keytabs := []struct{ method, path string }{
+		{"explicit_keytab", cfg.KerberosKeytab},
+	}
+	if cfg.Username != "" {
+		keytabs = append(keytabs, struct{ method, path string }{"default_keytab", getDefaultKeytabPath()})
+	}
+	for _, kt := range keytabs {
+		if !fileExists(kt.path) {
+			continue
+		}
 		tflog.SubsystemDebug(ctx, "ldap", "Kerberos credentials selected", map[string]any{
-			"method":   "explicit_keytab",
-			"path":     cfg.KerberosKeytab,
+			"method":   kt.method,
+			"path":     kt.path,
 			"username": cfg.Username,
 			"realm":    cfg.KerberosRealm,
 		})
-		client, err := gssapi.NewClientWithKeytab(cfg.Username, cfg.KerberosRealm, cfg.KerberosKeytab, krb5confPath, krb5client.DisablePAFXFAST(true))
+		creds, err := loadKeytabCredentials(cfg, kt.path, kt.method, krb5conf)
 		if err != nil {
 			tflog.SubsystemError(ctx, "ldap", "Kerberos keytab failed", map[string]any{
-				"path":  cfg.KerberosKeytab,
+				"path":  kt.path,
 				"error": err.Error(),
 			})
 			return nil, err
 		}
 		tflog.SubsystemDebug(ctx, "ldap", "Kerberos keytab loaded", map[string]any{
-			"path": cfg.KerberosKeytab,
+			"path": kt.path,
 		})
-		return client, nil
-	}
-
-	// Priority 4: Default keytab (if exists and username provided)
-	if cfg.Username != "" {
-		defaultKeytab := getDefaultKeytabPath()
-		if fileExists(defaultKeytab) {
-			tflog.SubsystemDebug(ctx, "ldap", "Kerberos credentials selected", map[string]any{
-				"method":   "default_keytab",
-				"path":     defaultKeytab,
-				"username": cfg.Username,
-				"realm":    cfg.KerberosRealm,
-			})
-			client, err := gssapi.NewClientWithKeytab(cfg.Username, cfg.KerberosRealm, defaultKeytab, krb5confPath, krb5client.DisablePAFXFAST(true))
-			if err != nil {
-				tflog.SubsystemError(ctx, "ldap", "Kerberos keytab failed", map[string]any{
-					"path":  defaultKeytab,
-					"error": err.Error(),
-				})
-				return nil, err
-			}
-			tflog.SubsystemDebug(ctx, "ldap", "Kerberos keytab loaded", map[string]any{
-				"path": defaultKeytab,
-			})
-			return client, nil
-		}
+		return creds, nil
 	}
 
 	// Priority 5: Password authentication
@@ -294,20 +336,14 @@ func createGSSAPIClientWithConfigPath(ctx context.Context, cfg *ConnectionConfig
 			"username": cfg.Username,
 			"realm":    cfg.KerberosRealm,
 		})
-		client, err := gssapi.NewClientWithPassword(cfg.Username, cfg.KerberosRealm, cfg.Password, krb5confPath, krb5client.DisablePAFXFAST(true))
-		if err != nil {
-			tflog.SubsystemError(ctx, "ldap", "Kerberos password auth failed", map[string]any{
-				"username": cfg.Username,
-				"realm":    cfg.KerberosRealm,
-				"error":    err.Error(),
-			})
-			return nil, err
-		}
-		tflog.SubsystemDebug(ctx, "ldap", "Kerberos password auth success", map[string]any{
-			"username": cfg.Username,
-			"realm":    cfg.KerberosRealm,
-		})
-		return client, nil
+		return &kerberosCredentials{
+			client: krb5client.NewWithPassword(cfg.Username, cfg.KerberosRealm, cfg.Password, krb5conf, krb5client.DisablePAFXFAST(true)),
+			source: kerberosSource{method: "password"},
+		}, nil
+	}
+
+	if expiredErr != nil {
+		return nil, expiredErr
 	}
 
 	tflog.SubsystemError(ctx, "ldap", "Kerberos no credentials found", map[string]any{
@@ -321,6 +357,51 @@ func createGSSAPIClientWithConfigPath(ctx context.Context, cfg *ConnectionConfig
 	return nil, fmt.Errorf("no suitable credentials found for Kerberos authentication")
 }
 
+// errCCacheExpired is returned when a credential cache holds no usable TGT.
+var errCCacheExpired = errors.New("kerberos credential cache TGT has expired")
+
+// loadCCacheCredentials loads a client and its TGT from a credential cache.
+func loadCCacheCredentials(path, method string, krb5conf *config.Config) (*kerberosCredentials, error) {
+	source, err := newKerberosSource(method, path)
+	if err != nil {
+		return nil, err
+	}
+	ccache, err := credentials.LoadCCache(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tgt, err := ccacheTGT(ccache)
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(tgt.endTime) {
+		return nil, fmt.Errorf("%w: %s expired at %s; run kinit to refresh it", errCCacheExpired, path, tgt.endTime.Format(time.RFC3339))
+	}
+
+	client, err := krb5client.NewFromCCache(ccache, krb5conf, krb5client.DisablePAFXFAST(true))
+	if err != nil {
+		return nil, err
+	}
+	return &kerberosCredentials{client: client, source: source, tgt: tgt}, nil
+}
+
+// loadKeytabCredentials creates a client that logs in with a keytab.
+func loadKeytabCredentials(cfg *ConnectionConfig, path, method string, krb5conf *config.Config) (*kerberosCredentials, error) {
+	source, err := newKerberosSource(method, path)
+	if err != nil {
+		return nil, err
+	}
+	kt, err := keytab.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return &kerberosCredentials{
+		client: krb5client.NewWithKeytab(cfg.Username, cfg.KerberosRealm, kt, krb5conf, krb5client.DisablePAFXFAST(true)),
+		source: source,
+	}, nil
+}
+
 // buildServicePrincipal constructs the LDAP service principal name from server info.
 // If cfg.KerberosSPN is set, it overrides the automatic SPN construction.
 func buildServicePrincipal(cfg *ConnectionConfig, serverInfo *ServerInfo) (string, error) {
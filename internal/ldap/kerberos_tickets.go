@@ -0,0 +1,325 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	krb5client "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/iana/nametype"
+	"github.com/jcmturner/gokrb5/v8/messages"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+// kerberosRefreshFraction is the share of a ticket's lifetime left at which
+// it is renewed or replaced, as gokrb5 does for its own sessions.
+const kerberosRefreshFraction = 6
+
+// kerberosTicket is a ticket, its session key and its validity.
+type kerberosTicket struct {
+	ticket    messages.Ticket
+	key       types.EncryptionKey
+	startTime time.Time
+	endTime   time.Time
+	renewTill time.Time
+}
+
+// newKerberosTicket builds a kerberosTicket from a KDC reply.
+func newKerberosTicket(tkt messages.Ticket, part messages.EncKDCRepPart) kerberosTicket {
+	start := part.StartTime
+	if start.IsZero() {
+		start = part.AuthTime
+	}
+	return kerberosTicket{
+		ticket:    tkt,
+		key:       part.Key,
+		startTime: start,
+		endTime:   part.EndTime,
+		renewTill: part.RenewTill,
+	}
+}
+
+// needsRefresh reports whether the ticket is missing or within the last
+// 1/kerberosRefreshFraction of its lifetime.
+func (t kerberosTicket) needsRefresh(now time.Time) bool {
+	if t.endTime.IsZero() {
+		return true
+	}
+	return t.endTime.Sub(now) <= t.endTime.Sub(t.startTime)/kerberosRefreshFraction
+}
+
+// expired reports whether the ticket can no longer be used.
+func (t kerberosTicket) expired(now time.Time) bool {
+	return !now.Before(t.endTime)
+}
+
+// renewable reports whether the KDC will still renew the ticket.
+func (t kerberosTicket) renewable(now time.Time) bool {
+	return !t.expired(now) && now.Before(t.renewTill)
+}
+
+// ccacheTGT returns the TGT for the default principal of a credential cache.
+func ccacheTGT(ccache *credentials.CCache) (kerberosTicket, error) {
+	spn := types.PrincipalName{
+		NameType:   nametype.KRB_NT_SRV_INST,
+		NameString: []string{"krbtgt", ccache.DefaultPrincipal.Realm},
+	}
+	cred, ok := ccache.GetEntry(spn)
+	if !ok {
+		return kerberosTicket{}, errors.New("TGT not found in credential cache")
+	}
+	var tkt messages.Ticket
+	if err := tkt.Unmarshal(cred.Ticket); err != nil {
+		return kerberosTicket{}, fmt.Errorf("TGT in credential cache is not valid: %w", err)
+	}
+
+	start := cred.StartTime
+	if start.IsZero() {
+		start = cred.AuthTime
+	}
+	return kerberosTicket{
+		ticket:    tkt,
+		key:       cred.Key,
+		startTime: start,
+		endTime:   cred.EndTime,
+		renewTill: cred.RenewTill,
+	}, nil
+}
+
+// kerberosSource records the file Kerberos credentials were loaded from so
+// that a rewritten credential cache or rotated keytab can be picked up.
+type kerberosSource struct {
+	method string // explicit_ccache, default_ccache, explicit_keytab, default_keytab or password
+	fileStamp
+}
+
+// newKerberosSource stats the credential file at path.
+func newKerberosSource(method, path string) (kerberosSource, error) {
+	stamp, err := statFile(path)
+	if err != nil {
+		return kerberosSource{}, err
+	}
+	return kerberosSource{method: method, fileStamp: stamp}, nil
+}
+
+// kerberosKDC performs the KDC exchanges needed to keep tickets fresh.
+type kerberosKDC interface {
+	login(client *krb5client.Client) (kerberosTicket, error)
+	renew(client *krb5client.Client, tgt kerberosTicket) (kerberosTicket, error)
+	serviceTicket(client *krb5client.Client, tgt kerberosTicket, spn string) (kerberosTicket, error)
+}
+
+// gokrb5KDC performs KDC exchanges with a gokrb5 client. The exchanges are
+// made directly rather than through the client's own session handling so
+// that the ticket lifetimes are known.
+type gokrb5KDC struct{}
+
+func (gokrb5KDC) login(client *krb5client.Client) (kerberosTicket, error) {
+	realm := client.Credentials.Domain()
+	req, err := messages.NewASReqForTGT(realm, client.Config, client.Credentials.CName())
+	if err != nil {
+		return kerberosTicket{}, err
+	}
+	rep, err := client.ASExchange(realm, req, 0)
+	if err != nil {
+		return kerberosTicket{}, err
+	}
+	return newKerberosTicket(rep.Ticket, rep.DecryptedEncPart), nil
+}
+
+func (gokrb5KDC) renew(client *krb5client.Client, tgt kerberosTicket) (kerberosTicket, error) {
+	_, rep, err := client.TGSREQGenerateAndExchange(tgt.ticket.SName, tgt.ticket.Realm, tgt.ticket, tgt.key, true)
+	if err != nil {
+		return kerberosTicket{}, err
+	}
+	return newKerberosTicket(rep.Ticket, rep.DecryptedEncPart), nil
+}
+
+func (gokrb5KDC) serviceTicket(client *krb5client.Client, tgt kerberosTicket, spn string) (kerberosTicket, error) {
+	principal := types.NewPrincipalName(nametype.KRB_NT_PRINCIPAL, spn)
+	_, rep, err := client.TGSREQGenerateAndExchange(principal, tgt.ticket.Realm, tgt.ticket, tgt.key, false)
+	if err != nil {
+		return kerberosTicket{}, err
+	}
+	return newKerberosTicket(rep.Ticket, rep.DecryptedEncPart), nil
+}
+
+// kerberosTicketCache holds the Kerberos credentials shared by every bind
+// made with a configuration. It renews the TGT before it expires, logs in
+// again from a keytab or password when renewal is not possible, and reloads
+// the credential cache or keytab when the file changes on disk.
+type kerberosTicketCache struct {
+	// config returns the configuration to load credentials with, following
+	// credentials refreshed at runtime.
+	config func() *ConnectionConfig
+
+	// load, kdc and now are replaced by tests.
+	load func(ctx context.Context, cfg *ConnectionConfig) (*kerberosCredentials, error)
+	kdc  kerberosKDC
+	now  func() time.Time
+
+	mu       sync.Mutex
+	client   *krb5client.Client
+	source   kerberosSource
+	tgt      kerberosTicket
+	services map[string]kerberosTicket
+}
+
+// newKerberosTicketCache creates an empty ticket cache for cfg.
+func newKerberosTicketCache(cfg *ConnectionConfig) *kerberosTicketCache {
+	return &kerberosTicketCache{
+		config:   func() *ConnectionConfig { return cfg },
+		load:     loadKerberosCredentials,
+		kdc:      gokrb5KDC{},
+		now:      time.Now,
+		services: make(map[string]kerberosTicket),
+	}
+}
+
+// serviceTicket returns the client credentials and a valid service ticket
+// for spn, refreshing the TGT first if required.
+func (c *kerberosTicketCache) serviceTicket(ctx context.Context, spn string) (*credentials.Credentials, kerberosTicket, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.ensureTGT(ctx); err != nil {
+		return nil, kerberosTicket{}, err
+	}
+
+	if tkt, ok := c.services[spn]; ok && !tkt.needsRefresh(c.now()) {
+		return c.client.Credentials, tkt, nil
+	}
+
+	tkt, err := c.kdc.serviceTicket(c.client, c.tgt, spn)
+	if err != nil {
+		return nil, kerberosTicket{}, fmt.Errorf("failed to get service ticket for %s: %w", spn, err)
+	}
+	c.services[spn] = tkt
+
+	tflog.SubsystemDebug(ctx, "ldap", "Kerberos service ticket acquired", map[string]any{
+		"spn":     spn,
+		"expires": tkt.endTime,
+	})
+	return c.client.Credentials, tkt, nil
+}
+
+// expiry returns when the current TGT expires, or the zero time if no TGT
+// has been obtained yet.
+func (c *kerberosTicketCache) expiry() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tgt.endTime
+}
+
+// invalidate discards the cached credentials so that the next bind loads
+// them afresh, for example after the configured password has changed.
+func (c *kerberosTicketCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset()
+}
+
+// ensureTGT makes sure a TGT is held that is not about to expire.
+func (c *kerberosTicketCache) ensureTGT(ctx context.Context) error {
+	if c.client != nil && c.source.changed() {
+		tflog.SubsystemInfo(ctx, "ldap", "Kerberos credentials changed on disk, reloading", map[string]any{
+			"method": c.source.method,
+			"path":   c.source.path,
+		})
+		c.reset()
+	}
+
+	if c.client == nil {
+		if err := c.reload(ctx); err != nil {
+			return err
+		}
+	}
+
+	if !c.tgt.needsRefresh(c.now()) {
+		return nil
+	}
+	return c.refreshTGT(ctx)
+}
+
+// reload loads credentials afresh and logs in when they do not come with a TGT.
+func (c *kerberosTicketCache) reload(ctx context.Context) error {
+	creds, err := c.load(ctx, c.config())
+	if err != nil {
+		return err
+	}
+	c.client = creds.client
+	c.source = creds.source
+	c.tgt = creds.tgt
+	c.services = make(map[string]kerberosTicket)
+
+	if c.tgt.endTime.IsZero() {
+		return c.login(ctx)
+	}
+	return nil
+}
+
+// refreshTGT renews the TGT if possible and otherwise obtains a new one.
+func (c *kerberosTicketCache) refreshTGT(ctx context.Context) error {
+	now := c.now()
+	fields := map[string]any{
+		"method":  c.source.method,
+		"expires": c.tgt.endTime,
+	}
+
+	if c.tgt.renewable(now) {
+		tgt, err := c.kdc.renew(c.client, c.tgt)
+		if err == nil {
+			c.tgt = tgt
+			fields["expires"] = tgt.endTime
+			tflog.SubsystemInfo(ctx, "ldap", "Kerberos TGT renewed", fields)
+			return nil
+		}
+		fields["error"] = err.Error()
+		tflog.SubsystemWarn(ctx, "ldap", "Kerberos TGT renewal failed", fields)
+	}
+
+	if c.client.Credentials.HasKeytab() || c.client.Credentials.HasPassword() {
+		return c.login(ctx)
+	}
+
+	// A credential cache cannot be refreshed here. Once its TGT has expired,
+	// start over: kinit may have refreshed the cache in the meantime, and
+	// otherwise a keytab or password takes over.
+	if c.tgt.expired(now) {
+		c.reset()
+		return c.reload(ctx)
+	}
+
+	tflog.SubsystemWarn(ctx, "ldap", "Kerberos TGT from credential cache expires soon and cannot be renewed", fields)
+	return nil
+}
+
+// login obtains a new TGT from the client's keytab or password.
+func (c *kerberosTicketCache) login(ctx context.Context) error {
+	tgt, err := c.kdc.login(c.client)
+	if err != nil {
+		return fmt.Errorf("kerberos login failed: %w", err)
+	}
+	c.tgt = tgt
+
+	tflog.SubsystemInfo(ctx, "ldap", "Kerberos TGT acquired", map[string]any{
+		"method":  c.source.method,
+		"expires": tgt.endTime,
+	})
+	return nil
+}
+
+// reset discards the current client and its tickets.
+func (c *kerberosTicketCache) reset() {
+	if c.client != nil {
+		c.client.Destroy()
+	}
+	c.client = nil
+	c.source = kerberosSource{}
+	c.tgt = kerberosTicket{}
+	c.services = make(map[string]kerberosTicket)
+}
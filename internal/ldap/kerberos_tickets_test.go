@@ -0,0 +1,239 @@
+package ldap
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	krb5client "github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/test/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKDC issues tickets valid for lifetime from the fake clock.
+type fakeKDC struct {
+	now       func() time.Time
+	lifetime  time.Duration
+	renewable time.Duration
+
+	logins, renewals, services int
+	renewErr                   error
+}
+
+func (k *fakeKDC) ticket() kerberosTicket {
+	now := k.now()
+	tkt := kerberosTicket{startTime: now, endTime: now.Add(k.lifetime)}
+	tkt.key.KeyType = 18
+	if k.renewable > 0 {
+		tkt.renewTill = now.Add(k.renewable)
+	}
+	return tkt
+}
+
+func (k *fakeKDC) login(*krb5client.Client) (kerberosTicket, error) {
+	k.logins++
+	return k.ticket(), nil
+}
+
+func (k *fakeKDC) renew(_ *krb5client.Client, tgt kerberosTicket) (kerberosTicket, error) {
+	k.renewals++
+	if k.renewErr != nil {
+		return kerberosTicket{}, k.renewErr
+	}
+	renewed := k.ticket()
+	renewed.renewTill = tgt.renewTill
+	return renewed, nil
+}
+
+func (k *fakeKDC) serviceTicket(*krb5client.Client, kerberosTicket, string) (kerberosTicket, error) {
+	k.services++
+	return k.ticket(), nil
+}
+
+// newTestTicketCache returns a ticket cache driven by a fake clock and KDC.
+// load returns credentials from newCreds and counts the calls.
+func newTestTicketCache(t *testing.T, newCreds func() *kerberosCredentials) (*kerberosTicketCache, *fakeKDC, *time.Time, *int) {
+	t.Helper()
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	kdc := &fakeKDC{now: func() time.Time { return now }, lifetime: 10 * time.Hour}
+	loads := 0
+
+	cache := newKerberosTicketCache(&ConnectionConfig{})
+	cache.kdc = kdc
+	cache.now = kdc.now
+	cache.load = func(context.Context, *ConnectionConfig) (*kerberosCredentials, error) {
+		loads++
+		return newCreds(), nil
+	}
+	return cache, kdc, &now, &loads
+}
+
+func passwordCredentials() *kerberosCredentials {
+	return &kerberosCredentials{
+		client: krb5client.NewWithPassword("svc", "EXAMPLE.COM", "secret", config.New()),
+		source: kerberosSource{method: "password"},
+	}
+}
+
+func TestKerberosTicket_NeedsRefresh(t *testing.T) {
+	start := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	tkt := kerberosTicket{startTime: start, endTime: start.Add(6 * time.Hour), renewTill: start.Add(24 * time.Hour)}
+
+	assert.True(t, kerberosTicket{}.needsRefresh(start), "a missing ticket needs refreshing")
+	assert.False(t, tkt.needsRefresh(start.Add(4*time.Hour)))
+	assert.True(t, tkt.needsRefresh(start.Add(5*time.Hour)), "last sixth of the lifetime")
+
+	assert.True(t, tkt.renewable(start.Add(5*time.Hour)))
+	assert.False(t, tkt.renewable(start.Add(6*time.Hour)), "an expired ticket cannot be renewed")
+	assert.True(t, tkt.expired(start.Add(6*time.Hour)))
+}
+
+func TestKerberosTicketCache_LoginAndServiceTicketReuse(t *testing.T) {
+	cache, kdc, now, loads := newTestTicketCache(t, passwordCredentials)
+	ctx := context.Background()
+
+	creds, tkt, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "svc", creds.UserName())
+	assert.Equal(t, now.Add(10*time.Hour), tkt.endTime)
+	assert.Equal(t, now.Add(10*time.Hour), cache.expiry())
+
+	*now = now.Add(time.Hour)
+	_, _, err = cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+	_, _, err = cache.serviceTicket(ctx, "ldap/dc2.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *loads)
+	assert.Equal(t, 1, kdc.logins)
+	assert.Equal(t, 2, kdc.services, "service tickets are cached per SPN")
+}
+
+func TestKerberosTicketCache_RenewsBeforeExpiry(t *testing.T) {
+	cache, kdc, now, _ := newTestTicketCache(t, passwordCredentials)
+	kdc.renewable = 7 * 24 * time.Hour
+	ctx := context.Background()
+
+	_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+
+	// Nine hours into a ten hour lifetime the TGT and service ticket are refreshed.
+	*now = now.Add(9 * time.Hour)
+	_, tkt, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, kdc.logins)
+	assert.Equal(t, 1, kdc.renewals)
+	assert.Equal(t, 2, kdc.services)
+	assert.Equal(t, now.Add(10*time.Hour), tkt.endTime)
+	assert.Equal(t, now.Add(10*time.Hour), cache.expiry())
+}
+
+func TestKerberosTicketCache_LogsInAgainWhenRenewalFails(t *testing.T) {
+	cache, kdc, now, _ := newTestTicketCache(t, passwordCredentials)
+	kdc.renewable = 7 * 24 * time.Hour
+	kdc.renewErr = assert.AnError
+	ctx := context.Background()
+
+	_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+
+	*now = now.Add(9 * time.Hour)
+	_, _, err = cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, kdc.renewals)
+	assert.Equal(t, 2, kdc.logins)
+}
+
+func TestKerberosTicketCache_CredentialCache(t *testing.T) {
+	ccachePath := filepath.Join(t.TempDir(), "krb5cc")
+	require.NoError(t, os.WriteFile(ccachePath, []byte("v1"), 0o600))
+
+	var start time.Time
+	newCreds := func() *kerberosCredentials {
+		source, err := newKerberosSource("explicit_ccache", ccachePath)
+		require.NoError(t, err)
+		return &kerberosCredentials{
+			// No password or keytab: the TGT can only come from the cache.
+			client: krb5client.NewWithPassword("svc", "EXAMPLE.COM", "", config.New()),
+			source: source,
+			tgt:    kerberosTicket{startTime: start, endTime: start.Add(10 * time.Hour)},
+		}
+	}
+	cache, kdc, now, loads := newTestTicketCache(t, newCreds)
+	start = *now
+	ctx := context.Background()
+
+	_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *loads)
+	assert.Zero(t, kdc.logins, "the TGT comes from the credential cache")
+
+	t.Run("reloads when the file changes", func(t *testing.T) {
+		start = now.Add(time.Hour)
+		require.NoError(t, os.WriteFile(ccachePath, []byte("v2 after kinit"), 0o600))
+
+		_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 2, *loads)
+		assert.Equal(t, start.Add(10*time.Hour), cache.expiry())
+	})
+
+	t.Run("keeps a ticket that cannot be renewed until it expires", func(t *testing.T) {
+		*now = now.Add(10 * time.Hour)
+		_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 2, *loads)
+	})
+
+	t.Run("starts over once expired", func(t *testing.T) {
+		*now = now.Add(time.Hour)
+		start = *now
+		_, _, err := cache.serviceTicket(ctx, "ldap/dc1.example.com")
+		require.NoError(t, err)
+		assert.Equal(t, 3, *loads)
+		assert.Zero(t, kdc.logins)
+	})
+}
+
+func TestKerberosSource_Changed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "svc.keytab")
+	require.NoError(t, os.WriteFile(path, []byte("keytab"), 0o600))
+
+	source, err := newKerberosSource("explicit_keytab", path)
+	require.NoError(t, err)
+	assert.False(t, source.changed())
+
+	require.NoError(t, os.WriteFile(path, []byte("rotated keytab"), 0o600))
+	assert.True(t, source.changed())
+
+	require.NoError(t, os.Remove(path))
+	assert.False(t, source.changed(), "a missing file keeps the loaded credentials")
+	assert.False(t, kerberosSource{method: "password"}.changed())
+}
+
+func TestLoadCCacheCredentials(t *testing.T) {
+	data, err := hex.DecodeString(testdata.CCACHE_TEST)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "krb5cc")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	ccache, err := credentials.LoadCCache(path)
+	require.NoError(t, err)
+	tgt, err := ccacheTGT(ccache)
+	require.NoError(t, err)
+	assert.Equal(t, "TEST.GOKRB5", tgt.ticket.Realm)
+	assert.False(t, tgt.endTime.IsZero())
+
+	// The test cache was issued long ago.
+	_, err = loadCCacheCredentials(path, "explicit_ccache", config.New())
+	assert.ErrorIs(t, err, errCCacheExpired)
+	assert.ErrorContains(t, err, "run kinit")
+}
@@ -0,0 +1,268 @@
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LAPS attribute names, in the order they are preferred.
+const (
+	LAPSSourceEncrypted = "msLAPS-EncryptedPassword"
+	LAPSSourceWindows   = "msLAPS-Password"
+	LAPSSourceLegacy    = "ms-Mcs-AdmPwd"
+)
+
+// lapsEncryptedHeaderLength is the size of the header that precedes the
+// DPAPI-NG blob in msLAPS-EncryptedPassword: the password update time as two
+// little-endian 32-bit halves (high first), the blob size and reserved flags.
+const lapsEncryptedHeaderLength = 16
+
+// LAPSPassword is a local administrator password managed by Windows LAPS or
+// legacy Microsoft LAPS.
+type LAPSPassword struct {
+	ComputerDN string
+	Account    string // Managed account name; empty for legacy LAPS, which does not record it
+	Password   string
+	Expiration *time.Time
+	UpdatedAt  *time.Time // When the password was last set; not recorded by legacy LAPS
+	Source     string     // Attribute the password was read from
+
+	// EncryptedUnreadable is set when the computer has an encrypted password
+	// that could not be decrypted and an older clear-text password was
+	// returned instead.
+	EncryptedUnreadable bool
+}
+
+// lapsPasswordJSON is the JSON document stored in msLAPS-Password, and in
+// decrypted msLAPS-EncryptedPassword blobs.
+type lapsPasswordJSON struct {
+	Account   string `json:"n"`
+	Timestamp string `json:"t"` // FILETIME in hexadecimal
+	Password  string `json:"p"`
+}
+
+// LAPSManager reads LAPS passwords from computer objects.
+type LAPSManager struct {
+	ctx     context.Context
+	client  Client
+	locator *computerLocator
+}
+
+// NewLAPSManager creates a new LAPS manager instance.
+func NewLAPSManager(ctx context.Context, client Client, baseDN string, cacheManager *CacheManager) *LAPSManager {
+	return &LAPSManager{
+		ctx:     ctx,
+		client:  client,
+		locator: newComputerLocator(client, baseDN, cacheManager),
+	}
+}
+
+// lapsAttributes returns the attributes read from a computer object.
+func lapsAttributes() []string {
+	return []string{
+		"distinguishedName",
+		LAPSSourceEncrypted, LAPSSourceWindows, "msLAPS-PasswordExpirationTime",
+		LAPSSourceLegacy, "ms-Mcs-AdmPwdExpirationTime",
+	}
+}
+
+// GetLAPSPassword returns the LAPS password of a computer identified by DN,
+// GUID, SID, DNS host name or SAM account name (with or without the trailing
+// "$"). Passwords are read from msLAPS-EncryptedPassword, msLAPS-Password and
+// ms-Mcs-AdmPwd, in that order. AD silently omits confidential attributes the
+// bind identity may not read, so a computer without a readable password
+// returns a not found error.
+func (lm *LAPSManager) GetLAPSPassword(computer string) (*LAPSPassword, error) {
+	if computer == "" {
+		return nil, fmt.Errorf("computer identifier cannot be empty")
+	}
+
+	searchReq, err := lm.locator.searchRequest("computer", computer, lapsAttributes())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := lm.client.Search(lm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_laps_computer", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_laps_password", "computer %s not found", computer)
+	}
+
+	return lm.entryToLAPSPassword(result.Entries[0])
+}
+
+// entryToLAPSPassword extracts the preferred readable password from a
+// computer entry, falling back to clear-text attributes when an encrypted
+// password cannot be decrypted.
+func (lm *LAPSManager) entryToLAPSPassword(entry *ldap.Entry) (*LAPSPassword, error) {
+	var encryptedErr error
+	if blob := entry.GetRawAttributeValue(LAPSSourceEncrypted); len(blob) > 0 {
+		password, err := lm.decryptLAPSPassword(blob)
+		if err == nil {
+			password.ComputerDN = entry.DN
+			password.Source = LAPSSourceEncrypted
+			password.Expiration = lapsExpiration(entry, "msLAPS-PasswordExpirationTime")
+			return password, nil
+		}
+		encryptedErr = err
+		tflog.SubsystemDebug(lm.ctx, "ldap", "Cannot decrypt LAPS password", map[string]any{
+			"computer_dn": entry.DN,
+			"error":       encryptedErr.Error(),
+		})
+	}
+
+	var password *LAPSPassword
+	switch {
+	case entry.GetAttributeValue(LAPSSourceWindows) != "":
+		var err error
+		password, err = parseLAPSPasswordJSON([]byte(entry.GetAttributeValue(LAPSSourceWindows)))
+		if err != nil {
+			return nil, WrapError("parse_laps_password", err)
+		}
+		password.Source = LAPSSourceWindows
+		password.Expiration = lapsExpiration(entry, "msLAPS-PasswordExpirationTime")
+	case entry.GetAttributeValue(LAPSSourceLegacy) != "":
+		password = &LAPSPassword{
+			Password:   entry.GetAttributeValue(LAPSSourceLegacy),
+			Expiration: lapsExpiration(entry, "ms-Mcs-AdmPwdExpirationTime"),
+			Source:     LAPSSourceLegacy,
+		}
+	case errors.Is(encryptedErr, ErrKDSRootKeyUnreadable):
+		// Name the missing permission rather than a failed decryption
+		ldapErr := NewLDAPError("read_kds_root_key", encryptedErr)
+		ldapErr.Category = ErrorCategoryPermission
+		return nil, ldapErr
+	case encryptedErr != nil:
+		return nil, WrapError("decrypt_laps_password", encryptedErr)
+	default:
+		return nil, NewNotFoundError("get_laps_password", "no readable LAPS password on computer %s", entry.DN)
+	}
+
+	password.ComputerDN = entry.DN
+	password.EncryptedUnreadable = encryptedErr != nil
+	return password, nil
+}
+
+// lapsExpiration parses a FILETIME expiry attribute, returning nil when it is
+// missing or invalid.
+func lapsExpiration(entry *ldap.Entry, attribute string) *time.Time {
+	t, err := parseADTimestamp(entry.GetAttributeValue(attribute))
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// parseLAPSPasswordJSON parses the JSON document stored by Windows LAPS.
+func parseLAPSPasswordJSON(data []byte) (*LAPSPassword, error) {
+	var doc lapsPasswordJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid LAPS password JSON: %w", err)
+	}
+	if doc.Password == "" {
+		return nil, fmt.Errorf("LAPS password JSON has no password")
+	}
+
+	password := &LAPSPassword{Account: doc.Account, Password: doc.Password}
+	if ticks, err := strconv.ParseInt(doc.Timestamp, 16, 64); err == nil {
+		if t, err := fileTimeToTime(ticks); err == nil {
+			password.UpdatedAt = &t
+		}
+	}
+	return password, nil
+}
+
+// decryptLAPSPassword decrypts an msLAPS-EncryptedPassword value with the
+// KDS root key it was protected with.
+func (lm *LAPSManager) decryptLAPSPassword(blob []byte) (*LAPSPassword, error) {
+	if len(blob) < lapsEncryptedHeaderLength {
+		return nil, fmt.Errorf("encrypted LAPS password is %d bytes, shorter than its header", len(blob))
+	}
+	size := binary.LittleEndian.Uint32(blob[8:12])
+	if int(size) != len(blob)-lapsEncryptedHeaderLength {
+		return nil, fmt.Errorf("encrypted LAPS password declares %d bytes but holds %d", size, len(blob)-lapsEncryptedHeaderLength)
+	}
+
+	protected, err := parseDPAPINGBlob(blob[lapsEncryptedHeaderLength:])
+	if err != nil {
+		return nil, err
+	}
+	rootKey, err := lm.getKDSRootKey(protected.keyID.rootKeyID)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := protected.decrypt(rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows LAPS encrypts the JSON document as null-terminated UTF-16LE
+	if len(plaintext) >= 2 && plaintext[1] == 0 {
+		plaintext = []byte(decodeUTF16LE(plaintext))
+	}
+	return parseLAPSPasswordJSON(bytes.TrimRight(plaintext, "\x00"))
+}
+
+// getKDSRootKey reads the KDS root key with the given GUID from the
+// configuration partition. Only Domain Admins and Enterprise Admins may read
+// msKds-RootKeyData by default; AD omits it for everyone else.
+func (lm *LAPSManager) getKDSRootKey(id []byte) (*kdsRootKey, error) {
+	guid, err := NewGUIDHandler().GUIDBytesToString(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KDS root key identifier: %w", err)
+	}
+	rootDSE, err := lm.client.GetRootDSE(lm.ctx)
+	if err != nil {
+		return nil, WrapError("get_root_dse", err)
+	}
+
+	result, err := lm.client.Search(lm.ctx, &SearchRequest{
+		BaseDN: "CN=Master Root Keys,CN=Group Key Distribution Service,CN=Services," + rootDSE.ConfigurationNamingContext,
+		Scope:  ScopeSingleLevel,
+		Filter: fmt.Sprintf("(&(objectClass=msKds-ProvRootKey)(cn=%s))", ldap.EscapeFilter(guid)),
+		Attributes: []string{
+			"msKds-RootKeyData", "msKds-KDFAlgorithmID", "msKds-KDFParam",
+			"msKds-SecretAgreementAlgorithmID", "msKds-PrivateKeyLength",
+		},
+	})
+	if err != nil {
+		if IsNotFoundError(err) || IsPermissionError(err) {
+			return nil, ErrKDSRootKeyUnreadable
+		}
+		return nil, WrapError("search_kds_root_key", err)
+	}
+	if len(result.Entries) == 0 || len(result.Entries[0].GetRawAttributeValue("msKds-RootKeyData")) == 0 {
+		return nil, ErrKDSRootKeyUnreadable
+	}
+	entry := result.Entries[0]
+
+	if algorithm := entry.GetAttributeValue("msKds-KDFAlgorithmID"); algorithm != "" && algorithm != "SP800_108_CTR_HMAC" {
+		return nil, fmt.Errorf("unsupported KDS KDF algorithm %q", algorithm)
+	}
+	kdf, err := kdfHash(entry.GetRawAttributeValue("msKds-KDFParam"))
+	if err != nil {
+		return nil, err
+	}
+	privateKeyLength, err := strconv.Atoi(entry.GetAttributeValue("msKds-PrivateKeyLength"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid msKds-PrivateKeyLength: %w", err)
+	}
+
+	return &kdsRootKey{
+		data:             entry.GetRawAttributeValue("msKds-RootKeyData"),
+		hash:             kdf,
+		secretAgreement:  entry.GetAttributeValue("msKds-SecretAgreementAlgorithmID"),
+		privateKeyLength: privateKeyLength,
+	}, nil
+}
@@ -0,0 +1,542 @@
+package ldap
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // Only used when a KDS root key names SHA1
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"strings"
+	"unicode/utf16"
+)
+
+// msLAPS-EncryptedPassword holds a DPAPI-NG blob: a CMS EnvelopedData whose
+// content encryption key is wrapped with a key derived from a KDS root key
+// (MS-GKDI). Domain controllers derive the same key for members of the
+// encryption principal through the Group Key Distribution Service RPC
+// interface; this package derives it locally from the root key, which the
+// bind identity must therefore be able to read.
+
+// ErrKDSRootKeyUnreadable is returned when an encrypted LAPS password cannot
+// be decrypted because the bind identity may not read the KDS root key it was
+// protected with.
+var ErrKDSRootKeyUnreadable = errors.New("decrypting msLAPS-EncryptedPassword requires reading the KDS root key (msKds-RootKeyData), which the bind identity cannot do")
+
+var (
+	oidEnvelopedData        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 3}
+	oidAES256Wrap           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 45}
+	oidAES256GCM            = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 46}
+	oidProtectionDescriptor = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 74, 1}
+	oidSIDProtection        = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 74, 1, 1}
+)
+
+// gkdiKeyIdentifierMagic is "KDSK", the magic of a group key identifier.
+const gkdiKeyIdentifierMagic = 0x4B53444B
+
+// gkdiKeyIdentifierPublicKey marks a key identifier whose key information is
+// the encryptor's public key rather than a random value.
+const gkdiKeyIdentifierPublicKey = 0x1
+
+// BCrypt public key blob magics.
+const (
+	bcryptDHPublicMagic       = 0x42504844 // DHPB
+	bcryptECDHP256PublicMagic = 0x314B4345 // ECK1
+	bcryptECDHP384PublicMagic = 0x334B4345 // ECK3
+	bcryptECDHP521PublicMagic = 0x354B4345 // ECK5
+)
+
+// gkdiSeedKeyLength is the length of the L0, L1 and L2 seed keys.
+const gkdiSeedKeyLength = 64
+
+// kdsRootKey is a KDS root key read from the configuration partition.
+type kdsRootKey struct {
+	data             []byte
+	hash             func() hash.Hash // KDF hash, SHA512 unless msKds-KDFParam names another
+	secretAgreement  string           // DH, ECDH_P256, ECDH_P384 or ECDH_P521
+	privateKeyLength int              // In bits
+}
+
+// gkdiKeyIdentifier is the group key identifier stored in the CMS recipient
+// information (MS-GKDI 2.2.4).
+type gkdiKeyIdentifier struct {
+	flags      uint32
+	l0, l1, l2 int32
+	rootKeyID  []byte // GUID in Active Directory byte order
+	keyInfo    []byte
+}
+
+// dpapiNGBlob is the parsed content of a DPAPI-NG protected value.
+type dpapiNGBlob struct {
+	keyID        *gkdiKeyIdentifier
+	sid          SID // Protection descriptor principal
+	encryptedKey []byte
+	nonce        []byte
+	ciphertext   []byte // Encrypted content followed by the GCM tag
+}
+
+type cmsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type cmsEnvelopedData struct {
+	Version              int
+	RecipientInfos       []asn1.RawValue `asn1:"set"`
+	EncryptedContentInfo cmsEncryptedContentInfo
+	UnprotectedAttrs     asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type cmsEncryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"optional,tag:0"`
+}
+
+type cmsKEKRecipientInfo struct {
+	Version                int
+	KEKID                  asn1.RawValue
+	KeyEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedKey           []byte
+}
+
+type cmsOtherKeyAttribute struct {
+	KeyAttrID asn1.ObjectIdentifier
+	KeyAttr   asn1.RawValue `asn1:"optional"`
+}
+
+type protectionDescriptor struct {
+	Type        asn1.ObjectIdentifier
+	Descriptors [][]protectionDescriptorValue
+}
+
+type protectionDescriptorValue struct {
+	Name  string `asn1:"utf8"`
+	Value string `asn1:"utf8"`
+}
+
+type gcmParameters struct {
+	Nonce  []byte
+	ICVLen int `asn1:"optional"`
+}
+
+// parseDPAPINGBlob parses a DPAPI-NG blob. Windows LAPS stores the encrypted
+// content after the CMS structure rather than inside it.
+func parseDPAPINGBlob(data []byte) (*dpapiNGBlob, error) {
+	var info cmsContentInfo
+	rest, err := asn1.Unmarshal(data, &info)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DPAPI-NG blob: %w", err)
+	}
+	if !info.ContentType.Equal(oidEnvelopedData) {
+		return nil, fmt.Errorf("DPAPI-NG blob has content type %s, not enveloped data", info.ContentType)
+	}
+
+	var enveloped cmsEnvelopedData
+	if _, err := asn1.Unmarshal(info.Content.Bytes, &enveloped); err != nil {
+		return nil, fmt.Errorf("invalid DPAPI-NG enveloped data: %w", err)
+	}
+	if len(enveloped.RecipientInfos) != 1 {
+		return nil, fmt.Errorf("DPAPI-NG blob has %d recipients, expected 1", len(enveloped.RecipientInfos))
+	}
+
+	// KEKRecipientInfo is the [2] alternative of RecipientInfo
+	recipient := enveloped.RecipientInfos[0]
+	if recipient.Class != asn1.ClassContextSpecific || recipient.Tag != 2 {
+		return nil, fmt.Errorf("DPAPI-NG recipient is not a KEK recipient")
+	}
+	var kek cmsKEKRecipientInfo
+	if _, err := asn1.UnmarshalWithParams(recipient.FullBytes, &kek, "tag:2"); err != nil {
+		return nil, fmt.Errorf("invalid DPAPI-NG recipient: %w", err)
+	}
+	if !kek.KeyEncryptionAlgorithm.Algorithm.Equal(oidAES256Wrap) {
+		return nil, fmt.Errorf("unsupported key encryption algorithm %s", kek.KeyEncryptionAlgorithm.Algorithm)
+	}
+
+	blob := &dpapiNGBlob{encryptedKey: kek.EncryptedKey}
+	if err := blob.parseKEKIdentifier(kek.KEKID.Bytes); err != nil {
+		return nil, err
+	}
+
+	content := enveloped.EncryptedContentInfo
+	if !content.ContentEncryptionAlgorithm.Algorithm.Equal(oidAES256GCM) {
+		return nil, fmt.Errorf("unsupported content encryption algorithm %s", content.ContentEncryptionAlgorithm.Algorithm)
+	}
+	var params gcmParameters
+	if _, err := asn1.Unmarshal(content.ContentEncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid AES-GCM parameters: %w", err)
+	}
+	blob.nonce = params.Nonce
+	blob.ciphertext = content.EncryptedContent
+	if len(blob.ciphertext) == 0 {
+		blob.ciphertext = rest
+	}
+	return blob, nil
+}
+
+// parseKEKIdentifier reads the group key identifier and the SID of the
+// protection descriptor from the contents of a KEKIdentifier.
+func (b *dpapiNGBlob) parseKEKIdentifier(data []byte) error {
+	var keyIdentifier []byte
+	rest, err := asn1.Unmarshal(data, &keyIdentifier)
+	if err != nil {
+		return fmt.Errorf("invalid DPAPI-NG key identifier: %w", err)
+	}
+	if b.keyID, err = parseGKDIKeyIdentifier(keyIdentifier); err != nil {
+		return err
+	}
+
+	// An optional date precedes the protection descriptor
+	for len(rest) > 0 {
+		var raw asn1.RawValue
+		if rest, err = asn1.Unmarshal(rest, &raw); err != nil {
+			return fmt.Errorf("invalid DPAPI-NG key identifier: %w", err)
+		}
+		if raw.Class != asn1.ClassUniversal || raw.Tag != asn1.TagSequence {
+			continue
+		}
+		var attribute cmsOtherKeyAttribute
+		if _, err := asn1.Unmarshal(raw.FullBytes, &attribute); err != nil {
+			return fmt.Errorf("invalid DPAPI-NG key attribute: %w", err)
+		}
+		if !attribute.KeyAttrID.Equal(oidProtectionDescriptor) {
+			continue
+		}
+		b.sid, err = parseSIDProtectionDescriptor(attribute.KeyAttr.FullBytes)
+		return err
+	}
+	return errors.New("DPAPI-NG blob has no protection descriptor")
+}
+
+// parseSIDProtectionDescriptor returns the principal of a "SID=..."
+// protection descriptor, the only form Windows LAPS uses.
+func parseSIDProtectionDescriptor(data []byte) (SID, error) {
+	var descriptor protectionDescriptor
+	if _, err := asn1.Unmarshal(data, &descriptor); err != nil {
+		return SID{}, fmt.Errorf("invalid protection descriptor: %w", err)
+	}
+	if !descriptor.Type.Equal(oidSIDProtection) {
+		return SID{}, fmt.Errorf("unsupported protection descriptor type %s", descriptor.Type)
+	}
+	if len(descriptor.Descriptors) != 1 || len(descriptor.Descriptors[0]) != 1 {
+		return SID{}, errors.New("protection descriptors with several principals are not supported")
+	}
+	value := descriptor.Descriptors[0][0]
+	if !strings.EqualFold(value.Name, "SID") {
+		return SID{}, fmt.Errorf("unsupported protection descriptor %s", value.Name)
+	}
+	return ParseSID(value.Value)
+}
+
+// parseGKDIKeyIdentifier parses a group key identifier.
+func parseGKDIKeyIdentifier(data []byte) (*gkdiKeyIdentifier, error) {
+	const headerLength = 52
+	if len(data) < headerLength {
+		return nil, fmt.Errorf("group key identifier is %d bytes, shorter than its header", len(data))
+	}
+	if version := binary.LittleEndian.Uint32(data[0:4]); version != 1 {
+		return nil, fmt.Errorf("unsupported group key identifier version %d", version)
+	}
+	if magic := binary.LittleEndian.Uint32(data[4:8]); magic != gkdiKeyIdentifierMagic {
+		return nil, fmt.Errorf("invalid group key identifier magic 0x%08x", magic)
+	}
+
+	id := &gkdiKeyIdentifier{
+		flags:     binary.LittleEndian.Uint32(data[8:12]),
+		l0:        int32(binary.LittleEndian.Uint32(data[12:16])),
+		l1:        int32(binary.LittleEndian.Uint32(data[16:20])),
+		l2:        int32(binary.LittleEndian.Uint32(data[20:24])),
+		rootKeyID: data[24:40],
+	}
+	if id.l1 < 0 || id.l1 > 31 || id.l2 < 0 || id.l2 > 31 {
+		return nil, fmt.Errorf("invalid group key indexes L1 %d, L2 %d", id.l1, id.l2)
+	}
+	keyInfoLength := binary.LittleEndian.Uint32(data[40:44])
+	if uint64(keyInfoLength) > uint64(len(data)-headerLength) {
+		return nil, fmt.Errorf("group key identifier declares %d bytes of key information but holds %d", keyInfoLength, len(data)-headerLength)
+	}
+	id.keyInfo = data[headerLength : headerLength+int(keyInfoLength)]
+	return id, nil
+}
+
+// utf16LE encodes s as UTF-16LE.
+func utf16LE(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	out := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(out[2*i:], u)
+	}
+	return out
+}
+
+// decodeUTF16LE decodes UTF-16LE text, dropping a trailing NUL.
+func decodeUTF16LE(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+}
+
+var (
+	gkdiServiceLabel   = utf16LE("KDS service\x00")
+	gkdiPublicKeyLabel = utf16LE("KDS public key\x00")
+)
+
+// gkdiKDF is the SP 800-108 counter mode HMAC KDF used by MS-GKDI, with the
+// "KDS service" label.
+func gkdiKDF(h func() hash.Hash, key, context []byte, length int) []byte {
+	var suffix []byte
+	suffix = append(suffix, gkdiServiceLabel...)
+	suffix = append(suffix, 0)
+	suffix = append(suffix, context...)
+	suffix = binary.BigEndian.AppendUint32(suffix, uint32(length*8))
+
+	var out []byte
+	for counter := uint32(1); len(out) < length; counter++ {
+		mac := hmac.New(h, key)
+		_ = binary.Write(mac, binary.BigEndian, counter)
+		mac.Write(suffix)
+		out = mac.Sum(out)
+	}
+	return out[:length]
+}
+
+// gkdiContext is the KDF context identifying a seed key.
+func gkdiContext(rootKeyID []byte, l0, l1, l2 int32) []byte {
+	context := append([]byte(nil), rootKeyID...)
+	context = binary.LittleEndian.AppendUint32(context, uint32(l0))
+	context = binary.LittleEndian.AppendUint32(context, uint32(l1))
+	return binary.LittleEndian.AppendUint32(context, uint32(l2))
+}
+
+// gkdiSecurityDescriptor returns the security descriptor a domain controller
+// builds from a "SID=..." protection descriptor, which is part of the L1 seed
+// key derivation: owned by SYSTEM, granting the principal and Everyone access.
+// The order of its parts matters, unlike in SecurityDescriptor.Marshal.
+func gkdiSecurityDescriptor(sid SID) ([]byte, error) {
+	localSystem := SID{RevisionLevel: 1, Authority: 5, SubAuthorities: []uint32{18}}
+	owner, err := localSystem.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	dacl, err := marshalACL(&ACL{ACEs: []ACE{
+		{AceType: AccessAllowedACEType, AccessMask: 0x3, SID: sid},
+		{AceType: AccessAllowedACEType, AccessMask: 0x2, SID: everyoneSIDValue},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Header, owner, group and DACL
+	sd := make([]byte, 20, 20+2*len(owner)+len(dacl))
+	sd[0] = 1
+	binary.LittleEndian.PutUint16(sd[2:4], SESelfRelative|SEDACLPresent)
+	binary.LittleEndian.PutUint32(sd[4:8], 20)
+	binary.LittleEndian.PutUint32(sd[8:12], uint32(20+len(owner)))
+	binary.LittleEndian.PutUint32(sd[16:20], uint32(20+2*len(owner)))
+	sd = append(sd, owner...)
+	sd = append(sd, owner...)
+	return append(sd, dacl...), nil
+}
+
+// seedKey derives the L2 seed key identified by id from the root key for
+// the protection descriptor's security descriptor (MS-GKDI 3.1.4.1.2).
+func (k *kdsRootKey) seedKey(id *gkdiKeyIdentifier, sd []byte) []byte {
+	l0Key := gkdiKDF(k.hash, k.data, gkdiContext(id.rootKeyID, id.l0, -1, -1), gkdiSeedKeyLength)
+
+	l1 := int32(31)
+	l1Key := gkdiKDF(k.hash, l0Key, append(gkdiContext(id.rootKeyID, id.l0, l1, -1), sd...), gkdiSeedKeyLength)
+	for l1 > id.l1 {
+		l1--
+		l1Key = gkdiKDF(k.hash, l1Key, gkdiContext(id.rootKeyID, id.l0, l1, -1), gkdiSeedKeyLength)
+	}
+
+	l2 := int32(31)
+	l2Key := gkdiKDF(k.hash, l1Key, gkdiContext(id.rootKeyID, id.l0, l1, l2), gkdiSeedKeyLength)
+	for l2 > id.l2 {
+		l2--
+		l2Key = gkdiKDF(k.hash, l2Key, gkdiContext(id.rootKeyID, id.l0, l1, l2), gkdiSeedKeyLength)
+	}
+	return l2Key
+}
+
+// keyEncryptionKey derives the key that wraps the content encryption key.
+// With a public key identifier, the encryptor agreed a secret between its own
+// key pair and the group public key; the group private key is derived from
+// the seed key.
+func (k *kdsRootKey) keyEncryptionKey(id *gkdiKeyIdentifier, seed []byte) ([]byte, error) {
+	if id.flags&gkdiKeyIdentifierPublicKey == 0 {
+		return gkdiKDF(k.hash, seed, id.keyInfo, 32), nil
+	}
+
+	privateKey := gkdiKDF(k.hash, seed, utf16LE(k.secretAgreement+"\x00"), (k.privateKeyLength+7)/8)
+	secret, err := agreeSecret(k.secretAgreement, privateKey, id.keyInfo)
+	if err != nil {
+		return nil, err
+	}
+	return gkdiPublicKeyKEK(k.hash, secret), nil
+}
+
+// gkdiPublicKeyKEK derives the key encryption key from a secret agreed with
+// the group public key: the SP 800-56A concatenation KDF, as BCryptDeriveKey
+// computes it, followed by the KDS KDF.
+func gkdiPublicKeyKEK(h func() hash.Hash, secret []byte) []byte {
+	otherInfo := append(utf16LE("SHA512\x00"), gkdiPublicKeyLabel...)
+	otherInfo = append(otherInfo, gkdiServiceLabel...)
+	digest := sha256.New()
+	_ = binary.Write(digest, binary.BigEndian, uint32(1))
+	digest.Write(secret)
+	digest.Write(otherInfo)
+
+	return gkdiKDF(h, digest.Sum(nil), gkdiPublicKeyLabel, 32)
+}
+
+// agreeSecret computes the shared secret between privateKey and the BCrypt
+// public key blob of the encryptor.
+func agreeSecret(algorithm string, privateKey, publicBlob []byte) ([]byte, error) {
+	if len(publicBlob) < 8 {
+		return nil, errors.New("public key blob is too short")
+	}
+	magic := binary.LittleEndian.Uint32(publicBlob[0:4])
+	size := int(binary.LittleEndian.Uint32(publicBlob[4:8]))
+	body := publicBlob[8:]
+
+	switch algorithm {
+	case "DH":
+		if magic != bcryptDHPublicMagic || size == 0 || len(body) != 3*size {
+			return nil, errors.New("invalid DH public key blob")
+		}
+		p := new(big.Int).SetBytes(body[:size])
+		y := new(big.Int).SetBytes(body[2*size:])
+		if y.Cmp(big.NewInt(1)) <= 0 || y.Cmp(new(big.Int).Sub(p, big.NewInt(1))) >= 0 {
+			return nil, errors.New("invalid DH public key")
+		}
+		x := new(big.Int).SetBytes(privateKey)
+		return new(big.Int).Exp(y, x, p).FillBytes(make([]byte, size)), nil
+
+	case "ECDH_P256", "ECDH_P384", "ECDH_P521":
+		curve, wantMagic := ecdh.P256(), uint32(bcryptECDHP256PublicMagic)
+		switch algorithm {
+		case "ECDH_P384":
+			curve, wantMagic = ecdh.P384(), bcryptECDHP384PublicMagic
+		case "ECDH_P521":
+			curve, wantMagic = ecdh.P521(), bcryptECDHP521PublicMagic
+		}
+		if magic != wantMagic || len(body) != 2*size {
+			return nil, errors.New("invalid ECDH public key blob")
+		}
+		public, err := curve.NewPublicKey(append([]byte{4}, body...))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECDH public key: %w", err)
+		}
+		private, err := curve.NewPrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECDH private key: %w", err)
+		}
+		return private.ECDH(public)
+
+	default:
+		return nil, fmt.Errorf("unsupported KDS secret agreement algorithm %q", algorithm)
+	}
+}
+
+// aesKeyUnwrap unwraps a key with the AES key wrap algorithm (RFC 3394).
+func aesKeyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 24 || len(wrapped)%8 != 0 {
+		return nil, fmt.Errorf("wrapped key is %d bytes", len(wrapped))
+	}
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(wrapped)/8 - 1
+	a := make([]byte, 8)
+	copy(a, wrapped[:8])
+	r := make([]byte, 8*n)
+	copy(r, wrapped[8:])
+
+	buf := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(buf[:8], binary.BigEndian.Uint64(a)^t)
+			copy(buf[8:], r[8*(i-1):8*i])
+			block.Decrypt(buf, buf)
+			copy(a, buf[:8])
+			copy(r[8*(i-1):8*i], buf[8:])
+		}
+	}
+
+	iv := []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+	if subtle.ConstantTimeCompare(a, iv) != 1 {
+		return nil, errors.New("key unwrap integrity check failed")
+	}
+	return r, nil
+}
+
+// decrypt returns the plaintext of the blob using rootKey.
+func (b *dpapiNGBlob) decrypt(rootKey *kdsRootKey) ([]byte, error) {
+	sd, err := gkdiSecurityDescriptor(b.sid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build security descriptor for %s: %w", b.sid, err)
+	}
+	kek, err := rootKey.keyEncryptionKey(b.keyID, rootKey.seedKey(b.keyID, sd))
+	if err != nil {
+		return nil, err
+	}
+	cek, err := aesKeyUnwrap(kek, b.encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap content encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(b.nonce))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, b.nonce, b.ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt content: %w", err)
+	}
+	return plaintext, nil
+}
+
+// kdfHash returns the hash named in msKds-KDFParam, which holds the
+// UTF-16LE name after a 16-byte header (MS-GKDI 2.2.1).
+func kdfHash(param []byte) (func() hash.Hash, error) {
+	name := "SHA512"
+	if len(param) >= 16 {
+		length := int(binary.LittleEndian.Uint32(param[8:12]))
+		if 16+length > len(param) {
+			return nil, errors.New("invalid msKds-KDFParam")
+		}
+		name = decodeUTF16LE(param[16 : 16+length])
+	}
+
+	switch strings.ToUpper(name) {
+	case "SHA1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA384":
+		return sha512.New384, nil
+	case "SHA512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported KDS KDF hash %q", name)
+	}
+}
@@ -0,0 +1,390 @@
+package ldap
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testKDSRootKeyGUID = "7ae8fbc4-c254-4b6a-a0a2-1b4e0f3c4d2e"
+
+// testLAPSEncryptor protects LAPS passwords the way Windows does, with a KDS
+// root key held by the test.
+type testLAPSEncryptor struct {
+	rootKey   *kdsRootKey
+	rootKeyID []byte
+	sid       SID
+
+	// Group key the password is protected with
+	l0, l1, l2 int32
+
+	dhPrime *big.Int // Field order for the DH secret agreement
+}
+
+func newTestLAPSEncryptor(t *testing.T, secretAgreement string, privateKeyLength int) *testLAPSEncryptor {
+	t.Helper()
+	rootKeyID, err := NewGUIDHandler().StringToGUIDBytes(testKDSRootKeyGUID)
+	require.NoError(t, err)
+	sid, err := ParseSID("S-1-5-21-1004336348-1177238915-682003330-512")
+	require.NoError(t, err)
+
+	e := &testLAPSEncryptor{
+		rootKey: &kdsRootKey{
+			data:             randomBytes(t, 64),
+			hash:             sha512.New,
+			secretAgreement:  secretAgreement,
+			privateKeyLength: privateKeyLength,
+		},
+		rootKeyID: rootKeyID,
+		sid:       sid,
+		l0:        361, l1: 17, l2: 5,
+	}
+	if secretAgreement == "DH" {
+		e.dhPrime, err = rand.Prime(rand.Reader, 1024)
+		require.NoError(t, err)
+	}
+	return e
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
+
+// keyIdentifier builds a group key identifier with the domain and forest
+// names Windows appends after the key information.
+func (e *testLAPSEncryptor) keyIdentifier(flags uint32, keyInfo []byte) []byte {
+	domain := utf16LE("example.com\x00")
+	id := binary.LittleEndian.AppendUint32(nil, 1)
+	id = binary.LittleEndian.AppendUint32(id, gkdiKeyIdentifierMagic)
+	id = binary.LittleEndian.AppendUint32(id, flags)
+	id = binary.LittleEndian.AppendUint32(id, uint32(e.l0))
+	id = binary.LittleEndian.AppendUint32(id, uint32(e.l1))
+	id = binary.LittleEndian.AppendUint32(id, uint32(e.l2))
+	id = append(id, e.rootKeyID...)
+	id = binary.LittleEndian.AppendUint32(id, uint32(len(keyInfo)))
+	id = binary.LittleEndian.AppendUint32(id, uint32(len(domain)))
+	id = binary.LittleEndian.AppendUint32(id, uint32(len(domain)))
+	id = append(id, keyInfo...)
+	id = append(id, domain...)
+	return append(id, domain...)
+}
+
+// keyEncryptionKey returns a key identifier and the key encryption key it
+// names. In public key mode the secret is agreed from the encryptor's side,
+// with an ephemeral key pair and the group public key.
+func (e *testLAPSEncryptor) keyEncryptionKey(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	sd, err := gkdiSecurityDescriptor(e.sid)
+	require.NoError(t, err)
+	seed := e.rootKey.seedKey(&gkdiKeyIdentifier{rootKeyID: e.rootKeyID, l0: e.l0, l1: e.l1, l2: e.l2}, sd)
+
+	if e.rootKey.secretAgreement == "" {
+		keyInfo := randomBytes(t, 32)
+		return e.keyIdentifier(0, keyInfo), gkdiKDF(e.rootKey.hash, seed, keyInfo, 32)
+	}
+
+	groupPrivate := gkdiKDF(e.rootKey.hash, seed, utf16LE(e.rootKey.secretAgreement+"\x00"), e.rootKey.privateKeyLength/8)
+	var keyInfo, secret []byte
+	switch e.rootKey.secretAgreement {
+	case "DH":
+		size := len(e.dhPrime.Bytes())
+		g := big.NewInt(2)
+		groupPublic := new(big.Int).Exp(g, new(big.Int).SetBytes(groupPrivate), e.dhPrime)
+		ephemeral := new(big.Int).SetBytes(randomBytes(t, 64))
+
+		keyInfo = binary.LittleEndian.AppendUint32(nil, bcryptDHPublicMagic)
+		keyInfo = binary.LittleEndian.AppendUint32(keyInfo, uint32(size))
+		keyInfo = append(keyInfo, e.dhPrime.FillBytes(make([]byte, size))...)
+		keyInfo = append(keyInfo, g.FillBytes(make([]byte, size))...)
+		keyInfo = append(keyInfo, new(big.Int).Exp(g, ephemeral, e.dhPrime).FillBytes(make([]byte, size))...)
+		secret = new(big.Int).Exp(groupPublic, ephemeral, e.dhPrime).FillBytes(make([]byte, size))
+	case "ECDH_P256":
+		group, err := ecdh.P256().NewPrivateKey(groupPrivate)
+		require.NoError(t, err)
+		ephemeral, err := ecdh.P256().GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		keyInfo = binary.LittleEndian.AppendUint32(nil, bcryptECDHP256PublicMagic)
+		keyInfo = binary.LittleEndian.AppendUint32(keyInfo, 32)
+		keyInfo = append(keyInfo, ephemeral.PublicKey().Bytes()[1:]...)
+		secret, err = ephemeral.ECDH(group.PublicKey())
+		require.NoError(t, err)
+	}
+	return e.keyIdentifier(gkdiKeyIdentifierPublicKey, keyInfo), gkdiPublicKeyKEK(e.rootKey.hash, secret)
+}
+
+// protect returns an msLAPS-EncryptedPassword value holding plaintext, with
+// the encrypted content after the CMS structure as Windows LAPS stores it.
+func (e *testLAPSEncryptor) protect(t *testing.T, plaintext []byte) []byte {
+	t.Helper()
+	keyIdentifier, kek := e.keyEncryptionKey(t)
+	cek := randomBytes(t, 32)
+	nonce := randomBytes(t, 12)
+
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	marshal := func(v any, params string) []byte {
+		b, err := asn1.MarshalWithParams(v, params)
+		require.NoError(t, err)
+		return b
+	}
+	descriptor := marshal(protectionDescriptor{
+		Type:        oidSIDProtection,
+		Descriptors: [][]protectionDescriptorValue{{{Name: "SID", Value: e.sid.String()}}},
+	}, "")
+	kekID := marshal(struct {
+		KeyIdentifier []byte
+		Other         cmsOtherKeyAttribute
+	}{keyIdentifier, cmsOtherKeyAttribute{KeyAttrID: oidProtectionDescriptor, KeyAttr: asn1.RawValue{FullBytes: descriptor}}}, "")
+	recipient := marshal(cmsKEKRecipientInfo{
+		Version:                4,
+		KEKID:                  asn1.RawValue{FullBytes: kekID},
+		KeyEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidAES256Wrap},
+		EncryptedKey:           testAESKeyWrap(t, kek, cek),
+	}, "tag:2")
+	enveloped := marshal(cmsEnvelopedData{
+		Version:        2,
+		RecipientInfos: []asn1.RawValue{{FullBytes: recipient}},
+		EncryptedContentInfo: cmsEncryptedContentInfo{
+			ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1},
+			ContentEncryptionAlgorithm: pkix.AlgorithmIdentifier{
+				Algorithm:  oidAES256GCM,
+				Parameters: asn1.RawValue{FullBytes: marshal(gcmParameters{Nonce: nonce}, "")},
+			},
+		},
+	}, "")
+	info := marshal(struct {
+		ContentType asn1.ObjectIdentifier
+		Content     asn1.RawValue
+	}{oidEnvelopedData, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: enveloped}}, "")
+
+	return encryptedLAPSBlob(append(info, ciphertext...))
+}
+
+// testAESKeyWrap wraps a key with the AES key wrap algorithm (RFC 3394).
+func testAESKeyWrap(t *testing.T, kek, key []byte) []byte {
+	t.Helper()
+	block, err := aes.NewCipher(kek)
+	require.NoError(t, err)
+
+	n := len(key) / 8
+	a := []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+	r := append([]byte(nil), key...)
+	buf := make([]byte, 16)
+	for j := range 6 {
+		for i := 1; i <= n; i++ {
+			copy(buf[:8], a)
+			copy(buf[8:], r[8*(i-1):8*i])
+			block.Encrypt(buf, buf)
+			binary.BigEndian.PutUint64(a, binary.BigEndian.Uint64(buf[:8])^uint64(n*j+i))
+			copy(r[8*(i-1):8*i], buf[8:])
+		}
+	}
+	return append(a, r...)
+}
+
+// rootKeyEntry returns the msKds-ProvRootKey entry for the encryptor's root
+// key.
+func (e *testLAPSEncryptor) rootKeyEntry() *ldap.Entry {
+	kdfParam := []byte{0, 0, 0, 0, 1, 0, 0, 0}
+	name := utf16LE("SHA512\x00")
+	kdfParam = binary.LittleEndian.AppendUint32(kdfParam, uint32(len(name)))
+	kdfParam = append(append(kdfParam, 0, 0, 0, 0), name...)
+
+	entry := ldap.NewEntry("CN="+testKDSRootKeyGUID+",CN=Master Root Keys,CN=Group Key Distribution Service,CN=Services,CN=Configuration,DC=example,DC=com", map[string][]string{
+		"msKds-KDFAlgorithmID":             {"SP800_108_CTR_HMAC"},
+		"msKds-SecretAgreementAlgorithmID": {e.rootKey.secretAgreement},
+		"msKds-PrivateKeyLength":           {formatInt(int64(e.rootKey.privateKeyLength))},
+	})
+	for name, value := range map[string][]byte{"msKds-RootKeyData": e.rootKey.data, "msKds-KDFParam": kdfParam} {
+		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{Name: name, Values: []string{string(value)}, ByteValues: [][]byte{value}})
+	}
+	return entry
+}
+
+// rootKeyClient returns a client that serves the root key entry, or no
+// entries when the bind identity may not read it.
+func rootKeyClient(entry *ldap.Entry) *MockClient {
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{ConfigurationNamingContext: "CN=Configuration,DC=example,DC=com"}, nil)
+	result := &SearchResult{}
+	if entry != nil {
+		result.Entries = []*ldap.Entry{entry}
+	}
+	client.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool {
+		return r.BaseDN == "CN=Master Root Keys,CN=Group Key Distribution Service,CN=Services,CN=Configuration,DC=example,DC=com" &&
+			r.Scope == ScopeSingleLevel &&
+			r.Filter == "(&(objectClass=msKds-ProvRootKey)(cn="+testKDSRootKeyGUID+"))"
+	})).Return(result, nil)
+	return client
+}
+
+func TestLAPSManager_DecryptLAPSPassword(t *testing.T) {
+	plaintext := append(utf16LE(`{"n":"LocalAdmin","t":"1dc2f8a8c6a4b00","p":"Encrypted!"}`), 0, 0)
+
+	for _, tt := range []struct {
+		name             string
+		secretAgreement  string
+		privateKeyLength int
+	}{
+		{name: "DH public key", secretAgreement: "DH", privateKeyLength: 512},
+		{name: "ECDH P-256 public key", secretAgreement: "ECDH_P256", privateKeyLength: 256},
+		{name: "random key information"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestLAPSEncryptor(t, tt.secretAgreement, tt.privateKeyLength)
+			lm := NewLAPSManager(t.Context(), rootKeyClient(e.rootKeyEntry()), "DC=example,DC=com", nil)
+
+			password, err := lm.entryToLAPSPassword(newLAPSEntry(map[string][]string{
+				LAPSSourceLegacy: {"legacy-pw"},
+			}, e.protect(t, plaintext)))
+			require.NoError(t, err)
+			assert.Equal(t, "LocalAdmin", password.Account)
+			assert.Equal(t, "Encrypted!", password.Password)
+			assert.Equal(t, LAPSSourceEncrypted, password.Source)
+			assert.Equal(t, testLAPSComputerDN, password.ComputerDN)
+			assert.NotNil(t, password.UpdatedAt)
+			assert.False(t, password.EncryptedUnreadable)
+		})
+	}
+
+	t.Run("different root key", func(t *testing.T) {
+		e := newTestLAPSEncryptor(t, "ECDH_P256", 256)
+		blob := e.protect(t, plaintext)
+		e.rootKey.data = randomBytes(t, 64)
+		lm := NewLAPSManager(t.Context(), rootKeyClient(e.rootKeyEntry()), "DC=example,DC=com", nil)
+
+		_, err := lm.entryToLAPSPassword(newLAPSEntry(map[string][]string{}, blob))
+		assert.ErrorContains(t, err, "integrity check failed")
+	})
+
+	t.Run("root key unreadable", func(t *testing.T) {
+		e := newTestLAPSEncryptor(t, "ECDH_P256", 256)
+		lm := NewLAPSManager(t.Context(), rootKeyClient(nil), "DC=example,DC=com", nil)
+
+		password, err := lm.entryToLAPSPassword(newLAPSEntry(map[string][]string{
+			LAPSSourceLegacy: {"legacy-pw"},
+		}, e.protect(t, plaintext)))
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-pw", password.Password)
+		assert.True(t, password.EncryptedUnreadable)
+
+		_, err = lm.entryToLAPSPassword(newLAPSEntry(map[string][]string{}, e.protect(t, plaintext)))
+		assert.True(t, errors.Is(err, ErrKDSRootKeyUnreadable), "got %v", err)
+		assert.True(t, IsPermissionError(err))
+		assert.ErrorContains(t, err, "msKds-RootKeyData")
+	})
+}
+
+// lapsKnownAnswer is a password encrypted by Windows LAPS on a domain
+// controller, with the KDS root key it was protected with, as described in
+// testdata/laps/README.md.
+type lapsKnownAnswer struct {
+	EncryptedPassword string            `json:"encrypted_password"` // base64 msLAPS-EncryptedPassword
+	RootKey           map[string]string `json:"root_key"`           // msKds-ProvRootKey attributes; binary ones in base64
+	Account           string            `json:"account"`
+	Password          string            `json:"password"`
+}
+
+func TestLAPSManager_DecryptKnownAnswer(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "laps", "*.json"))
+	require.NoError(t, err)
+	if len(paths) == 0 {
+		t.Skip("no Windows LAPS known-answer vectors in testdata/laps")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var vector lapsKnownAnswer
+			require.NoError(t, json.Unmarshal(data, &vector))
+
+			blob, err := base64.StdEncoding.DecodeString(vector.EncryptedPassword)
+			require.NoError(t, err)
+			attributes := map[string][]string{}
+			for name, value := range vector.RootKey {
+				if name == "msKds-RootKeyData" || name == "msKds-KDFParam" {
+					raw, err := base64.StdEncoding.DecodeString(value)
+					require.NoError(t, err, name)
+					value = string(raw)
+				}
+				attributes[name] = []string{value}
+			}
+
+			client := &MockClient{}
+			client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{ConfigurationNamingContext: "CN=Configuration,DC=example,DC=com"}, nil)
+			client.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool {
+				return strings.Contains(r.Filter, "(cn="+vector.RootKey["cn"]+")")
+			})).Return(&SearchResult{Entries: []*ldap.Entry{ldap.NewEntry("CN="+vector.RootKey["cn"], attributes)}}, nil)
+
+			lm := NewLAPSManager(t.Context(), client, "DC=example,DC=com", nil)
+			password, err := lm.decryptLAPSPassword(blob)
+			require.NoError(t, err)
+			assert.Equal(t, vector.Account, password.Account)
+			assert.Equal(t, vector.Password, password.Password)
+		})
+	}
+}
+
+func TestAESKeyUnwrap(t *testing.T) {
+	// RFC 3394 section 4.1
+	kek, _ := hex.DecodeString("000102030405060708090A0B0C0D0E0F")
+	wrapped, _ := hex.DecodeString("1FA68B0A8112B447AEF34BD8FB5A7B829D3E862371D2CFE5")
+
+	key, err := aesKeyUnwrap(kek, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "00112233445566778899aabbccddeeff", hex.EncodeToString(key))
+
+	wrapped[0] ^= 1
+	_, err = aesKeyUnwrap(kek, wrapped)
+	assert.ErrorContains(t, err, "integrity check failed")
+}
+
+func TestGKDISecurityDescriptor(t *testing.T) {
+	sid, err := ParseSID("S-1-5-21-1-2-3-512")
+	require.NoError(t, err)
+	sd, err := gkdiSecurityDescriptor(sid)
+	require.NoError(t, err)
+
+	parsed, err := UnmarshalSecurityDescriptor(sd)
+	require.NoError(t, err)
+	require.NotNil(t, parsed.Owner)
+	assert.Equal(t, "S-1-5-18", parsed.Owner.String())
+	require.NotNil(t, parsed.DACL)
+	require.Len(t, parsed.DACL.ACEs, 2)
+	assert.Equal(t, sid.String(), parsed.DACL.ACEs[0].SID.String())
+	assert.Equal(t, uint32(3), parsed.DACL.ACEs[0].AccessMask)
+	assert.Equal(t, "S-1-1-0", parsed.DACL.ACEs[1].SID.String())
+
+	// Owner, group, then DACL
+	assert.Equal(t, uint32(20), binary.LittleEndian.Uint32(sd[4:8]))
+	assert.Less(t, binary.LittleEndian.Uint32(sd[8:12]), binary.LittleEndian.Uint32(sd[16:20]))
+}
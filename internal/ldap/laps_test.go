@@ -0,0 +1,147 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testLAPSComputerDN = "CN=WS01,OU=Workstations,DC=example,DC=com"
+
+// encryptedLAPSBlob returns an msLAPS-EncryptedPassword value with a valid
+// header and an opaque payload.
+func encryptedLAPSBlob(payload []byte) []byte {
+	blob := make([]byte, lapsEncryptedHeaderLength, lapsEncryptedHeaderLength+len(payload))
+	binary.LittleEndian.PutUint32(blob[8:12], uint32(len(payload)))
+	return append(blob, payload...)
+}
+
+func newLAPSEntry(attrs map[string][]string, encrypted []byte) *ldap.Entry {
+	entry := ldap.NewEntry(testLAPSComputerDN, attrs)
+	if encrypted != nil {
+		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{
+			Name:       LAPSSourceEncrypted,
+			Values:     []string{string(encrypted)},
+			ByteValues: [][]byte{encrypted},
+		})
+	}
+	return entry
+}
+
+func TestLAPSManager_EntryToLAPSPassword(t *testing.T) {
+	lm := NewLAPSManager(t.Context(), &MockClient{}, "DC=example,DC=com", nil)
+	expiry := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	updated := time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC)
+	toFileTime := func(t time.Time) int64 { return t.UnixNano()/100 + 116444736000000000 }
+
+	t.Run("Windows LAPS", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{
+			LAPSSourceWindows:               {`{"n":"LocalAdmin","t":"` + formatHex(toFileTime(updated)) + `","p":"s3cret!"}`},
+			"msLAPS-PasswordExpirationTime": {formatInt(toFileTime(expiry))},
+		}, nil)
+
+		password, err := lm.entryToLAPSPassword(entry)
+		require.NoError(t, err)
+		assert.Equal(t, testLAPSComputerDN, password.ComputerDN)
+		assert.Equal(t, "LocalAdmin", password.Account)
+		assert.Equal(t, "s3cret!", password.Password)
+		assert.Equal(t, LAPSSourceWindows, password.Source)
+		require.NotNil(t, password.Expiration)
+		assert.True(t, expiry.Equal(*password.Expiration))
+		require.NotNil(t, password.UpdatedAt)
+		assert.True(t, updated.Equal(*password.UpdatedAt))
+		assert.False(t, password.EncryptedUnreadable)
+	})
+
+	t.Run("legacy LAPS", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{
+			LAPSSourceLegacy:              {"legacy-pw"},
+			"ms-Mcs-AdmPwdExpirationTime": {formatInt(toFileTime(expiry))},
+		}, nil)
+
+		password, err := lm.entryToLAPSPassword(entry)
+		require.NoError(t, err)
+		assert.Empty(t, password.Account)
+		assert.Equal(t, "legacy-pw", password.Password)
+		assert.Equal(t, LAPSSourceLegacy, password.Source)
+		require.NotNil(t, password.Expiration)
+		assert.True(t, expiry.Equal(*password.Expiration))
+		assert.Nil(t, password.UpdatedAt)
+	})
+
+	t.Run("Windows LAPS preferred over legacy", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{
+			LAPSSourceWindows: {`{"n":"Administrator","t":"0","p":"new"}`},
+			LAPSSourceLegacy:  {"old"},
+		}, nil)
+
+		password, err := lm.entryToLAPSPassword(entry)
+		require.NoError(t, err)
+		assert.Equal(t, "new", password.Password)
+		assert.Nil(t, password.UpdatedAt)
+	})
+
+	t.Run("encrypted with clear-text fallback", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{LAPSSourceLegacy: {"legacy-pw"}}, encryptedLAPSBlob([]byte{0x30, 0x82}))
+
+		password, err := lm.entryToLAPSPassword(entry)
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-pw", password.Password)
+		assert.True(t, password.EncryptedUnreadable)
+	})
+
+	t.Run("encrypted only", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{}, encryptedLAPSBlob([]byte{0x30, 0x82}))
+
+		_, err := lm.entryToLAPSPassword(entry)
+		assert.ErrorContains(t, err, "invalid DPAPI-NG blob")
+	})
+
+	t.Run("malformed encrypted value", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{}, []byte{1, 2, 3})
+
+		_, err := lm.entryToLAPSPassword(entry)
+		assert.ErrorContains(t, err, "shorter than its header")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		entry := newLAPSEntry(map[string][]string{LAPSSourceWindows: {"not json"}}, nil)
+
+		_, err := lm.entryToLAPSPassword(entry)
+		assert.ErrorContains(t, err, "invalid LAPS password JSON")
+	})
+
+	t.Run("no readable password", func(t *testing.T) {
+		_, err := lm.entryToLAPSPassword(newLAPSEntry(map[string][]string{}, nil))
+		assert.True(t, IsNotFoundError(err), "got %v", err)
+	})
+}
+
+func TestLAPSManager_GetLAPSPassword(t *testing.T) {
+	client := &MockClient{}
+	client.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool {
+		return r.Filter == "(&(objectClass=computer)(sAMAccountName=WS01$))"
+	})).Return(&SearchResult{Entries: []*ldap.Entry{newLAPSEntry(map[string][]string{LAPSSourceLegacy: {"legacy-pw"}}, nil)}}, nil)
+	client.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{}, nil)
+	lm := NewLAPSManager(t.Context(), client, "DC=example,DC=com", nil)
+
+	password, err := lm.GetLAPSPassword("WS01")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-pw", password.Password)
+
+	_, err = lm.GetLAPSPassword("WS02")
+	assert.True(t, IsNotFoundError(err), "got %v", err)
+
+	_, err = lm.GetLAPSPassword("")
+	assert.Error(t, err)
+}
+
+func formatInt(n int64) string { return strconv.FormatInt(n, 10) }
+
+func formatHex(n int64) string { return strconv.FormatInt(n, 16) }
@@ -0,0 +1,178 @@
+package ldap
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logonHoursLength is the size of the logonHours bitmap: one bit per hour of the week.
+const logonHoursLength = 21
+
+const hoursPerWeek = 7 * 24
+
+// LogonHours is the logonHours bitmap. Bit 0 of byte 0 is Sunday 00:00-01:00
+// UTC, and each following bit is the next hour, least significant bit first.
+// A set bit allows logon during that hour.
+type LogonHours [logonHoursLength]byte
+
+// HourRange is a range of whole hours within a day, from Start (0-23)
+// inclusive to End (1-24) exclusive.
+type HourRange struct {
+	Start int
+	End   int
+}
+
+// String formats the range as "HH-HH".
+func (r HourRange) String() string {
+	return fmt.Sprintf("%02d-%02d", r.Start, r.End)
+}
+
+// ParseHourRange parses an "HH-HH" hour range such as "08-17".
+func ParseHourRange(s string) (HourRange, error) {
+	start, end, ok := strings.Cut(s, "-")
+	if !ok || len(start) != 2 || len(end) != 2 {
+		return HourRange{}, fmt.Errorf("hour range %q must be in HH-HH format", s)
+	}
+	startHour, startErr := strconv.Atoi(start)
+	endHour, endErr := strconv.Atoi(end)
+	if startErr != nil || endErr != nil {
+		return HourRange{}, fmt.Errorf("hour range %q must be in HH-HH format", s)
+	}
+	if startHour < 0 || endHour > 24 || startHour >= endHour {
+		return HourRange{}, fmt.Errorf("hour range %q must start before it ends, between 00 and 24", s)
+	}
+	return HourRange{Start: startHour, End: endHour}, nil
+}
+
+// LogonSchedule is a weekly logon schedule in local time: the allowed hour
+// ranges of each day. Days without ranges deny logon.
+type LogonSchedule map[time.Weekday][]HourRange
+
+// ParseLogonHours decodes a logonHours attribute value.
+func ParseLogonHours(b []byte) (*LogonHours, error) {
+	if len(b) != logonHoursLength {
+		return nil, fmt.Errorf("logonHours must be %d bytes, got %d", logonHoursLength, len(b))
+	}
+	var h LogonHours
+	copy(h[:], b)
+	return &h, nil
+}
+
+// UnrestrictedLogonHours returns a bitmap that allows logon at every hour.
+func UnrestrictedLogonHours() *LogonHours {
+	var h LogonHours
+	for i := range h {
+		h[i] = 0xff
+	}
+	return &h
+}
+
+// ParseUTCOffset parses a UTC offset in "+HH:MM" or "-HH:MM" form, or "Z".
+func ParseUTCOffset(s string) (time.Duration, error) {
+	t, err := time.Parse("Z07:00", s)
+	if err != nil {
+		return 0, fmt.Errorf("UTC offset %q must be in +HH:MM, -HH:MM or Z form", s)
+	}
+	_, seconds := t.Zone()
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// NewLogonHours encodes a schedule expressed in a fixed UTC offset into the
+// UTC logonHours bitmap. The offset must be a whole number of hours because
+// the bitmap has hourly resolution.
+func NewLogonHours(schedule LogonSchedule, offset time.Duration) (*LogonHours, error) {
+	shift, err := offsetHours(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var h LogonHours
+	for day, ranges := range schedule {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("invalid weekday %d", day)
+		}
+		for _, r := range ranges {
+			if r.Start < 0 || r.End > 24 || r.Start >= r.End {
+				return nil, fmt.Errorf("invalid hour range %s", r)
+			}
+			for hour := r.Start; hour < r.End; hour++ {
+				h.allow(int(day)*24 + hour - shift)
+			}
+		}
+	}
+	return &h, nil
+}
+
+// Schedule decodes the bitmap into a schedule expressed in a fixed UTC
+// offset, with adjacent hours merged into ranges. Days on which logon is
+// denied are omitted.
+func (h *LogonHours) Schedule(offset time.Duration) (LogonSchedule, error) {
+	shift, err := offsetHours(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := LogonSchedule{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		var ranges []HourRange
+		for hour := 0; hour < 24; hour++ {
+			if !h.allowed(int(day)*24 + hour - shift) {
+				continue
+			}
+			if n := len(ranges); n > 0 && ranges[n-1].End == hour {
+				ranges[n-1].End = hour + 1
+			} else {
+				ranges = append(ranges, HourRange{Start: hour, End: hour + 1})
+			}
+		}
+		if ranges != nil {
+			schedule[day] = ranges
+		}
+	}
+	return schedule, nil
+}
+
+// AllowsAll reports whether logon is allowed at every hour, which AD treats
+// the same as an absent logonHours attribute.
+func (h *LogonHours) AllowsAll() bool {
+	return !slices.ContainsFunc(h[:], func(b byte) bool { return b != 0xff })
+}
+
+// Bytes returns the attribute value.
+func (h *LogonHours) Bytes() []byte {
+	return slices.Clone(h[:])
+}
+
+// allowed reports whether the bit for a UTC hour of the week is set, wrapping
+// hours that fall outside the week.
+func (h *LogonHours) allowed(hour int) bool {
+	hour = (hour%hoursPerWeek + hoursPerWeek) % hoursPerWeek
+	return h[hour/8]&(1<<(hour%8)) != 0
+}
+
+// allow sets the bit for a UTC hour of the week, wrapping hours that fall
+// outside the week.
+func (h *LogonHours) allow(hour int) {
+	hour = (hour%hoursPerWeek + hoursPerWeek) % hoursPerWeek
+	h[hour/8] |= 1 << (hour % 8)
+}
+
+// offsetHours converts a UTC offset to whole hours.
+func offsetHours(offset time.Duration) (int, error) {
+	if offset%time.Hour != 0 || offset < -14*time.Hour || offset > 14*time.Hour {
+		return 0, fmt.Errorf("UTC offset %s must be a whole number of hours between -14h and +14h", offset)
+	}
+	return int(offset / time.Hour), nil
+}
+
+// logonHoursEqual reports whether a requested bitmap matches the current one,
+// where nil and a bitmap allowing every hour both mean unrestricted.
+func logonHoursEqual(requested, current *LogonHours) bool {
+	if requested == nil || requested.AllowsAll() {
+		return current == nil || current.AllowsAll()
+	}
+	return current != nil && *requested == *current
+}
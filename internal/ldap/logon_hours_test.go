@@ -0,0 +1,135 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHourRange(t *testing.T) {
+	valid := map[string]HourRange{
+		"00-24": {Start: 0, End: 24},
+		"08-17": {Start: 8, End: 17},
+		"23-24": {Start: 23, End: 24},
+	}
+	for s, expected := range valid {
+		r, err := ParseHourRange(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, r)
+		assert.Equal(t, s, r.String())
+	}
+
+	for _, s := range []string{"", "8-17", "08:00-17:00", "17-08", "08-08", "08-25", "ab-cd", "08"} {
+		_, err := ParseHourRange(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestLogonHours_UTC(t *testing.T) {
+	h, err := NewLogonHours(LogonSchedule{time.Sunday: {{Start: 0, End: 1}}, time.Saturday: {{Start: 23, End: 24}}}, 0)
+	require.NoError(t, err)
+
+	expected := make([]byte, logonHoursLength)
+	expected[0] = 0x01
+	expected[20] = 0x80
+	assert.Equal(t, expected, h.Bytes())
+}
+
+func TestLogonHours_RoundTrip(t *testing.T) {
+	schedule := LogonSchedule{
+		time.Monday:    {{Start: 8, End: 12}, {Start: 13, End: 18}},
+		time.Tuesday:   {{Start: 8, End: 18}},
+		time.Wednesday: {{Start: 0, End: 24}},
+		time.Sunday:    {{Start: 22, End: 24}},
+	}
+
+	for _, offset := range []time.Duration{0, time.Hour, -5 * time.Hour, 14 * time.Hour, -12 * time.Hour} {
+		t.Run(offset.String(), func(t *testing.T) {
+			h, err := NewLogonHours(schedule, offset)
+			require.NoError(t, err)
+
+			parsed, err := ParseLogonHours(h.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, *h, *parsed)
+
+			decoded, err := parsed.Schedule(offset)
+			require.NoError(t, err)
+			assert.Equal(t, schedule, decoded)
+		})
+	}
+}
+
+func TestLogonHours_OffsetWrapsAcrossWeek(t *testing.T) {
+	// Sunday 00:00-02:00 at UTC+1 is Saturday 23:00 to Sunday 01:00 UTC.
+	h, err := NewLogonHours(LogonSchedule{time.Sunday: {{Start: 0, End: 2}}}, time.Hour)
+	require.NoError(t, err)
+
+	utc, err := h.Schedule(0)
+	require.NoError(t, err)
+	assert.Equal(t, LogonSchedule{
+		time.Sunday:   {{Start: 0, End: 1}},
+		time.Saturday: {{Start: 23, End: 24}},
+	}, utc)
+}
+
+func TestLogonHours_AllowsAll(t *testing.T) {
+	all := LogonSchedule{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		all[day] = []HourRange{{Start: 0, End: 24}}
+	}
+	h, err := NewLogonHours(all, 3*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, h.AllowsAll())
+	assert.Equal(t, *UnrestrictedLogonHours(), *h)
+
+	none, err := NewLogonHours(LogonSchedule{}, 0)
+	require.NoError(t, err)
+	assert.False(t, none.AllowsAll())
+
+	assert.True(t, logonHoursEqual(nil, h))
+	assert.True(t, logonHoursEqual(h, nil))
+	assert.False(t, logonHoursEqual(none, nil))
+	assert.True(t, logonHoursEqual(none, &LogonHours{}))
+}
+
+func TestLogonHours_Errors(t *testing.T) {
+	_, err := ParseLogonHours(make([]byte, 20))
+	assert.ErrorContains(t, err, "must be 21 bytes")
+
+	_, err = NewLogonHours(LogonSchedule{time.Monday: {{Start: 8, End: 17}}}, 30*time.Minute)
+	assert.ErrorContains(t, err, "whole number of hours")
+
+	_, err = NewLogonHours(LogonSchedule{time.Monday: {{Start: 17, End: 8}}}, 0)
+	assert.ErrorContains(t, err, "invalid hour range")
+
+	_, err = NewLogonHours(LogonSchedule{time.Weekday(7): {{Start: 8, End: 17}}}, 0)
+	assert.ErrorContains(t, err, "invalid weekday")
+}
+
+func TestParseUTCOffset(t *testing.T) {
+	valid := map[string]time.Duration{
+		"Z":      0,
+		"+00:00": 0,
+		"+01:00": time.Hour,
+		"-05:00": -5 * time.Hour,
+		"+05:30": 5*time.Hour + 30*time.Minute,
+	}
+	for s, expected := range valid {
+		offset, err := ParseUTCOffset(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, offset, s)
+	}
+
+	for _, s := range []string{"", "+1", "UTC", "Europe/London", "01:00"} {
+		_, err := ParseUTCOffset(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestWorkstationsEqual(t *testing.T) {
+	assert.True(t, workstationsEqual([]string{"PC1", "pc2"}, []string{"PC2", "pc1"}))
+	assert.True(t, workstationsEqual(nil, []string{}))
+	assert.False(t, workstationsEqual([]string{"PC1"}, []string{"PC1", "PC2"}))
+}
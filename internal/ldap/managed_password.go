@@ -0,0 +1,121 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/md4" //nolint:staticcheck // NT hashes are defined as MD4
+)
+
+// managedPasswordHeaderLength is the size of the fixed fields at the start of
+// an MSDS-MANAGEDPASSWORD_BLOB: version, reserved, length and four offsets.
+const managedPasswordHeaderLength = 16
+
+// ManagedPassword is a decoded MSDS-MANAGEDPASSWORD_BLOB, the value of the
+// msDS-ManagedPassword attribute of a group managed service account
+// (MS-ADTS 2.2.19).
+type ManagedPassword struct {
+	// CurrentPassword and PreviousPassword are the raw UTF-16LE password
+	// bytes without the terminating null. gMSA passwords are random and
+	// need not be valid UTF-16, so they are kept as bytes. PreviousPassword
+	// is nil until the password has been changed once.
+	CurrentPassword  []byte
+	PreviousPassword []byte
+
+	// QueryPasswordInterval is how long after the read the password should
+	// be queried again, as it will have been changed by then.
+	QueryPasswordInterval time.Duration
+
+	// UnchangedPasswordInterval is how long after the read the password is
+	// guaranteed not to change.
+	UnchangedPasswordInterval time.Duration
+}
+
+// ParseManagedPasswordBlob decodes an MSDS-MANAGEDPASSWORD_BLOB.
+func ParseManagedPasswordBlob(blob []byte) (*ManagedPassword, error) {
+	if len(blob) < managedPasswordHeaderLength {
+		return nil, fmt.Errorf("managed password blob is %d bytes, shorter than its header", len(blob))
+	}
+
+	version := binary.LittleEndian.Uint16(blob[0:2])
+	if version != 1 {
+		return nil, fmt.Errorf("unsupported managed password blob version %d", version)
+	}
+	length := binary.LittleEndian.Uint32(blob[4:8])
+	if int64(length) > int64(len(blob)) || length < managedPasswordHeaderLength {
+		return nil, fmt.Errorf("managed password blob declares %d bytes but holds %d", length, len(blob))
+	}
+	blob = blob[:length]
+
+	currentOffset := binary.LittleEndian.Uint16(blob[8:10])
+	previousOffset := binary.LittleEndian.Uint16(blob[10:12])
+	queryOffset := binary.LittleEndian.Uint16(blob[12:14])
+	unchangedOffset := binary.LittleEndian.Uint16(blob[14:16])
+
+	current, err := readManagedPassword(blob, currentOffset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current password: %w", err)
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("managed password blob has an empty current password")
+	}
+
+	var previous []byte
+	if previousOffset != 0 {
+		if previous, err = readManagedPassword(blob, previousOffset); err != nil {
+			return nil, fmt.Errorf("invalid previous password: %w", err)
+		}
+	}
+
+	queryInterval, err := readManagedPasswordInterval(blob, queryOffset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query password interval: %w", err)
+	}
+	unchangedInterval, err := readManagedPasswordInterval(blob, unchangedOffset)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unchanged password interval: %w", err)
+	}
+
+	return &ManagedPassword{
+		CurrentPassword:           current,
+		PreviousPassword:          previous,
+		QueryPasswordInterval:     queryInterval,
+		UnchangedPasswordInterval: unchangedInterval,
+	}, nil
+}
+
+// readManagedPassword reads a null-terminated UTF-16LE string at offset,
+// returning its bytes without the terminator.
+func readManagedPassword(blob []byte, offset uint16) ([]byte, error) {
+	if int(offset) < managedPasswordHeaderLength || int(offset) >= len(blob) {
+		return nil, fmt.Errorf("offset %d is outside the blob", offset)
+	}
+	for end := int(offset); end+1 < len(blob); end += 2 {
+		if blob[end] == 0 && blob[end+1] == 0 {
+			password := make([]byte, end-int(offset))
+			copy(password, blob[offset:end])
+			return password, nil
+		}
+	}
+	return nil, fmt.Errorf("password at offset %d is not null-terminated", offset)
+}
+
+// readManagedPasswordInterval reads a 64-bit interval in 100-nanosecond units.
+func readManagedPasswordInterval(blob []byte, offset uint16) (time.Duration, error) {
+	if int(offset) < managedPasswordHeaderLength || int(offset)+8 > len(blob) {
+		return 0, fmt.Errorf("offset %d is outside the blob", offset)
+	}
+	ticks := binary.LittleEndian.Uint64(blob[offset : offset+8])
+	if ticks > uint64(1<<63-1)/100 {
+		return 0, fmt.Errorf("interval of %d ticks is out of range", ticks)
+	}
+	return time.Duration(ticks * 100), nil
+}
+
+// NTHash returns the NT hash (MD4) of a UTF-16LE encoded password.
+func NTHash(utf16Password []byte) []byte {
+	h := md4.New()
+	h.Write(utf16Password)
+	return h.Sum(nil)
+}
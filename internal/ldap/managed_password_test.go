@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildManagedPasswordBlob lays out an MSDS-MANAGEDPASSWORD_BLOB the way
+// domain controllers do: header, null-terminated passwords, then the two
+// intervals aligned to eight bytes.
+func buildManagedPasswordBlob(current, previous []byte, query, unchanged time.Duration) []byte {
+	blob := make([]byte, managedPasswordHeaderLength)
+	binary.LittleEndian.PutUint16(blob[0:2], 1)
+
+	binary.LittleEndian.PutUint16(blob[8:10], uint16(len(blob)))
+	blob = append(append(blob, current...), 0, 0)
+	if previous != nil {
+		binary.LittleEndian.PutUint16(blob[10:12], uint16(len(blob)))
+		blob = append(append(blob, previous...), 0, 0)
+	}
+	for len(blob)%8 != 0 {
+		blob = append(blob, 0)
+	}
+
+	binary.LittleEndian.PutUint16(blob[12:14], uint16(len(blob)))
+	blob = binary.LittleEndian.AppendUint64(blob, uint64(query/100))
+	binary.LittleEndian.PutUint16(blob[14:16], uint16(len(blob)))
+	blob = binary.LittleEndian.AppendUint64(blob, uint64(unchanged/100))
+
+	binary.LittleEndian.PutUint32(blob[4:8], uint32(len(blob)))
+	return blob
+}
+
+func TestParseManagedPasswordBlob(t *testing.T) {
+	current := utf16LE("current-password")
+	previous := utf16LE("previous-password")
+
+	t.Run("current and previous password", func(t *testing.T) {
+		blob := buildManagedPasswordBlob(current, previous, 30*24*time.Hour, 29*24*time.Hour)
+
+		password, err := ParseManagedPasswordBlob(blob)
+		require.NoError(t, err)
+		assert.Equal(t, current, password.CurrentPassword)
+		assert.Equal(t, previous, password.PreviousPassword)
+		assert.Equal(t, 30*24*time.Hour, password.QueryPasswordInterval)
+		assert.Equal(t, 29*24*time.Hour, password.UnchangedPasswordInterval)
+	})
+
+	t.Run("no previous password", func(t *testing.T) {
+		blob := buildManagedPasswordBlob(current, nil, time.Hour, time.Minute)
+
+		password, err := ParseManagedPasswordBlob(blob)
+		require.NoError(t, err)
+		assert.Equal(t, current, password.CurrentPassword)
+		assert.Nil(t, password.PreviousPassword)
+	})
+
+	t.Run("trailing bytes beyond the declared length are ignored", func(t *testing.T) {
+		blob := append(buildManagedPasswordBlob(current, nil, time.Hour, time.Minute), 0xff, 0xff)
+
+		_, err := ParseManagedPasswordBlob(blob)
+		require.NoError(t, err)
+	})
+
+	valid := buildManagedPasswordBlob(current, nil, time.Hour, time.Minute)
+	corrupt := func(mutate func(b []byte) []byte) []byte {
+		return mutate(append([]byte(nil), valid...))
+	}
+
+	errorCases := []struct {
+		name     string
+		blob     []byte
+		expected string
+	}{
+		{"short", valid[:10], "shorter than its header"},
+		{"version", corrupt(func(b []byte) []byte { b[0] = 2; return b }), "unsupported managed password blob version 2"},
+		{"truncated", valid[:len(valid)-4], "declares"},
+		{"current offset", corrupt(func(b []byte) []byte { binary.LittleEndian.PutUint16(b[8:10], 4); return b }), "invalid current password"},
+		{"unterminated", corrupt(func(b []byte) []byte {
+			for i := managedPasswordHeaderLength; i < len(b); i++ {
+				b[i] = 'x'
+			}
+			return b
+		}), "not null-terminated"},
+		{"empty current password", corrupt(func(b []byte) []byte { b[16], b[17] = 0, 0; return b }), "empty current password"},
+		{"interval offset", corrupt(func(b []byte) []byte { binary.LittleEndian.PutUint16(b[14:16], uint16(len(b)-4)); return b }), "invalid unchanged password interval"},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseManagedPasswordBlob(tc.blob)
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestNTHash(t *testing.T) {
+	assert.Equal(t, "8846f7eaee8fb117ad06bdd830b7586c", hex.EncodeToString(NTHash(utf16LE("password"))))
+}
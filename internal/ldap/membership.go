@@ -3,9 +3,12 @@ package ldap
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/go-ldap/ldap/v3"
 )
 
 // Active Directory membership operation limits.
@@ -76,6 +79,18 @@ func (gmm *GroupMembershipManager) SetGroupMembers(groupGUID string, memberDNs [
 		return WrapError("calculate_membership_delta", err)
 	}
 
+	// Members whose primary group is this group are implicit members that AD
+	// refuses to add to member again
+	if len(delta.ToAdd) > 0 {
+		primaryMembers, err := gmm.PrimaryGroupMembers(groupGUID, delta.ToAdd)
+		if err != nil {
+			return WrapError("get_primary_group_members", err)
+		}
+		delta.ToAdd = slices.DeleteFunc(delta.ToAdd, func(dn string) bool {
+			return slices.ContainsFunc(primaryMembers, func(p string) bool { return DNEqual(p, dn) })
+		})
+	}
+
 	// If no changes needed, return early
 	if len(delta.ToAdd) == 0 && len(delta.ToRemove) == 0 {
 		return nil
@@ -120,6 +135,61 @@ func (gmm *GroupMembershipManager) GetGroupMembers(groupGUID string) ([]string,
 	return normalizedDNs, nil
 }
 
+// PrimaryGroupMembers returns those of candidateDNs whose primary group is
+// the group. AD does not list such accounts in the group's member attribute;
+// they are members through their primaryGroupID, which is the group's RID.
+// Only candidates are looked up, as the primary group of most accounts
+// (Domain Users) would otherwise return every user of the domain.
+func (gmm *GroupMembershipManager) PrimaryGroupMembers(groupGUID string, candidateDNs []string) ([]string, error) {
+	if groupGUID == "" {
+		return nil, fmt.Errorf("group GUID cannot be empty")
+	}
+
+	if len(candidateDNs) == 0 {
+		return nil, nil
+	}
+
+	group, err := gmm.groupManager.GetGroup(groupGUID)
+	if err != nil {
+		return nil, WrapError("get_group", err)
+	}
+
+	// Only groups of the domain can be a primary group
+	groupSID, err := ParseSID(group.ObjectSid)
+	if err != nil || !isDomainSID(groupSID) {
+		return nil, nil
+	}
+
+	var dnFilter strings.Builder
+	for _, dn := range candidateDNs {
+		fmt.Fprintf(&dnFilter, "(distinguishedName=%s)", ldap.EscapeFilter(dn))
+	}
+
+	searchReq := &SearchRequest{
+		BaseDN:     gmm.groupManager.baseDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     fmt.Sprintf("(&(primaryGroupID=%d)(|%s))", groupSID.RID(), dnFilter.String()),
+		Attributes: []string{"distinguishedName"},
+		TimeLimit:  gmm.timeout,
+	}
+
+	result, err := gmm.client.SearchWithPaging(gmm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_primary_group_members", err)
+	}
+
+	var members []string
+	for _, dn := range candidateDNs {
+		for _, entry := range result.Entries {
+			if DNEqual(entry.DN, dn) {
+				members = append(members, dn)
+				break
+			}
+		}
+	}
+	return members, nil
+}
+
 // AddGroupMembers adds new members to a group using batch operations.
 // All members must be provided as Distinguished Names (DNs).
 // Uses ADMemberBatchSize to respect Active Directory's member operation limits.
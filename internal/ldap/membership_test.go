@@ -26,7 +26,9 @@ type MockGroup struct {
 	DistinguishedName string
 	Name              string
 	SAMAccountName    string
+	ObjectSid         string
 	Members           []string // Member DNs
+	PrimaryMembers    []string // DNs of accounts whose primaryGroupID is the group's RID
 }
 
 // MockObject represents any AD object for member resolution.
@@ -128,6 +130,11 @@ func (m *MockGroupMembershipClient) GetBaseDN(ctx context.Context) (string, erro
 func (m *MockGroupMembershipClient) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
 	m.operationLog = append(m.operationLog, fmt.Sprintf("Search: %s", req.Filter))
 
+	// Handle primary group member searches
+	if strings.Contains(req.Filter, "primaryGroupID") {
+		return m.handlePrimaryGroupSearch(req)
+	}
+
 	// Handle group searches by GUID
 	if strings.Contains(req.Filter, "objectGUID") {
 		return m.handleGroupGUIDSearch(req)
@@ -231,6 +238,22 @@ func (m *MockGroupMembershipClient) handleGroupGUIDSearch(req *SearchRequest) (*
 	return &SearchResult{Entries: []*ldap.Entry{}, Total: 0}, nil
 }
 
+func (m *MockGroupMembershipClient) handlePrimaryGroupSearch(req *SearchRequest) (*SearchResult, error) {
+	var entries []*ldap.Entry
+	for _, group := range m.groups {
+		sid, err := ParseSID(group.ObjectSid)
+		if err != nil || !strings.Contains(req.Filter, fmt.Sprintf("(primaryGroupID=%d)", sid.RID())) {
+			continue
+		}
+		for _, dn := range group.PrimaryMembers {
+			if strings.Contains(req.Filter, "(distinguishedName="+dn+")") {
+				entries = append(entries, &ldap.Entry{DN: dn})
+			}
+		}
+	}
+	return &SearchResult{Entries: entries, Total: len(entries)}, nil
+}
+
 func (m *MockGroupMembershipClient) handleBaseObjectSearch(req *SearchRequest) (*SearchResult, error) {
 	// Check if it's a group
 	if group, exists := m.groupsByDN[req.BaseDN]; exists {
@@ -287,6 +310,11 @@ func (m *MockGroupMembershipClient) createGroupEntry(group *MockGroup) *ldap.Ent
 		},
 	}
 
+	if group.ObjectSid != "" {
+		sidBytes, _ := NewSIDHandler().StringToSIDBytes(group.ObjectSid)
+		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{Name: "objectSid", ByteValues: [][]byte{sidBytes}})
+	}
+
 	// Add members if any
 	if len(group.Members) > 0 {
 		entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{
@@ -682,6 +710,67 @@ func TestSetGroupMembers(t *testing.T) {
 	}
 }
 
+func TestSetGroupMembersSkipsPrimaryGroupMembers(t *testing.T) {
+	gmm, client := createTestMembershipManager(t)
+
+	groupGUID := "12345678-1234-1234-1234-123456789012"
+	groupDN := "CN=Linux Users,OU=Groups,DC=example,DC=com"
+	client.AddMockGroup(groupGUID, groupDN, "Linux Users", "linuxusers")
+
+	user1DN := "CN=User1,OU=Users,DC=example,DC=com"
+	user2DN := "CN=User2,OU=Users,DC=example,DC=com"
+
+	// User2's primary group is the group, so it is absent from member
+	group := client.groups[groupGUID]
+	group.ObjectSid = "S-1-5-21-1-2-3-1105"
+	group.Members = []string{user1DN}
+	group.PrimaryMembers = []string{user2DN}
+
+	err := gmm.SetGroupMembers(groupGUID, []string{user1DN, user2DN})
+	if err != nil {
+		t.Fatalf("SetGroupMembers failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(group.Members, []string{user1DN}) {
+		t.Errorf("Expected member to be unchanged, got %v", group.Members)
+	}
+	for _, op := range client.GetOperationLog() {
+		if strings.HasPrefix(op, "Modify:") {
+			t.Errorf("Expected no modify operation, got %s", op)
+		}
+	}
+}
+
+func TestPrimaryGroupMembers(t *testing.T) {
+	gmm, client := createTestMembershipManager(t)
+
+	groupGUID := "12345678-1234-1234-1234-123456789012"
+	client.AddMockGroup(groupGUID, "CN=Linux Users,OU=Groups,DC=example,DC=com", "Linux Users", "linuxusers")
+
+	user1DN := "CN=User1,OU=Users,DC=example,DC=com"
+	user2DN := "CN=User2,OU=Users,DC=example,DC=com"
+	group := client.groups[groupGUID]
+	group.PrimaryMembers = []string{user2DN}
+
+	// Without a domain SID the group cannot be a primary group
+	members, err := gmm.PrimaryGroupMembers(groupGUID, []string{user1DN, user2DN})
+	if err != nil {
+		t.Fatalf("PrimaryGroupMembers failed: %v", err)
+	}
+	if len(members) != 0 {
+		t.Errorf("Expected no primary group members, got %v", members)
+	}
+
+	group.ObjectSid = "S-1-5-21-1-2-3-1105"
+	members, err = gmm.PrimaryGroupMembers(groupGUID, []string{user1DN, user2DN})
+	if err != nil {
+		t.Fatalf("PrimaryGroupMembers failed: %v", err)
+	}
+	if !reflect.DeepEqual(members, []string{user2DN}) {
+		t.Errorf("Expected primary group members %v, got %v", []string{user2DN}, members)
+	}
+}
+
 func TestSetGroupMembersEmptyDesiredList(t *testing.T) {
 	gmm, client := createTestMembershipManager(t)
 
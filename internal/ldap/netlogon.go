@@ -0,0 +1,268 @@
+package ldap
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Netlogon message constants (MS-ADTS 6.3.1).
+const (
+	netlogonOpcodeSAMLogonResponseEx = 23
+	netlogonOpcodeSAMUserUnknownEx   = 25
+
+	// netlogonNtVersion requests NETLOGON_NT_VERSION_5 | NETLOGON_NT_VERSION_5EX
+	// so that the DC answers with NETLOGON_SAM_LOGON_RESPONSE_EX, the only
+	// response format that carries the client site name.
+	netlogonNtVersion = `\06\00\00\00`
+
+	// cldapPort is the UDP port used for the CLDAP netlogon ping.
+	cldapPort = 389
+)
+
+// NetlogonResponse contains the fields of a NETLOGON_SAM_LOGON_RESPONSE_EX
+// message returned by a domain controller in response to a CLDAP ping.
+type NetlogonResponse struct {
+	Flags               uint32
+	DNSForestName       string
+	DNSDomainName       string
+	DNSHostName         string
+	NetbiosDomainName   string
+	NetbiosComputerName string
+	UserName            string
+	DCSiteName          string
+	ClientSiteName      string
+}
+
+// NetlogonPing sends a CLDAP netlogon ping (an LDAP search of the RootDSE
+// Netlogon attribute over UDP) to a domain controller and returns the parsed
+// response. The response reveals the AD site the client's IP address maps to.
+func NetlogonPing(ctx context.Context, host, domain string, timeout time.Duration) (*NetlogonResponse, error) {
+	messageID, err := newCLDAPMessageID()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := buildNetlogonPingRequest(messageID, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "udp", net.JoinHostPort(host, strconv.Itoa(cldapPort)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial CLDAP on %s: %w", host, err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("failed to set CLDAP deadline: %w", err)
+	}
+
+	if _, err := conn.Write(request.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to send CLDAP netlogon ping to %s: %w", host, err)
+	}
+
+	// Datagrams that do not answer this request, such as late replies to an
+	// earlier ping, are skipped until the deadline.
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("no CLDAP netlogon response from %s: %w", host, err)
+		}
+
+		netlogon, err := parseNetlogonPingReply(buf[:n], messageID)
+		if errors.Is(err, errCLDAPMessageIDMismatch) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CLDAP netlogon response from %s: %w", host, err)
+		}
+
+		return parseNetlogonResponse(netlogon)
+	}
+}
+
+// errCLDAPMessageIDMismatch reports a CLDAP reply to a different request.
+var errCLDAPMessageIDMismatch = errors.New("CLDAP message ID does not match request")
+
+// newCLDAPMessageID returns a random positive LDAP message ID so that a
+// spoofed or stale datagram is unlikely to be accepted as the reply.
+func newCLDAPMessageID() (int64, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate CLDAP message ID: %w", err)
+	}
+	return int64(binary.BigEndian.Uint32(b[:])&0x7FFFFFFF) | 1, nil
+}
+
+// buildNetlogonPingRequest encodes the LDAP search request used for a CLDAP
+// netlogon ping: base "", scope base, filter on DnsDomain and NtVer, and the
+// single attribute Netlogon.
+func buildNetlogonPingRequest(messageID int64, domain string) (*ber.Packet, error) {
+	filter, err := ldap.CompileFilter(fmt.Sprintf("(&(DnsDomain=%s)(NtVer=%s))", ldap.EscapeFilter(domain), netlogonNtVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile netlogon filter: %w", err)
+	}
+
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+
+	search := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchRequest, nil, "Search Request")
+	search.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Base DN"))
+	search.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, ldap.ScopeBaseObject, "Scope"))
+	search.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, ldap.NeverDerefAliases, "Deref Aliases"))
+	search.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 0, "Size Limit"))
+	search.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 0, "Time Limit"))
+	search.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, false, "Types Only"))
+	search.AppendChild(filter)
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	attributes.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "Netlogon", "Attribute"))
+	search.AppendChild(attributes)
+
+	packet.AppendChild(search)
+	return packet, nil
+}
+
+// parseNetlogonPingReply extracts the Netlogon attribute value from the first
+// LDAP message of a CLDAP reply datagram. It returns errCLDAPMessageIDMismatch
+// when the message does not carry the given message ID.
+func parseNetlogonPingReply(data []byte, messageID int64) ([]byte, error) {
+	packet, err := ber.DecodePacketErr(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode LDAP message: %w", err)
+	}
+	if len(packet.Children) < 2 {
+		return nil, errors.New("malformed LDAP message")
+	}
+
+	if id, ok := packet.Children[0].Value.(int64); !ok || id != messageID {
+		return nil, errCLDAPMessageIDMismatch
+	}
+
+	op := packet.Children[1]
+	if op.ClassType != ber.ClassApplication || op.Tag != ldap.ApplicationSearchResultEntry {
+		return nil, fmt.Errorf("unexpected LDAP operation %d", op.Tag)
+	}
+	if len(op.Children) < 2 {
+		return nil, errors.New("malformed search result entry")
+	}
+
+	for _, attribute := range op.Children[1].Children {
+		if len(attribute.Children) < 2 {
+			continue
+		}
+		name, _ := attribute.Children[0].Value.(string)
+		if !strings.EqualFold(name, "Netlogon") {
+			continue
+		}
+		values := attribute.Children[1].Children
+		if len(values) == 0 {
+			break
+		}
+		return values[0].ByteValue, nil
+	}
+
+	return nil, errors.New("response does not contain a Netlogon attribute")
+}
+
+// parseNetlogonResponse decodes a NETLOGON_SAM_LOGON_RESPONSE_EX structure.
+func parseNetlogonResponse(data []byte) (*NetlogonResponse, error) {
+	// Opcode (2) + Sbz (2) + Flags (4) + DomainGuid (16)
+	const headerLen = 24
+	if len(data) < headerLen {
+		return nil, fmt.Errorf("netlogon response too short: %d bytes", len(data))
+	}
+
+	opcode := binary.LittleEndian.Uint16(data[0:2])
+	if opcode != netlogonOpcodeSAMLogonResponseEx && opcode != netlogonOpcodeSAMUserUnknownEx {
+		return nil, fmt.Errorf("unsupported netlogon response opcode %d", opcode)
+	}
+
+	resp := &NetlogonResponse{
+		Flags: binary.LittleEndian.Uint32(data[4:8]),
+	}
+
+	fields := []*string{
+		&resp.DNSForestName,
+		&resp.DNSDomainName,
+		&resp.DNSHostName,
+		&resp.NetbiosDomainName,
+		&resp.NetbiosComputerName,
+		&resp.UserName,
+		&resp.DCSiteName,
+		&resp.ClientSiteName,
+	}
+
+	offset := headerLen
+	for _, field := range fields {
+		name, next, err := readCompressedName(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		*field = name
+		offset = next
+	}
+
+	return resp, nil
+}
+
+// readCompressedName reads an RFC 1035 compressed name starting at offset.
+// Pointers are relative to the start of the netlogon structure. It returns
+// the dotted name and the offset just past the name in the original stream.
+func readCompressedName(data []byte, offset int) (string, int, error) {
+	var labels []string
+	next := -1
+	pos := offset
+
+	// Bound the number of pointer jumps so malformed input cannot loop.
+	for jumps := 0; jumps <= len(data); {
+		if pos >= len(data) {
+			return "", 0, fmt.Errorf("netlogon name at offset %d is truncated", offset)
+		}
+
+		length := int(data[pos])
+		switch {
+		case length == 0:
+			if next < 0 {
+				next = pos + 1
+			}
+			return strings.Join(labels, "."), next, nil
+
+		case length&0xC0 == 0xC0:
+			if pos+1 >= len(data) {
+				return "", 0, fmt.Errorf("netlogon name pointer at offset %d is truncated", pos)
+			}
+			if next < 0 {
+				next = pos + 2
+			}
+			pos = int(binary.BigEndian.Uint16(data[pos:pos+2]) & 0x3FFF)
+			jumps++
+
+		default:
+			end := pos + 1 + length
+			if end > len(data) {
+				return "", 0, fmt.Errorf("netlogon label at offset %d is truncated", pos)
+			}
+			labels = append(labels, string(data[pos+1:end]))
+			pos = end
+		}
+	}
+
+	return "", 0, fmt.Errorf("netlogon name at offset %d contains a pointer loop", offset)
+}
@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"encoding/binary"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// netlogonBlobBuilder assembles NETLOGON_SAM_LOGON_RESPONSE_EX test data with
+// RFC 1035 name compression.
+type netlogonBlobBuilder struct {
+	data []byte
+}
+
+func newNetlogonBlob(opcode uint16, flags uint32) *netlogonBlobBuilder {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint16(header[0:2], opcode)
+	binary.LittleEndian.PutUint32(header[4:8], flags)
+	return &netlogonBlobBuilder{data: header}
+}
+
+// labels appends the given labels, optionally terminated by a pointer instead
+// of a zero byte, and returns the offset the name starts at.
+func (b *netlogonBlobBuilder) labels(pointer int, labels ...string) int {
+	start := len(b.data)
+	for _, label := range labels {
+		b.data = append(b.data, byte(len(label)))
+		b.data = append(b.data, label...)
+	}
+	if pointer >= 0 {
+		b.data = binary.BigEndian.AppendUint16(b.data, 0xC000|uint16(pointer))
+	} else {
+		b.data = append(b.data, 0)
+	}
+	return start
+}
+
+func TestParseNetlogonResponse(t *testing.T) {
+	b := newNetlogonBlob(netlogonOpcodeSAMLogonResponseEx, 0x3f3fd)
+	forest := b.labels(-1, "example", "com") // DnsForestName
+	b.labels(forest)                         // DnsDomainName -> forest
+	b.labels(forest, "dc1")                  // DnsHostName
+	b.labels(-1, "EXAMPLE")                  // NetbiosDomainName
+	b.labels(-1, "DC1")                      // NetbiosComputerName
+	b.labels(-1)                             // UserName
+	site := b.labels(-1, "London")           // DcSiteName
+	b.labels(site)                           // ClientSiteName -> DcSiteName
+	b.data = append(b.data, 0x05, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff)
+
+	resp, err := parseNetlogonResponse(b.data)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(0x3f3fd), resp.Flags)
+	assert.Equal(t, "example.com", resp.DNSForestName)
+	assert.Equal(t, "example.com", resp.DNSDomainName)
+	assert.Equal(t, "dc1.example.com", resp.DNSHostName)
+	assert.Equal(t, "EXAMPLE", resp.NetbiosDomainName)
+	assert.Equal(t, "DC1", resp.NetbiosComputerName)
+	assert.Empty(t, resp.UserName)
+	assert.Equal(t, "London", resp.DCSiteName)
+	assert.Equal(t, "London", resp.ClientSiteName)
+}
+
+func TestParseNetlogonResponse_Errors(t *testing.T) {
+	t.Run("too short", func(t *testing.T) {
+		_, err := parseNetlogonResponse([]byte{23, 0})
+		assert.ErrorContains(t, err, "too short")
+	})
+
+	t.Run("unsupported opcode", func(t *testing.T) {
+		b := newNetlogonBlob(19, 0)
+		_, err := parseNetlogonResponse(b.data)
+		assert.ErrorContains(t, err, "unsupported netlogon response opcode")
+	})
+
+	t.Run("truncated label", func(t *testing.T) {
+		b := newNetlogonBlob(netlogonOpcodeSAMLogonResponseEx, 0)
+		b.data = append(b.data, 10, 'a', 'b')
+		_, err := parseNetlogonResponse(b.data)
+		assert.ErrorContains(t, err, "truncated")
+	})
+
+	t.Run("pointer loop", func(t *testing.T) {
+		b := newNetlogonBlob(netlogonOpcodeSAMLogonResponseEx, 0)
+		b.data = binary.BigEndian.AppendUint16(b.data, 0xC000|uint16(len(b.data)))
+		_, err := parseNetlogonResponse(b.data)
+		assert.ErrorContains(t, err, "pointer loop")
+	})
+}
+
+func TestBuildNetlogonPingRequest(t *testing.T) {
+	packet, err := buildNetlogonPingRequest(7, "example.com")
+	require.NoError(t, err)
+
+	decoded, err := ber.DecodePacketErr(packet.Bytes())
+	require.NoError(t, err)
+	require.Len(t, decoded.Children, 2)
+
+	search := decoded.Children[1]
+	assert.Equal(t, ber.Tag(ldap.ApplicationSearchRequest), search.Tag)
+	require.Len(t, search.Children, 8)
+	assert.Equal(t, "", search.Children[0].Value)
+
+	// (&(DnsDomain=example.com)(NtVer=<uint32 LE 6>))
+	and := search.Children[6]
+	require.Len(t, and.Children, 2)
+	assert.Equal(t, "DnsDomain", and.Children[0].Children[0].Value)
+	assert.Equal(t, "example.com", and.Children[0].Children[1].Data.String())
+	assert.Equal(t, "NtVer", and.Children[1].Children[0].Value)
+	assert.Equal(t, []byte{0x06, 0x00, 0x00, 0x00}, and.Children[1].Children[1].Data.Bytes())
+
+	attributes := search.Children[7].Children
+	require.Len(t, attributes, 1)
+	assert.Equal(t, "Netlogon", attributes[0].Value)
+}
+
+func TestParseNetlogonPingReply(t *testing.T) {
+	netlogon := []byte{23, 0, 0, 0, 1, 2, 3, 4}
+
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "DN"))
+	attributes := ber.NewSequence("Attributes")
+	attribute := ber.NewSequence("Attribute")
+	attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "netlogon", "Type"))
+	values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+	values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, string(netlogon), "Value"))
+	attribute.AppendChild(values)
+	attributes.AppendChild(attribute)
+	entry.AppendChild(attributes)
+
+	message := ber.NewSequence("LDAP Message")
+	message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 1, "MessageID"))
+	message.AppendChild(entry)
+
+	got, err := parseNetlogonPingReply(message.Bytes(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, netlogon, got)
+
+	t.Run("message ID mismatch", func(t *testing.T) {
+		_, err := parseNetlogonPingReply(message.Bytes(), 2)
+		assert.ErrorIs(t, err, errCLDAPMessageIDMismatch)
+	})
+
+	t.Run("search result done", func(t *testing.T) {
+		done := ber.NewSequence("LDAP Message")
+		done.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 1, "MessageID"))
+		done.AppendChild(ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultDone, nil, "Done"))
+
+		_, err := parseNetlogonPingReply(done.Bytes(), 1)
+		assert.ErrorContains(t, err, "unexpected LDAP operation")
+	})
+}
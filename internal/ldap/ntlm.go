@@ -0,0 +1,117 @@
+package ldap
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-ntlmssp"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// ntlmBinder is the subset of *ldap.Conn used for NTLM binds.
+type ntlmBinder interface {
+	NTLMBind(domain, username, password string) error
+	NTLMBindWithHash(domain, username, hash string) error
+	NTLMChallengeBind(req *ldap.NTLMBindRequest) (*ldap.NTLMBindResult, error)
+}
+
+// channelBindingNegotiator is an ldap.NTLMNegotiator that adds a channel
+// binding to the NTLMv2 response.
+type channelBindingNegotiator struct {
+	channelBinding []byte // tls-server-end-point application data
+}
+
+func (n *channelBindingNegotiator) Negotiate(domain, workstation string) ([]byte, error) {
+	return ntlmssp.NewNegotiateMessage(domain, workstation)
+}
+
+func (n *channelBindingNegotiator) ChallengeResponse(challenge []byte, username, hash string) ([]byte, error) {
+	challenge, err := addNTLMChannelBinding(challenge, n.channelBinding)
+	if err != nil {
+		return nil, err
+	}
+	return ntlmssp.NewAuthenticateMessage(challenge, username, hash, &ntlmssp.AuthenticateMessageOptions{
+		PasswordHashed: true,
+	})
+}
+
+// ValidateNTHash reports whether hash is a hex-encoded 16-byte NT hash.
+func ValidateNTHash(hash string) error {
+	decoded, err := hex.DecodeString(hash)
+	if err != nil || len(decoded) != 16 {
+		return fmt.Errorf("NT hash must be 32 hexadecimal characters")
+	}
+	return nil
+}
+
+// ntlmCredentials returns the domain and username to send in an NTLM bind.
+// A down-level logon name (DOMAIN\user) supplies the domain when none is
+// configured; UPNs are passed through unchanged with an empty domain, which
+// the server accepts.
+func ntlmCredentials(cfg *ConnectionConfig) (string, string) {
+	domain, username := cfg.NTLMDomain, cfg.Username
+	if before, after, found := strings.Cut(username, `\`); found {
+		if domain == "" {
+			domain = before
+		}
+		username = after
+	}
+	return domain, username
+}
+
+// performNTLMBind performs an NTLM bind using the password or, when set, the
+// NT hash from the configuration. It is shared by the client and the pool.
+// A non-empty channelBinding is included in the NTLMv2 response.
+func performNTLMBind(ctx context.Context, conn ntlmBinder, cfg *ConnectionConfig, channelBinding []byte) error {
+	start := time.Now()
+	domain, username := ntlmCredentials(cfg)
+	if username == "" {
+		return fmt.Errorf("username is required for NTLM authentication")
+	}
+
+	fields := map[string]any{
+		"domain":          domain,
+		"username":        username,
+		"with_hash":       cfg.NTLMHash != "",
+		"channel_binding": len(channelBinding) > 0,
+	}
+	tflog.SubsystemDebug(ctx, "ldap", "Performing NTLM bind", fields)
+
+	if cfg.NTLMHash != "" {
+		if err := ValidateNTHash(cfg.NTLMHash); err != nil {
+			return err
+		}
+	} else if cfg.Password == "" {
+		return fmt.Errorf("password or NT hash is required for NTLM authentication")
+	}
+
+	var err error
+	switch {
+	case len(channelBinding) > 0:
+		_, err = conn.NTLMChallengeBind(&ldap.NTLMBindRequest{
+			Domain:     domain,
+			Username:   username,
+			Password:   cfg.Password,
+			Hash:       cfg.NTLMHash,
+			Negotiator: &channelBindingNegotiator{channelBinding: channelBinding},
+		})
+	case cfg.NTLMHash != "":
+		err = conn.NTLMBindWithHash(domain, username, cfg.NTLMHash)
+	default:
+		err = conn.NTLMBind(domain, username, cfg.Password)
+	}
+
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemError(ctx, "ldap", "NTLM bind failed", fields)
+		return fmt.Errorf("NTLM bind failed for %s: %w", username, err)
+	}
+
+	tflog.SubsystemDebug(ctx, "ldap", "NTLM bind successful", fields)
+	return nil
+}
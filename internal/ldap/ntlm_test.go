@@ -0,0 +1,192 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNTLMBinder records the arguments of the last NTLM bind.
+type fakeNTLMBinder struct {
+	method   string
+	domain   string
+	username string
+	secret   string
+	err      error
+
+	negotiator ldap.NTLMNegotiator
+}
+
+func (f *fakeNTLMBinder) NTLMBind(domain, username, password string) error {
+	f.method, f.domain, f.username, f.secret = "password", domain, username, password
+	return f.err
+}
+
+func (f *fakeNTLMBinder) NTLMBindWithHash(domain, username, hash string) error {
+	f.method, f.domain, f.username, f.secret = "hash", domain, username, hash
+	return f.err
+}
+
+func (f *fakeNTLMBinder) NTLMChallengeBind(req *ldap.NTLMBindRequest) (*ldap.NTLMBindResult, error) {
+	f.method, f.domain, f.username, f.secret = "challenge", req.Domain, req.Username, req.Password+req.Hash
+	f.negotiator = req.Negotiator
+	return &ldap.NTLMBindResult{}, f.err
+}
+
+func TestPerformNTLMBind(t *testing.T) {
+	const hash = "8846f7eaee8fb117ad06bdd830b7586c"
+
+	tests := []struct {
+		name         string
+		config       *ConnectionConfig
+		wantMethod   string
+		wantDomain   string
+		wantUsername string
+		wantErr      string
+	}{
+		{
+			name:         "password with configured domain",
+			config:       &ConnectionConfig{Username: "svc-terraform", Password: "secret", NTLMDomain: "EXAMPLE"},
+			wantMethod:   "password",
+			wantDomain:   "EXAMPLE",
+			wantUsername: "svc-terraform",
+		},
+		{
+			name:         "hash takes precedence over password",
+			config:       &ConnectionConfig{Username: "svc-terraform", Password: "secret", NTLMHash: hash},
+			wantMethod:   "hash",
+			wantUsername: "svc-terraform",
+		},
+		{
+			name:         "down-level logon name supplies the domain",
+			config:       &ConnectionConfig{Username: `EXAMPLE\svc-terraform`, Password: "secret"},
+			wantMethod:   "password",
+			wantDomain:   "EXAMPLE",
+			wantUsername: "svc-terraform",
+		},
+		{
+			name:         "configured domain wins over down-level prefix",
+			config:       &ConnectionConfig{Username: `OTHER\svc-terraform`, Password: "secret", NTLMDomain: "EXAMPLE"},
+			wantMethod:   "password",
+			wantDomain:   "EXAMPLE",
+			wantUsername: "svc-terraform",
+		},
+		{
+			name:         "UPN is passed through",
+			config:       &ConnectionConfig{Username: "svc-terraform@example.com", NTLMHash: hash},
+			wantMethod:   "hash",
+			wantUsername: "svc-terraform@example.com",
+		},
+		{
+			name:    "invalid hash",
+			config:  &ConnectionConfig{Username: "svc-terraform", NTLMHash: "not-a-hash"},
+			wantErr: "32 hexadecimal characters",
+		},
+		{
+			name:    "missing secret",
+			config:  &ConnectionConfig{Username: "svc-terraform", NTLMDomain: "EXAMPLE"},
+			wantErr: "password or NT hash is required",
+		},
+		{
+			name:    "missing username",
+			config:  &ConnectionConfig{Password: "secret", NTLMDomain: "EXAMPLE"},
+			wantErr: "username is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binder := &fakeNTLMBinder{}
+			err := performNTLMBind(t.Context(), binder, tt.config, nil)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Empty(t, binder.method, "no bind should be attempted")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, binder.method)
+			assert.Equal(t, tt.wantDomain, binder.domain)
+			assert.Equal(t, tt.wantUsername, binder.username)
+		})
+	}
+}
+
+func TestPerformNTLMBind_Error(t *testing.T) {
+	binder := &fakeNTLMBinder{err: errors.New("LDAP Result Code 49")}
+	err := performNTLMBind(t.Context(), binder, &ConnectionConfig{Username: "svc-terraform", Password: "secret", NTLMDomain: "EXAMPLE"}, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, binder.err)
+}
+
+func TestPerformNTLMBind_ChannelBinding(t *testing.T) {
+	binding := []byte("tls-server-end-point:test")
+	binder := &fakeNTLMBinder{}
+
+	err := performNTLMBind(t.Context(), binder, &ConnectionConfig{Username: `EXAMPLE\svc-terraform`, Password: "secret"}, binding)
+	require.NoError(t, err)
+	assert.Equal(t, "challenge", binder.method)
+	assert.Equal(t, "EXAMPLE", binder.domain)
+	assert.Equal(t, "svc-terraform", binder.username)
+	assert.Equal(t, "secret", binder.secret)
+	require.IsType(t, &channelBindingNegotiator{}, binder.negotiator)
+}
+
+// testNTLMChallenge returns a minimal NTLMv2 CHALLENGE message whose target
+// info holds a NetBIOS domain name.
+func testNTLMChallenge() []byte {
+	domain := []byte{'E', 0, 'X', 0}
+	targetInfo := binary.LittleEndian.AppendUint16(nil, 2) // MsvAvNbDomainName
+	targetInfo = binary.LittleEndian.AppendUint16(targetInfo, uint16(len(domain)))
+	targetInfo = append(targetInfo, domain...)
+	targetInfo = append(targetInfo, 0, 0, 0, 0)
+
+	const headerLen = 56
+	msg := make([]byte, headerLen+len(targetInfo))
+	copy(msg, "NTLMSSP\x00")
+	binary.LittleEndian.PutUint32(msg[8:], 2)
+	binary.LittleEndian.PutUint32(msg[16:], headerLen)
+	// Unicode, NTLM, target info.
+	binary.LittleEndian.PutUint32(msg[20:], 0x00000001|0x00000200|0x00800000)
+	copy(msg[24:32], "12345678")
+	binary.LittleEndian.PutUint16(msg[40:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint16(msg[42:], uint16(len(targetInfo)))
+	binary.LittleEndian.PutUint32(msg[44:], headerLen)
+	copy(msg[headerLen:], targetInfo)
+	return msg
+}
+
+func TestChannelBindingNegotiator(t *testing.T) {
+	binding := []byte("tls-server-end-point:test")
+	negotiator := &channelBindingNegotiator{channelBinding: binding}
+
+	negotiate, err := negotiator.Negotiate("EXAMPLE", "")
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(negotiate, []byte("NTLMSSP\x00")))
+
+	auth, err := negotiator.ChallengeResponse(testNTLMChallenge(), "svc-terraform", "8846f7eaee8fb117ad06bdd830b7586c")
+	require.NoError(t, err)
+
+	// NtChallengeResponse fields follow the LmChallengeResponse fields.
+	length := binary.LittleEndian.Uint16(auth[20:])
+	offset := binary.LittleEndian.Uint32(auth[24:])
+	ntResponse := auth[offset : offset+uint32(length)]
+
+	pair := append([]byte{0x0A, 0x00, 0x10, 0x00}, channelBindingHash(binding)...)
+	assert.True(t, bytes.Contains(ntResponse, pair), "NTLMv2 response should carry MsvAvChannelBindings")
+	assert.True(t, bytes.Contains(ntResponse, []byte{0x02, 0x00, 0x04, 0x00, 'E', 0, 'X', 0}), "server AV pairs are kept")
+}
+
+func TestValidateNTHash(t *testing.T) {
+	assert.NoError(t, ValidateNTHash("8846f7eaee8fb117ad06bdd830b7586c"))
+	assert.NoError(t, ValidateNTHash("8846F7EAEE8FB117AD06BDD830B7586C"))
+	assert.Error(t, ValidateNTHash("8846f7eaee8fb117ad06bdd830b7586"))
+	assert.Error(t, ValidateNTHash("zz46f7eaee8fb117ad06bdd830b7586c"))
+	assert.Error(t, ValidateNTHash(""))
+}
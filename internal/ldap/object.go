@@ -0,0 +1,464 @@
+package ldap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Object represents an arbitrary Active Directory object, identified by its
+// objectGUID and managed through its object class and a set of attributes.
+type Object struct {
+	// Core identification
+	ObjectGUID        string `json:"objectGUID"`
+	DistinguishedName string `json:"distinguishedName"`
+
+	// Naming
+	ObjectClass  []string `json:"objectClass"`  // objectClass values, most specific class last
+	RDNAttribute string   `json:"rdnAttribute"` // Naming attribute type of the RDN (e.g. cn)
+	Name         string   `json:"name"`         // Naming attribute value
+	Container    string   `json:"container"`    // Parent container DN
+
+	// Attributes requested via SetAttributes, with binary values base64-encoded
+	Attributes map[string][]string `json:"attributes,omitempty"`
+
+	// Timestamps
+	WhenCreated time.Time `json:"whenCreated"`
+	WhenChanged time.Time `json:"whenChanged"`
+}
+
+// CreateObjectRequest represents a request to create a new object.
+type CreateObjectRequest struct {
+	ObjectClass  string              `json:"objectClass"`          // Required: Structural object class
+	RDNAttribute string              `json:"rdnAttribute"`         // Required: Naming attribute type (e.g. cn)
+	Name         string              `json:"name"`                 // Required: Naming attribute value
+	Container    string              `json:"container"`            // Required: Parent container DN
+	Attributes   map[string][]string `json:"attributes,omitempty"` // Optional: Attributes, binary values base64-encoded
+}
+
+// UpdateObjectRequest represents a request to update an existing object.
+type UpdateObjectRequest struct {
+	Name       *string             `json:"name,omitempty"`       // Optional: New naming attribute value (triggers rename)
+	Container  *string             `json:"container,omitempty"`  // Optional: New container DN (triggers move)
+	Attributes map[string][]string `json:"attributes,omitempty"` // Optional: Attributes, binary values base64-encoded (empty list = delete)
+}
+
+// ObjectManager handles operations on Active Directory objects of any class.
+// Attribute values are converted according to the syntax the schema defines
+// for each attribute: binary values are exchanged base64-encoded, and
+// distinguished names compare case-insensitively.
+type ObjectManager struct {
+	ctx         context.Context
+	client      Client
+	guidHandler *GUIDHandler
+	baseDN      string
+	timeout     time.Duration
+
+	// attributes are the attributes read into Object.Attributes
+	attributes []string
+
+	// schemas caches attribute definitions, keyed by lowercased name
+	schemas map[string]AttributeSchema
+}
+
+// NewObjectManager creates a new object manager instance.
+func NewObjectManager(ctx context.Context, client Client, baseDN string) *ObjectManager {
+	return &ObjectManager{
+		ctx:         ctx,
+		client:      client,
+		guidHandler: NewGUIDHandler(),
+		baseDN:      baseDN,
+		timeout:     30 * time.Second,
+		schemas:     make(map[string]AttributeSchema),
+	}
+}
+
+// SetTimeout sets the LDAP operation timeout.
+func (om *ObjectManager) SetTimeout(timeout time.Duration) {
+	om.timeout = timeout
+}
+
+// SetAttributes sets the attributes read into Object.Attributes.
+func (om *ObjectManager) SetAttributes(names []string) {
+	om.attributes = names
+}
+
+// getAllObjectAttributes returns the LDAP attributes to retrieve for objects.
+func (om *ObjectManager) getAllObjectAttributes() []string {
+	return append([]string{
+		"objectGUID", "distinguishedName", "objectClass", "whenCreated", "whenChanged",
+	}, om.attributes...)
+}
+
+// CreateObject creates a new object.
+func (om *ObjectManager) CreateObject(req *CreateObjectRequest) (*Object, error) {
+	if req == nil {
+		return nil, fmt.Errorf("create object request cannot be nil")
+	}
+	if req.ObjectClass == "" || req.RDNAttribute == "" || req.Name == "" {
+		return nil, fmt.Errorf("object class, RDN attribute and name are required")
+	}
+	if _, err := ldap.ParseDN(req.Container); err != nil {
+		return nil, fmt.Errorf("invalid container DN syntax: %w", err)
+	}
+
+	objectDN := fmt.Sprintf("%s=%s,%s", req.RDNAttribute, ldap.EscapeDN(req.Name), req.Container)
+
+	attributes := map[string][]string{
+		"objectClass":    {req.ObjectClass},
+		req.RDNAttribute: {req.Name},
+	}
+	encoded, err := om.encodeAttributes(req.Attributes)
+	if err != nil {
+		return nil, WrapError("encode_object_attributes", err)
+	}
+	for name, values := range encoded {
+		if len(values) > 0 {
+			attributes[name] = values
+		}
+	}
+
+	if err := om.client.Add(om.ctx, &AddRequest{DN: objectDN, Attributes: attributes}); err != nil {
+		return nil, WrapError("create_object", err)
+	}
+
+	object, err := om.GetObjectByDN(objectDN)
+	if err != nil {
+		return nil, WrapError("retrieve_created_object", err)
+	}
+
+	return object, nil
+}
+
+// GetObject retrieves an object by its objectGUID.
+func (om *ObjectManager) GetObject(guid string) (*Object, error) {
+	if guid == "" {
+		return nil, fmt.Errorf("object GUID cannot be empty")
+	}
+
+	if !om.guidHandler.IsValidGUID(guid) {
+		return nil, fmt.Errorf("invalid GUID format: %s", guid)
+	}
+
+	searchReq, err := om.guidHandler.GenerateGUIDSearchRequest(om.baseDN, guid)
+	if err != nil {
+		return nil, WrapError("generate_guid_search", err)
+	}
+	searchReq.Attributes = om.getAllObjectAttributes()
+	searchReq.TimeLimit = om.timeout
+
+	result, err := om.client.Search(om.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_object_by_guid", err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_object", "object with GUID %s not found", guid)
+	}
+
+	return om.entryToObject(result.Entries[0])
+}
+
+// GetObjectByDN retrieves an object by its distinguished name.
+func (om *ObjectManager) GetObjectByDN(dn string) (*Object, error) {
+	if dn == "" {
+		return nil, fmt.Errorf("object DN cannot be empty")
+	}
+
+	searchReq := &SearchRequest{
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=*)",
+		Attributes: om.getAllObjectAttributes(),
+		SizeLimit:  1,
+		TimeLimit:  om.timeout,
+	}
+
+	result, err := om.client.Search(om.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_object_by_dn", err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_object_by_dn", "object not found at DN: %s", dn)
+	}
+
+	return om.entryToObject(result.Entries[0])
+}
+
+// UpdateObject renames or moves an object and updates its attributes.
+func (om *ObjectManager) UpdateObject(guid string, req *UpdateObjectRequest) (*Object, error) {
+	if req == nil {
+		return nil, fmt.Errorf("update object request cannot be nil")
+	}
+
+	currentObject, err := om.GetObject(guid)
+	if err != nil {
+		return nil, WrapError("get_current_object", err)
+	}
+
+	// Handle name and/or container changes (both require ModifyDN)
+	needsRename := req.Name != nil && *req.Name != currentObject.Name
+	needsMove := req.Container != nil && !DNEqual(*req.Container, currentObject.Container)
+
+	if needsRename || needsMove {
+		modifyDNReq := &ModifyDNRequest{
+			DN:           currentObject.DistinguishedName,
+			NewRDN:       fmt.Sprintf("%s=%s", currentObject.RDNAttribute, ldap.EscapeDN(currentObject.Name)),
+			DeleteOldRDN: true,
+		}
+		if needsRename {
+			modifyDNReq.NewRDN = fmt.Sprintf("%s=%s", currentObject.RDNAttribute, ldap.EscapeDN(*req.Name))
+		}
+		if needsMove {
+			modifyDNReq.NewSuperior = *req.Container
+		}
+
+		if err := om.client.ModifyDN(om.ctx, modifyDNReq); err != nil {
+			return nil, WrapError("rename_or_move_object", err)
+		}
+
+		currentObject, err = om.GetObject(guid)
+		if err != nil {
+			return nil, WrapError("refresh_object_after_move", err)
+		}
+	}
+
+	modReq := &ModifyRequest{
+		DN:                currentObject.DistinguishedName,
+		ReplaceAttributes: make(map[string][]string),
+	}
+	hasChanges, err := om.calculateAttributeChanges(modReq, req.Attributes, currentObject.Attributes)
+	if err != nil {
+		return nil, WrapError("encode_object_attributes", err)
+	}
+
+	if !hasChanges {
+		return currentObject, nil
+	}
+
+	if err := om.client.Modify(om.ctx, modReq); err != nil {
+		return nil, WrapError("modify_object", err)
+	}
+
+	updatedObject, err := om.GetObject(guid)
+	if err != nil {
+		return nil, WrapError("retrieve_updated_object", err)
+	}
+
+	return updatedObject, nil
+}
+
+// DeleteObject deletes an object. Deleting an object that no longer exists
+// succeeds.
+func (om *ObjectManager) DeleteObject(guid string) error {
+	object, err := om.GetObject(guid)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil
+		}
+		return WrapError("get_object_for_deletion", err)
+	}
+
+	if err := om.client.Delete(om.ctx, object.DistinguishedName); err != nil {
+		return WrapError("delete_object", err)
+	}
+
+	return nil
+}
+
+// AttributeValuesEqual reports whether two value lists of an attribute hold
+// the same values, ignoring order. Distinguished names are compared
+// case-insensitively. The attribute's schema must have been loaded by a
+// previous operation; otherwise values are compared exactly.
+func (om *ObjectManager) AttributeValuesEqual(name string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	schema := om.schemas[strings.ToLower(name)]
+	if !schema.IsDN() {
+		return SameAttributeValues(a, b)
+	}
+
+	remaining := slices.Clone(b)
+	for _, value := range a {
+		i := slices.IndexFunc(remaining, func(other string) bool { return DNEqual(value, other) })
+		if i < 0 {
+			return false
+		}
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	return true
+}
+
+// entryToObject converts an LDAP entry to an Object.
+func (om *ObjectManager) entryToObject(entry *ldap.Entry) (*Object, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("LDAP entry cannot be nil")
+	}
+
+	guid, err := om.guidHandler.ExtractGUID(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract GUID: %w", err)
+	}
+
+	object := &Object{
+		ObjectGUID:        guid,
+		DistinguishedName: entry.DN,
+		ObjectClass:       entry.GetAttributeValues("objectClass"),
+	}
+
+	parsedDN, err := ldap.ParseDN(entry.DN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DN %q: %w", entry.DN, err)
+	}
+	if len(parsedDN.RDNs) == 0 || len(parsedDN.RDNs[0].Attributes) == 0 {
+		return nil, fmt.Errorf("DN %q has no RDN", entry.DN)
+	}
+	object.RDNAttribute = parsedDN.RDNs[0].Attributes[0].Type
+	object.Name = parsedDN.RDNs[0].Attributes[0].Value
+	if object.Container, err = GetDNParent(entry.DN); err != nil {
+		return nil, err
+	}
+
+	if object.Attributes, err = om.readAttributes(entry); err != nil {
+		return nil, err
+	}
+
+	if whenCreated := entry.GetAttributeValue("whenCreated"); whenCreated != "" {
+		if t, err := time.Parse("20060102150405.0Z", whenCreated); err == nil {
+			object.WhenCreated = t
+		}
+	}
+	if whenChanged := entry.GetAttributeValue("whenChanged"); whenChanged != "" {
+		if t, err := time.Parse("20060102150405.0Z", whenChanged); err == nil {
+			object.WhenChanged = t
+		}
+	}
+
+	return object, nil
+}
+
+// readAttributes returns the values of the requested attributes from an
+// entry, matching names case-insensitively and base64-encoding binary
+// values. The result is keyed by the requested names and omits attributes
+// without values.
+func (om *ObjectManager) readAttributes(entry *ldap.Entry) (map[string][]string, error) {
+	if len(om.attributes) == 0 {
+		return nil, nil
+	}
+
+	schemas, err := om.lookupSchemas(om.attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]string, len(om.attributes))
+	for _, name := range om.attributes {
+		for _, attr := range entry.Attributes {
+			if !strings.EqualFold(attr.Name, name) || len(attr.Values) == 0 {
+				continue
+			}
+			if schemas[strings.ToLower(name)].IsBinary() {
+				encoded := make([]string, len(attr.ByteValues))
+				for i, raw := range attr.ByteValues {
+					encoded[i] = base64.StdEncoding.EncodeToString(raw)
+				}
+				values[name] = encoded
+			} else {
+				values[name] = attr.Values
+			}
+			break
+		}
+	}
+	return values, nil
+}
+
+// calculateAttributeChanges adds a modification for each requested attribute
+// whose values differ from the current ones, following the semantics of
+// calculateExtraAttributeChanges. Returns true if any change was added.
+func (om *ObjectManager) calculateAttributeChanges(modReq *ModifyRequest, requested, current map[string][]string) (bool, error) {
+	changed := make(map[string][]string)
+	for name, values := range requested {
+		if !om.AttributeValuesEqual(name, values, current[name]) {
+			changed[name] = values
+		}
+	}
+
+	encoded, err := om.encodeAttributes(changed)
+	if err != nil {
+		return false, err
+	}
+	for name, values := range encoded {
+		if len(values) == 0 {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, name)
+		} else {
+			modReq.ReplaceAttributes[name] = values
+		}
+	}
+	return len(encoded) > 0, nil
+}
+
+// encodeAttributes converts attribute values to their LDAP form, decoding
+// base64 values of binary attributes.
+func (om *ObjectManager) encodeAttributes(attributes map[string][]string) (map[string][]string, error) {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	schemas, err := om.lookupSchemas(names)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make(map[string][]string, len(attributes))
+	for name, values := range attributes {
+		if !schemas[strings.ToLower(name)].IsBinary() {
+			encoded[name] = values
+			continue
+		}
+		raw := make([]string, len(values))
+		for i, value := range values {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s is binary and its values must be base64-encoded: %w", name, err)
+			}
+			raw[i] = string(decoded)
+		}
+		encoded[name] = raw
+	}
+	return encoded, nil
+}
+
+// lookupSchemas returns the schema definitions of the named attributes,
+// reading those not yet cached from the schema naming context.
+func (om *ObjectManager) lookupSchemas(names []string) (map[string]AttributeSchema, error) {
+	var missing []string
+	for _, name := range names {
+		if _, ok := om.schemas[strings.ToLower(name)]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		schemas, err := LookupAttributeSchema(om.ctx, om.client, missing)
+		if err != nil {
+			return nil, WrapError("lookup_attribute_schema", err)
+		}
+		for _, name := range missing {
+			schema, ok := schemas[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("attribute %s is not defined in the schema", name)
+			}
+			om.schemas[strings.ToLower(name)] = schema
+		}
+	}
+
+	return om.schemas, nil
+}
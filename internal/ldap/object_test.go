@@ -0,0 +1,186 @@
+package ldap
+
+import (
+	"encoding/base64"
+	"slices"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testObjectSchemaDN = "CN=Schema,CN=Configuration,DC=test,DC=local"
+
+// createTestObjectManager returns an ObjectManager whose mock client serves
+// the schema definitions of the printQueue attributes used in these tests.
+func createTestObjectManager(t *testing.T) (*ObjectManager, *MockClient) {
+	t.Helper()
+
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{SchemaNamingContext: testObjectSchemaDN}, nil).Maybe()
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == testObjectSchemaDN
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("CN=Location,"+testObjectSchemaDN, map[string][]string{
+			"lDAPDisplayName": {"location"},
+			"attributeSyntax": {"2.5.5.12"},
+			"isSingleValued":  {"TRUE"},
+		}),
+		ldap.NewEntry("CN=Managed-By,"+testObjectSchemaDN, map[string][]string{
+			"lDAPDisplayName": {"managedBy"},
+			"attributeSyntax": {"2.5.5.1"},
+			"isSingleValued":  {"TRUE"},
+		}),
+		ldap.NewEntry("CN=Print-Bin-Names,"+testObjectSchemaDN, map[string][]string{
+			"lDAPDisplayName": {"printBinNames"},
+			"attributeSyntax": {"2.5.5.12"},
+		}),
+		ldap.NewEntry("CN=Print-Attributes-Blob,"+testObjectSchemaDN, map[string][]string{
+			"lDAPDisplayName": {"thumbnailPhoto"},
+			"attributeSyntax": {"2.5.5.10"},
+			"isSingleValued":  {"TRUE"},
+		}),
+	}}, nil).Maybe()
+
+	return NewObjectManager(t.Context(), client, "DC=test,DC=local"), client
+}
+
+// createMockObjectEntry returns a printQueue entry with the given attributes.
+func createMockObjectEntry(t *testing.T, guid, dn string, attributes ...*ldap.EntryAttribute) *ldap.Entry {
+	t.Helper()
+
+	guidBytes, err := NewGUIDHandler().StringToGUIDBytes(guid)
+	require.NoError(t, err)
+
+	return &ldap.Entry{
+		DN: dn,
+		Attributes: append([]*ldap.EntryAttribute{
+			{Name: "objectGUID", ByteValues: [][]byte{guidBytes}},
+			{Name: "distinguishedName", Values: []string{dn}},
+			{Name: "objectClass", Values: []string{"top", "leaf", "connectionPoint", "printQueue"}},
+			{Name: "whenCreated", Values: []string{"20240101120000.0Z"}},
+			{Name: "whenChanged", Values: []string{"20240101120000.0Z"}},
+		}, attributes...),
+	}
+}
+
+func TestCreateObject(t *testing.T) {
+	om, client := createTestObjectManager(t)
+	om.SetAttributes([]string{"location", "thumbnailPhoto"})
+
+	testGUID := "12345678-1234-1234-1234-123456789012"
+	testDN := "CN=PRN-01,OU=Printers,DC=test,DC=local"
+	blob := []byte{0x00, 0x01, 0xfe, 0xff}
+
+	client.On("Add", mock.Anything, mock.MatchedBy(func(req *AddRequest) bool {
+		return DNEqual(req.DN, testDN) &&
+			slices.Equal(req.Attributes["objectClass"], []string{"printQueue"}) &&
+			slices.Equal(req.Attributes["cn"], []string{"PRN-01"}) &&
+			slices.Equal(req.Attributes["location"], []string{"Building 1"}) &&
+			slices.Equal(req.Attributes["thumbnailPhoto"], []string{string(blob)})
+	})).Return(nil).Once()
+
+	client.On("Search", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return DNEqual(req.BaseDN, testDN) && req.Scope == ScopeBaseObject
+	})).Return(&SearchResult{Entries: []*ldap.Entry{createMockObjectEntry(t, testGUID, testDN,
+		&ldap.EntryAttribute{Name: "location", Values: []string{"Building 1"}, ByteValues: [][]byte{[]byte("Building 1")}},
+		&ldap.EntryAttribute{Name: "thumbnailPhoto", Values: []string{string(blob)}, ByteValues: [][]byte{blob}},
+	)}}, nil).Once()
+
+	object, err := om.CreateObject(&CreateObjectRequest{
+		ObjectClass:  "printQueue",
+		RDNAttribute: "cn",
+		Name:         "PRN-01",
+		Container:    "OU=Printers,DC=test,DC=local",
+		Attributes: map[string][]string{
+			"location":       {"Building 1"},
+			"thumbnailPhoto": {base64.StdEncoding.EncodeToString(blob)},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, testGUID, object.ObjectGUID)
+	assert.Equal(t, "CN", object.RDNAttribute)
+	assert.Equal(t, "PRN-01", object.Name)
+	assert.Equal(t, "OU=Printers,DC=test,DC=local", object.Container)
+	assert.Equal(t, map[string][]string{
+		"location":       {"Building 1"},
+		"thumbnailPhoto": {base64.StdEncoding.EncodeToString(blob)},
+	}, object.Attributes)
+	client.AssertExpectations(t)
+}
+
+func TestCreateObject_InvalidBase64(t *testing.T) {
+	om, client := createTestObjectManager(t)
+
+	_, err := om.CreateObject(&CreateObjectRequest{
+		ObjectClass:  "printQueue",
+		RDNAttribute: "cn",
+		Name:         "PRN-01",
+		Container:    "OU=Printers,DC=test,DC=local",
+		Attributes:   map[string][]string{"thumbnailPhoto": {"not base64!"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "base64")
+	client.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestUpdateObject(t *testing.T) {
+	om, client := createTestObjectManager(t)
+	om.SetAttributes([]string{"managedBy", "printBinNames", "location"})
+
+	testGUID := "12345678-1234-1234-1234-123456789012"
+	oldDN := "CN=PRN-01,OU=Printers,DC=test,DC=local"
+	newDN := "CN=PRN-02,OU=Printers,DC=test,DC=local"
+	attributes := []*ldap.EntryAttribute{
+		{Name: "managedBy", Values: []string{"CN=Print Operators,CN=Builtin,DC=test,DC=local"}},
+		{Name: "printBinNames", Values: []string{"Tray 1", "Tray 2"}},
+		{Name: "location", Values: []string{"Building 1"}},
+	}
+
+	client.On("Search", mock.Anything, mock.Anything).
+		Return(&SearchResult{Entries: []*ldap.Entry{createMockObjectEntry(t, testGUID, oldDN, attributes...)}}, nil).Once()
+	client.On("ModifyDN", mock.Anything, mock.MatchedBy(func(req *ModifyDNRequest) bool {
+		return req.DN == oldDN && req.NewRDN == "CN=PRN-02" && req.DeleteOldRDN && req.NewSuperior == ""
+	})).Return(nil).Once()
+	client.On("Search", mock.Anything, mock.Anything).
+		Return(&SearchResult{Entries: []*ldap.Entry{createMockObjectEntry(t, testGUID, newDN, attributes...)}}, nil)
+	client.On("Modify", mock.Anything, mock.MatchedBy(func(req *ModifyRequest) bool {
+		return req.DN == newDN &&
+			len(req.ReplaceAttributes) == 0 &&
+			slices.Equal(req.DeleteAttributes, []string{"location"})
+	})).Return(nil).Once()
+
+	newName := "PRN-02"
+	_, err := om.UpdateObject(testGUID, &UpdateObjectRequest{
+		Name: &newName,
+		Attributes: map[string][]string{
+			// DNs compare case-insensitively and values ignore order
+			"managedBy":     {"cn=print operators,cn=builtin,dc=test,dc=local"},
+			"printBinNames": {"Tray 2", "Tray 1"},
+			"location":      {},
+		},
+	})
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestDeleteObject_NotFound(t *testing.T) {
+	om, client := createTestObjectManager(t)
+
+	client.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{}, nil).Once()
+
+	require.NoError(t, om.DeleteObject("12345678-1234-1234-1234-123456789012"))
+	client.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestEntryToObject_UnknownAttribute(t *testing.T) {
+	om, _ := createTestObjectManager(t)
+	om.SetAttributes([]string{"noSuchAttribute"})
+
+	_, err := om.entryToObject(createMockObjectEntry(t, "12345678-1234-1234-1234-123456789012", "CN=PRN-01,OU=Printers,DC=test,DC=local"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not defined in the schema")
+}
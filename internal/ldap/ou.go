@@ -31,6 +31,9 @@ type OU struct {
 	// Timestamps
 	WhenCreated time.Time `json:"whenCreated"`
 	WhenChanged time.Time `json:"whenChanged"`
+
+	// Attributes not otherwise modeled, as requested via SetExtraAttributes
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"`
 }
 
 // CreateOURequest represents a request to create a new OU.
@@ -40,6 +43,8 @@ type CreateOURequest struct {
 	Description string `json:"description"`         // Optional: OU description
 	Protected   bool   `json:"protected"`           // Optional: Enable OU protection
 	ManagedBy   string `json:"managedBy,omitempty"` // Optional: DN of manager
+
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"` // Optional: Attributes not otherwise modeled
 }
 
 // UpdateOURequest represents a request to update an existing OU.
@@ -49,6 +54,8 @@ type UpdateOURequest struct {
 	Protected   *bool   `json:"protected,omitempty"`   // Optional: Change protection status
 	ManagedBy   *string `json:"managedBy,omitempty"`   // Optional: DN of manager (nil = no change, empty string = clear)
 	Path        *string `json:"path,omitempty"`        // Optional: New parent DN (triggers OU move)
+
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"` // Optional: Attributes not otherwise modeled (empty list = delete)
 }
 
 // OUManager handles Active Directory organizational unit operations.
@@ -58,6 +65,9 @@ type OUManager struct {
 	guidHandler *GUIDHandler
 	baseDN      string
 	timeout     time.Duration
+
+	// extraAttributes are additional attributes read into OU.ExtraAttributes
+	extraAttributes []string
 }
 
 // NewOUManager creates a new OU manager instance.
@@ -76,14 +86,19 @@ func (om *OUManager) SetTimeout(timeout time.Duration) {
 	om.timeout = timeout
 }
 
+// SetExtraAttributes sets the additional attributes read into OU.ExtraAttributes.
+func (om *OUManager) SetExtraAttributes(names []string) {
+	om.extraAttributes = names
+}
+
 // getAllOUAttributes returns the standard set of LDAP attributes to retrieve for OUs.
 // This ensures consistency across all OU search and retrieval operations.
 func (om *OUManager) getAllOUAttributes() []string {
-	return []string{
+	return append([]string{
 		"objectGUID", "distinguishedName", "ou", "name",
 		"description", "ntSecurityDescriptor", "managedBy",
 		"whenCreated", "whenChanged",
-	}
+	}, om.extraAttributes...)
 }
 
 // BuildOUDN constructs a proper Distinguished Name for an OU.
@@ -170,6 +185,8 @@ func (om *OUManager) CreateOU(req *CreateOURequest) (*OU, error) {
 		attributes["managedBy"] = []string{req.ManagedBy}
 	}
 
+	addExtraAttributes(attributes, req.ExtraAttributes)
+
 	// Create the OU
 	addReq := &AddRequest{
 		DN:         ouDN,
@@ -219,10 +236,7 @@ func (om *OUManager) GetOU(guid string) (*OU, error) {
 	}
 
 	// Expand attributes to include all OU-relevant fields
-	searchReq.Attributes = []string{
-		"objectGUID", "distinguishedName", "ou", "name",
-		"description", "ntSecurityDescriptor", "whenCreated", "whenChanged", "managedBy",
-	}
+	searchReq.Attributes = om.getAllOUAttributes()
 	searchReq.TimeLimit = om.timeout
 
 	// Add the organizationalUnit filter
@@ -257,15 +271,12 @@ func (om *OUManager) GetOUByDN(dn string) (*OU, error) {
 // getOUByDN is the internal implementation for DN-based OU retrieval.
 func (om *OUManager) getOUByDN(dn string) (*OU, error) {
 	searchReq := &SearchRequest{
-		BaseDN: dn,
-		Scope:  ScopeBaseObject,
-		Filter: "(objectClass=organizationalUnit)",
-		Attributes: []string{
-			"objectGUID", "distinguishedName", "ou", "name",
-			"description", "ntSecurityDescriptor", "whenCreated", "whenChanged", "managedBy",
-		},
-		SizeLimit: 1,
-		TimeLimit: om.timeout,
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=organizationalUnit)",
+		Attributes: om.getAllOUAttributes(),
+		SizeLimit:  1,
+		TimeLimit:  om.timeout,
 	}
 
 	result, err := om.client.Search(om.ctx, searchReq)
@@ -353,6 +364,11 @@ func (om *OUManager) UpdateOU(guid string, req *UpdateOURequest) (*OU, error) {
 		hasChanges = true
 	}
 
+	// Handle extra attribute changes
+	if calculateExtraAttributeChanges(modReq, req.ExtraAttributes, currentOU.ExtraAttributes) {
+		hasChanges = true
+	}
+
 	// Handle protection change
 	if req.Protected != nil && *req.Protected != currentOU.Protected {
 		if err := om.SetOUProtection(currentOU.DistinguishedName, *req.Protected); err != nil {
@@ -657,6 +673,7 @@ func (om *OUManager) entryToOU(entry *ldap.Entry) (*OU, error) {
 
 	ou.Description = entry.GetAttributeValue("description")
 	ou.ManagedBy = entry.GetAttributeValue("managedBy")
+	ou.ExtraAttributes = readExtraAttributes(entry, om.extraAttributes)
 
 	// Extract parent from DN
 	if ou.DistinguishedName != "" {
@@ -1,7 +1,11 @@
 package ldap
 
 import (
+	"crypto/rand"
 	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
 	"unicode/utf16"
 )
 
@@ -25,3 +29,95 @@ func EncodeADPassword(password string) []byte {
 
 	return result
 }
+
+// Character classes for generated passwords. Look-alike characters are left
+// out, as are quotes, backslashes and dollar signs that need escaping in
+// shells and HCL.
+var passwordCharacterClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!#%+-=?@^_~",
+}
+
+// MaxGeneratedPasswordLength is the longest password GeneratePassword returns.
+const MaxGeneratedPasswordLength = 256
+
+// GeneratePassword returns a random password of at least length characters
+// that satisfies policy. It always contains every character class, which
+// meets the Windows complexity rule of three out of four categories, and when
+// complexity is enabled it never contains the account's names.
+func GeneratePassword(length int, policy *PasswordPolicy) (string, error) {
+	if policy != nil {
+		length = max(length, policy.MinLength)
+	}
+	length = max(length, len(passwordCharacterClasses))
+	if length > MaxGeneratedPasswordLength {
+		return "", errors.New("password policy requires a password longer than the maximum supported length")
+	}
+
+	all := strings.Join(passwordCharacterClasses, "")
+	for range 100 {
+		password := make([]byte, length)
+		for i := range password {
+			class := all
+			if i < len(passwordCharacterClasses) {
+				class = passwordCharacterClasses[i]
+			}
+			c, err := randomChar(class)
+			if err != nil {
+				return "", err
+			}
+			password[i] = c
+		}
+		if err := shuffle(password); err != nil {
+			return "", err
+		}
+
+		if policy == nil || !policy.ComplexityEnabled || !containsAccountName(string(password), policy.AccountNames) {
+			return string(password), nil
+		}
+	}
+	return "", errors.New("could not generate a password that avoids the account names")
+}
+
+// randomChar returns a uniformly chosen character from chars.
+func randomChar(chars string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n.Int64()], nil
+}
+
+// shuffle permutes b uniformly at random.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := n.Int64()
+		b[i], b[j] = b[j], b[i]
+	}
+	return nil
+}
+
+// containsAccountName applies the Windows complexity rule on account names:
+// the password must not contain the sAMAccountName, or any token of three or
+// more characters of the display name, ignoring case.
+func containsAccountName(password string, names []string) bool {
+	password = strings.ToLower(password)
+	for _, name := range names {
+		tokens := strings.FieldsFunc(name, func(r rune) bool {
+			return strings.ContainsRune(",.-_# \t", r)
+		})
+		tokens = append(tokens, name)
+		for _, token := range tokens {
+			if len(token) >= 3 && strings.Contains(password, strings.ToLower(token)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package ldap
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// domainPasswordComplex is the DOMAIN_PASSWORD_COMPLEX flag of pwdProperties.
+const domainPasswordComplex = 0x1
+
+// PasswordPolicy is the password policy in effect for an account or, when no
+// account is given, for the domain.
+type PasswordPolicy struct {
+	Source            string // DN of the password settings object or domain the policy comes from
+	MinLength         int
+	ComplexityEnabled bool
+	HistoryLength     int
+
+	// UnreadablePSO is the DN of a password settings object that applies to
+	// the account but could not be read, in which case the domain policy is
+	// reported instead. Reading PSOs requires delegated permissions.
+	UnreadablePSO string
+
+	// AccountNames are the sAMAccountName and displayName of the account,
+	// which a complex password must not contain.
+	AccountNames []string
+}
+
+// PasswordPolicyManager reads effective password policies.
+type PasswordPolicyManager struct {
+	ctx     context.Context
+	client  Client
+	baseDN  string
+	timeout time.Duration
+	users   *UserManager
+}
+
+// NewPasswordPolicyManager creates a new password policy manager instance.
+func NewPasswordPolicyManager(ctx context.Context, client Client, baseDN string, cacheManager *CacheManager) *PasswordPolicyManager {
+	return &PasswordPolicyManager{
+		ctx:     ctx,
+		client:  client,
+		baseDN:  baseDN,
+		timeout: 30 * time.Second,
+		users:   NewUserManager(ctx, client, baseDN, cacheManager),
+	}
+}
+
+// GetPasswordPolicy returns the password policy in effect for target: a user
+// identified by DN, GUID, SID, UPN or SAM account name, or the DN of an OU or
+// container. Fine-grained password policies apply only to users and groups,
+// so for an OU, a container or an empty target the domain policy is returned.
+// For a user, the policy comes from the PSO named by msDS-ResultantPSO.
+func (pm *PasswordPolicyManager) GetPasswordPolicy(target string) (*PasswordPolicy, error) {
+	if target == "" {
+		return pm.domainPolicy()
+	}
+
+	targetDN := target
+	if pm.users.normalizer.DetectIdentifierType(target) != IdentifierTypeDN {
+		user, err := pm.users.GetUser(target)
+		if err != nil {
+			return nil, WrapError("get_password_policy_target", err)
+		}
+		targetDN = user.DistinguishedName
+	}
+
+	result, err := pm.client.Search(pm.ctx, &SearchRequest{
+		BaseDN:     targetDN,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=*)",
+		Attributes: []string{"objectClass", "sAMAccountName", "displayName", "msDS-ResultantPSO"},
+		SizeLimit:  1,
+		TimeLimit:  pm.timeout,
+	})
+	if err != nil {
+		return nil, WrapError("search_password_policy_target", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_password_policy", "object not found at DN: %s", targetDN)
+	}
+	entry := result.Entries[0]
+
+	if !slices.Contains(entry.GetAttributeValues("objectClass"), "user") {
+		return pm.domainPolicy()
+	}
+
+	var names []string
+	for _, name := range []string{entry.GetAttributeValue("sAMAccountName"), entry.GetAttributeValue("displayName")} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	pso := entry.GetAttributeValue("msDS-ResultantPSO")
+	var policy *PasswordPolicy
+	if pso != "" {
+		policy, err = pm.psoPolicy(pso)
+		if err != nil {
+			tflog.SubsystemWarn(pm.ctx, "ldap", "Cannot read password settings object, using domain policy", map[string]any{
+				"pso":   pso,
+				"error": err.Error(),
+			})
+		}
+	}
+	if policy == nil {
+		if policy, err = pm.domainPolicy(); err != nil {
+			return nil, err
+		}
+		if pso != "" {
+			policy.UnreadablePSO = pso
+		}
+	}
+	policy.AccountNames = names
+	return policy, nil
+}
+
+// domainPolicy reads the default password policy from the domain head.
+func (pm *PasswordPolicyManager) domainPolicy() (*PasswordPolicy, error) {
+	domainDN := pm.baseDN
+	if rootDSE, err := pm.client.GetRootDSE(pm.ctx); err == nil && rootDSE.DefaultNamingContext != "" {
+		domainDN = rootDSE.DefaultNamingContext
+	}
+
+	result, err := pm.client.Search(pm.ctx, &SearchRequest{
+		BaseDN:     domainDN,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=domain)",
+		Attributes: []string{"minPwdLength", "pwdProperties", "pwdHistoryLength"},
+		SizeLimit:  1,
+		TimeLimit:  pm.timeout,
+	})
+	if err != nil {
+		return nil, WrapError("search_domain_password_policy", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_domain_password_policy", "domain not found at DN: %s", domainDN)
+	}
+	entry := result.Entries[0]
+
+	properties := atoiOrZero(entry.GetAttributeValue("pwdProperties"))
+	return &PasswordPolicy{
+		Source:            domainDN,
+		MinLength:         atoiOrZero(entry.GetAttributeValue("minPwdLength")),
+		ComplexityEnabled: properties&domainPasswordComplex != 0,
+		HistoryLength:     atoiOrZero(entry.GetAttributeValue("pwdHistoryLength")),
+	}, nil
+}
+
+// psoPolicy reads a password settings object.
+func (pm *PasswordPolicyManager) psoPolicy(dn string) (*PasswordPolicy, error) {
+	result, err := pm.client.Search(pm.ctx, &SearchRequest{
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=msDS-PasswordSettings)",
+		Attributes: []string{"msDS-MinimumPasswordLength", "msDS-PasswordComplexityEnabled", "msDS-PasswordHistoryLength"},
+		SizeLimit:  1,
+		TimeLimit:  pm.timeout,
+	})
+	if err != nil {
+		return nil, WrapError("search_password_settings_object", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_password_settings_object", "password settings object not readable at DN: %s", dn)
+	}
+	entry := result.Entries[0]
+
+	return &PasswordPolicy{
+		Source:            dn,
+		MinLength:         atoiOrZero(entry.GetAttributeValue("msDS-MinimumPasswordLength")),
+		ComplexityEnabled: strings.EqualFold(entry.GetAttributeValue("msDS-PasswordComplexityEnabled"), "TRUE"),
+		HistoryLength:     atoiOrZero(entry.GetAttributeValue("msDS-PasswordHistoryLength")),
+	}, nil
+}
+
+// atoiOrZero parses an integer attribute value, treating a missing or
+// malformed value as zero.
+func atoiOrZero(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return n
+}
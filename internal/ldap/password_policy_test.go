@@ -0,0 +1,139 @@
+package ldap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPolicyDomainDN = "DC=example,DC=com"
+	testPolicyUserDN   = "CN=Jane Doe,OU=Users,DC=example,DC=com"
+	testPolicyPSODN    = "CN=Admins PSO,CN=Password Settings Container,CN=System,DC=example,DC=com"
+)
+
+// searchBase matches a search request by its base DN.
+func searchBase(dn string) any {
+	return mock.MatchedBy(func(r *SearchRequest) bool { return r.BaseDN == dn })
+}
+
+func entryResult(dn string, attrs map[string][]string) *SearchResult {
+	return &SearchResult{Entries: []*ldap.Entry{ldap.NewEntry(dn, attrs)}, Total: 1}
+}
+
+// newTestPolicyClient returns a mock client serving the domain policy:
+// minimum length 7 with complexity enabled.
+func newTestPolicyClient() *MockClient {
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{DefaultNamingContext: testPolicyDomainDN}, nil)
+	client.On("Search", mock.Anything, searchBase(testPolicyDomainDN)).Return(entryResult(testPolicyDomainDN, map[string][]string{
+		"minPwdLength":     {"7"},
+		"pwdProperties":    {"1"},
+		"pwdHistoryLength": {"24"},
+	}), nil)
+	return client
+}
+
+func userPolicyEntry(pso string) *SearchResult {
+	attrs := map[string][]string{
+		"objectClass":    {"top", "person", "organizationalPerson", "user"},
+		"sAMAccountName": {"jdoe"},
+		"displayName":    {"Jane Doe"},
+	}
+	if pso != "" {
+		attrs["msDS-ResultantPSO"] = []string{pso}
+	}
+	return entryResult(testPolicyUserDN, attrs)
+}
+
+func TestPasswordPolicyManager_DomainPolicy(t *testing.T) {
+	client := newTestPolicyClient()
+	pm := NewPasswordPolicyManager(t.Context(), client, "OU=Managed,"+testPolicyDomainDN, nil)
+
+	policy, err := pm.GetPasswordPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, &PasswordPolicy{
+		Source:            testPolicyDomainDN,
+		MinLength:         7,
+		ComplexityEnabled: true,
+		HistoryLength:     24,
+	}, policy)
+}
+
+func TestPasswordPolicyManager_UserWithPSO(t *testing.T) {
+	client := newTestPolicyClient()
+	client.On("Search", mock.Anything, searchBase(testPolicyUserDN)).Return(userPolicyEntry(testPolicyPSODN), nil)
+	client.On("Search", mock.Anything, searchBase(testPolicyPSODN)).Return(entryResult(testPolicyPSODN, map[string][]string{
+		"msDS-MinimumPasswordLength":     {"20"},
+		"msDS-PasswordComplexityEnabled": {"FALSE"},
+		"msDS-PasswordHistoryLength":     {"12"},
+	}), nil)
+	pm := NewPasswordPolicyManager(t.Context(), client, testPolicyDomainDN, nil)
+
+	policy, err := pm.GetPasswordPolicy(testPolicyUserDN)
+	require.NoError(t, err)
+	assert.Equal(t, testPolicyPSODN, policy.Source)
+	assert.Equal(t, 20, policy.MinLength)
+	assert.False(t, policy.ComplexityEnabled)
+	assert.Equal(t, 12, policy.HistoryLength)
+	assert.Equal(t, []string{"jdoe", "Jane Doe"}, policy.AccountNames)
+	assert.Empty(t, policy.UnreadablePSO)
+}
+
+func TestPasswordPolicyManager_UnreadablePSO(t *testing.T) {
+	client := newTestPolicyClient()
+	client.On("Search", mock.Anything, searchBase(testPolicyUserDN)).Return(userPolicyEntry(testPolicyPSODN), nil)
+	client.On("Search", mock.Anything, searchBase(testPolicyPSODN)).Return(&SearchResult{}, nil)
+	pm := NewPasswordPolicyManager(t.Context(), client, testPolicyDomainDN, nil)
+
+	policy, err := pm.GetPasswordPolicy(testPolicyUserDN)
+	require.NoError(t, err)
+	assert.Equal(t, testPolicyDomainDN, policy.Source)
+	assert.Equal(t, 7, policy.MinLength)
+	assert.Equal(t, testPolicyPSODN, policy.UnreadablePSO)
+	assert.Equal(t, []string{"jdoe", "Jane Doe"}, policy.AccountNames)
+}
+
+func TestPasswordPolicyManager_UserWithoutPSO(t *testing.T) {
+	client := newTestPolicyClient()
+	client.On("Search", mock.Anything, searchBase(testPolicyUserDN)).Return(userPolicyEntry(""), nil)
+	pm := NewPasswordPolicyManager(t.Context(), client, testPolicyDomainDN, nil)
+
+	policy, err := pm.GetPasswordPolicy(testPolicyUserDN)
+	require.NoError(t, err)
+	assert.Equal(t, testPolicyDomainDN, policy.Source)
+	assert.True(t, policy.ComplexityEnabled)
+	assert.Equal(t, []string{"jdoe", "Jane Doe"}, policy.AccountNames)
+}
+
+func TestPasswordPolicyManager_OU(t *testing.T) {
+	ouDN := "OU=Users,DC=example,DC=com"
+	client := newTestPolicyClient()
+	client.On("Search", mock.Anything, searchBase(ouDN)).Return(entryResult(ouDN, map[string][]string{
+		"objectClass": {"top", "organizationalUnit"},
+	}), nil)
+	pm := NewPasswordPolicyManager(t.Context(), client, testPolicyDomainDN, nil)
+
+	policy, err := pm.GetPasswordPolicy(ouDN)
+	require.NoError(t, err)
+	assert.Equal(t, testPolicyDomainDN, policy.Source)
+	assert.Empty(t, policy.AccountNames)
+}
+
+func TestPasswordPolicyManager_Errors(t *testing.T) {
+	missingDN := "OU=Missing,DC=example,DC=com"
+	client := newTestPolicyClient()
+	client.On("Search", mock.Anything, searchBase(missingDN)).Return(&SearchResult{}, nil)
+	client.On("Search", mock.Anything, searchBase("OU=Broken,DC=example,DC=com")).Return(nil, errors.New("connection reset"))
+	pm := NewPasswordPolicyManager(t.Context(), client, testPolicyDomainDN, nil)
+
+	_, err := pm.GetPasswordPolicy(missingDN)
+	assert.True(t, IsNotFoundError(err), "got %v", err)
+
+	_, err = pm.GetPasswordPolicy("OU=Broken,DC=example,DC=com")
+	assert.ErrorContains(t, err, "connection reset")
+}
@@ -0,0 +1,55 @@
+package ldap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	t.Run("meets the minimum length and every character class", func(t *testing.T) {
+		password, err := GeneratePassword(8, &PasswordPolicy{MinLength: 14, ComplexityEnabled: true})
+		require.NoError(t, err)
+		assert.Len(t, password, 14)
+		for _, class := range passwordCharacterClasses {
+			assert.True(t, strings.ContainsAny(password, class), "missing one of %q in %q", class, password)
+		}
+	})
+
+	t.Run("longer length requested", func(t *testing.T) {
+		password, err := GeneratePassword(32, &PasswordPolicy{MinLength: 14})
+		require.NoError(t, err)
+		assert.Len(t, password, 32)
+	})
+
+	t.Run("without a policy", func(t *testing.T) {
+		password, err := GeneratePassword(1, nil)
+		require.NoError(t, err)
+		assert.Len(t, password, len(passwordCharacterClasses))
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		first, err := GeneratePassword(24, nil)
+		require.NoError(t, err)
+		second, err := GeneratePassword(24, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("policy longer than supported", func(t *testing.T) {
+		_, err := GeneratePassword(8, &PasswordPolicy{MinLength: MaxGeneratedPasswordLength + 1})
+		assert.Error(t, err)
+	})
+}
+
+func TestContainsAccountName(t *testing.T) {
+	names := []string{"jdoe", "Jane M. Doe"}
+
+	assert.True(t, containsAccountName("x7JDOE!a", names), "sAMAccountName, ignoring case")
+	assert.True(t, containsAccountName("a#jane9Q", names), "display name token")
+	assert.True(t, containsAccountName("xxDOE-1", names), "display name token of three characters")
+	assert.False(t, containsAccountName("M.x#9Qa", names), "tokens shorter than three characters are allowed")
+	assert.False(t, containsAccountName("Q8#vbnzx", names))
+}
@@ -6,7 +6,11 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,18 +33,52 @@ const (
 	// Terraform operations while staying well below typical AD server limits
 	// (which often default to 1000+ concurrent connections).
 	MaxConnectionPoolLimit = 100
+
+	// rediscoveryMinInterval is the minimum time between SRV re-discovery
+	// runs, so that an outage does not turn every retry into a DNS query.
+	rediscoveryMinInterval = 30 * time.Second
 )
 
 // connectionPool implements ConnectionPool interface.
 type connectionPool struct {
 	ctx         context.Context // Logging context with LDAP subsystem
 	config      *ConnectionConfig
+	bind        *bindState // Credentials, tickets and TLS files used by this pool only
 	servers     []*ServerInfo
 	connections chan *PooledConnection
 	mu          sync.RWMutex
 	closed      bool
 	discovery   *SRVDiscovery
 
+	// Global Catalog pools connect to GC servers of the forest and verify
+	// isGlobalCatalogReady before handing out connections.
+	globalCatalog bool
+	forest        string
+
+	// site is the AD site used to select domain controllers, if any.
+	site string
+
+	// serversExpire is when the SRV-discovered server list is due for
+	// refresh; zero for configured URLs. lastDiscovery rate-limits
+	// re-discovery and discoveryMu serialises it.
+	serversExpire time.Time
+	lastDiscovery time.Time
+	discoveryMu   sync.Mutex
+
+	// activeServer is the domain controller the pool is pinned to. Every new
+	// connection goes to it so that a read following a write observes the
+	// write without waiting for replication. It only changes when the pinned
+	// server fails with a connection-category error.
+	activeServer *ServerInfo
+
+	// health tracks per-server failures and latency, and drives the
+	// circuit breaker and weighted server selection.
+	health *serverHealthTracker
+
+	// connect opens a single connection to a server. Tests replace it to
+	// exercise server selection without a live directory.
+	connect func(ctx context.Context, server *ServerInfo) (*PooledConnection, error)
+
 	// Statistics
 	activeConns  int64
 	totalCreated int64
@@ -97,8 +135,22 @@ func buildCertPool(certFile, certContent string) (*x509.CertPool, error) {
 
 // NewConnectionPool creates a new connection pool.
 func NewConnectionPool(ctx context.Context, config *ConnectionConfig) (ConnectionPool, error) {
+	return newConnectionPool(ctx, config, false, "")
+}
+
+// NewGlobalCatalogPool creates a connection pool whose servers are Global
+// Catalog servers of the given forest. Configured LDAP URLs are mapped to the
+// GC ports (3268/3269); otherwise servers are discovered via _gc._tcp SRV
+// records. Every new connection is checked for isGlobalCatalogReady.
+func NewGlobalCatalogPool(ctx context.Context, config *ConnectionConfig, forest string) (ConnectionPool, error) {
+	return newConnectionPool(ctx, config, true, forest)
+}
+
+func newConnectionPool(ctx context.Context, config *ConnectionConfig, globalCatalog bool, forest string) (ConnectionPool, error) {
 	start := time.Now()
-	tflog.SubsystemDebug(ctx, "ldap", "Creating new connection pool")
+	tflog.SubsystemDebug(ctx, "ldap", "Creating new connection pool", map[string]any{
+		"global_catalog": globalCatalog,
+	})
 
 	if config == nil {
 		config = DefaultConfig()
@@ -108,27 +160,34 @@ func NewConnectionPool(ctx context.Context, config *ConnectionConfig) (Connectio
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Build certificate pool with system CAs and optional custom CAs
-	// This ensures TLS connections always have access to trusted CAs
-	if config.TLSConfig != nil {
-		certPool, err := buildCertPool(config.TLSCACertFile, config.TLSCACert)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build certificate pool: %w", err)
-		}
-		config.TLSConfig.RootCAs = certPool
-		tflog.SubsystemDebug(ctx, "ldap", "Certificate pool configured", map[string]any{
-			"has_custom_ca": config.TLSCACertFile != "" || config.TLSCACert != "",
-		})
+	if globalCatalog && forest == "" {
+		forest = config.Domain
+	}
+
+	bind, err := newBindState(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	// Point the caller's TLS configuration at the CA pool, as the first pool
+	// always has; later pools, such as the Global Catalog pool, leave it alone.
+	if config.TLSConfig != nil && config.TLSConfig.RootCAs == nil {
+		config.TLSConfig.RootCAs = bind.tlsFiles.roots(ctx)
 	}
 
 	pool := &connectionPool{
 		ctx:         ctx, // Store context for logging
 		config:      config,
+		bind:        bind,
 		connections: make(chan *PooledConnection, config.MaxConnections),
 		discovery:   NewSRVDiscovery(ctx),
 		startTime:   time.Now(),
 		healthStop:  make(chan struct{}),
+		health:      newServerHealthTracker(),
+
+		globalCatalog: globalCatalog,
+		forest:        forest,
 	}
+	pool.connect = pool.createSingleConnection
 
 	// Discover servers with timing
 	if err := pool.discoverServers(); err != nil {
@@ -154,33 +213,54 @@ func (p *connectionPool) discoverServers() error {
 	// Use configured URLs if provided
 	if len(p.config.LDAPURLs) > 0 {
 		tflog.SubsystemDebug(p.ctx, "ldap", "Using configured LDAP URLs", map[string]any{
-			"urls": p.config.LDAPURLs,
+			"urls":           p.config.LDAPURLs,
+			"global_catalog": p.globalCatalog,
 		})
 		for _, url := range p.config.LDAPURLs {
 			server, err := ParseLDAPURL(url)
 			if err != nil {
 				return fmt.Errorf("invalid LDAP URL %s: %w", url, err)
 			}
+			if p.globalCatalog {
+				server = globalCatalogServer(server)
+			}
 			servers = append(servers, server)
 		}
 		tflog.SubsystemDebug(p.ctx, "ldap", "Parsed servers from URLs", map[string]any{
 			"server_count":   len(servers),
 			"parse_duration": time.Since(start).String(),
 		})
+	} else if p.globalCatalog && p.forest != "" {
+		// Use _gc._tcp SRV discovery for the forest
+		tflog.SubsystemDebug(p.ctx, "ldap", "Starting Global Catalog SRV discovery for forest", map[string]any{
+			"forest":  p.forest,
+			"timeout": p.config.Timeout.String(),
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
+		defer cancel()
+
+		discoveredServers, err := p.discovery.DiscoverGlobalCatalogServers(ctx, p.forest)
+		if err != nil {
+			return fmt.Errorf("global catalog SRV discovery failed: %w", err)
+		}
+		servers = discoveredServers
 	} else if p.config.Domain != "" {
-		// Use SRV discovery
+		// Use SRV discovery, preferring domain controllers in the client's site
 		tflog.SubsystemDebug(p.ctx, "ldap", "Starting SRV discovery for domain", map[string]any{
-			"domain":  p.config.Domain,
-			"timeout": p.config.Timeout.String(),
+			"domain":         p.config.Domain,
+			"site":           p.config.Site,
+			"site_detection": p.config.SiteDetection,
+			"timeout":        p.config.Timeout.String(),
 		})
 		ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
 		defer cancel()
 
 		discoveryStart := time.Now()
-		discoveredServers, err := p.discovery.DiscoverServers(ctx, p.config.Domain)
+		discoveredServers, site, err := p.discoverSiteServers(ctx)
 		discoveryDuration := time.Since(discoveryStart)
 		tflog.SubsystemDebug(p.ctx, "ldap", "SRV discovery completed", map[string]any{
 			"duration": discoveryDuration.String(),
+			"site":     site,
 		})
 
 		if err != nil {
@@ -191,6 +271,9 @@ func (p *connectionPool) discoverServers() error {
 			return fmt.Errorf("SRV discovery failed: %w", err)
 		}
 		servers = discoveredServers
+		p.mu.Lock()
+		p.site = site
+		p.mu.Unlock()
 		tflog.SubsystemDebug(p.ctx, "ldap", "SRV discovery found servers", map[string]any{
 			"server_count": len(servers),
 		})
@@ -202,8 +285,28 @@ func (p *connectionPool) discoverServers() error {
 		return errors.New("no servers discovered")
 	}
 
+	// SRV-discovered lists expire with the shortest record TTL.
+	var expire time.Time
+	if len(p.config.LDAPURLs) == 0 {
+		ttl := servers[0].TTL
+		for _, server := range servers[1:] {
+			ttl = min(ttl, server.TTL)
+		}
+		if ttl > 0 {
+			expire = time.Now().Add(ttl)
+		}
+	}
+
 	p.mu.Lock()
 	p.servers = servers
+	p.serversExpire = expire
+	p.lastDiscovery = time.Now()
+	if p.activeServer != nil && !slices.ContainsFunc(servers, func(s *ServerInfo) bool { return sameServer(s, p.activeServer) }) {
+		tflog.SubsystemWarn(p.ctx, "ldap", "Pinned domain controller is no longer advertised, unpinning", map[string]any{
+			"server": serverAddress(p.activeServer),
+		})
+		p.activeServer = nil
+	}
 	p.mu.Unlock()
 
 	tflog.SubsystemDebug(p.ctx, "ldap", "Server discovery completed", map[string]any{
@@ -213,6 +316,85 @@ func (p *connectionPool) discoverServers() error {
 	return nil
 }
 
+// rediscoverServers re-runs SRV discovery and atomically swaps in the new
+// server list. It is a no-op for configured URLs and when discovery ran less
+// than rediscoveryMinInterval ago, which also collapses concurrent callers
+// into a single lookup. If discovery fails the current list is kept.
+func (p *connectionPool) rediscoverServers(reason string) {
+	if len(p.config.LDAPURLs) > 0 {
+		return
+	}
+
+	p.discoveryMu.Lock()
+	defer p.discoveryMu.Unlock()
+
+	p.mu.RLock()
+	lastDiscovery := p.lastDiscovery
+	previous := len(p.servers)
+	p.mu.RUnlock()
+	if time.Since(lastDiscovery) < rediscoveryMinInterval {
+		return
+	}
+
+	if err := p.discoverServers(); err != nil {
+		p.mu.Lock()
+		p.lastDiscovery = time.Now()
+		p.mu.Unlock()
+		tflog.SubsystemWarn(p.ctx, "ldap", "Server re-discovery failed, keeping current servers", map[string]any{
+			"reason": reason,
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	p.mu.RLock()
+	current := len(p.servers)
+	p.mu.RUnlock()
+	tflog.SubsystemInfo(p.ctx, "ldap", "Re-discovered domain controllers", map[string]any{
+		"reason":                reason,
+		"server_count":          current,
+		"previous_server_count": previous,
+	})
+}
+
+// serversExpired reports whether the SRV TTL of the server list has passed.
+func (p *connectionPool) serversExpired() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return !p.serversExpire.IsZero() && time.Now().After(p.serversExpire)
+}
+
+// discoverSiteServers resolves the client's site (configured, or detected via
+// a CLDAP netlogon ping) and discovers domain controllers for it, falling back
+// to the domain-wide SRV records. It returns the servers and the client site.
+func (p *connectionPool) discoverSiteServers(ctx context.Context) ([]*ServerInfo, string, error) {
+	site := p.config.Site
+
+	if site == "" && p.config.SiteDetection {
+		domainServers, err := p.discovery.DiscoverServers(ctx, p.config.Domain)
+		if err != nil {
+			return nil, "", err
+		}
+
+		detected, err := p.discovery.DetectSite(ctx, p.config.Domain, domainServers)
+		if err != nil || detected == "" {
+			fields := map[string]any{"domain": p.config.Domain}
+			if err != nil {
+				fields["error"] = err.Error()
+			}
+			tflog.SubsystemDebug(p.ctx, "ldap", "Could not detect client site, using domain-wide domain controllers", fields)
+			return domainServers, "", nil
+		}
+		site = detected
+	}
+
+	servers, _, err := p.discovery.DiscoverSiteServers(ctx, p.config.Domain, site)
+	if err != nil {
+		return nil, "", err
+	}
+	return servers, site, nil
+}
+
 // Get retrieves a connection from the pool.
 func (p *connectionPool) Get(ctx context.Context) (*PooledConnection, error) {
 	p.mu.RLock()
@@ -225,10 +407,15 @@ func (p *connectionPool) Get(ctx context.Context) (*PooledConnection, error) {
 	// Try to get an existing connection from the pool
 	select {
 	case conn := <-p.connections:
+		if !p.onActiveServer(conn) {
+			// Left over from before a failover; never mix servers.
+			p.closeConnection(conn)
+			break
+		}
 		if p.isConnectionHealthy(conn) {
 			// Check if authentication is still valid or if we need to re-authenticate
 			if p.config.HasAuthentication() && p.needsReAuthentication(conn) {
-				if err := p.authenticateConnection(conn); err != nil {
+				if err := p.reauthenticate(conn); err != nil {
 					// Re-authentication failed, close connection and create new one
 					p.closeConnection(conn)
 					break
@@ -248,25 +435,53 @@ func (p *connectionPool) Get(ctx context.Context) (*PooledConnection, error) {
 	return p.createConnection(ctx)
 }
 
-// createConnection creates a new connection with retry logic.
+// createConnection creates a new connection with retry logic. Servers are
+// tried in candidateServers order, so the pinned server is always attempted
+// first while its circuit is closed; the pool only moves on to another
+// server when the failure is a connection-category error.
 func (p *connectionPool) createConnection(ctx context.Context) (*PooledConnection, error) {
 	var lastErr error
 	backoff := p.config.InitialBackoff
 
 	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
-		for _, server := range p.servers {
-			conn, err := p.createSingleConnection(ctx, server)
+		if p.serversExpired() {
+			p.rediscoverServers("ttl_expired")
+		}
+
+		allFailed := true
+		for _, server := range p.candidateServers() {
+			start := time.Now()
+			conn, err := p.connect(ctx, server)
 			if err != nil {
 				lastErr = err
 				atomic.AddInt64(&p.totalErrors, 1)
+				if !isFailoverError(err) {
+					// Authentication, permission and similar failures would
+					// recur on any server; retry rather than fail over.
+					allFailed = false
+					break
+				}
+				if p.health.recordFailure(server, err) {
+					tflog.SubsystemWarn(p.ctx, "ldap", "Opened circuit for failing domain controller", map[string]any{
+						"server": serverAddress(server),
+						"error":  err.Error(),
+					})
+				}
 				continue
 			}
 
+			p.health.recordSuccess(server, time.Since(start))
+			p.setActiveServer(server)
 			atomic.AddInt64(&p.totalCreated, 1)
 			atomic.AddInt64(&p.activeConns, 1)
 			return conn, nil
 		}
 
+		// Every known server is unreachable; the DCs may have been replaced.
+		if allFailed {
+			p.rediscoverServers("all_servers_failed")
+		}
+
 		// All servers failed, wait before retrying
 		if attempt < p.config.MaxRetries {
 			select {
@@ -282,8 +497,126 @@ func (p *connectionPool) createConnection(ctx context.Context) (*PooledConnectio
 	return nil, NewConnectionError("failed to create connection after retries", true, lastErr)
 }
 
+// candidateServers returns the servers to try for a new connection: the
+// pinned server first unless its circuit is open, followed by the remaining
+// servers in health-weighted order.
+func (p *connectionPool) candidateServers() []*ServerInfo {
+	p.mu.RLock()
+	servers := p.servers
+	active := p.activeServer
+	p.mu.RUnlock()
+
+	ordered := p.health.order(servers)
+	if active == nil || p.health.isOpen(active) {
+		return ordered
+	}
+
+	candidates := make([]*ServerInfo, 0, len(ordered))
+	candidates = append(candidates, active)
+	for _, server := range ordered {
+		if !sameServer(server, active) {
+			candidates = append(candidates, server)
+		}
+	}
+	return candidates
+}
+
+// setActiveServer pins the pool to server, logging the initial choice and
+// any subsequent failover.
+func (p *connectionPool) setActiveServer(server *ServerInfo) {
+	p.mu.Lock()
+	previous := p.activeServer
+	if sameServer(previous, server) {
+		p.mu.Unlock()
+		return
+	}
+	p.activeServer = server
+	site := p.site // Rediscovery may change it
+	p.mu.Unlock()
+
+	if previous == nil {
+		tflog.SubsystemInfo(p.ctx, "ldap", "Pinned connection pool to domain controller", map[string]any{
+			"server": serverAddress(server),
+			"site":   site,
+		})
+		return
+	}
+
+	tflog.SubsystemWarn(p.ctx, "ldap", "Failed over to another domain controller", map[string]any{
+		"previous_server": serverAddress(previous),
+		"server":          serverAddress(server),
+	})
+}
+
+// onActiveServer reports whether conn belongs to the pinned server. Before a
+// server has been pinned every connection qualifies.
+func (p *connectionPool) onActiveServer(conn *PooledConnection) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.onActiveServerLocked(conn)
+}
+
+// onActiveServerLocked is onActiveServer for callers already holding p.mu.
+func (p *connectionPool) onActiveServerLocked(conn *PooledConnection) bool {
+	if p.activeServer == nil || conn == nil {
+		return true
+	}
+	return sameServer(conn.serverInfo, p.activeServer)
+}
+
+// sameServer reports whether two server entries address the same endpoint.
+// Entries are compared by value because discovery may return fresh
+// ServerInfo instances for the same domain controller.
+func sameServer(a, b *ServerInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return strings.EqualFold(a.Host, b.Host) && a.Port == b.Port
+}
+
+// serverAddress formats a server as host:port for logs and statistics.
+func serverAddress(server *ServerInfo) string {
+	if server == nil {
+		return ""
+	}
+	return net.JoinHostPort(server.Host, strconv.Itoa(server.Port))
+}
+
+// isFailoverError reports whether err indicates the server itself is
+// unreachable, in which case the pool may move to another domain controller.
+func isFailoverError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		return true
+	}
+
+	var ldapErr *ldap.Error
+	if errors.As(err, &ldapErr) {
+		switch ldapErr.ResultCode {
+		case ldap.ErrorNetwork, ldap.LDAPResultServerDown, ldap.LDAPResultUnavailable:
+			return true
+		}
+		return categorizeError(ldapErr.ResultCode) == ErrorCategoryConnection
+	}
+
+	// Only inspect the root cause: wrapping messages such as "failed to
+	// authenticate connection" would otherwise always match.
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			break
+		}
+		err = inner
+	}
+	return categorizeGenericError(err) == ErrorCategoryConnection
+}
+
 // createSingleConnection creates a connection to a specific server.
-func (p *connectionPool) createSingleConnection(_ context.Context, server *ServerInfo) (*PooledConnection, error) {
+func (p *connectionPool) createSingleConnection(ctx context.Context, server *ServerInfo) (*PooledConnection, error) {
 	url := ServerInfoToURL(server)
 
 	var conn *ldap.Conn
@@ -294,6 +627,10 @@ func (p *connectionPool) createSingleConnection(_ context.Context, server *Serve
 	if p.config.TLSConfig != nil {
 		// Clone the config to avoid race conditions
 		tlsConfig = p.config.TLSConfig.Clone()
+		if p.bind.tlsFiles != nil {
+			// Pick up a rotated CA bundle or client certificate
+			p.bind.tlsFiles.configure(p.ctx, tlsConfig)
+		}
 
 		// Set ServerName for proper certificate validation
 		// This is required by Go's TLS library when InsecureSkipVerify is false
@@ -302,9 +639,13 @@ func (p *connectionPool) createSingleConnection(_ context.Context, server *Serve
 		}
 	}
 
+	var layer *saslConn
 	if server.UseTLS {
 		// Direct TLS connection (LDAPS)
 		conn, err = ldap.DialURL(url, ldap.DialWithTLSConfig(tlsConfig))
+	} else if p.useSASLSecurityLayer() {
+		// Plain connection protected by a SASL security layer after bind
+		conn, layer, err = p.dialSASLConn(ctx, server)
 	} else {
 		// Plain connection, will use StartTLS if needed
 		conn, err = ldap.DialURL(url)
@@ -315,20 +656,29 @@ func (p *connectionPool) createSingleConnection(_ context.Context, server *Serve
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+		return nil, NewConnectionError(fmt.Sprintf("failed to connect to %s", url), true, err)
 	}
 
 	// Set connection timeout
 	conn.SetTimeout(p.config.Timeout)
 
+	channelBinding, err := connectionChannelBinding(conn, p.config.ChannelBinding)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to compute channel binding for %s: %w", url, err)
+	}
+
 	pooledConn := &PooledConnection{
-		conn:          conn,
-		lastUsed:      time.Now(),
-		healthy:       true,
-		authenticated: false,
-		authTime:      time.Time{},
-		serverInfo:    server,
-		returnToPool:  p.returnConnection,
+		conn:           conn,
+		lastUsed:       time.Now(),
+		healthy:        true,
+		authenticated:  false,
+		authTime:       time.Time{},
+		serverInfo:     server,
+		returnToPool:   p.returnConnection,
+		saslLayer:      layer,
+		channelBinding: channelBinding,
+		bind:           p.bind,
 	}
 
 	// Authenticate the connection immediately if authentication is configured
@@ -339,30 +689,62 @@ func (p *connectionPool) createSingleConnection(_ context.Context, server *Serve
 		}
 	}
 
+	// A GC SRV record or port alone does not guarantee the server has
+	// finished building its partial replicas.
+	if p.globalCatalog {
+		if err := verifyGlobalCatalogReady(connOps(pooledConn)); err != nil {
+			conn.Close()
+			return nil, NewConnectionError(fmt.Sprintf("server %s is not a usable global catalog", url), true, err)
+		}
+	}
+
 	return pooledConn, nil
 }
 
+// useSASLSecurityLayer reports whether plain connections should negotiate a
+// SASL security layer. The layer is only available with Kerberos binds and is
+// redundant when StartTLS is in use.
+func (p *connectionPool) useSASLSecurityLayer() bool {
+	layer := p.config.SASLSecurityLayer
+	if layer == "" || layer == SASLSecurityLayerNone || p.config.GetAuthMethod() != AuthMethodKerberos {
+		return false
+	}
+	return !p.config.UseTLS || p.config.SkipTLS
+}
+
+// dialSASLConn dials a plain LDAP connection over a saslConn so that a
+// security layer can be enabled once the GSSAPI bind completes.
+func (p *connectionPool) dialSASLConn(ctx context.Context, server *ServerInfo) (*ldap.Conn, *saslConn, error) {
+	dialer := &net.Dialer{Timeout: p.config.Timeout}
+	raw, err := dialer.DialContext(ctx, "tcp", serverAddress(server))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layer := &saslConn{Conn: raw}
+	conn := ldap.NewConn(layer, false)
+	conn.Start()
+	return conn, layer, nil
+}
+
 // authenticateConnection authenticates a pooled connection using the configured method.
 func (p *connectionPool) authenticateConnection(pooledConn *PooledConnection) error {
 	if pooledConn == nil || pooledConn.conn == nil {
 		return fmt.Errorf("connection is nil")
 	}
 
-	authMethod := p.config.GetAuthMethod()
-	var err error
+	// A new bind would have to be sent inside the existing security layer
+	// and renegotiate it; replace the connection instead.
+	if pooledConn.hasSASLSecurityLayer() {
+		return fmt.Errorf("connection with an active SASL security layer cannot be re-authenticated")
+	}
 
-	switch authMethod {
-	case AuthMethodSimpleBind:
-		if p.config.Username == "" {
-			return fmt.Errorf("username is required for simple bind authentication")
-		}
-		err = pooledConn.conn.Bind(p.config.Username, p.config.Password)
-	case AuthMethodKerberos:
-		err = p.authenticateKerberos(pooledConn.conn, pooledConn.serverInfo)
-	case AuthMethodExternal:
-		err = pooledConn.conn.Bind("", "")
-	default:
-		return fmt.Errorf("unsupported authentication method: %s", authMethod.String())
+	err := p.bindConnection(pooledConn, p.bind.credentialsForBind(p.ctx))
+	// The password may have been rotated since the credentials were fetched;
+	// retry once if the credential command returns different ones.
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) && p.bind.refreshCredentials(p.ctx) {
+		tflog.SubsystemDebug(p.ctx, "ldap", "Bind rejected, retrying with refreshed credentials")
+		err = p.bindConnection(pooledConn, p.bind.bindConfig())
 	}
 
 	if err != nil {
@@ -377,9 +759,41 @@ func (p *connectionPool) authenticateConnection(pooledConn *PooledConnection) er
 	return nil
 }
 
-// authenticateKerberos performs Kerberos authentication on a pooled connection.
-func (p *connectionPool) authenticateKerberos(conn *ldap.Conn, serverInfo *ServerInfo) error {
-	return performKerberosAuth(conn, p.config, serverInfo)
+// bindConnection binds a pooled connection with the credentials in cfg.
+func (p *connectionPool) bindConnection(pooledConn *PooledConnection, cfg *ConnectionConfig) error {
+	switch authMethod := cfg.GetAuthMethod(); authMethod {
+	case AuthMethodSimpleBind:
+		if cfg.Username == "" {
+			return fmt.Errorf("username is required for simple bind authentication")
+		}
+		return pooledConn.conn.Bind(cfg.Username, cfg.Password)
+	case AuthMethodKerberos:
+		return p.authenticateKerberos(pooledConn, cfg)
+	case AuthMethodExternal:
+		return pooledConn.conn.Bind("", "")
+	case AuthMethodNTLM:
+		return performNTLMBind(p.ctx, pooledConn.conn, cfg, pooledConn.channelBinding)
+	default:
+		return fmt.Errorf("unsupported authentication method: %s", authMethod.String())
+	}
+}
+
+// authenticateKerberos performs Kerberos authentication on a pooled connection,
+// binding it to the TLS channel and enabling the SASL security layer when the
+// connection was dialed for one.
+func (p *connectionPool) authenticateKerberos(pooledConn *PooledConnection, cfg *ConnectionConfig) error {
+	return performKerberosAuthWithOptions(p.ctx, pooledConn.conn, cfg, pooledConn.serverInfo, kerberosBindOptions{
+		layer:          pooledConn.saslLayer,
+		channelBinding: pooledConn.channelBinding,
+		tickets:        p.bind.tickets,
+	})
+}
+
+// reauthenticate refreshes the credentials from the credential command, if
+// one is configured, and binds the connection again.
+func (p *connectionPool) reauthenticate(conn *PooledConnection) error {
+	p.bind.refreshCredentials(p.ctx)
+	return p.authenticateConnection(conn)
 }
 
 // needsReAuthentication determines if a connection needs to be re-authenticated.
@@ -393,6 +807,13 @@ func (p *connectionPool) needsReAuthentication(conn *PooledConnection) bool {
 		return true
 	}
 
+	// A SASL security layer stays bound to the Kerberos context it was
+	// negotiated with, and a new bind inside it would have to renegotiate
+	// the layer. Such connections keep their bind until they are closed.
+	if conn.hasSASLSecurityLayer() {
+		return false
+	}
+
 	// If authentication is too old (5 minutes), re-authenticate
 	authAge := time.Since(conn.authTime)
 	maxAuthAge := 5 * time.Minute
@@ -415,8 +836,9 @@ func (p *connectionPool) returnConnection(conn *PooledConnection) {
 		return
 	}
 
-	// Check if connection is still healthy and not too old
-	if p.isConnectionHealthy(conn) && time.Since(conn.lastUsed) < p.config.MaxIdleTime {
+	// Check if connection is still healthy, not too old, and still on the
+	// pinned server
+	if p.isConnectionHealthy(conn) && time.Since(conn.lastUsed) < p.config.MaxIdleTime && p.onActiveServerLocked(conn) {
 		select {
 		case p.connections <- conn:
 			// Successfully returned to pool
@@ -498,6 +920,10 @@ func (p *connectionPool) Stats() PoolStats {
 		Created: atomic.LoadInt64(&p.totalCreated),
 		Errors:  atomic.LoadInt64(&p.totalErrors),
 		Uptime:  time.Since(p.startTime),
+		Site:    p.site,
+
+		ActiveServer: serverAddress(p.activeServer),
+		Servers:      p.health.stats(p.servers),
 	}
 
 	return stats
@@ -570,7 +996,7 @@ func (p *connectionPool) testConnection(_ context.Context, conn *PooledConnectio
 
 	// Check if connection needs re-authentication
 	if p.config.HasAuthentication() && p.needsReAuthentication(conn) {
-		if err := p.authenticateConnection(conn); err != nil {
+		if err := p.reauthenticate(conn); err != nil {
 			return false
 		}
 	}
@@ -624,6 +1050,14 @@ func validateConfig(config *ConnectionConfig) error {
 		return errors.New("BackoffFactor must be greater than 1.0")
 	}
 
+	if err := ValidateSASLSecurityLayer(config.SASLSecurityLayer); err != nil {
+		return err
+	}
+
+	if err := ValidateChannelBinding(config.ChannelBinding); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -638,6 +1072,12 @@ func (pc *PooledConnection) Conn() *ldap.Conn {
 	return pc.conn
 }
 
+// hasSASLSecurityLayer reports whether the connection is protected by an
+// active SASL security layer.
+func (pc *PooledConnection) hasSASLSecurityLayer() bool {
+	return pc.saslLayer != nil && pc.saslLayer.enabled()
+}
+
 func (pc *PooledConnection) ServerInfo() *ServerInfo {
 	return pc.serverInfo
 }
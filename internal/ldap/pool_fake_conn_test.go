@@ -2,9 +2,11 @@ package ldap
 
 import (
 	"net"
+	"sync"
 	"testing"
 	"time"
 
+	ber "github.com/go-asn1-ber/asn1-ber"
 	"github.com/go-ldap/ldap/v3"
 )
 
@@ -113,3 +115,65 @@ func newHealthyPooled(tb testing.TB, pool *connectionPool) *PooledConnection {
 	}
 	return pc
 }
+
+// fakeBindServer answers simple binds on a loopback connection, accepting
+// only the given password, and records the passwords it was sent.
+type fakeBindServer struct {
+	accept string
+
+	mu        sync.Mutex
+	passwords []string
+}
+
+// newFakeBindConn returns a started *ldap.Conn whose binds are answered by
+// server. Other requests are not answered.
+func newFakeBindConn(tb testing.TB, server *fakeBindServer) *ldap.Conn {
+	tb.Helper()
+	client, peer := net.Pipe()
+	tb.Cleanup(func() { _ = peer.Close() })
+
+	go func() {
+		for {
+			packet, err := ber.ReadPacket(peer)
+			if err != nil {
+				return
+			}
+			if len(packet.Children) < 2 || packet.Children[1].Tag != ldap.ApplicationBindRequest {
+				continue
+			}
+			request := packet.Children[1]
+			password := request.Children[2].Data.String()
+
+			server.mu.Lock()
+			server.passwords = append(server.passwords, password)
+			server.mu.Unlock()
+
+			resultCode := ldap.LDAPResultSuccess
+			if password != server.accept {
+				resultCode = ldap.LDAPResultInvalidCredentials
+			}
+			response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindResponse, nil, "Bind Response")
+			response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "resultCode"))
+			response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+			response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+
+			message := ber.NewSequence("LDAP Message")
+			message.AppendChild(packet.Children[0])
+			message.AppendChild(response)
+			if _, err := peer.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}()
+
+	conn := ldap.NewConn(client, false)
+	conn.Start()
+	return conn
+}
+
+// binds returns the passwords the server was sent, in order.
+func (s *fakeBindServer) binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.passwords...)
+}
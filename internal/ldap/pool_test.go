@@ -3,10 +3,18 @@ package ldap
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/go-ldap/ldap/v3"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -593,6 +601,122 @@ func TestNewConnectionPool_CertPoolSet(t *testing.T) {
 	}
 }
 
+func TestNewConnectionPool_BindStatePerPool(t *testing.T) {
+	// Pools sharing a configuration, such as the main and Global Catalog
+	// pools, must not share or store runtime state on it.
+	config := DefaultConfig()
+	config.LDAPURLs = []string{"ldaps://dc1.example.com:636"}
+	config.Domain = ""
+	roots := x509.NewCertPool()
+	config.TLSConfig.RootCAs = roots
+
+	pools := make([]*connectionPool, 2)
+	for i := range pools {
+		pool, err := NewConnectionPool(t.Context(), config)
+		if err != nil {
+			t.Fatalf("Failed to create pool: %v", err)
+		}
+		defer pool.Close()
+		pools[i] = pool.(*connectionPool)
+	}
+	first, second := pools[0], pools[1]
+
+	if first.bind == second.bind || first.bind.tlsFiles == second.bind.tlsFiles {
+		t.Error("each pool should hold its own bind state")
+	}
+	if config.TLSConfig.RootCAs != roots {
+		t.Error("an existing RootCAs should not be replaced")
+	}
+	if config.TLSConfig.GetClientCertificate != nil {
+		t.Error("the shared TLS configuration should not be modified")
+	}
+}
+
+func TestConnectionPool_AuthenticateRefreshesCredentials(t *testing.T) {
+	// The credential command prints the password in the file given as its
+	// argument; the server only accepts the rotated one.
+	passwordPath := filepath.Join(t.TempDir(), "password")
+	helper := writeCredentialHelper(t, `printf '{"password":"%s"}' "$(cat "$1")"`)
+
+	newPool := func(t *testing.T) *connectionPool {
+		config := DefaultConfig()
+		config.LDAPURLs = []string{"ldaps://dc1.example.com:636"}
+		config.Domain = ""
+		config.HealthCheck = 0
+		config.Username = "svc-terraform"
+		config.Password = "old"
+		config.CredentialCommand = []string{helper, passwordPath}
+
+		pool, err := NewConnectionPool(t.Context(), config)
+		if err != nil {
+			t.Fatalf("Failed to create pool: %v", err)
+		}
+		t.Cleanup(func() { _ = pool.Close() })
+		return pool.(*connectionPool)
+	}
+	authenticate := func(t *testing.T, pool *connectionPool, server *fakeBindServer) (*PooledConnection, error) {
+		pc := &PooledConnection{
+			conn:       newFakeBindConn(t, server),
+			serverInfo: &ServerInfo{Host: "dc1.example.com", Port: 636, UseTLS: true},
+		}
+		return pc, pool.authenticateConnection(pc)
+	}
+
+	t.Run("retries once with rotated credentials", func(t *testing.T) {
+		if err := os.WriteFile(passwordPath, []byte("new"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		pool := newPool(t)
+		server := &fakeBindServer{accept: "new"}
+
+		pc, err := authenticate(t, pool, server)
+		if err != nil {
+			t.Fatalf("authenticateConnection() failed: %v", err)
+		}
+		if got := strings.Join(server.binds(), ","); got != "old,new" {
+			t.Errorf("binds = %q, want %q", got, "old,new")
+		}
+		if !pc.authenticated {
+			t.Error("connection should be authenticated")
+		}
+	})
+
+	t.Run("does not retry with unchanged credentials", func(t *testing.T) {
+		if err := os.WriteFile(passwordPath, []byte("old"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		pool := newPool(t)
+		server := &fakeBindServer{accept: "new"}
+
+		pc, err := authenticate(t, pool, server)
+		if !ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			t.Fatalf("authenticateConnection() error = %v, want invalid credentials", err)
+		}
+		if got := strings.Join(server.binds(), ","); got != "old" {
+			t.Errorf("binds = %q, want %q", got, "old")
+		}
+		if pc.authenticated {
+			t.Error("connection should not be authenticated")
+		}
+	})
+
+	t.Run("refreshes stale credentials before binding", func(t *testing.T) {
+		if err := os.WriteFile(passwordPath, []byte("new"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		pool := newPool(t)
+		pool.bind.credentials.fetchedAt = time.Now().Add(-defaultCredentialRefreshInterval)
+		server := &fakeBindServer{accept: "new"}
+
+		if _, err := authenticate(t, pool, server); err != nil {
+			t.Fatalf("authenticateConnection() failed: %v", err)
+		}
+		if got := strings.Join(server.binds(), ","); got != "new" {
+			t.Errorf("binds = %q, want %q", got, "new")
+		}
+	})
+}
+
 // TestConnectionPool_ReuseAfterReturn verifies that a healthy connection
 // returned to the pool via PooledConnection.Close() is handed back out by
 // the next call to Get(). Identity is checked via pointer equality to
@@ -752,3 +876,293 @@ func TestConnectionPool_UnhealthyDiscardedOnGet(t *testing.T) {
 		// No-op; it may have been consumed in a future Get().
 	}
 }
+
+// newPinningTestPool builds a pool over two domain controllers whose connect
+// function is replaced by dial. Every server attempted is appended to the
+// returned slice.
+func newPinningTestPool(t *testing.T, dial func(server *ServerInfo) error) (*connectionPool, *[]string) {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.LDAPURLs = []string{"ldaps://dc1.example.com:636", "ldaps://dc2.example.com:636"}
+	cfg.Domain = ""
+	cfg.HealthCheck = 0
+	cfg.MaxRetries = 0
+
+	poolIface, err := NewConnectionPool(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("NewConnectionPool failed: %v", err)
+	}
+	t.Cleanup(func() { _ = poolIface.Close() })
+	pool := poolIface.(*connectionPool)
+	// Always draw the first server so that selection is deterministic.
+	pool.health.randIntN = func(int) int { return 0 }
+
+	var attempts []string
+	pool.connect = func(_ context.Context, server *ServerInfo) (*PooledConnection, error) {
+		attempts = append(attempts, server.Host)
+		if err := dial(server); err != nil {
+			return nil, err
+		}
+		return &PooledConnection{
+			lastUsed:     time.Now(),
+			healthy:      true,
+			serverInfo:   server,
+			returnToPool: pool.returnConnection,
+		}, nil
+	}
+	return pool, &attempts
+}
+
+func TestConnectionPool_PinsActiveServer(t *testing.T) {
+	down := map[string]bool{}
+	pool, attempts := newPinningTestPool(t, func(server *ServerInfo) error {
+		if down[server.Host] {
+			return NewConnectionError("failed to connect to "+server.Host, true, errors.New("connection refused"))
+		}
+		return nil
+	})
+
+	// dc1 is picked first and every later connection sticks to it.
+	for range 3 {
+		if _, err := pool.createConnection(t.Context()); err != nil {
+			t.Fatalf("createConnection failed: %v", err)
+		}
+	}
+	if got := strings.Join(*attempts, ","); got != "dc1.example.com,dc1.example.com,dc1.example.com" {
+		t.Errorf("attempts = %s, want only dc1", got)
+	}
+	if got := pool.Stats().ActiveServer; got != "dc1.example.com:636" {
+		t.Errorf("ActiveServer = %q, want dc1.example.com:636", got)
+	}
+
+	// A connection failure on dc1 fails over to dc2, which is then pinned.
+	down["dc1.example.com"] = true
+	*attempts = nil
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection after failover failed: %v", err)
+	}
+	down["dc1.example.com"] = false
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+	if got := strings.Join(*attempts, ","); got != "dc1.example.com,dc2.example.com,dc2.example.com" {
+		t.Errorf("attempts = %s, want failover to dc2 and stay there", got)
+	}
+	if got := pool.Stats().ActiveServer; got != "dc2.example.com:636" {
+		t.Errorf("ActiveServer = %q, want dc2.example.com:636", got)
+	}
+}
+
+func TestConnectionPool_NoFailoverOnNonConnectionError(t *testing.T) {
+	fail := false
+	pool, attempts := newPinningTestPool(t, func(server *ServerInfo) error {
+		if fail {
+			return fmt.Errorf("failed to authenticate connection to %s: %w", server.Host,
+				ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials")))
+		}
+		return nil
+	})
+
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+
+	fail = true
+	*attempts = nil
+	if _, err := pool.createConnection(t.Context()); err == nil {
+		t.Fatal("createConnection should fail")
+	}
+	if got := strings.Join(*attempts, ","); got != "dc1.example.com" {
+		t.Errorf("attempts = %s, want only the pinned dc1", got)
+	}
+	if got := pool.Stats().ActiveServer; got != "dc1.example.com:636" {
+		t.Errorf("ActiveServer = %q, want dc1.example.com:636", got)
+	}
+}
+
+func TestConnectionPool_ReturnClosesConnectionToPreviousServer(t *testing.T) {
+	pool := newTestPool(t, 4)
+	pool.setActiveServer(&ServerInfo{Host: "dc2.example.com", Port: 636, UseTLS: true})
+
+	// newHealthyPooled connections belong to test.invalid, which is no
+	// longer the active server.
+	stale := newHealthyPooled(t, pool)
+	atomic.AddInt64(&pool.activeConns, 1)
+	stale.Close()
+
+	if len(pool.connections) != 0 {
+		t.Errorf("idle connections = %d, want 0", len(pool.connections))
+	}
+	if stale.healthy {
+		t.Error("connection to the previous server should have been closed")
+	}
+}
+
+func TestIsFailoverError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection error", NewConnectionError("failed to connect", true, errors.New("boom")), true},
+		{"network", ldap.NewError(ldap.ErrorNetwork, errors.New("dial tcp: i/o timeout")), true},
+		{"server down", ldap.NewError(ldap.LDAPResultServerDown, errors.New("down")), true},
+		{"unavailable", ldap.NewError(ldap.LDAPResultUnavailable, errors.New("unavailable")), true},
+		{"connect error", ldap.NewError(ldap.LDAPResultConnectError, errors.New("connect")), true},
+		{"invalid credentials", ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("bad password")), false},
+		{"busy", ldap.NewError(ldap.LDAPResultBusy, errors.New("busy")), false},
+		{"generic reset", fmt.Errorf("search: %w", errors.New("connection reset by peer")), true},
+		{"wrapped kerberos failure", fmt.Errorf("failed to authenticate connection to dc1: %w", errors.New("KDC_ERR_PREAUTH_FAILED")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isFailoverError(tt.err); got != tt.want {
+				t.Errorf("isFailoverError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// newRediscoveryTestPool creates a pool that discovers _ldap._tcp.example.com
+// through resolver and whose connections are stubbed by dial.
+func newRediscoveryTestPool(t *testing.T, resolver *fakeSRVResolver, maxRetries int, dial func(server *ServerInfo) error) (*connectionPool, *[]string) {
+	t.Helper()
+	swapSRVResolver(t, resolver)
+
+	cfg := DefaultConfig()
+	cfg.Domain = "example.com"
+	cfg.SiteDetection = false
+	cfg.HealthCheck = 0
+	cfg.MaxRetries = maxRetries
+	cfg.InitialBackoff = time.Millisecond
+
+	poolIface, err := NewConnectionPool(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("NewConnectionPool failed: %v", err)
+	}
+	t.Cleanup(func() { _ = poolIface.Close() })
+	pool := poolIface.(*connectionPool)
+
+	var attempts []string
+	pool.connect = func(_ context.Context, server *ServerInfo) (*PooledConnection, error) {
+		attempts = append(attempts, server.Host)
+		if err := dial(server); err != nil {
+			return nil, err
+		}
+		return &PooledConnection{lastUsed: time.Now(), healthy: true, serverInfo: server}, nil
+	}
+	return pool, &attempts
+}
+
+// expireDiscovery makes the pool's last discovery look old enough to allow
+// re-discovery.
+func expireDiscovery(pool *connectionPool, ttl bool) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.lastDiscovery = time.Now().Add(-rediscoveryMinInterval)
+	if ttl {
+		pool.serversExpire = time.Now().Add(-time.Second)
+	}
+}
+
+func TestConnectionPool_RediscoversOnTTLExpiry(t *testing.T) {
+	resolver := &fakeSRVResolver{ttl: time.Minute}
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc1.example.com.", Port: 389})
+	pool, attempts := newRediscoveryTestPool(t, resolver, 0, func(*ServerInfo) error { return nil })
+
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+	if until := time.Until(pool.serversExpire); until <= 0 || until > time.Minute {
+		t.Errorf("serversExpire in %v, want within the 1m TTL", until)
+	}
+
+	// DNS now advertises a replacement DC, but the TTL has not expired.
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc2.example.com.", Port: 389})
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+	if resolver.lookups != 1 {
+		t.Errorf("lookups = %d, want 1 before the TTL expires", resolver.lookups)
+	}
+
+	expireDiscovery(pool, true)
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+	if resolver.lookups != 2 {
+		t.Errorf("lookups = %d, want 2 after the TTL expires", resolver.lookups)
+	}
+	if got := strings.Join(*attempts, ","); got != "dc1.example.com,dc1.example.com,dc2.example.com" {
+		t.Errorf("attempts = %s, want switch to dc2 after re-discovery", got)
+	}
+	if got := pool.Stats().ActiveServer; got != "dc2.example.com:389" {
+		t.Errorf("ActiveServer = %q, want dc2.example.com:389", got)
+	}
+}
+
+func TestConnectionPool_RediscoversWhenAllServersFail(t *testing.T) {
+	resolver := &fakeSRVResolver{ttl: time.Hour}
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc1.example.com.", Port: 389})
+	pool, attempts := newRediscoveryTestPool(t, resolver, 1, func(server *ServerInfo) error {
+		if server.Host == "dc1.example.com" {
+			return NewConnectionError("failed to connect to dc1", true, errors.New("connection refused"))
+		}
+		return nil
+	})
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc2.example.com.", Port: 389})
+
+	t.Run("rate limited", func(t *testing.T) {
+		pool.config.MaxRetries = 0
+		defer func() { pool.config.MaxRetries = 1 }()
+		if _, err := pool.createConnection(t.Context()); err == nil {
+			t.Fatal("createConnection should fail while only dc1 is known")
+		}
+		if resolver.lookups != 1 {
+			t.Errorf("lookups = %d, want no re-discovery within %v of the last one", resolver.lookups, rediscoveryMinInterval)
+		}
+	})
+
+	*attempts = nil
+	expireDiscovery(pool, false)
+	if _, err := pool.createConnection(t.Context()); err != nil {
+		t.Fatalf("createConnection failed: %v", err)
+	}
+	if got := strings.Join(*attempts, ","); got != "dc1.example.com,dc2.example.com" {
+		t.Errorf("attempts = %s, want dc1 then dc2 after re-discovery", got)
+	}
+}
+
+func TestConnectionPool_RediscoveryFailureKeepsServers(t *testing.T) {
+	resolver := &fakeSRVResolver{}
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc1.example.com.", Port: 389})
+	pool, _ := newRediscoveryTestPool(t, resolver, 0, func(*ServerInfo) error { return nil })
+
+	resolver.err = errors.New("SERVFAIL")
+	expireDiscovery(pool, true)
+	pool.rediscoverServers("ttl_expired")
+
+	if len(pool.servers) != 1 || pool.servers[0].Host != "dc1.example.com" {
+		t.Errorf("servers = %v, want the previous list to be kept", pool.servers)
+	}
+	if time.Since(pool.lastDiscovery) > time.Second {
+		t.Error("failed re-discovery should still count towards the rate limit")
+	}
+}
+
+func TestConnectionPool_ConfiguredURLsAreNotRediscovered(t *testing.T) {
+	resolver := &fakeSRVResolver{}
+	swapSRVResolver(t, resolver)
+	pool := newTestPool(t, 1)
+
+	if !pool.serversExpire.IsZero() {
+		t.Errorf("serversExpire = %v, want zero for configured URLs", pool.serversExpire)
+	}
+	expireDiscovery(pool, false)
+	pool.rediscoverServers("all_servers_failed")
+	if resolver.lookups != 0 {
+		t.Errorf("lookups = %d, want 0 for configured URLs", resolver.lookups)
+	}
+}
@@ -0,0 +1,189 @@
+package ldap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Relative identifiers of well-known domain groups (MS-DTYP 2.4.2.4).
+const (
+	RIDDomainAdmins        uint32 = 512
+	RIDDomainUsers         uint32 = 513
+	RIDSchemaAdmins        uint32 = 518
+	RIDEnterpriseAdmins    uint32 = 519
+	RIDProtectedUsers      uint32 = 525
+	RIDKeyAdmins           uint32 = 526
+	RIDEnterpriseKeyAdmins uint32 = 527
+)
+
+// Relative identifiers of well-known groups in the BUILTIN domain (S-1-5-32).
+const (
+	RIDBuiltinAdministrators   uint32 = 544
+	RIDBuiltinAccountOperators uint32 = 548
+	RIDBuiltinServerOperators  uint32 = 549
+	RIDBuiltinPrintOperators   uint32 = 550
+	RIDBuiltinBackupOperators  uint32 = 551
+)
+
+// privilegedDomainRIDs are the domain groups whose members administer the
+// domain or forest (tier 0).
+var privilegedDomainRIDs = []uint32{
+	RIDDomainAdmins, RIDSchemaAdmins, RIDEnterpriseAdmins, RIDKeyAdmins, RIDEnterpriseKeyAdmins,
+}
+
+// privilegedBuiltinRIDs are the BUILTIN groups whose members can take over
+// domain controllers, as protected by AdminSDHolder.
+var privilegedBuiltinRIDs = []uint32{
+	RIDBuiltinAdministrators, RIDBuiltinAccountOperators, RIDBuiltinServerOperators,
+	RIDBuiltinPrintOperators, RIDBuiltinBackupOperators,
+}
+
+// GroupRef identifies a group by distinguished name and SID.
+type GroupRef struct {
+	DistinguishedName string `json:"distinguishedName"`
+	SID               string `json:"sid"`
+}
+
+// isDomainSID reports whether sid is an account SID of a domain
+// (S-1-5-21-x-y-z-RID).
+func isDomainSID(sid SID) bool {
+	return sid.RevisionLevel == 1 &&
+		sid.Authority == 5 &&
+		len(sid.SubAuthorities) == 5 &&
+		sid.SubAuthorities[0] == 21
+}
+
+// isBuiltinSID reports whether sid is in the BUILTIN domain (S-1-5-32-RID).
+func isBuiltinSID(sid SID) bool {
+	return sid.RevisionLevel == 1 &&
+		sid.Authority == 5 &&
+		len(sid.SubAuthorities) == 2 &&
+		sid.SubAuthorities[0] == 32
+}
+
+// IsPrivilegedGroupSID reports whether sid names a tier 0 group: Domain
+// Admins, Schema Admins, Enterprise Admins, Key Admins or Enterprise Key
+// Admins of any domain, or the BUILTIN Administrators, Account Operators,
+// Server Operators, Print Operators or Backup Operators.
+func IsPrivilegedGroupSID(sid string) bool {
+	parsed, err := ParseSID(sid)
+	if err != nil {
+		return false
+	}
+	switch {
+	case isDomainSID(parsed):
+		for _, rid := range privilegedDomainRIDs {
+			if parsed.RID() == rid {
+				return true
+			}
+		}
+	case isBuiltinSID(parsed):
+		for _, rid := range privilegedBuiltinRIDs {
+			if parsed.RID() == rid {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsProtectedUsersSID reports whether sid names the Protected Users group of
+// a domain.
+func IsProtectedUsersSID(sid string) bool {
+	parsed, err := ParseSID(sid)
+	return err == nil && isDomainSID(parsed) && parsed.RID() == RIDProtectedUsers
+}
+
+// GetTransitiveGroups returns every group of the domain the user is a member
+// of, directly or through nested groups, using LDAP_MATCHING_RULE_IN_CHAIN.
+// The primary group, which is not recorded in member, is included along with
+// the groups it is nested in. The search covers the whole domain, so groups
+// outside the configured base DN, such as Domain Admins and those under
+// CN=Builtin, are found.
+func (um *UserManager) GetTransitiveGroups(user *User) ([]GroupRef, error) {
+	if user == nil || user.DistinguishedName == "" {
+		return nil, fmt.Errorf("user distinguished name cannot be empty")
+	}
+
+	const inChain = "member:1.2.840.113556.1.4.1941:="
+	filter := "(" + inChain + ldap.EscapeFilter(user.DistinguishedName) + ")"
+	primaryDN := primaryGroupDN(user)
+	if primaryDN != "" {
+		filter = "(|" + filter + "(" + inChain + ldap.EscapeFilter(primaryDN) + "))"
+	}
+
+	groups, err := um.searchDomainGroups("search_transitive_groups", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.PrimaryGroupSID != "" {
+		groups = append(groups, GroupRef{
+			DistinguishedName: primaryDN,
+			SID:               user.PrimaryGroupSID,
+		})
+	}
+
+	tflog.SubsystemDebug(um.ctx, "ldap", "Resolved transitive group membership", map[string]any{
+		"user_dn":     user.DistinguishedName,
+		"group_count": len(groups),
+	})
+
+	return groups, nil
+}
+
+// GetGroupAndParents returns the group with the given DN and every group of
+// the domain it is nested in, so that a group planned as a user's primary
+// group can be checked before the user is a member.
+func (um *UserManager) GetGroupAndParents(groupDN string) ([]GroupRef, error) {
+	if groupDN == "" {
+		return nil, fmt.Errorf("group distinguished name cannot be empty")
+	}
+
+	escaped := ldap.EscapeFilter(groupDN)
+	filter := "(|(distinguishedName=" + escaped + ")(member:1.2.840.113556.1.4.1941:=" + escaped + "))"
+	return um.searchDomainGroups("search_group_parents", filter)
+}
+
+// searchDomainGroups returns the groups matching filter anywhere in the
+// domain, rather than only under the configured base DN.
+func (um *UserManager) searchDomainGroups(operation, filter string) ([]GroupRef, error) {
+	domainDN := um.baseDN
+	if rootDSE, err := um.client.GetRootDSE(um.ctx); err == nil && rootDSE.DefaultNamingContext != "" {
+		domainDN = rootDSE.DefaultNamingContext
+	}
+
+	searchReq := &SearchRequest{
+		BaseDN:     domainDN,
+		Scope:      ScopeWholeSubtree,
+		Filter:     "(&(objectClass=group)" + filter + ")",
+		Attributes: []string{"distinguishedName", "objectSid"},
+		TimeLimit:  um.timeout,
+	}
+
+	result, err := um.client.SearchWithPaging(um.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError(operation, err)
+	}
+
+	groups := make([]GroupRef, 0, len(result.Entries)+1)
+	for _, entry := range result.Entries {
+		groups = append(groups, GroupRef{
+			DistinguishedName: entry.DN,
+			SID:               um.sidHandler.ExtractSIDSafe(entry),
+		})
+	}
+	return groups, nil
+}
+
+// primaryGroupDN returns the DN of the user's primary group, which
+// entryToUser leaves as the SID when it cannot be resolved.
+func primaryGroupDN(user *User) string {
+	if strings.HasPrefix(user.PrimaryGroup, "S-") {
+		return ""
+	}
+	return user.PrimaryGroup
+}
@@ -0,0 +1,120 @@
+package ldap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPrivilegedGroupSID(t *testing.T) {
+	tests := map[string]bool{
+		"S-1-5-21-1004336348-1177238915-682003330-512": true, // Domain Admins
+		"S-1-5-21-1004336348-1177238915-682003330-519": true, // Enterprise Admins
+		"S-1-5-21-1004336348-1177238915-682003330-527": true, // Enterprise Key Admins
+		"S-1-5-32-544": true, // Administrators
+		"S-1-5-32-551": true, // Backup Operators
+		"S-1-5-21-1004336348-1177238915-682003330-513":  false, // Domain Users
+		"S-1-5-21-1004336348-1177238915-682003330-525":  false, // Protected Users
+		"S-1-5-21-1004336348-1177238915-682003330-1512": false,
+		"S-1-5-32-545": false, // Users
+		"S-1-5-512":    false,
+		"not-a-sid":    false,
+	}
+	for sid, want := range tests {
+		assert.Equal(t, want, IsPrivilegedGroupSID(sid), sid)
+	}
+}
+
+func TestIsProtectedUsersSID(t *testing.T) {
+	assert.True(t, IsProtectedUsersSID("S-1-5-21-1004336348-1177238915-682003330-525"))
+	assert.False(t, IsProtectedUsersSID("S-1-5-32-525"))
+	assert.False(t, IsProtectedUsersSID("S-1-5-21-1004336348-1177238915-682003330-512"))
+}
+
+func TestUserManager_GetTransitiveGroups(t *testing.T) {
+	userDN := "CN=Admin (T0),OU=Admins,OU=Corp,DC=example,DC=com"
+	groupDN := "CN=Tier0 Admins,OU=Groups,OU=Corp,DC=example,DC=com"
+	groupSID := "S-1-5-21-1-2-3-1105"
+	builtinDN := "CN=Administrators,CN=Builtin,DC=example,DC=com"
+	builtinSID := "S-1-5-32-544"
+	primaryDN := "CN=Domain Admins,CN=Users,DC=example,DC=com"
+	primarySID := "S-1-5-21-1-2-3-512"
+
+	groupEntry := func(dn, sid string) *ldap.Entry {
+		sidBytes, err := NewSIDHandler().StringToSIDBytes(sid)
+		require.NoError(t, err)
+		return &ldap.Entry{DN: dn, Attributes: []*ldap.EntryAttribute{{Name: "objectSid", ByteValues: [][]byte{sidBytes}}}}
+	}
+
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{DefaultNamingContext: "DC=example,DC=com"}, nil)
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		// Rooted at the domain rather than the configured OU, with the DNs
+		// escaped for use in the in-chain filters.
+		return req.BaseDN == "DC=example,DC=com" &&
+			strings.Contains(req.Filter, "(member:1.2.840.113556.1.4.1941:=CN=Admin \\28T0\\29,OU=Admins,OU=Corp,DC=example,DC=com)") &&
+			strings.Contains(req.Filter, "(member:1.2.840.113556.1.4.1941:="+primaryDN+")")
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		groupEntry(groupDN, groupSID),
+		groupEntry(builtinDN, builtinSID),
+	}}, nil).Once()
+
+	um := NewUserManager(t.Context(), client, "OU=Corp,DC=example,DC=com", nil)
+	groups, err := um.GetTransitiveGroups(&User{
+		DistinguishedName: userDN,
+		PrimaryGroup:      primaryDN,
+		PrimaryGroupSID:   primarySID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []GroupRef{
+		{DistinguishedName: groupDN, SID: groupSID},
+		{DistinguishedName: builtinDN, SID: builtinSID},
+		{DistinguishedName: primaryDN, SID: primarySID},
+	}, groups)
+	client.AssertExpectations(t)
+
+	_, err = um.GetTransitiveGroups(&User{})
+	assert.Error(t, err)
+}
+
+func TestUserManager_GetGroupAndParents(t *testing.T) {
+	groupDN := "CN=Tier0 Admins,OU=Groups,OU=Corp,DC=example,DC=com"
+	groupSID := "S-1-5-21-1-2-3-1105"
+	parentDN := "CN=Domain Admins,CN=Users,DC=example,DC=com"
+	parentSID := "S-1-5-21-1-2-3-512"
+
+	groupEntry := func(dn, sid string) *ldap.Entry {
+		sidBytes, err := NewSIDHandler().StringToSIDBytes(sid)
+		require.NoError(t, err)
+		return &ldap.Entry{DN: dn, Attributes: []*ldap.EntryAttribute{{Name: "objectSid", ByteValues: [][]byte{sidBytes}}}}
+	}
+
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{DefaultNamingContext: "DC=example,DC=com"}, nil)
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == "DC=example,DC=com" &&
+			strings.Contains(req.Filter, "(distinguishedName="+groupDN+")") &&
+			strings.Contains(req.Filter, "(member:1.2.840.113556.1.4.1941:="+groupDN+")")
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		groupEntry(groupDN, groupSID),
+		groupEntry(parentDN, parentSID),
+	}}, nil).Once()
+
+	um := NewUserManager(t.Context(), client, "OU=Corp,DC=example,DC=com", nil)
+	groups, err := um.GetGroupAndParents(groupDN)
+
+	require.NoError(t, err)
+	assert.Equal(t, []GroupRef{
+		{DistinguishedName: groupDN, SID: groupSID},
+		{DistinguishedName: parentDN, SID: parentSID},
+	}, groups)
+	client.AssertExpectations(t)
+
+	_, err = um.GetGroupAndParents("")
+	assert.Error(t, err)
+}
@@ -14,6 +14,7 @@ type ProviderData struct {
 	Client               Client        // LDAP client for directory operations
 	CacheManager         *CacheManager // Cache manager for performance optimization
 	IgnoreMissingMembers bool          // When true, unresolvable members emit warnings instead of errors
+	EncryptedConnection  bool          // When true, connections are protected by TLS or a SASL seal layer
 }
 
 // NewProviderData creates a new provider data wrapper.
@@ -148,12 +149,32 @@ func (pd *ProviderData) GetCombinedStats() map[string]any {
 			"created":        poolStats.Created,
 			"errors":         poolStats.Errors,
 			"uptime_seconds": poolStats.Uptime.Seconds(),
+			"site":           poolStats.Site,
+			"active_server":  poolStats.ActiveServer,
+			"servers":        serverStatsMaps(poolStats.Servers),
 		}
 	}
 
 	return stats
 }
 
+// serverStatsMaps converts per-server pool statistics for logging.
+func serverStatsMaps(servers []ServerStats) []map[string]any {
+	result := make([]map[string]any, 0, len(servers))
+	for _, s := range servers {
+		result = append(result, map[string]any{
+			"address":      s.Address,
+			"priority":     s.Priority,
+			"weight":       s.Weight,
+			"failures":     s.Failures,
+			"circuit_open": s.CircuitOpen,
+			"latency_ms":   s.Latency.Milliseconds(),
+			"last_error":   s.LastError,
+		})
+	}
+	return result
+}
+
 // Close closes both the client and clears the cache.
 func (pd *ProviderData) Close() error {
 	var err error
@@ -0,0 +1,374 @@
+package ldap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jcmturner/gokrb5/v8/crypto"
+	krb5gssapi "github.com/jcmturner/gokrb5/v8/gssapi"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/iana/keyusage"
+	"github.com/jcmturner/gokrb5/v8/types"
+)
+
+// SASL security layers that can be negotiated after a GSSAPI bind (RFC 4752).
+const (
+	SASLSecurityLayerNone = "none" // Authentication only
+	SASLSecurityLayerSign = "sign" // Integrity protection (LDAP signing)
+	SASLSecurityLayerSeal = "seal" // Integrity and confidentiality protection
+)
+
+// SASL security layer bit masks exchanged in the final GSSAPI handshake.
+const (
+	saslLayerMaskNone = 0x01
+	saslLayerMaskSign = 0x02
+	saslLayerMaskSeal = 0x04
+
+	// saslMaxReceiveSize is the largest wrapped message we accept, and is
+	// advertised to the server during negotiation.
+	saslMaxReceiveSize = 0xFFFFFF
+
+	// saslWrapOverhead bounds the bytes a wrap token adds to its payload:
+	// the token header plus, when sealed, the confounder, the encrypted
+	// header copy and the HMAC.
+	saslWrapOverhead = 64
+)
+
+// RFC 4121 wrap token flags.
+const (
+	wrapFlagSentByAcceptor = 0x01
+	wrapFlagSealed         = 0x02
+	wrapFlagAcceptorSubkey = 0x04
+)
+
+// ValidateSASLSecurityLayer reports whether layer is a supported SASL
+// security layer. An empty string is treated as none.
+func ValidateSASLSecurityLayer(layer string) error {
+	switch layer {
+	case "", SASLSecurityLayerNone, SASLSecurityLayerSign, SASLSecurityLayerSeal:
+		return nil
+	default:
+		return fmt.Errorf("invalid SASL security layer %q: must be one of %s, %s or %s",
+			layer, SASLSecurityLayerNone, SASLSecurityLayerSign, SASLSecurityLayerSeal)
+	}
+}
+
+// saslLayerMask returns the negotiation bit for a security layer.
+func saslLayerMask(layer string) byte {
+	switch layer {
+	case SASLSecurityLayerSign:
+		return saslLayerMaskSign
+	case SASLSecurityLayerSeal:
+		return saslLayerMaskSeal
+	default:
+		return saslLayerMaskNone
+	}
+}
+
+// wrapGSSToken builds an RFC 4121 wrap token around payload. The payload is
+// encrypted when flags include wrapFlagSealed and only checksummed otherwise.
+func wrapGSSToken(key types.EncryptionKey, payload []byte, flags byte, seq uint64) ([]byte, error) {
+	encType, err := crypto.GetEtype(key.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	usage := uint32(keyusage.GSSAPI_INITIATOR_SEAL)
+	if flags&wrapFlagSentByAcceptor != 0 {
+		usage = keyusage.GSSAPI_ACCEPTOR_SEAL
+	}
+
+	if flags&wrapFlagSealed == 0 {
+		token := &krb5gssapi.WrapToken{
+			Flags:     flags,
+			EC:        uint16(encType.GetHMACBitLength() / 8),
+			SndSeqNum: seq,
+			Payload:   payload,
+		}
+		if err := token.SetCheckSum(key, usage); err != nil {
+			return nil, err
+		}
+		return token.Marshal()
+	}
+
+	// AES uses ciphertext stealing, so no filler is needed (EC = 0) and the
+	// encrypted copy of the header is identical to the one sent in clear.
+	header := wrapTokenHeader(flags, 0, 0, seq)
+	plain := make([]byte, 0, len(payload)+len(header))
+	plain = append(plain, payload...)
+	plain = append(plain, header...)
+	_, sealed, err := encType.EncryptMessage(key.KeyValue, plain, usage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal wrap token: %w", err)
+	}
+	return append(header, sealed...), nil
+}
+
+// unwrapGSSToken verifies an RFC 4121 wrap token and returns its payload and
+// flags. Rotated tokens (RRC > 0), as sent by Windows, are accepted.
+func unwrapGSSToken(key types.EncryptionKey, token []byte, fromAcceptor bool) ([]byte, byte, error) {
+	if len(token) < krb5gssapi.HdrLen {
+		return nil, 0, errors.New("wrap token shorter than header")
+	}
+	if token[0] != 0x05 || token[1] != 0x04 || token[3] != krb5gssapi.FillerByte {
+		return nil, 0, errors.New("not a GSS-API wrap token")
+	}
+	flags := token[2]
+	if (flags&wrapFlagSentByAcceptor != 0) != fromAcceptor {
+		return nil, 0, errors.New("wrap token sent in the wrong direction")
+	}
+
+	encType, err := crypto.GetEtype(key.KeyType)
+	if err != nil {
+		return nil, 0, err
+	}
+	usage := uint32(keyusage.GSSAPI_INITIATOR_SEAL)
+	if fromAcceptor {
+		usage = keyusage.GSSAPI_ACCEPTOR_SEAL
+	}
+
+	ec := int(binary.BigEndian.Uint16(token[4:6]))
+	rrc := int(binary.BigEndian.Uint16(token[6:8]))
+	seq := binary.BigEndian.Uint64(token[8:16])
+	data := rotateLeft(token[krb5gssapi.HdrLen:], rrc)
+
+	if flags&wrapFlagSealed == 0 {
+		if ec > len(data) {
+			return nil, 0, errors.New("wrap token checksum length exceeds token")
+		}
+		wt := &krb5gssapi.WrapToken{
+			Flags:     flags,
+			EC:        uint16(ec),
+			SndSeqNum: seq,
+			Payload:   data[:len(data)-ec],
+			CheckSum:  data[len(data)-ec:],
+		}
+		if _, err := wt.Verify(key, usage); err != nil {
+			return nil, 0, fmt.Errorf("wrap token integrity check failed: %w", err)
+		}
+		return wt.Payload, flags, nil
+	}
+
+	plain, err := encType.DecryptMessage(key.KeyValue, data, usage)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to unseal wrap token: %w", err)
+	}
+	if len(plain) < ec+krb5gssapi.HdrLen {
+		return nil, 0, errors.New("sealed wrap token too short")
+	}
+	trailer := plain[len(plain)-krb5gssapi.HdrLen:]
+	// The encrypted header copy carries RRC = 0; every other field must match.
+	if !bytes.Equal(trailer[:6], token[:6]) || !bytes.Equal(trailer[8:], token[8:16]) {
+		return nil, 0, errors.New("sealed wrap token header mismatch")
+	}
+	return plain[:len(plain)-krb5gssapi.HdrLen-ec], flags, nil
+}
+
+// wrapTokenSequence returns the sequence number of a wrap token. The header
+// is covered by the checksum or the encrypted header copy, so the value is
+// authenticated once unwrapGSSToken has accepted the token.
+func wrapTokenSequence(token []byte) uint64 {
+	return binary.BigEndian.Uint64(token[8:16])
+}
+
+// wrapTokenHeader returns the 16-byte RFC 4121 wrap token header.
+func wrapTokenHeader(flags byte, ec, rrc uint16, seq uint64) []byte {
+	header := make([]byte, krb5gssapi.HdrLen)
+	header[0], header[1] = 0x05, 0x04
+	header[2] = flags
+	header[3] = krb5gssapi.FillerByte
+	binary.BigEndian.PutUint16(header[4:6], ec)
+	binary.BigEndian.PutUint16(header[6:8], rrc)
+	binary.BigEndian.PutUint64(header[8:16], seq)
+	return header
+}
+
+// rotateLeft undoes a right rotation of n bytes.
+func rotateLeft(data []byte, n int) []byte {
+	out := make([]byte, len(data))
+	if len(data) == 0 {
+		return out
+	}
+	n %= len(data)
+	copy(out, data[n:])
+	copy(out[len(data)-n:], data[:n])
+	return out
+}
+
+// isAESEnctype reports whether the key can be used for RFC 4121 tokens as
+// implemented here. RC4 uses the older RFC 4757 token format.
+func isAESEnctype(keyType int32) bool {
+	switch keyType {
+	case etypeID.AES128_CTS_HMAC_SHA1_96, etypeID.AES256_CTS_HMAC_SHA1_96,
+		etypeID.AES128_CTS_HMAC_SHA256_128, etypeID.AES256_CTS_HMAC_SHA384_192:
+		return true
+	}
+	return false
+}
+
+// saslSecurityContext wraps and unwraps LDAP messages once a security layer
+// is in effect.
+type saslSecurityContext struct {
+	key     types.EncryptionKey
+	subkey  bool // Whether key is the acceptor subkey
+	seal    bool
+	maxSend int    // Largest wrapped message the server accepts
+	sendSeq uint64 // Next initiator sequence number; callers serialise wrap
+	recvSeq uint64 // Next expected acceptor sequence number; only the reader unwraps
+}
+
+func (s *saslSecurityContext) wrap(payload []byte) ([]byte, error) {
+	var flags byte
+	if s.subkey {
+		flags |= wrapFlagAcceptorSubkey
+	}
+	if s.seal {
+		flags |= wrapFlagSealed
+	}
+	token, err := wrapGSSToken(s.key, payload, flags, s.sendSeq)
+	if err != nil {
+		return nil, err
+	}
+	s.sendSeq++
+	return token, nil
+}
+
+func (s *saslSecurityContext) unwrap(token []byte) ([]byte, error) {
+	payload, flags, err := unwrapGSSToken(s.key, token, true)
+	if err != nil {
+		return nil, err
+	}
+	if s.seal && flags&wrapFlagSealed == 0 {
+		return nil, errors.New("server sent an unsealed message on a sealed connection")
+	}
+	// LDAP runs over a stream, so any gap or repeat is a replayed, dropped
+	// or reordered message.
+	if seq := wrapTokenSequence(token); seq != s.recvSeq {
+		return nil, fmt.Errorf("wrap token out of sequence: got %d, want %d", seq, s.recvSeq)
+	}
+	s.recvSeq++
+	return payload, nil
+}
+
+// maxChunk returns the largest payload that fits in a single wrap token.
+func (s *saslSecurityContext) maxChunk() int {
+	return max(s.maxSend-saslWrapOverhead, 1)
+}
+
+// saslConn is a net.Conn that passes traffic through unchanged until a SASL
+// security layer is enabled, after which every message is framed with a
+// 4-byte length and wrapped as described in RFC 4752.
+type saslConn struct {
+	net.Conn
+
+	security atomic.Pointer[saslSecurityContext]
+	writeMu  sync.Mutex
+
+	// Read state, only touched by the single LDAP reader goroutine.
+	raw   []byte // Received bytes not yet unwrapped
+	plain []byte // Unwrapped bytes not yet returned
+}
+
+// enable switches the connection to wrapped mode. It must be called after
+// the bind response has been read and before the next request is sent.
+func (c *saslConn) enable(security *saslSecurityContext) {
+	c.security.Store(security)
+}
+
+// enabled reports whether a security layer is in effect.
+func (c *saslConn) enabled() bool {
+	return c.security.Load() != nil
+}
+
+func (c *saslConn) Read(p []byte) (int, error) {
+	security := c.security.Load()
+	if security == nil {
+		n, err := c.Conn.Read(p)
+		if security = c.security.Load(); security == nil || n == 0 {
+			return n, err
+		}
+		// The layer was enabled while this read was blocked, so the bytes
+		// received belong to the first wrapped message.
+		c.raw = append(c.raw, p[:n]...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for len(c.plain) == 0 {
+		token, err := c.readFrame()
+		if err != nil {
+			return 0, err
+		}
+		if c.plain, err = security.unwrap(token); err != nil {
+			return 0, fmt.Errorf("SASL security layer: %w", err)
+		}
+	}
+
+	n := copy(p, c.plain)
+	c.plain = c.plain[n:]
+	return n, nil
+}
+
+// readFrame returns the next length-prefixed wrap token.
+func (c *saslConn) readFrame() ([]byte, error) {
+	if err := c.fill(4); err != nil {
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint32(c.raw[:4]))
+	if size > saslMaxReceiveSize {
+		return nil, fmt.Errorf("SASL security layer: message of %d bytes exceeds maximum of %d", size, saslMaxReceiveSize)
+	}
+	if err := c.fill(4 + size); err != nil {
+		return nil, err
+	}
+	token := c.raw[4 : 4+size]
+	c.raw = c.raw[4+size:]
+	return token, nil
+}
+
+// fill reads until at least n raw bytes are buffered.
+func (c *saslConn) fill(n int) error {
+	if len(c.raw) >= n {
+		return nil
+	}
+	buf := make([]byte, n-len(c.raw))
+	if _, err := io.ReadFull(c.Conn, buf); err != nil {
+		return err
+	}
+	c.raw = append(c.raw, buf...)
+	return nil
+}
+
+func (c *saslConn) Write(p []byte) (int, error) {
+	security := c.security.Load()
+	if security == nil {
+		return c.Conn.Write(p)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:min(len(p), security.maxChunk())]
+		token, err := security.wrap(chunk)
+		if err != nil {
+			return written, fmt.Errorf("SASL security layer: %w", err)
+		}
+		frame := make([]byte, 4+len(token))
+		binary.BigEndian.PutUint32(frame, uint32(len(token)))
+		copy(frame[4:], token)
+		if _, err := c.Conn.Write(frame); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
@@ -0,0 +1,260 @@
+package ldap
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSessionKey(t *testing.T) types.EncryptionKey {
+	t.Helper()
+	value := make([]byte, 32)
+	_, err := rand.Read(value)
+	require.NoError(t, err)
+	return types.EncryptionKey{KeyType: etypeID.AES256_CTS_HMAC_SHA1_96, KeyValue: value}
+}
+
+// rotateRight applies an RFC 4121 right rotation to a token, as Windows does.
+func rotateRight(t *testing.T, token []byte, rrc int) []byte {
+	t.Helper()
+	data := token[16:]
+	n := rrc % len(data)
+	rotated := append(append([]byte{}, data[len(data)-n:]...), data[:len(data)-n]...)
+	out := append(append([]byte{}, token[:16]...), rotated...)
+	binary.BigEndian.PutUint16(out[6:8], uint16(rrc))
+	return out
+}
+
+// readSASLFrame reads one length-prefixed wrap token from the server side of a pipe.
+func readSASLFrame(t *testing.T, conn net.Conn) []byte {
+	t.Helper()
+	var size [4]byte
+	_, err := io.ReadFull(conn, size[:])
+	require.NoError(t, err)
+	token := make([]byte, binary.BigEndian.Uint32(size[:]))
+	_, err = io.ReadFull(conn, token)
+	require.NoError(t, err)
+	return token
+}
+
+func TestValidateSASLSecurityLayer(t *testing.T) {
+	for _, layer := range []string{"", "none", "sign", "seal"} {
+		assert.NoError(t, ValidateSASLSecurityLayer(layer), layer)
+	}
+	assert.Error(t, ValidateSASLSecurityLayer("encrypt"))
+}
+
+func TestGSSWrapToken_RoundTrip(t *testing.T) {
+	key := testSessionKey(t)
+	payload := []byte("LDAP message payload")
+
+	tests := []struct {
+		name  string
+		flags byte
+		rrc   int
+	}{
+		{name: "sign", flags: wrapFlagAcceptorSubkey},
+		{name: "seal", flags: wrapFlagAcceptorSubkey | wrapFlagSealed},
+		{name: "sign rotated", flags: wrapFlagSentByAcceptor | wrapFlagAcceptorSubkey, rrc: 12},
+		{name: "seal rotated", flags: wrapFlagSentByAcceptor | wrapFlagAcceptorSubkey | wrapFlagSealed, rrc: 28},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := wrapGSSToken(key, payload, tt.flags, 7)
+			require.NoError(t, err)
+			if tt.flags&wrapFlagSealed != 0 {
+				assert.False(t, bytes.Contains(token, payload), "sealed token must not contain the plaintext")
+			}
+			if tt.rrc > 0 {
+				token = rotateRight(t, token, tt.rrc)
+			}
+
+			fromAcceptor := tt.flags&wrapFlagSentByAcceptor != 0
+			got, flags, err := unwrapGSSToken(key, token, fromAcceptor)
+			require.NoError(t, err)
+			assert.Equal(t, payload, got)
+			assert.Equal(t, tt.flags, flags)
+
+			_, _, err = unwrapGSSToken(key, token, !fromAcceptor)
+			assert.Error(t, err, "direction must be checked")
+
+			tampered := append([]byte{}, token...)
+			tampered[len(tampered)-1] ^= 0xFF
+			_, _, err = unwrapGSSToken(key, tampered, fromAcceptor)
+			assert.Error(t, err, "tampering must be detected")
+		})
+	}
+}
+
+func TestSASLConn(t *testing.T) {
+	for _, layer := range []string{SASLSecurityLayerSign, SASLSecurityLayerSeal} {
+		t.Run(layer, func(t *testing.T) {
+			key := testSessionKey(t)
+			clientSide, serverSide := net.Pipe()
+			defer clientSide.Close()
+			defer serverSide.Close()
+			conn := &saslConn{Conn: clientSide}
+
+			// Traffic passes through unchanged until the layer is enabled.
+			go func() { _, _ = conn.Write([]byte("bind")) }()
+			buf := make([]byte, 4)
+			_, err := io.ReadFull(serverSide, buf)
+			require.NoError(t, err)
+			assert.Equal(t, "bind", string(buf))
+
+			seal := layer == SASLSecurityLayerSeal
+			conn.enable(&saslSecurityContext{key: key, subkey: true, seal: seal, maxSend: saslWrapOverhead + 8, sendSeq: 2, recvSeq: 1})
+
+			// Writes are split to the server's maximum buffer size.
+			message := []byte("search request body")
+			go func() { _, _ = conn.Write(message) }()
+			var received []byte
+			for seq := uint64(2); len(received) < len(message); seq++ {
+				token := readSASLFrame(t, serverSide)
+				assert.Equal(t, seq, binary.BigEndian.Uint64(token[8:16]))
+				payload, flags, err := unwrapGSSToken(key, token, false)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(payload), 8)
+				assert.Equal(t, seal, flags&wrapFlagSealed != 0)
+				received = append(received, payload...)
+			}
+			assert.Equal(t, message, received)
+
+			// Responses are unwrapped, including tokens rotated by the server.
+			flags := byte(wrapFlagSentByAcceptor | wrapFlagAcceptorSubkey)
+			if seal {
+				flags |= wrapFlagSealed
+			}
+			response, err := wrapGSSToken(key, []byte("search result"), flags, 1)
+			require.NoError(t, err)
+			response = rotateRight(t, response, 28)
+			frame := binary.BigEndian.AppendUint32(nil, uint32(len(response)))
+			go func() { _, _ = serverSide.Write(append(frame, response...)) }()
+
+			got := make([]byte, len("search result"))
+			_, err = io.ReadFull(conn, got)
+			require.NoError(t, err)
+			assert.Equal(t, "search result", string(got))
+		})
+	}
+}
+
+func TestSASLConn_EnabledDuringBlockedRead(t *testing.T) {
+	key := testSessionKey(t)
+	clientSide, serverSide := net.Pipe()
+	defer clientSide.Close()
+	defer serverSide.Close()
+	conn := &saslConn{Conn: clientSide}
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		buf := make([]byte, 64)
+		n, err := conn.Read(buf)
+		done <- result{buf[:n], err}
+	}()
+
+	// The reader is blocked in plain mode when the bind completes.
+	conn.enable(&saslSecurityContext{key: key, maxSend: saslMaxReceiveSize, recvSeq: 1})
+	token, err := wrapGSSToken(key, []byte("wrapped"), wrapFlagSentByAcceptor|wrapFlagAcceptorSubkey, 1)
+	require.NoError(t, err)
+	_, err = serverSide.Write(binary.BigEndian.AppendUint32(nil, uint32(len(token))))
+	require.NoError(t, err)
+	_, err = serverSide.Write(token)
+	require.NoError(t, err)
+
+	res := <-done
+	require.NoError(t, res.err)
+	assert.Equal(t, "wrapped", string(res.data))
+}
+
+func TestSASLConn_RejectsUnsealedOnSealedConnection(t *testing.T) {
+	key := testSessionKey(t)
+	clientSide, serverSide := net.Pipe()
+	defer clientSide.Close()
+	defer serverSide.Close()
+	conn := &saslConn{Conn: clientSide}
+	conn.enable(&saslSecurityContext{key: key, seal: true, maxSend: saslMaxReceiveSize, recvSeq: 1})
+
+	token, err := wrapGSSToken(key, []byte("signed only"), wrapFlagSentByAcceptor|wrapFlagAcceptorSubkey, 1)
+	require.NoError(t, err)
+	go func() {
+		_, _ = serverSide.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(token))), token...))
+	}()
+
+	_, err = conn.Read(make([]byte, 64))
+	assert.ErrorContains(t, err, "unsealed")
+}
+
+func TestSASLConn_RejectsOutOfSequence(t *testing.T) {
+	key := testSessionKey(t)
+	clientSide, serverSide := net.Pipe()
+	defer clientSide.Close()
+	defer serverSide.Close()
+	conn := &saslConn{Conn: clientSide}
+	conn.enable(&saslSecurityContext{key: key, maxSend: saslMaxReceiveSize, recvSeq: 1})
+
+	// The first message is accepted and then replayed.
+	token, err := wrapGSSToken(key, []byte("result"), wrapFlagSentByAcceptor|wrapFlagAcceptorSubkey, 1)
+	require.NoError(t, err)
+	frame := append(binary.BigEndian.AppendUint32(nil, uint32(len(token))), token...)
+	go func() {
+		_, _ = serverSide.Write(frame)
+		_, _ = serverSide.Write(frame)
+	}()
+
+	got := make([]byte, len("result"))
+	_, err = io.ReadFull(conn, got)
+	require.NoError(t, err)
+	assert.Equal(t, "result", string(got))
+
+	_, err = conn.Read(make([]byte, 64))
+	assert.ErrorContains(t, err, "out of sequence")
+}
+
+func TestConnectionPool_UseSASLSecurityLayer(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ConnectionConfig
+		want   bool
+	}{
+		{name: "kerberos without TLS", config: ConnectionConfig{KerberosRealm: "EXAMPLE.COM", Username: "admin", SASLSecurityLayer: "sign"}, want: true},
+		{name: "kerberos with StartTLS", config: ConnectionConfig{KerberosRealm: "EXAMPLE.COM", Username: "admin", SASLSecurityLayer: "seal", UseTLS: true}},
+		{name: "kerberos with TLS skipped", config: ConnectionConfig{KerberosRealm: "EXAMPLE.COM", Username: "admin", SASLSecurityLayer: "seal", UseTLS: true, SkipTLS: true}, want: true},
+		{name: "layer none", config: ConnectionConfig{KerberosRealm: "EXAMPLE.COM", Username: "admin", SASLSecurityLayer: "none"}},
+		{name: "simple bind", config: ConnectionConfig{Username: "admin", Password: "secret", SASLSecurityLayer: "sign"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &connectionPool{config: &tt.config}
+			assert.Equal(t, tt.want, pool.useSASLSecurityLayer())
+		})
+	}
+}
+
+func TestConnectionPool_SecurityLayerConnectionIsNotRebound(t *testing.T) {
+	pool := &connectionPool{config: &ConnectionConfig{KerberosRealm: "EXAMPLE.COM", Username: "admin", SASLSecurityLayer: "sign"}}
+	layer := &saslConn{}
+	layer.enable(&saslSecurityContext{})
+	conn := &PooledConnection{conn: &ldap.Conn{}, saslLayer: layer, authenticated: true, authTime: time.Now().Add(-time.Hour)}
+
+	assert.False(t, pool.needsReAuthentication(conn), "an aged security layer connection is kept, not re-bound")
+
+	err := pool.authenticateConnection(conn)
+	assert.ErrorContains(t, err, "cannot be re-authenticated")
+}
@@ -1,6 +1,7 @@
 package ldap
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"slices"
@@ -34,6 +35,16 @@ const (
 	AccessAllowedACEType uint8 = 0x00
 	AccessDeniedACEType  uint8 = 0x01
 	SystemAuditACEType   uint8 = 0x02
+
+	AccessAllowedObjectACEType uint8 = 0x05
+	AccessDeniedObjectACEType  uint8 = 0x06
+)
+
+// Object ACE flags (MS-DTYP 2.4.4.3), stating which GUIDs follow the access
+// mask in an object ACE body.
+const (
+	ACEObjectTypePresent          uint32 = 0x00000001
+	ACEInheritedObjectTypePresent uint32 = 0x00000002
 )
 
 // ACE flags (MS-DTYP 2.4.4.1).
@@ -54,6 +65,17 @@ const (
 	AccessMaskDeleteChild uint32 = 0x00000040
 )
 
+// AccessMaskControlAccess is ADS_RIGHT_DS_CONTROL_ACCESS, which grants the
+// extended right named by the object type of an object ACE.
+const AccessMaskControlAccess uint32 = 0x00000100
+
+// changePasswordRightGUID is the User-Change-Password extended right
+// (ab721a53-1e2f-11d0-9819-00aa0040529b) in its binary (mixed-endian) form.
+var changePasswordRightGUID = [16]byte{
+	0x53, 0x1a, 0x72, 0xab, 0x2f, 0x1e, 0xd0, 0x11,
+	0x98, 0x19, 0x00, 0xaa, 0x00, 0x40, 0x52, 0x9b,
+}
+
 // LDAP_SERVER_SD_FLAGS_OID control value bits. Only DACL is requested/written
 // when toggling OU protection so owner/group/SACL are left untouched.
 const (
@@ -412,6 +434,21 @@ func isEveryoneSID(s SID) bool {
 		s.SubAuthorities[0] == 0
 }
 
+// selfSIDValue is the well-known S-1-5-10 (Principal Self) SID.
+var selfSIDValue = SID{
+	RevisionLevel:  1,
+	Authority:      5,
+	SubAuthorities: []uint32{10},
+}
+
+// isSelfSID reports whether s is the Principal Self SID (S-1-5-10).
+func isSelfSID(s SID) bool {
+	return s.RevisionLevel == 1 &&
+		s.Authority == 5 &&
+		len(s.SubAuthorities) == 1 &&
+		s.SubAuthorities[0] == 10
+}
+
 // isDenyDeleteEveryoneACE reports whether the ACE is the canonical
 // "protect from accidental deletion" entry: deny DELETE+DELETE_CHILD to
 // Everyone, with no opaque body.
@@ -469,3 +506,119 @@ func (sd *SecurityDescriptor) RemoveDenyDeleteEveryoneACE() bool {
 	sd.DACL.ACEs = filtered
 	return removed
 }
+
+// newObjectACE builds an object ACE of aceType granting or denying mask on
+// the object type objectType to sid. The body is kept in RawBody, as for
+// object ACEs read by Unmarshal.
+func newObjectACE(aceType uint8, mask uint32, objectType [16]byte, sid SID) (ACE, error) {
+	sidBytes, err := sid.Bytes()
+	if err != nil {
+		return ACE{}, err
+	}
+	body := make([]byte, 8, 8+len(objectType)+len(sidBytes))
+	binary.LittleEndian.PutUint32(body[0:4], mask)
+	binary.LittleEndian.PutUint32(body[4:8], ACEObjectTypePresent)
+	body = append(body, objectType[:]...)
+	body = append(body, sidBytes...)
+	return ACE{AceType: aceType, RawBody: body}, nil
+}
+
+// parseObjectACE decodes the body of an object ACE into its access mask,
+// object type (nil when absent) and SID.
+func parseObjectACE(ace ACE) (mask uint32, objectType []byte, sid SID, ok bool) {
+	if ace.AceType != AccessAllowedObjectACEType && ace.AceType != AccessDeniedObjectACEType {
+		return 0, nil, SID{}, false
+	}
+	body := ace.RawBody
+	if len(body) < 8 {
+		return 0, nil, SID{}, false
+	}
+	mask = binary.LittleEndian.Uint32(body[0:4])
+	flags := binary.LittleEndian.Uint32(body[4:8])
+	offset := 8
+	if flags&ACEObjectTypePresent != 0 {
+		if len(body) < offset+16 {
+			return 0, nil, SID{}, false
+		}
+		objectType = body[offset : offset+16]
+		offset += 16
+	}
+	if flags&ACEInheritedObjectTypePresent != 0 {
+		offset += 16
+	}
+	if len(body) < offset+8 {
+		return 0, nil, SID{}, false
+	}
+	sid, err := DecodeSID(body[offset:])
+	if err != nil {
+		return 0, nil, SID{}, false
+	}
+	return mask, objectType, sid, true
+}
+
+// isDenyChangePasswordACE reports whether the ACE is one of the entries
+// Windows uses for "user cannot change password": deny the
+// User-Change-Password extended right to Everyone or to Principal Self.
+func isDenyChangePasswordACE(ace ACE) bool {
+	if ace.AceType != AccessDeniedObjectACEType || ace.AceFlags&InheritedACE != 0 {
+		return false
+	}
+	mask, objectType, sid, ok := parseObjectACE(ace)
+	return ok &&
+		mask&AccessMaskControlAccess != 0 &&
+		bytes.Equal(objectType, changePasswordRightGUID[:]) &&
+		(isEveryoneSID(sid) || isSelfSID(sid))
+}
+
+// HasDenyChangePasswordACE reports whether the DACL denies the
+// User-Change-Password extended right to Everyone or Principal Self, which
+// is how Windows implements "user cannot change password".
+func (sd *SecurityDescriptor) HasDenyChangePasswordACE() bool {
+	if sd == nil || sd.DACL == nil {
+		return false
+	}
+	return slices.ContainsFunc(sd.DACL.ACEs, isDenyChangePasswordACE)
+}
+
+// AddDenyChangePasswordACEs inserts ACEs denying the User-Change-Password
+// extended right to Everyone and Principal Self at the front of the DACL, so
+// they precede any allow ACEs. A new DACL is created if none exists.
+func (sd *SecurityDescriptor) AddDenyChangePasswordACEs() error {
+	var aces []ACE
+	for _, sid := range []SID{everyoneSIDValue, selfSIDValue} {
+		ace, err := newObjectACE(AccessDeniedObjectACEType, AccessMaskControlAccess, changePasswordRightGUID, sid)
+		if err != nil {
+			return err
+		}
+		aces = append(aces, ace)
+	}
+	if sd.DACL == nil {
+		sd.DACL = &ACL{AclRevision: 4, ACEs: aces}
+		sd.Control |= SEDACLPresent
+		return nil
+	}
+	// Object ACEs require the DS revision of the ACL
+	sd.DACL.AclRevision = max(sd.DACL.AclRevision, 4)
+	sd.DACL.ACEs = append(aces, sd.DACL.ACEs...)
+	return nil
+}
+
+// RemoveDenyChangePasswordACEs drops the explicit ACEs denying the
+// User-Change-Password extended right to Everyone and Principal Self.
+// Returns true if any ACE was removed.
+func (sd *SecurityDescriptor) RemoveDenyChangePasswordACEs() bool {
+	if sd == nil || sd.DACL == nil {
+		return false
+	}
+	filtered := sd.DACL.ACEs[:0]
+	removed := false
+	for _, ace := range sd.DACL.ACEs {
+		if isDenyChangePasswordACE(ace) {
+			removed = true
+			continue
+		}
+		filtered = append(filtered, ace)
+	}
+	sd.DACL.ACEs = filtered
+	return removed
+}
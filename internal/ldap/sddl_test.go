@@ -10,11 +10,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// accessAllowedObjectACEType is 0x05 — ACCESS_ALLOWED_OBJECT_ACE (MS-DTYP
-// 2.4.4.3). Kept as a test-local constant so the production code isn't
-// polluted with a constant it never uses.
-const accessAllowedObjectACEType uint8 = 0x05
-
 // buildObjectACEBody constructs a plausible ACCESS_ALLOWED_OBJECT_ACE body
 // (everything after the 4-byte generic ACE header):
 //
@@ -217,6 +212,65 @@ func TestSecurityDescriptor_AddDenyDeleteACE_NoDACL(t *testing.T) {
 	assert.True(t, sd.HasDenyDeleteEveryoneACE())
 }
 
+func TestSecurityDescriptor_DenyChangePasswordACEs(t *testing.T) {
+	sd := buildSD(false)
+	assert.False(t, sd.HasDenyChangePasswordACE())
+
+	require.NoError(t, sd.AddDenyChangePasswordACEs())
+	assert.True(t, sd.HasDenyChangePasswordACE())
+	// Both deny ACEs must precede any allow.
+	assert.Equal(t, AccessDeniedObjectACEType, sd.DACL.ACEs[0].AceType)
+	assert.Equal(t, AccessDeniedObjectACEType, sd.DACL.ACEs[1].AceType)
+
+	// The object ACEs survive a marshal round trip.
+	raw, err := sd.Marshal()
+	require.NoError(t, err)
+	parsed, err := UnmarshalSecurityDescriptor(raw)
+	require.NoError(t, err)
+	assert.True(t, parsed.HasDenyChangePasswordACE())
+	assert.False(t, parsed.HasDenyDeleteEveryoneACE())
+
+	_, objectType, sid, ok := parseObjectACE(parsed.DACL.ACEs[1])
+	require.True(t, ok)
+	assert.Equal(t, changePasswordRightGUID[:], objectType)
+	assert.Equal(t, "S-1-5-10", sid.String())
+
+	assert.True(t, parsed.RemoveDenyChangePasswordACEs())
+	assert.False(t, parsed.HasDenyChangePasswordACE())
+	assert.Len(t, parsed.DACL.ACEs, len(buildSD(false).DACL.ACEs))
+
+	// Removing again is a no-op.
+	assert.False(t, parsed.RemoveDenyChangePasswordACEs())
+}
+
+func TestSecurityDescriptor_DenyChangePasswordACE_OtherRights(t *testing.T) {
+	var otherGUID [16]byte
+	otherGUID[0] = 0x01
+
+	inherited, err := newObjectACE(AccessDeniedObjectACEType, AccessMaskControlAccess, changePasswordRightGUID, everyoneSIDValue)
+	require.NoError(t, err)
+	inherited.AceFlags = InheritedACE
+	otherRight, err := newObjectACE(AccessDeniedObjectACEType, AccessMaskControlAccess, otherGUID, everyoneSIDValue)
+	require.NoError(t, err)
+	allowed, err := newObjectACE(AccessAllowedObjectACEType, AccessMaskControlAccess, changePasswordRightGUID, selfSIDValue)
+	require.NoError(t, err)
+
+	sd := &SecurityDescriptor{DACL: &ACL{AclRevision: 4, ACEs: []ACE{inherited, otherRight, allowed}}}
+	assert.False(t, sd.HasDenyChangePasswordACE())
+	assert.False(t, sd.RemoveDenyChangePasswordACEs())
+	assert.Len(t, sd.DACL.ACEs, 3)
+}
+
+func TestSecurityDescriptor_AddDenyChangePasswordACEs_NoDACL(t *testing.T) {
+	sd := &SecurityDescriptor{Revision: 1, Control: SESelfRelative}
+
+	require.NoError(t, sd.AddDenyChangePasswordACEs())
+	require.NotNil(t, sd.DACL)
+	assert.True(t, sd.Control&SEDACLPresent != 0)
+	assert.Equal(t, uint8(4), sd.DACL.AclRevision)
+	assert.True(t, sd.HasDenyChangePasswordACE())
+}
+
 func TestUnmarshalSecurityDescriptor_Errors(t *testing.T) {
 	// Too short.
 	_, err := UnmarshalSecurityDescriptor([]byte{0x01, 0x00, 0x00, 0x80})
@@ -284,7 +338,7 @@ func TestSecurityDescriptor_ObjectACE_RoundTrip(t *testing.T) {
 					SID:        everyoneSIDValue,
 				},
 				{
-					AceType:  accessAllowedObjectACEType,
+					AceType:  AccessAllowedObjectACEType,
 					AceFlags: ContainerInheritACE | InheritedACE,
 					RawBody:  objectBody,
 				},
@@ -309,7 +363,7 @@ func TestSecurityDescriptor_ObjectACE_RoundTrip(t *testing.T) {
 	assert.Equal(t, everyoneSIDValue.String(), parsed.DACL.ACEs[0].SID.String())
 
 	// Object ACE preserved opaquely.
-	assert.Equal(t, accessAllowedObjectACEType, parsed.DACL.ACEs[1].AceType)
+	assert.Equal(t, AccessAllowedObjectACEType, parsed.DACL.ACEs[1].AceType)
 	require.NotNil(t, parsed.DACL.ACEs[1].RawBody)
 	assert.True(t, bytes.Equal(objectBody, parsed.DACL.ACEs[1].RawBody),
 		"object ACE body must round-trip verbatim")
@@ -345,7 +399,7 @@ func TestSecurityDescriptor_ObjectACE_ProtectionHelpers(t *testing.T) {
 			AclRevision: 4,
 			ACEs: []ACE{
 				{
-					AceType:  accessAllowedObjectACEType,
+					AceType:  AccessAllowedObjectACEType,
 					AceFlags: ContainerInheritACE,
 					RawBody:  append([]byte(nil), objectBody...),
 				},
@@ -367,7 +421,7 @@ func TestSecurityDescriptor_ObjectACE_ProtectionHelpers(t *testing.T) {
 	require.Len(t, parsed.DACL.ACEs, 2)
 	assert.Equal(t, AccessDeniedACEType, parsed.DACL.ACEs[0].AceType)
 	assert.Equal(t, everyoneSIDValue.String(), parsed.DACL.ACEs[0].SID.String())
-	assert.Equal(t, accessAllowedObjectACEType, parsed.DACL.ACEs[1].AceType)
+	assert.Equal(t, AccessAllowedObjectACEType, parsed.DACL.ACEs[1].AceType)
 	require.NotNil(t, parsed.DACL.ACEs[1].RawBody)
 	assert.True(t, bytes.Equal(objectBody, parsed.DACL.ACEs[1].RawBody),
 		"object ACE body must be untouched after Add")
@@ -380,14 +434,14 @@ func TestSecurityDescriptor_ObjectACE_ProtectionHelpers(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, reparsed.HasDenyDeleteEveryoneACE())
 	require.Len(t, reparsed.DACL.ACEs, 2)
-	assert.Equal(t, accessAllowedObjectACEType, reparsed.DACL.ACEs[1].AceType)
+	assert.Equal(t, AccessAllowedObjectACEType, reparsed.DACL.ACEs[1].AceType)
 	assert.True(t, bytes.Equal(objectBody, reparsed.DACL.ACEs[1].RawBody))
 
 	// Remove drops only the deny ACE and leaves the object ACE intact.
 	removed := reparsed.RemoveDenyDeleteEveryoneACE()
 	assert.True(t, removed)
 	require.Len(t, reparsed.DACL.ACEs, 1)
-	assert.Equal(t, accessAllowedObjectACEType, reparsed.DACL.ACEs[0].AceType)
+	assert.Equal(t, AccessAllowedObjectACEType, reparsed.DACL.ACEs[0].AceType)
 	require.NotNil(t, reparsed.DACL.ACEs[0].RawBody)
 	assert.True(t, bytes.Equal(objectBody, reparsed.DACL.ACEs[0].RawBody),
 		"object ACE body must survive Remove")
@@ -0,0 +1,104 @@
+package ldap
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// SearchEntry is an entry returned by SearchEntries, with attribute values
+// decoded to strings.
+type SearchEntry struct {
+	DN         string              `json:"dn"`
+	Attributes map[string][]string `json:"attributes"`
+}
+
+// SearchEntries performs a paged search with a raw filter and decodes the
+// returned attribute values according to their schema syntax: GUIDs (such
+// as objectGUID) are decoded through GUIDHandler, SIDs (such as objectSid
+// and sIDHistory) through SIDHandler, and other binary values are
+// base64-encoded. Attributes are keyed by the names the server returns.
+// The second result reports whether req.SizeLimit truncated the results.
+func SearchEntries(ctx context.Context, client Client, req *SearchRequest) ([]*SearchEntry, bool, error) {
+	if req == nil {
+		return nil, false, fmt.Errorf("search request cannot be nil")
+	}
+	if _, err := ldap.CompileFilter(req.Filter); err != nil {
+		return nil, false, fmt.Errorf("invalid search filter %q: %w", req.Filter, err)
+	}
+
+	result, err := client.SearchWithPaging(ctx, req)
+	if err != nil {
+		return nil, false, WrapError("search_entries", err)
+	}
+
+	// Look up the syntax of every attribute returned, once per search
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range result.Entries {
+		for _, attr := range entry.Attributes {
+			if key := strings.ToLower(attr.Name); !seen[key] {
+				seen[key] = true
+				names = append(names, attr.Name)
+			}
+		}
+	}
+	schemas, err := LookupAttributeSchema(ctx, client, names)
+	if err != nil {
+		return nil, false, WrapError("lookup_attribute_schema", err)
+	}
+
+	entries := make([]*SearchEntry, len(result.Entries))
+	for i, entry := range result.Entries {
+		searchEntry := &SearchEntry{
+			DN:         entry.DN,
+			Attributes: make(map[string][]string, len(entry.Attributes)),
+		}
+		for _, attr := range entry.Attributes {
+			schema, known := schemas[strings.ToLower(attr.Name)]
+			searchEntry.Attributes[attr.Name] = decodeAttributeValues(attr, schema, known)
+		}
+		entries[i] = searchEntry
+	}
+
+	return entries, result.HasMore, nil
+}
+
+// decodeAttributeValues converts the values of an attribute to strings. An
+// octet string attribute whose name ends in "GUID" holds GUIDs. Values of
+// attributes missing from the schema, such as ranged attributes, are
+// base64-encoded only when they are not valid UTF-8.
+func decodeAttributeValues(attr *ldap.EntryAttribute, schema AttributeSchema, known bool) []string {
+	values := make([]string, len(attr.ByteValues))
+	for i, raw := range attr.ByteValues {
+		switch {
+		case !known:
+			if utf8.Valid(raw) {
+				values[i] = string(raw)
+			} else {
+				values[i] = base64.StdEncoding.EncodeToString(raw)
+			}
+		case schema.Syntax == syntaxSID:
+			sid, err := NewSIDHandler().ConvertBinarySIDToString(raw)
+			if err != nil {
+				sid = base64.StdEncoding.EncodeToString(raw)
+			}
+			values[i] = sid
+		case schema.Syntax == syntaxOctetString && strings.HasSuffix(strings.ToLower(attr.Name), "guid"):
+			guid, err := NewGUIDHandler().GUIDBytesToString(raw)
+			if err != nil {
+				guid = base64.StdEncoding.EncodeToString(raw)
+			}
+			values[i] = guid
+		case schema.IsBinary():
+			values[i] = base64.StdEncoding.EncodeToString(raw)
+		default:
+			values[i] = string(raw)
+		}
+	}
+	return values
+}
@@ -0,0 +1,81 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchEntries(t *testing.T) {
+	const schemaDN = "CN=Schema,CN=Configuration,DC=test,DC=local"
+
+	guid := "12345678-1234-1234-1234-123456789012"
+	guidBytes, err := NewGUIDHandler().StringToGUIDBytes(guid)
+	require.NoError(t, err)
+	sid := "S-1-5-21-1004336348-1177238915-682003330-512"
+	sidBytes, err := NewSIDHandler().StringToSIDBytes(sid)
+	require.NoError(t, err)
+	photo := []byte{0xff, 0xd8, 0xff, 0xe0}
+
+	client := &MockClient{}
+	client.On("GetRootDSE", mock.Anything).Return(&RootDSEInfo{SchemaNamingContext: schemaDN}, nil)
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == "OU=Groups,DC=test,DC=local" && req.SizeLimit == 1
+	})).Return(&SearchResult{
+		Entries: []*ldap.Entry{{
+			DN: "CN=Admins,OU=Groups,DC=test,DC=local",
+			Attributes: []*ldap.EntryAttribute{
+				{Name: "cn", Values: []string{"Admins"}, ByteValues: [][]byte{[]byte("Admins")}},
+				{Name: "objectGUID", Values: []string{string(guidBytes)}, ByteValues: [][]byte{guidBytes}},
+				{Name: "objectSid", Values: []string{string(sidBytes)}, ByteValues: [][]byte{sidBytes}},
+				{Name: "thumbnailPhoto", Values: []string{string(photo)}, ByteValues: [][]byte{photo}},
+				{Name: "member;range=0-1499", Values: []string{"CN=a,DC=test,DC=local"}, ByteValues: [][]byte{[]byte("CN=a,DC=test,DC=local")}},
+			},
+		}},
+		Total:   1,
+		HasMore: true,
+	}, nil).Once()
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.BaseDN == schemaDN
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry("CN=Common-Name,"+schemaDN, map[string][]string{"lDAPDisplayName": {"cn"}, "attributeSyntax": {"2.5.5.12"}}),
+		ldap.NewEntry("CN=Object-Guid,"+schemaDN, map[string][]string{"lDAPDisplayName": {"objectGUID"}, "attributeSyntax": {"2.5.5.10"}}),
+		ldap.NewEntry("CN=Object-Sid,"+schemaDN, map[string][]string{"lDAPDisplayName": {"objectSid"}, "attributeSyntax": {"2.5.5.17"}}),
+		ldap.NewEntry("CN=Picture,"+schemaDN, map[string][]string{"lDAPDisplayName": {"thumbnailPhoto"}, "attributeSyntax": {"2.5.5.10"}}),
+	}}, nil).Once()
+
+	entries, truncated, err := SearchEntries(t.Context(), client, &SearchRequest{
+		BaseDN:    "OU=Groups,DC=test,DC=local",
+		Scope:     ScopeWholeSubtree,
+		Filter:    "(objectClass=group)",
+		SizeLimit: 1,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, truncated)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "CN=Admins,OU=Groups,DC=test,DC=local", entries[0].DN)
+	assert.Equal(t, map[string][]string{
+		"cn":                  {"Admins"},
+		"objectGUID":          {guid},
+		"objectSid":           {sid},
+		"thumbnailPhoto":      {"/9j/4A=="},
+		"member;range=0-1499": {"CN=a,DC=test,DC=local"},
+	}, entries[0].Attributes)
+	client.AssertExpectations(t)
+}
+
+func TestSearchEntries_InvalidFilter(t *testing.T) {
+	client := &MockClient{}
+
+	_, _, err := SearchEntries(t.Context(), client, &SearchRequest{
+		BaseDN: "DC=test,DC=local",
+		Filter: "(objectClass=user",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid search filter")
+	client.AssertNotCalled(t, "SearchWithPaging", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,227 @@
+package ldap
+
+import (
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Circuit breaker settings for individual domain controllers.
+const (
+	// circuitFailureThreshold is the number of consecutive connection
+	// failures after which a server's circuit opens.
+	circuitFailureThreshold = 2
+
+	// circuitBaseCooldown is how long a circuit stays open after it first
+	// trips. Each further failure while half-open doubles the cool-down.
+	circuitBaseCooldown = 5 * time.Second
+
+	// circuitMaxCooldown caps the exponential cool-down.
+	circuitMaxCooldown = 5 * time.Minute
+
+	// latencySmoothing is the weight given to the newest sample in the
+	// exponentially weighted moving average of connect latency.
+	latencySmoothing = 0.3
+)
+
+// ServerStats describes the health of a single domain controller as seen by
+// the connection pool.
+type ServerStats struct {
+	Address     string        // host:port
+	Priority    int           // SRV priority
+	Weight      int           // SRV weight
+	Failures    int           // Consecutive connection failures
+	CircuitOpen bool          // Whether the server is currently skipped
+	OpenUntil   time.Time     // When the circuit closes again, if open
+	Latency     time.Duration // Moving average of connection setup time
+	LastError   string        // Most recent connection failure
+}
+
+// serverHealth is the mutable state tracked per server.
+type serverHealth struct {
+	failures  int
+	openUntil time.Time
+	latency   time.Duration
+	lastError string
+}
+
+// serverHealthTracker records connection outcomes per server and orders
+// servers for connection attempts.
+type serverHealthTracker struct {
+	mu      sync.Mutex
+	servers map[string]*serverHealth
+
+	// now and randIntN are replaced in tests.
+	now      func() time.Time
+	randIntN func(n int) int
+}
+
+func newServerHealthTracker() *serverHealthTracker {
+	return &serverHealthTracker{
+		servers:  make(map[string]*serverHealth),
+		now:      time.Now,
+		randIntN: rand.IntN,
+	}
+}
+
+// get returns the state for server, creating it if necessary. The caller
+// must hold t.mu.
+func (t *serverHealthTracker) get(server *ServerInfo) *serverHealth {
+	key := serverAddress(server)
+	h, ok := t.servers[key]
+	if !ok {
+		h = &serverHealth{}
+		t.servers[key] = h
+	}
+	return h
+}
+
+// recordSuccess closes the server's circuit and folds latency into its
+// moving average.
+func (t *serverHealthTracker) recordSuccess(server *ServerInfo, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.get(server)
+	h.failures = 0
+	h.openUntil = time.Time{}
+	h.lastError = ""
+	if h.latency == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(h.latency))
+	}
+}
+
+// recordFailure counts a connection failure and opens the circuit once the
+// threshold is reached. It reports whether the circuit was opened.
+func (t *serverHealthTracker) recordFailure(server *ServerInfo, err error) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.get(server)
+	h.failures++
+	if err != nil {
+		h.lastError = err.Error()
+	}
+	if h.failures < circuitFailureThreshold {
+		return false
+	}
+
+	cooldown := circuitBaseCooldown << min(h.failures-circuitFailureThreshold, 16)
+	h.openUntil = t.now().Add(min(cooldown, circuitMaxCooldown))
+	return true
+}
+
+// isOpen reports whether the server's circuit is open. Once the cool-down has
+// passed the circuit is half-open and the server may be tried again.
+func (t *serverHealthTracker) isOpen(server *ServerInfo) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.servers[serverAddress(server)]
+	return ok && t.now().Before(h.openUntil)
+}
+
+// order returns servers in the order they should be tried. Servers with a
+// closed circuit come first, grouped by ascending priority with a weighted
+// random order within each priority (RFC 2782). Servers with an open
+// circuit follow, soonest to close first, so that a connection can still be
+// attempted when every server is failing.
+func (t *serverHealthTracker) order(servers []*ServerInfo) []*ServerInfo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var available, open []*ServerInfo
+	for _, server := range servers {
+		if h, ok := t.servers[serverAddress(server)]; ok && now.Before(h.openUntil) {
+			open = append(open, server)
+		} else {
+			available = append(available, server)
+		}
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].Priority < available[j].Priority
+	})
+	result := make([]*ServerInfo, 0, len(servers))
+	for start := 0; start < len(available); {
+		end := start
+		for end < len(available) && available[end].Priority == available[start].Priority {
+			end++
+		}
+		result = append(result, t.weightedShuffle(available[start:end])...)
+		start = end
+	}
+
+	sort.SliceStable(open, func(i, j int) bool {
+		return t.servers[serverAddress(open[i])].openUntil.Before(t.servers[serverAddress(open[j])].openUntil)
+	})
+	return append(result, open...)
+}
+
+// weightedShuffle orders servers of equal priority by repeated weighted
+// random selection as described in RFC 2782. Zero-weight servers are only
+// selected ahead of weighted ones by chance of a zero draw.
+func (t *serverHealthTracker) weightedShuffle(servers []*ServerInfo) []*ServerInfo {
+	remaining := make([]*ServerInfo, len(servers))
+	copy(remaining, servers)
+	// RFC 2782 places zero-weight entries first so they can be drawn at all.
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Weight == 0 && remaining[j].Weight != 0
+	})
+
+	result := make([]*ServerInfo, 0, len(servers))
+	for len(remaining) > 0 {
+		total := 0
+		for _, server := range remaining {
+			total += server.Weight
+		}
+
+		pick := 0
+		if total > 0 {
+			target := t.randIntN(total + 1)
+			sum := 0
+			for i, server := range remaining {
+				sum += server.Weight
+				if sum >= target {
+					pick = i
+					break
+				}
+			}
+		}
+
+		result = append(result, remaining[pick])
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+	return result
+}
+
+// stats returns the state of each server in the given order.
+func (t *serverHealthTracker) stats(servers []*ServerInfo) []ServerStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	result := make([]ServerStats, 0, len(servers))
+	for _, server := range servers {
+		s := ServerStats{
+			Address:  serverAddress(server),
+			Priority: server.Priority,
+			Weight:   server.Weight,
+		}
+		if h, ok := t.servers[s.Address]; ok {
+			s.Failures = h.failures
+			s.CircuitOpen = now.Before(h.openUntil)
+			if s.CircuitOpen {
+				s.OpenUntil = h.openUntil
+			}
+			s.Latency = h.latency
+			s.LastError = h.lastError
+		}
+		result = append(result, s)
+	}
+	return result
+}
@@ -0,0 +1,182 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHealthTracker returns a tracker with a controllable clock.
+func newTestHealthTracker() (*serverHealthTracker, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newServerHealthTracker()
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func hosts(servers []*ServerInfo) []string {
+	result := make([]string, 0, len(servers))
+	for _, server := range servers {
+		result = append(result, server.Host)
+	}
+	return result
+}
+
+func TestServerHealthTracker_CircuitBreaker(t *testing.T) {
+	tracker, now := newTestHealthTracker()
+	dc1 := &ServerInfo{Host: "dc1.example.com", Port: 636}
+	boom := errors.New("connection refused")
+
+	assert.False(t, tracker.recordFailure(dc1, boom), "first failure stays below threshold")
+	assert.False(t, tracker.isOpen(dc1))
+
+	assert.True(t, tracker.recordFailure(dc1, boom), "threshold opens the circuit")
+	assert.True(t, tracker.isOpen(dc1))
+
+	// Half-open once the base cool-down has passed.
+	*now = now.Add(circuitBaseCooldown)
+	assert.False(t, tracker.isOpen(dc1))
+
+	// Failing while half-open doubles the cool-down.
+	assert.True(t, tracker.recordFailure(dc1, boom))
+	*now = now.Add(circuitBaseCooldown)
+	assert.True(t, tracker.isOpen(dc1))
+	*now = now.Add(circuitBaseCooldown)
+	assert.False(t, tracker.isOpen(dc1))
+
+	// The cool-down is capped.
+	for range 20 {
+		tracker.recordFailure(dc1, boom)
+	}
+	stats := tracker.stats([]*ServerInfo{dc1})
+	require.Len(t, stats, 1)
+	assert.Equal(t, now.Add(circuitMaxCooldown), stats[0].OpenUntil)
+	assert.Equal(t, "connection refused", stats[0].LastError)
+
+	// A success closes the circuit and resets the failure count.
+	tracker.recordSuccess(dc1, 10*time.Millisecond)
+	assert.False(t, tracker.isOpen(dc1))
+	stats = tracker.stats([]*ServerInfo{dc1})
+	assert.Zero(t, stats[0].Failures)
+	assert.Empty(t, stats[0].LastError)
+	assert.Equal(t, 10*time.Millisecond, stats[0].Latency)
+}
+
+func TestServerHealthTracker_Latency(t *testing.T) {
+	tracker, _ := newTestHealthTracker()
+	dc1 := &ServerInfo{Host: "dc1.example.com", Port: 636}
+
+	tracker.recordSuccess(dc1, 100*time.Millisecond)
+	tracker.recordSuccess(dc1, 200*time.Millisecond)
+
+	stats := tracker.stats([]*ServerInfo{dc1})
+	assert.Equal(t, 130*time.Millisecond, stats[0].Latency)
+}
+
+func TestServerHealthTracker_Order(t *testing.T) {
+	servers := []*ServerInfo{
+		{Host: "backup.example.com", Port: 389, Priority: 10, Weight: 100},
+		{Host: "small.example.com", Port: 389, Priority: 0, Weight: 10},
+		{Host: "big.example.com", Port: 389, Priority: 0, Weight: 90},
+	}
+
+	t.Run("priority groups and weighted draw", func(t *testing.T) {
+		tracker, _ := newTestHealthTracker()
+		var draws []int
+		tracker.randIntN = func(n int) int {
+			draws = append(draws, n)
+			return n - 1
+		}
+
+		got := tracker.order(servers)
+		// The first draw over weights 10+90 lands in big's range.
+		assert.Equal(t, []string{"big.example.com", "small.example.com", "backup.example.com"}, hosts(got))
+		assert.Equal(t, []int{101, 11, 101}, draws)
+	})
+
+	t.Run("low draw favours the first weighted server", func(t *testing.T) {
+		tracker, _ := newTestHealthTracker()
+		tracker.randIntN = func(int) int { return 5 }
+
+		got := tracker.order(servers)
+		assert.Equal(t, []string{"small.example.com", "big.example.com", "backup.example.com"}, hosts(got))
+	})
+
+	t.Run("open circuits go last", func(t *testing.T) {
+		tracker, now := newTestHealthTracker()
+		tracker.randIntN = func(int) int { return 0 }
+		boom := errors.New("connection refused")
+
+		for range circuitFailureThreshold {
+			tracker.recordFailure(servers[2], boom)
+		}
+		*now = now.Add(time.Second)
+		for range circuitFailureThreshold {
+			tracker.recordFailure(servers[1], boom)
+		}
+
+		got := tracker.order(servers)
+		assert.Equal(t, []string{"backup.example.com", "big.example.com", "small.example.com"}, hosts(got))
+	})
+
+	t.Run("zero weights", func(t *testing.T) {
+		tracker, _ := newTestHealthTracker()
+		zero := []*ServerInfo{
+			{Host: "a.example.com", Port: 389},
+			{Host: "b.example.com", Port: 389},
+		}
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, hosts(tracker.order(zero)))
+	})
+}
+
+func TestConnectionPool_SkipsServerWithOpenCircuit(t *testing.T) {
+	down := map[string]bool{"dc1.example.com": true, "dc2.example.com": true}
+	pool, attempts := newPinningTestPool(t, func(server *ServerInfo) error {
+		if down[server.Host] {
+			return NewConnectionError("failed to connect to "+server.Host, true, errors.New("connection refused"))
+		}
+		return nil
+	})
+
+	// Both servers fail once; then dc2 recovers while dc1 reaches the
+	// threshold and its circuit opens.
+	_, err := pool.createConnection(context.Background())
+	require.Error(t, err)
+	down["dc2.example.com"] = false
+	_, err = pool.createConnection(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dc1.example.com", "dc2.example.com", "dc1.example.com", "dc2.example.com"}, *attempts)
+
+	// Without pinning, dc1 would be tried first by weight and order.
+	pool.activeServer = nil
+	*attempts = nil
+	_, err = pool.createConnection(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dc2.example.com"}, *attempts, "dc1 should be skipped while its circuit is open")
+
+	stats := pool.Stats()
+	assert.Equal(t, "dc2.example.com:636", stats.ActiveServer)
+	require.Len(t, stats.Servers, 2)
+	assert.Equal(t, "dc1.example.com:636", stats.Servers[0].Address)
+	assert.True(t, stats.Servers[0].CircuitOpen)
+	assert.Equal(t, circuitFailureThreshold, stats.Servers[0].Failures)
+	assert.False(t, stats.Servers[1].CircuitOpen)
+	assert.Zero(t, stats.Servers[1].Failures)
+}
+
+func TestConnectionPool_PinnedServerWithOpenCircuitIsNotPreferred(t *testing.T) {
+	pool, _ := newPinningTestPool(t, func(*ServerInfo) error { return nil })
+	dc1, dc2 := pool.servers[0], pool.servers[1]
+	pool.setActiveServer(dc1)
+
+	assert.Equal(t, []string{"dc1.example.com", "dc2.example.com"}, hosts(pool.candidateServers()))
+
+	for range circuitFailureThreshold {
+		pool.health.recordFailure(dc1, errors.New("connection refused"))
+	}
+	assert.Equal(t, []string{dc2.Host, dc1.Host}, hosts(pool.candidateServers()))
+}
@@ -0,0 +1,259 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// SPNAccount is an account (user, computer or managed service account) and
+// the service principal names registered to it.
+type SPNAccount struct {
+	ObjectGUID            string   `json:"objectGUID"`
+	DistinguishedName     string   `json:"distinguishedName"`
+	ServicePrincipalNames []string `json:"servicePrincipalNames,omitempty"`
+}
+
+// SPNOwner is an object that a service principal name is registered to.
+type SPNOwner struct {
+	SPN               string `json:"spn"`               // The SPN as registered on the owner
+	ObjectGUID        string `json:"objectGUID"`        // objectGUID of the owner
+	DistinguishedName string `json:"distinguishedName"` // DN of the owner
+}
+
+// SPNManager manages the servicePrincipalName values of accounts. Kerberos
+// requires an SPN to be registered to a single account in the forest, so
+// SPNs are checked for duplicates against a Global Catalog server.
+type SPNManager struct {
+	ctx         context.Context
+	client      Client
+	guidHandler *GUIDHandler
+	baseDN      string
+	timeout     time.Duration
+}
+
+// NewSPNManager creates a new SPN manager instance.
+func NewSPNManager(ctx context.Context, client Client, baseDN string) *SPNManager {
+	return &SPNManager{
+		ctx:         ctx,
+		client:      client,
+		guidHandler: NewGUIDHandler(),
+		baseDN:      baseDN,
+		timeout:     30 * time.Second,
+	}
+}
+
+// SetTimeout sets the LDAP operation timeout.
+func (sm *SPNManager) SetTimeout(timeout time.Duration) {
+	sm.timeout = timeout
+}
+
+// ValidateSPN checks that spn has the form serviceclass/host[:port][/name],
+// such as HTTP/web.example.com or MSSQLSvc/db.example.com:1433.
+func ValidateSPN(spn string) error {
+	if strings.ContainsAny(spn, " \t\r\n") {
+		return fmt.Errorf("SPN cannot contain whitespace")
+	}
+
+	parts := strings.Split(spn, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("SPN must have the form serviceclass/host[:port][/name]")
+	}
+	if slices.Contains(parts, "") {
+		return fmt.Errorf("SPN components cannot be empty")
+	}
+
+	host, port, hasPort := strings.Cut(parts[1], ":")
+	if host == "" {
+		return fmt.Errorf("SPN host cannot be empty")
+	}
+	if hasPort {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("SPN port %q must be a number between 1 and 65535", port)
+		}
+	}
+
+	return nil
+}
+
+// SPNEqual reports whether two SPNs are the same. Active Directory compares
+// SPNs case-insensitively.
+func SPNEqual(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// SPNsEqual reports whether two SPN lists hold the same SPNs, ignoring order
+// and case.
+func SPNsEqual(a, b []string) bool {
+	normalize := func(spns []string) []string {
+		out := make([]string, len(spns))
+		for i, spn := range spns {
+			out[i] = strings.ToLower(spn)
+		}
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+// GetAccount retrieves an account and its SPNs by objectGUID.
+func (sm *SPNManager) GetAccount(guid string) (*SPNAccount, error) {
+	if guid == "" {
+		return nil, fmt.Errorf("account GUID cannot be empty")
+	}
+
+	if !sm.guidHandler.IsValidGUID(guid) {
+		return nil, fmt.Errorf("invalid GUID format: %s", guid)
+	}
+
+	searchReq, err := sm.guidHandler.GenerateGUIDSearchRequest(sm.baseDN, guid)
+	if err != nil {
+		return nil, WrapError("generate_guid_search", err)
+	}
+	searchReq.Attributes = []string{"objectGUID", "distinguishedName", "servicePrincipalName"}
+	searchReq.TimeLimit = sm.timeout
+
+	result, err := sm.client.Search(sm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_account_by_guid", err)
+	}
+
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_account", "account with GUID %s not found", guid)
+	}
+
+	entry := result.Entries[0]
+	account := &SPNAccount{
+		DistinguishedName:     entry.DN,
+		ServicePrincipalNames: entry.GetAttributeValues("servicePrincipalName"),
+	}
+	account.ObjectGUID, err = sm.guidHandler.ExtractGUID(entry)
+	if err != nil {
+		return nil, WrapError("extract_account_guid", err)
+	}
+
+	return account, nil
+}
+
+// AddSPN registers spn to the account with the given objectGUID. Adding an
+// SPN the account already holds succeeds.
+func (sm *SPNManager) AddSPN(guid, spn string) error {
+	if err := ValidateSPN(spn); err != nil {
+		return err
+	}
+
+	account, err := sm.GetAccount(guid)
+	if err != nil {
+		return WrapError("get_account_for_add_spn", err)
+	}
+
+	if slices.ContainsFunc(account.ServicePrincipalNames, func(s string) bool { return SPNEqual(s, spn) }) {
+		return nil
+	}
+
+	modReq := &ModifyRequest{
+		DN:            account.DistinguishedName,
+		AddAttributes: map[string][]string{"servicePrincipalName": {spn}},
+	}
+	if err := sm.client.Modify(sm.ctx, modReq); err != nil {
+		return WrapError("add_spn", err)
+	}
+
+	return nil
+}
+
+// RemoveSPN unregisters spn from the account with the given objectGUID.
+// Removing an SPN the account does not hold, or from an account that no
+// longer exists, succeeds.
+func (sm *SPNManager) RemoveSPN(guid, spn string) error {
+	account, err := sm.GetAccount(guid)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil
+		}
+		return WrapError("get_account_for_remove_spn", err)
+	}
+
+	// Delete the value as registered, which may differ in case
+	i := slices.IndexFunc(account.ServicePrincipalNames, func(s string) bool { return SPNEqual(s, spn) })
+	if i < 0 {
+		return nil
+	}
+
+	modReq := &ModifyRequest{
+		DN:           account.DistinguishedName,
+		DeleteValues: map[string][]string{"servicePrincipalName": {account.ServicePrincipalNames[i]}},
+	}
+	if err := sm.client.Modify(sm.ctx, modReq); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return nil
+		}
+		return WrapError("remove_spn", err)
+	}
+
+	return nil
+}
+
+// FindDuplicateSPNs searches the forest through a Global Catalog server for
+// objects other than the account with objectGUID ownerGUID that hold any of
+// spns. An empty ownerGUID reports every owner. The result is keyed by the
+// SPN as given in spns.
+func (sm *SPNManager) FindDuplicateSPNs(spns []string, ownerGUID string) (map[string][]SPNOwner, error) {
+	if len(spns) == 0 {
+		return nil, nil
+	}
+
+	var filter strings.Builder
+	filter.WriteString("(|")
+	for _, spn := range spns {
+		fmt.Fprintf(&filter, "(servicePrincipalName=%s)", ldap.EscapeFilter(spn))
+	}
+	filter.WriteString(")")
+
+	// An empty base DN covers every domain of the forest
+	searchReq := &SearchRequest{
+		BaseDN:        "",
+		Scope:         ScopeWholeSubtree,
+		Filter:        filter.String(),
+		Attributes:    []string{"objectGUID", "distinguishedName", "servicePrincipalName"},
+		TimeLimit:     sm.timeout,
+		GlobalCatalog: true,
+	}
+
+	tflog.SubsystemDebug(sm.ctx, "ldap", "Searching Global Catalog for duplicate SPNs", map[string]any{
+		"spn_count":  len(spns),
+		"owner_guid": ownerGUID,
+	})
+
+	result, err := sm.client.SearchWithPaging(sm.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("search_duplicate_spns", err)
+	}
+
+	duplicates := make(map[string][]SPNOwner)
+	for _, entry := range result.Entries {
+		guid := sm.guidHandler.ExtractGUIDSafe(entry)
+		if ownerGUID != "" && strings.EqualFold(guid, ownerGUID) {
+			continue
+		}
+		for _, registered := range entry.GetAttributeValues("servicePrincipalName") {
+			for _, spn := range spns {
+				if SPNEqual(spn, registered) {
+					duplicates[spn] = append(duplicates[spn], SPNOwner{
+						SPN:               registered,
+						ObjectGUID:        guid,
+						DistinguishedName: entry.DN,
+					})
+				}
+			}
+		}
+	}
+
+	return duplicates, nil
+}
@@ -0,0 +1,153 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// createMockSPNEntry returns an account entry holding the given SPNs.
+func createMockSPNEntry(t *testing.T, guid, dn string, spns ...string) *ldap.Entry {
+	t.Helper()
+
+	guidBytes, err := NewGUIDHandler().StringToGUIDBytes(guid)
+	require.NoError(t, err)
+
+	return &ldap.Entry{
+		DN: dn,
+		Attributes: []*ldap.EntryAttribute{
+			{Name: "objectGUID", ByteValues: [][]byte{guidBytes}},
+			{Name: "distinguishedName", Values: []string{dn}},
+			{Name: "servicePrincipalName", Values: spns},
+		},
+	}
+}
+
+func TestValidateSPN(t *testing.T) {
+	tests := []struct {
+		spn     string
+		wantErr bool
+	}{
+		{"HTTP/web.example.com", false},
+		{"HTTP/web", false},
+		{"MSSQLSvc/db.example.com:1433", false},
+		{"ldap/dc01.example.com/example.com", false},
+		{"HTTP", true},
+		{"HTTP/", true},
+		{"/web.example.com", true},
+		{"HTTP/web.example.com/a/b", true},
+		{"HTTP/web example.com", true},
+		{"MSSQLSvc/db.example.com:", true},
+		{"MSSQLSvc/db.example.com:sql", true},
+		{"MSSQLSvc/db.example.com:70000", true},
+		{"MSSQLSvc/:1433", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spn, func(t *testing.T) {
+			err := ValidateSPN(tt.spn)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateSPNs(t *testing.T) {
+	ownerGUID := "12345678-1234-1234-1234-123456789012"
+	otherGUID := "87654321-4321-4321-4321-210987654321"
+
+	client := &MockClient{}
+	client.On("SearchWithPaging", mock.Anything, mock.MatchedBy(func(req *SearchRequest) bool {
+		return req.GlobalCatalog && req.BaseDN == "" &&
+			req.Filter == `(|(servicePrincipalName=HTTP/web.example.com)(servicePrincipalName=HTTP/api.example.com))`
+	})).Return(&SearchResult{Entries: []*ldap.Entry{
+		createMockSPNEntry(t, ownerGUID, "CN=svc-web,OU=Service,DC=test,DC=local", "HTTP/web.example.com"),
+		createMockSPNEntry(t, otherGUID, "CN=svc-other,OU=Service,DC=child,DC=test,DC=local", "http/WEB.example.com", "HTTP/other.example.com"),
+	}}, nil).Once()
+
+	sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+	duplicates, err := sm.FindDuplicateSPNs([]string{"HTTP/web.example.com", "HTTP/api.example.com"}, ownerGUID)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]SPNOwner{
+		"HTTP/web.example.com": {{
+			SPN:               "http/WEB.example.com",
+			ObjectGUID:        otherGUID,
+			DistinguishedName: "CN=svc-other,OU=Service,DC=child,DC=test,DC=local",
+		}},
+	}, duplicates)
+	client.AssertExpectations(t)
+}
+
+func TestAddSPN(t *testing.T) {
+	guid := "12345678-1234-1234-1234-123456789012"
+	dn := "CN=svc-web,OU=Service,DC=test,DC=local"
+
+	t.Run("adds a new SPN", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockSPNEntry(t, guid, dn, "HTTP/web.example.com")}}, nil).Once()
+		client.On("Modify", mock.Anything, mock.MatchedBy(func(req *ModifyRequest) bool {
+			return req.DN == dn && assert.ObjectsAreEqual(req.AddAttributes, map[string][]string{
+				"servicePrincipalName": {"HTTP/api.example.com"},
+			})
+		})).Return(nil).Once()
+
+		sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+		require.NoError(t, sm.AddSPN(guid, "HTTP/api.example.com"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("existing SPN is not added again", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockSPNEntry(t, guid, dn, "HTTP/web.example.com")}}, nil).Once()
+
+		sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+		require.NoError(t, sm.AddSPN(guid, "http/WEB.example.com"))
+		client.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid SPN", func(t *testing.T) {
+		client := &MockClient{}
+
+		sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+		require.Error(t, sm.AddSPN(guid, "web.example.com"))
+		client.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRemoveSPN(t *testing.T) {
+	guid := "12345678-1234-1234-1234-123456789012"
+	dn := "CN=svc-web,OU=Service,DC=test,DC=local"
+
+	t.Run("removes the registered value", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).
+			Return(&SearchResult{Entries: []*ldap.Entry{createMockSPNEntry(t, guid, dn, "HTTP/Web.example.com", "HTTP/api.example.com")}}, nil).Once()
+		client.On("Modify", mock.Anything, mock.MatchedBy(func(req *ModifyRequest) bool {
+			return req.DN == dn && assert.ObjectsAreEqual(req.DeleteValues, map[string][]string{
+				"servicePrincipalName": {"HTTP/Web.example.com"},
+			})
+		})).Return(nil).Once()
+
+		sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+		require.NoError(t, sm.RemoveSPN(guid, "HTTP/web.example.com"))
+		client.AssertExpectations(t)
+	})
+
+	t.Run("missing account", func(t *testing.T) {
+		client := &MockClient{}
+		client.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{}, nil).Once()
+
+		sm := NewSPNManager(t.Context(), client, "DC=test,DC=local")
+		require.NoError(t, sm.RemoveSPN(guid, "HTTP/web.example.com"))
+		client.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+	})
+}
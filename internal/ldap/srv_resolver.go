@@ -0,0 +1,281 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// resolvConfPath is the resolver configuration read for nameservers.
+const resolvConfPath = "/etc/resolv.conf"
+
+// dnsQueryTimeout bounds a single query to one nameserver.
+const dnsQueryTimeout = 2 * time.Second
+
+// dnsUDPSize is the UDP payload size advertised with EDNS(0), large enough
+// for the SRV record sets of domains with many domain controllers.
+const dnsUDPSize = 4096
+
+// errDNSTruncated reports a UDP response that did not fit and must be
+// retried over TCP.
+var errDNSTruncated = errors.New("DNS response truncated")
+
+// dnsSRVResolver resolves SRV records with the system resolver and learns
+// their TTL with a direct query to the nameservers of resolv.conf. The
+// standard library does not expose TTLs, and querying nameservers directly
+// for the records themselves would bypass nsswitch and the split-DNS, VPN
+// and scoped resolvers of the platform. The direct query is therefore only
+// advisory: when it fails, DefaultSRVTTL applies.
+type dnsSRVResolver struct {
+	resolver    SRVResolver
+	nameservers []string // host:port
+}
+
+// newDNSSRVResolver returns a resolver using the system resolver for records
+// and the nameservers of resolv.conf for TTLs.
+func newDNSSRVResolver() *dnsSRVResolver {
+	return &dnsSRVResolver{
+		resolver:    netSRVResolver{resolver: net.DefaultResolver},
+		nameservers: readNameservers(resolvConfPath),
+	}
+}
+
+// readNameservers returns the nameserver addresses of a resolv.conf file,
+// or nil if it cannot be read.
+func readNameservers(path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var servers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		// Validate the address, ignoring an IPv6 zone
+		if ip := net.ParseIP(strings.Split(fields[1], "%")[0]); ip != nil {
+			servers = append(servers, net.JoinHostPort(fields[1], "53"))
+		}
+	}
+	return servers
+}
+
+// LookupSRV returns the records reported by the system resolver and the
+// TTL of the record set, or DefaultSRVTTL when the TTL cannot be learned.
+func (r *dnsSRVResolver) LookupSRV(ctx context.Context, name string) ([]*net.SRV, time.Duration, error) {
+	records, _, err := r.resolver.LookupSRV(ctx, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return records, r.lookupTTL(ctx, name), nil
+}
+
+// lookupTTL queries each nameserver in turn for the TTL of the SRV record
+// set of name and returns the first answer, or DefaultSRVTTL if none
+// answers.
+func (r *dnsSRVResolver) lookupTTL(ctx context.Context, name string) time.Duration {
+	for _, server := range r.nameservers {
+		if ctx.Err() != nil {
+			break
+		}
+		if ttl, err := querySRVTTL(ctx, server, name); err == nil {
+			return ttl
+		}
+	}
+	return DefaultSRVTTL
+}
+
+// querySRVTTL sends an SRV query for name to server over UDP, retrying over
+// TCP when the response is truncated, and returns the lowest TTL of the
+// answer.
+func querySRVTTL(ctx context.Context, server, name string) (time.Duration, error) {
+	fqdn := name
+	if !strings.HasSuffix(fqdn, ".") {
+		fqdn += "."
+	}
+	qname, err := dnsmessage.NewName(fqdn)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DNS name %q: %w", name, err)
+	}
+	question := dnsmessage.Question{Name: qname, Type: dnsmessage.TypeSRV, Class: dnsmessage.ClassINET}
+
+	var idBytes [2]byte
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return 0, fmt.Errorf("failed to generate DNS query ID: %w", err)
+	}
+	id := binary.BigEndian.Uint16(idBytes[:])
+
+	query, err := buildSRVQuery(id, question)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := exchangeDNS(ctx, "udp", server, query, id, question)
+	if err == nil {
+		ttl, err := parseSRVTTL(response, id, question)
+		if !errors.Is(err, errDNSTruncated) {
+			return ttl, err
+		}
+	}
+
+	response, err = exchangeDNS(ctx, "tcp", server, query, id, question)
+	if err != nil {
+		return 0, err
+	}
+	return parseSRVTTL(response, id, question)
+}
+
+// buildSRVQuery builds a recursive SRV query advertising EDNS(0).
+func buildSRVQuery(id uint16, question dnsmessage.Question) ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(question); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(dnsUDPSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// exchangeDNS sends query to server over network and returns the response.
+// UDP datagrams that do not answer the query, identified by id and
+// question, are ignored until the deadline. TCP messages carry a two-byte
+// length prefix (RFC 1035 4.2.2).
+func exchangeDNS(ctx context.Context, network, server string, query []byte, id uint16, question dnsmessage.Question) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, dnsQueryTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, dnsUDPSize)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, err
+			}
+			if dnsResponseMatches(buf[:n], id, question) {
+				return buf[:n], nil
+			}
+		}
+	}
+
+	framed := binary.BigEndian.AppendUint16(nil, uint16(len(query)))
+	if _, err := conn.Write(append(framed, query...)); err != nil {
+		return nil, err
+	}
+	var length [2]byte
+	if _, err := io.ReadFull(conn, length[:]); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// dnsResponseMatches reports whether response answers the query with the
+// given id and question.
+func dnsResponseMatches(response []byte, id uint16, question dnsmessage.Question) bool {
+	var p dnsmessage.Parser
+	header, err := p.Start(response)
+	if err != nil || header.ID != id || !header.Response {
+		return false
+	}
+	q, err := p.Question()
+	if err != nil {
+		return false
+	}
+	return q.Type == question.Type && q.Class == question.Class &&
+		strings.EqualFold(q.Name.String(), question.Name.String())
+}
+
+// parseSRVTTL returns the lowest TTL of the SRV records in a response to
+// the query with the given id and question.
+func parseSRVTTL(response []byte, id uint16, question dnsmessage.Question) (time.Duration, error) {
+	if !dnsResponseMatches(response, id, question) {
+		return 0, fmt.Errorf("unexpected DNS response")
+	}
+
+	var p dnsmessage.Parser
+	header, err := p.Start(response)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DNS response: %w", err)
+	}
+	if header.Truncated {
+		return 0, errDNSTruncated
+	}
+	if header.RCode != dnsmessage.RCodeSuccess {
+		return 0, fmt.Errorf("DNS query failed: %s", header.RCode)
+	}
+
+	if err := p.SkipAllQuestions(); err != nil {
+		return 0, fmt.Errorf("invalid DNS response: %w", err)
+	}
+
+	var ttl time.Duration
+	found := false
+	for {
+		h, err := p.AnswerHeader()
+		if errors.Is(err, dnsmessage.ErrSectionDone) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid DNS response: %w", err)
+		}
+		if err := p.SkipAnswer(); err != nil {
+			return 0, fmt.Errorf("invalid DNS response: %w", err)
+		}
+		// CNAMEs leading to the SRV records are followed by the recursor
+		if h.Type != dnsmessage.TypeSRV {
+			continue
+		}
+		recordTTL := time.Duration(h.TTL) * time.Second
+		if !found || recordTTL < ttl {
+			ttl = recordTTL
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("DNS response contains no SRV records")
+	}
+	return ttl, nil
+}
@@ -0,0 +1,233 @@
+package ldap
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testSRVAnswer is an SRV record served by the fake DNS server.
+type testSRVAnswer struct {
+	target string
+	ttl    uint32
+}
+
+// serveDNS answers queries over UDP and TCP on a loopback port for as long
+// as the test runs. Responses are built by respond from the parsed query.
+func serveDNS(t *testing.T, respond func(header dnsmessage.Header, q dnsmessage.Question, tcp bool) []byte) string {
+	t.Helper()
+
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { udp.Close() })
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	if err != nil {
+		t.Skipf("TCP port of the fake DNS server is taken: %v", err)
+	}
+	t.Cleanup(func() { tcp.Close() })
+
+	parse := func(query []byte) (dnsmessage.Header, dnsmessage.Question, error) {
+		var p dnsmessage.Parser
+		header, err := p.Start(query)
+		if err != nil {
+			return header, dnsmessage.Question{}, err
+		}
+		q, err := p.Question()
+		return header, q, err
+	}
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, addr, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if header, q, err := parse(buf[:n]); err == nil {
+				_, _ = udp.WriteTo(respond(header, q, false), addr)
+			}
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+			var length [2]byte
+			if _, err := io.ReadFull(conn, length[:]); err == nil {
+				query := make([]byte, binary.BigEndian.Uint16(length[:]))
+				if _, err := io.ReadFull(conn, query); err == nil {
+					if header, q, err := parse(query); err == nil {
+						response := respond(header, q, true)
+						_, _ = conn.Write(append(binary.BigEndian.AppendUint16(nil, uint16(len(response))), response...))
+					}
+				}
+			}
+			conn.Close()
+		}
+	}()
+
+	return udp.LocalAddr().String()
+}
+
+// srvResponse builds a response to query carrying answers. It runs on the
+// server goroutines, so a build failure yields an empty, invalid response.
+func srvResponse(query dnsmessage.Header, q dnsmessage.Question, rcode dnsmessage.RCode, truncated bool, answers ...testSRVAnswer) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: query.ID, Response: true, RCode: rcode, Truncated: truncated})
+	err := errors.Join(b.StartQuestions(), b.Question(q), b.StartAnswers())
+	for _, a := range answers {
+		err = errors.Join(err, b.SRVResource(
+			dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: a.ttl},
+			dnsmessage.SRVResource{Priority: 0, Weight: 100, Port: 389, Target: dnsmessage.MustNewName(a.target)},
+		))
+	}
+	response, finishErr := b.Finish()
+	if errors.Join(err, finishErr) != nil {
+		return nil
+	}
+	return response
+}
+
+// systemResolver returns a fake system resolver holding one SRV record for
+// _ldap._tcp.example.com.
+func systemResolver() *fakeSRVResolver {
+	resolver := &fakeSRVResolver{}
+	resolver.set("_ldap._tcp.example.com", &net.SRV{Target: "dc1.example.com.", Port: 389})
+	return resolver
+}
+
+func TestDNSSRVResolver_LookupSRV(t *testing.T) {
+	server := serveDNS(t, func(h dnsmessage.Header, q dnsmessage.Question, _ bool) []byte {
+		return srvResponse(h, q, dnsmessage.RCodeSuccess, false,
+			testSRVAnswer{target: "dc1.example.com.", ttl: 600},
+			testSRVAnswer{target: "dc2.example.com.", ttl: 300},
+		)
+	})
+
+	r := &dnsSRVResolver{resolver: systemResolver(), nameservers: []string{server}}
+	records, ttl, err := r.LookupSRV(t.Context(), "_ldap._tcp.example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, 300*time.Second, ttl, "the lowest TTL of the record set applies")
+	require.Len(t, records, 1, "records come from the system resolver")
+	assert.Equal(t, "dc1.example.com.", records[0].Target)
+	assert.Equal(t, uint16(389), records[0].Port)
+}
+
+func TestDNSSRVResolver_TruncatedRetriesOverTCP(t *testing.T) {
+	server := serveDNS(t, func(h dnsmessage.Header, q dnsmessage.Question, tcp bool) []byte {
+		if !tcp {
+			return srvResponse(h, q, dnsmessage.RCodeSuccess, true)
+		}
+		return srvResponse(h, q, dnsmessage.RCodeSuccess, false, testSRVAnswer{target: "dc1.example.com.", ttl: 900})
+	})
+
+	r := &dnsSRVResolver{resolver: systemResolver(), nameservers: []string{server}}
+	records, ttl, err := r.LookupSRV(t.Context(), "_ldap._tcp.example.com")
+
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 900*time.Second, ttl)
+}
+
+func TestDNSSRVResolver_SystemResolverIsAuthoritative(t *testing.T) {
+	// A resolv.conf nameserver that does not know the split-DNS name must
+	// not hide the records found by the system resolver.
+	server := serveDNS(t, func(h dnsmessage.Header, q dnsmessage.Question, _ bool) []byte {
+		return srvResponse(h, q, dnsmessage.RCodeNameError, false)
+	})
+
+	r := &dnsSRVResolver{resolver: systemResolver(), nameservers: []string{server}}
+	records, ttl, err := r.LookupSRV(t.Context(), "_ldap._tcp.example.com")
+
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, DefaultSRVTTL, ttl)
+
+	// A name error from the system resolver is returned as-is.
+	_, _, err = r.LookupSRV(t.Context(), "_ldap._tcp.missing.example.com")
+	var dnsErr *net.DNSError
+	require.True(t, errors.As(err, &dnsErr))
+	assert.True(t, dnsErr.IsNotFound)
+}
+
+func TestDNSSRVResolver_DefaultTTL(t *testing.T) {
+	// A nameserver that refuses the query leaves the TTL unknown
+	server := serveDNS(t, func(h dnsmessage.Header, q dnsmessage.Question, _ bool) []byte {
+		return srvResponse(h, q, dnsmessage.RCodeRefused, false)
+	})
+
+	for name, nameservers := range map[string][]string{"no nameservers": nil, "refused": {server}} {
+		t.Run(name, func(t *testing.T) {
+			r := &dnsSRVResolver{resolver: systemResolver(), nameservers: nameservers}
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+
+			records, ttl, err := r.LookupSRV(ctx, "_ldap._tcp.example.com")
+			require.NoError(t, err)
+			assert.Len(t, records, 1)
+			assert.Equal(t, DefaultSRVTTL, ttl)
+		})
+	}
+}
+
+func TestDNSSRVResolver_IgnoresMismatchedReplies(t *testing.T) {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { udp.Close() })
+
+	// Each query is answered first with a spoofed ID, then with a different
+	// question, and finally with the genuine response.
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, addr, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var p dnsmessage.Parser
+			header, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			q, err := p.Question()
+			if err != nil {
+				continue
+			}
+
+			spoofed := header
+			spoofed.ID++
+			other := q
+			other.Name = dnsmessage.MustNewName("_ldap._tcp.attacker.example.")
+
+			_, _ = udp.WriteTo(srvResponse(spoofed, q, dnsmessage.RCodeSuccess, false, testSRVAnswer{target: "evil.", ttl: 1}), addr)
+			_, _ = udp.WriteTo(srvResponse(header, other, dnsmessage.RCodeSuccess, false, testSRVAnswer{target: "evil.", ttl: 2}), addr)
+			_, _ = udp.WriteTo(srvResponse(header, q, dnsmessage.RCodeSuccess, false, testSRVAnswer{target: "dc1.example.com.", ttl: 120}), addr)
+		}
+	}()
+
+	ttl, err := querySRVTTL(t.Context(), udp.LocalAddr().String(), "_ldap._tcp.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 120*time.Second, ttl)
+}
+
+func TestReadNameservers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"# generated\nsearch example.com\nnameserver 10.0.0.53\nnameserver fe80::1%eth0\nnameserver not-an-ip\noptions ndots:1\n",
+	), 0o600))
+
+	assert.Equal(t, []string{"10.0.0.53:53", "[fe80::1%eth0]:53"}, readNameservers(path))
+	assert.Nil(t, readNameservers(filepath.Join(t.TempDir(), "missing")))
+}
@@ -3,7 +3,10 @@ package ldap
 import (
 	"context"
 	"errors"
+	"net"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/mock"
@@ -111,6 +114,50 @@ func swapConnOps(tb testing.TB, ops ldapOps) {
 	tb.Cleanup(func() { connOps = original })
 }
 
+// fakeSRVResolver is an in-memory SRVResolver. Records are keyed by the full
+// service name, e.g. "_ldap._tcp.example.com".
+type fakeSRVResolver struct {
+	mu      sync.Mutex
+	records map[string][]*net.SRV
+	ttl     time.Duration
+	err     error
+	lookups int
+}
+
+func (r *fakeSRVResolver) LookupSRV(_ context.Context, name string) ([]*net.SRV, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups++
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	records, ok := r.records[name]
+	if !ok {
+		return nil, 0, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, r.ttl, nil
+}
+
+// set replaces the records for name.
+func (r *fakeSRVResolver) set(name string, records ...*net.SRV) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.records == nil {
+		r.records = make(map[string][]*net.SRV)
+	}
+	r.records[name] = records
+}
+
+// swapSRVResolver makes NewSRVDiscovery use resolver for the duration of the
+// test. Like swapConnOps it mutates package state, so callers must not run in
+// parallel.
+func swapSRVResolver(tb testing.TB, resolver SRVResolver) {
+	tb.Helper()
+	original := newSRVResolver
+	newSRVResolver = func() SRVResolver { return resolver }
+	tb.Cleanup(func() { newSRVResolver = original })
+}
+
 // newTestClient constructs a *client backed by the given ConnectionPool.
 // It bypasses NewClient (which validates config and performs discovery)
 // so unit tests can exercise the client's CRUD methods without requiring
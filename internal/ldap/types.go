@@ -3,6 +3,7 @@ package ldap
 import (
 	"context"
 	"crypto/tls"
+	"strings"
 	"time"
 
 	"github.com/go-ldap/ldap/v3"
@@ -19,6 +20,9 @@ type ConnectionConfig struct {
 	// Authentication settings
 	Username               string
 	Password               string
+	PasswordFile           string // File holding Password, read again when it changes
+	NTLMDomain             string // NetBIOS or DNS domain for NTLM binds
+	NTLMHash               string // Hex NT hash used instead of Password for NTLM binds
 	KerberosRealm          string
 	KerberosKeytab         string
 	KerberosConfig         string
@@ -26,6 +30,17 @@ type ConnectionConfig struct {
 	KerberosSPN            string
 	KerberosDNSLookupKDC   bool
 	KerberosDNSLookupRealm bool
+	SASLSecurityLayer      string // none, sign or seal; applies to Kerberos binds without TLS
+	ChannelBinding         string // auto, required or disabled; applies to NTLM and Kerberos binds
+
+	// CredentialCommand is an executable and its arguments that print the
+	// bind credentials as JSON. It is run again before connections are
+	// re-authenticated, before a new connection is bound with credentials
+	// older than CredentialRefreshInterval, and after a bind is rejected for
+	// invalid credentials, so that rotated credentials are picked up. A zero
+	// CredentialRefreshInterval means five minutes.
+	CredentialCommand         []string
+	CredentialRefreshInterval time.Duration
 
 	// TLS settings
 	TLSConfig         *tls.Config
@@ -36,6 +51,12 @@ type ConnectionConfig struct {
 	TLSClientCertFile string
 	TLSClientKeyFile  string
 
+	// Site settings. Site selects domain controllers from
+	// _ldap._tcp.<site>._sites.<domain>; when empty and SiteDetection is
+	// enabled, the site is detected with a CLDAP netlogon ping.
+	Site          string
+	SiteDetection bool
+
 	// Pool settings
 	MaxConnections int
 	MaxIdleTime    time.Duration
@@ -70,13 +91,16 @@ func DefaultConfig() *ConnectionConfig {
 
 // PooledConnection represents a connection in the pool.
 type PooledConnection struct {
-	conn          *ldap.Conn
-	lastUsed      time.Time
-	healthy       bool
-	authenticated bool
-	authTime      time.Time
-	serverInfo    *ServerInfo
-	returnToPool  func(*PooledConnection)
+	conn           *ldap.Conn
+	lastUsed       time.Time
+	healthy        bool
+	authenticated  bool
+	authTime       time.Time
+	serverInfo     *ServerInfo
+	returnToPool   func(*PooledConnection)
+	saslLayer      *saslConn  // non-nil when dialed for a SASL security layer
+	channelBinding []byte     // tls-server-end-point data for NTLM and Kerberos binds
+	bind           *bindState // State of the pool that created the connection
 }
 
 // ServerInfo contains information about an LDAP server.
@@ -87,6 +111,7 @@ type ServerInfo struct {
 	Priority int
 	Weight   int
 	Source   string
+	TTL      time.Duration // SRV record TTL; zero for configured URLs
 }
 
 // ConnectionPool manages a pool of LDAP connections.
@@ -113,6 +138,10 @@ type PoolStats struct {
 	Created   int64
 	Errors    int64
 	Uptime    time.Duration
+	Site      string // AD site used for domain controller selection, if any
+
+	ActiveServer string        // host:port of the domain controller the pool is pinned to
+	Servers      []ServerStats // Per-server health, in discovery order
 }
 
 // Client provides high-level LDAP operations.
@@ -157,6 +186,10 @@ type SearchRequest struct {
 	// Controls are optional LDAP controls to send with the request
 	// (e.g., LDAP_SERVER_SD_FLAGS_OID).
 	Controls []ldap.Control
+	// GlobalCatalog routes the search to a Global Catalog server instead of
+	// the domain pool. Requested attributes are limited to the partial
+	// attribute set replicated to the GC.
+	GlobalCatalog bool
 }
 
 // SearchResult contains search results and metadata.
@@ -178,6 +211,9 @@ type ModifyRequest struct {
 	AddAttributes     map[string][]string
 	ReplaceAttributes map[string][]string
 	DeleteAttributes  []string
+	// DeleteValues removes individual values of multi-valued attributes,
+	// leaving the remaining values in place.
+	DeleteValues map[string][]string
 	// Controls are optional LDAP controls to send with the request
 	// (e.g., LDAP_SERVER_SD_FLAGS_OID).
 	Controls []ldap.Control
@@ -194,6 +230,10 @@ type ModifyDNRequest struct {
 // WhoAmIResult contains the result of an LDAP Who Am I? extended operation.
 type WhoAmIResult struct {
 	AuthzID string // Raw authorization ID from server (e.g., "u:CN=User,CN=Users,DC=example,DC=com")
+
+	// KerberosTicketExpiry is when the provider's Kerberos TGT expires; nil
+	// unless Kerberos authentication is in use.
+	KerberosTicketExpiry *time.Time
 }
 
 // RootDSEInfo contains Active Directory RootDSE attributes and derived forest configuration.
@@ -225,6 +265,11 @@ type RootDSEInfo struct {
 	IsGlobalCatalogReady bool
 	IsSynchronized       bool
 
+	// Sites: ServerSiteName is the site of the connected DC (from serverName),
+	// ClientSiteName the site used for DC selection (configured or detected).
+	ServerSiteName string
+	ClientSiteName string
+
 	// Forest configuration (from Configuration partition)
 	Forest ForestInfo
 }
@@ -278,6 +323,7 @@ const (
 	AuthMethodSimpleBind AuthMethod = iota // Username/password authentication
 	AuthMethodKerberos                     // GSSAPI/Kerberos authentication
 	AuthMethodExternal                     // External/certificate authentication
+	AuthMethodNTLM                         // NTLM authentication with a password or NT hash
 )
 
 // String returns string representation of authentication method.
@@ -289,6 +335,8 @@ func (a AuthMethod) String() string {
 		return "kerberos"
 	case AuthMethodExternal:
 		return "external"
+	case AuthMethodNTLM:
+		return "ntlm"
 	default:
 		return "unknown"
 	}
@@ -301,6 +349,11 @@ func (c *ConnectionConfig) GetAuthMethod() AuthMethod {
 		return AuthMethodKerberos
 	}
 
+	// NTLM is selected by an NTLM domain or NT hash
+	if c.Username != "" && (c.NTLMDomain != "" || c.NTLMHash != "") {
+		return AuthMethodNTLM
+	}
+
 	// Simple bind authentication
 	if c.Username != "" && c.Password != "" {
 		return AuthMethodSimpleBind
@@ -324,8 +377,30 @@ func (c *ConnectionConfig) HasAuthentication() bool {
 	hasPassword := c.Username != "" && c.Password != ""
 	hasKerberos := c.KerberosRealm != "" && (c.KerberosKeytab != "" || c.Username != "")
 	hasExternal := c.TLSClientCertFile != "" && c.TLSClientKeyFile != ""
+	hasNTLMHash := c.Username != "" && c.NTLMHash != ""
 
-	return hasPassword || hasKerberos || hasExternal
+	return hasPassword || hasKerberos || hasExternal || hasNTLMHash
+}
+
+// IsEncrypted reports whether connections made with this configuration are
+// encrypted, by TLS or by a Kerberos SASL seal layer. AD withholds some
+// attributes, such as msDS-ManagedPassword, from unencrypted connections.
+func (c *ConnectionConfig) IsEncrypted() bool {
+	if c.UseTLS && !c.SkipTLS {
+		return true
+	}
+	if c.GetAuthMethod() == AuthMethodKerberos && c.SASLSecurityLayer == SASLSecurityLayerSeal {
+		return true
+	}
+	if len(c.LDAPURLs) == 0 {
+		return false
+	}
+	for _, u := range c.LDAPURLs {
+		if !strings.HasPrefix(strings.ToLower(u), "ldaps://") {
+			return false
+		}
+	}
+	return true
 }
 
 // RetryableError indicates an error that can be retried.
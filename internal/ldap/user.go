@@ -3,6 +3,7 @@ package ldap
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -35,6 +36,55 @@ const (
 	UACTrustedToAuthForDeleg   int32 = 0x01000000 // Trusted to authenticate for delegation
 )
 
+// Kerberos encryption type flags stored in msDS-SupportedEncryptionTypes.
+const (
+	EncTypeDESCBCCRC  int32 = 0x00000001 // DES-CBC-CRC
+	EncTypeDESCBCMD5  int32 = 0x00000002 // DES-CBC-MD5
+	EncTypeRC4HMAC    int32 = 0x00000004 // RC4-HMAC
+	EncTypeAES128     int32 = 0x00000008 // AES128-CTS-HMAC-SHA1-96
+	EncTypeAES256     int32 = 0x00000010 // AES256-CTS-HMAC-SHA1-96
+	EncTypeAESSession int32 = 0x00000020 // AES session keys
+)
+
+// KerberosEncryptionTypes maps the encryption type names accepted by the
+// provider to their msDS-SupportedEncryptionTypes flags.
+var KerberosEncryptionTypes = map[string]int32{
+	"rc4":    EncTypeRC4HMAC,
+	"aes128": EncTypeAES128,
+	"aes256": EncTypeAES256,
+}
+
+// namedEncryptionTypes is the union of the flags in KerberosEncryptionTypes.
+// Other bits of msDS-SupportedEncryptionTypes are preserved on update.
+const namedEncryptionTypes = EncTypeRC4HMAC | EncTypeAES128 | EncTypeAES256
+
+// EncryptionTypesFromNames converts encryption type names to their combined
+// msDS-SupportedEncryptionTypes flags.
+func EncryptionTypesFromNames(names []string) (int32, error) {
+	var flags int32
+	for _, name := range names {
+		flag, ok := KerberosEncryptionTypes[strings.ToLower(name)]
+		if !ok {
+			return 0, fmt.Errorf("unknown Kerberos encryption type %q", name)
+		}
+		flags |= flag
+	}
+	return flags, nil
+}
+
+// EncryptionTypeNames returns the sorted names of the named encryption types
+// set in flags. Unnamed bits are ignored.
+func EncryptionTypeNames(flags int32) []string {
+	var names []string
+	for name, flag := range KerberosEncryptionTypes {
+		if flags&flag != 0 {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // UserSearchFilter represents user-friendly filter options for searching users.
 type UserSearchFilter struct {
 	// Name filters
@@ -79,6 +129,11 @@ type UserSearchFilter struct {
 	// searches (data sources) by trimming wire payload and skipping per-user
 	// derivations like primary-group SID resolution.
 	Attributes []string `json:"-"`
+
+	// GlobalCatalog searches a Global Catalog server instead of the domain.
+	// With no Container the whole forest is searched. Only attributes in the
+	// partial attribute set are returned.
+	GlobalCatalog bool `json:"globalCatalog,omitempty"`
 }
 
 // User represents an Active Directory user with comprehensive attributes.
@@ -131,19 +186,35 @@ type User struct {
 	ProfilePath   string `json:"profilePath,omitempty"`   // Profile path
 	LogonScript   string `json:"logonScript,omitempty"`   // Logon script path
 
+	// Logon restrictions
+	LogonHours        *LogonHours `json:"logonHours,omitempty"`        // Allowed logon hours (nil means unrestricted)
+	LogonWorkstations []string    `json:"logonWorkstations,omitempty"` // Computers the user may log on to
+
+	// Kerberos
+	ServicePrincipalNames    []string `json:"servicePrincipalNames,omitempty"`    // SPNs registered to the user
+	AllowedToDelegateTo      []string `json:"allowedToDelegateTo,omitempty"`      // SPNs the user may delegate to (constrained delegation)
+	SupportedEncryptionTypes int32    `json:"supportedEncryptionTypes,omitempty"` // msDS-SupportedEncryptionTypes (0 means domain default)
+
 	// Account status and security
-	AccountEnabled         bool  `json:"accountEnabled"`         // Account is enabled
-	PasswordNeverExpires   bool  `json:"passwordNeverExpires"`   // Password never expires
-	PasswordNotRequired    bool  `json:"passwordNotRequired"`    // No password required
-	ChangePasswordAtLogon  bool  `json:"changePasswordAtLogon"`  // Must change password at next logon
-	SmartCardLogonRequired bool  `json:"smartCardLogonRequired"` // Smart card required
-	TrustedForDelegation   bool  `json:"trustedForDelegation"`   // Trusted for delegation
-	AccountLockedOut       bool  `json:"accountLockedOut"`       // Account is locked out
-	UserAccountControl     int32 `json:"userAccountControl"`     // Raw UAC value
+	AccountEnabled                    bool  `json:"accountEnabled"`                    // Account is enabled
+	PasswordNeverExpires              bool  `json:"passwordNeverExpires"`              // Password never expires
+	PasswordNotRequired               bool  `json:"passwordNotRequired"`               // No password required
+	ChangePasswordAtLogon             bool  `json:"changePasswordAtLogon"`             // Must change password at next logon
+	CannotChangePassword              bool  `json:"cannotChangePassword"`              // Change Password right denied (DACL, not a UAC bit)
+	SmartCardLogonRequired            bool  `json:"smartCardLogonRequired"`            // Smart card required
+	TrustedForDelegation              bool  `json:"trustedForDelegation"`              // Trusted for delegation
+	TrustedToAuthForDeleg             bool  `json:"trustedToAuthForDeleg"`             // Trusted to authenticate for delegation (protocol transition)
+	AccountNotDelegated               bool  `json:"accountNotDelegated"`               // Sensitive and cannot be delegated
+	DoesNotRequirePreAuth             bool  `json:"doesNotRequirePreAuth"`             // Kerberos pre-authentication not required
+	UseDESKeyOnly                     bool  `json:"useDESKeyOnly"`                     // Restricted to DES encryption types
+	AllowReversiblePasswordEncryption bool  `json:"allowReversiblePasswordEncryption"` // Password stored with reversible encryption
+	AccountLockedOut                  bool  `json:"accountLockedOut"`                  // Account is locked out
+	UserAccountControl                int32 `json:"userAccountControl"`                // Raw UAC value
 
 	// Group memberships
-	MemberOf     []string `json:"memberOf,omitempty"`     // Groups this user is a member of (DNs)
-	PrimaryGroup string   `json:"primaryGroup,omitempty"` // Primary group DN
+	MemberOf        []string `json:"memberOf,omitempty"`        // Groups this user is a member of (DNs)
+	PrimaryGroup    string   `json:"primaryGroup,omitempty"`    // Primary group DN
+	PrimaryGroupSID string   `json:"primaryGroupSID,omitempty"` // Primary group SID (domain SID + primaryGroupID)
 
 	// Timestamps
 	WhenCreated     time.Time  `json:"whenCreated"`               // When user was created
@@ -151,6 +222,9 @@ type User struct {
 	LastLogon       *time.Time `json:"lastLogon,omitempty"`       // Last logon timestamp
 	PasswordLastSet *time.Time `json:"passwordLastSet,omitempty"` // Password last set timestamp
 	AccountExpires  *time.Time `json:"accountExpires,omitempty"`  // Account expiration timestamp
+
+	// Attributes not otherwise modeled, as requested via SetExtraAttributes
+	ExtraAttributes map[string][]string `json:"extraAttributes,omitempty"`
 }
 
 // CreateUserRequest represents a request to create a new user.
@@ -165,11 +239,32 @@ type CreateUserRequest struct {
 	InitialPassword string
 
 	// Security flags (pointers for optional with defaults)
-	Enabled                *bool // Default: true
-	PasswordNeverExpires   *bool // Default: false
-	SmartCardLogonRequired *bool // Default: false
-	TrustedForDelegation   *bool // Default: false
-	ChangePasswordAtLogon  *bool // Default: false
+	Enabled                           *bool // Default: true
+	PasswordNeverExpires              *bool // Default: false
+	SmartCardLogonRequired            *bool // Default: false
+	TrustedForDelegation              *bool // Default: false
+	TrustedToAuthForDeleg             *bool // Default: false
+	AccountNotDelegated               *bool // Default: false
+	DoesNotRequirePreAuth             *bool // Default: false
+	UseDESKeyOnly                     *bool // Default: false
+	AllowReversiblePasswordEncryption *bool // Default: false
+	ChangePasswordAtLogon             *bool // Default: false
+	CannotChangePassword              *bool // Default: false
+
+	// Account expiration (nil or zero time means never)
+	AccountExpires *time.Time // accountExpires
+
+	// Logon restrictions (nil or empty means unrestricted)
+	LogonHours        *LogonHours // logonHours
+	LogonWorkstations []string    // userWorkstations
+
+	// Primary group DN (empty keeps the domain default, Domain Users)
+	PrimaryGroup string // primaryGroupID
+
+	// Kerberos (checked for forest-wide duplicates by the caller)
+	ServicePrincipalNames    []string // servicePrincipalName
+	AllowedToDelegateTo      []string // msDS-AllowedToDelegateTo
+	SupportedEncryptionTypes int32    // msDS-SupportedEncryptionTypes (0 leaves the domain default)
 
 	// Personal information
 	DisplayName string // displayName
@@ -210,6 +305,9 @@ type CreateUserRequest struct {
 	HomeDrive     string // homeDrive
 	ProfilePath   string // profilePath
 	LogonScript   string // scriptPath
+
+	// Attributes not otherwise modeled, keyed by LDAP attribute name
+	ExtraAttributes map[string][]string
 }
 
 // UpdateUserRequest represents a request to update an existing user.
@@ -226,11 +324,36 @@ type UpdateUserRequest struct {
 	SAMAccountName    *string // sAMAccountName
 
 	// Security flags
-	Enabled                *bool
-	PasswordNeverExpires   *bool
-	SmartCardLogonRequired *bool
-	TrustedForDelegation   *bool
-	ChangePasswordAtLogon  *bool
+	Enabled                           *bool
+	PasswordNeverExpires              *bool
+	SmartCardLogonRequired            *bool
+	TrustedForDelegation              *bool
+	TrustedToAuthForDeleg             *bool
+	AccountNotDelegated               *bool
+	DoesNotRequirePreAuth             *bool
+	UseDESKeyOnly                     *bool
+	AllowReversiblePasswordEncryption *bool
+	ChangePasswordAtLogon             *bool
+	CannotChangePassword              *bool
+
+	// Account expiration (zero time means never)
+	AccountExpires *time.Time // accountExpires
+
+	// Logon restrictions (a bitmap allowing every hour or an empty list means unrestricted)
+	LogonHours        *LogonHours // logonHours
+	LogonWorkstations *[]string   // userWorkstations
+
+	// Primary group DN
+	PrimaryGroup *string // primaryGroupID
+
+	// Kerberos (an empty list removes every SPN)
+	ServicePrincipalNames *[]string // servicePrincipalName
+	AllowedToDelegateTo   *[]string // msDS-AllowedToDelegateTo
+
+	// Kerberos encryption types: the RC4 and AES flags of
+	// msDS-SupportedEncryptionTypes are replaced, other flags are kept. Zero
+	// with no other flags set removes the attribute (domain default).
+	SupportedEncryptionTypes *int32
 
 	// Personal information
 	DisplayName *string
@@ -271,6 +394,10 @@ type UpdateUserRequest struct {
 	HomeDrive     *string
 	ProfilePath   *string
 	LogonScript   *string
+
+	// Attributes not otherwise modeled, keyed by LDAP attribute name
+	// (an empty list deletes the attribute)
+	ExtraAttributes map[string][]string
 }
 
 // UserManager handles Active Directory user operations (both read and write).
@@ -283,6 +410,13 @@ type UserManager struct {
 	baseDN       string
 	timeout      time.Duration
 	cacheManager *CacheManager
+
+	// extraAttributes are additional attributes read into User.ExtraAttributes
+	extraAttributes []string
+
+	// readCannotChangePassword makes single-user reads also read the DACL
+	// into User.CannotChangePassword
+	readCannotChangePassword bool
 }
 
 // NewUserManager creates a new user manager instance.
@@ -305,6 +439,18 @@ func (um *UserManager) SetTimeout(timeout time.Duration) {
 	um.normalizer.SetTimeout(timeout)
 }
 
+// SetExtraAttributes sets the additional attributes read into User.ExtraAttributes.
+func (um *UserManager) SetExtraAttributes(names []string) {
+	um.extraAttributes = names
+}
+
+// SetReadCannotChangePassword sets whether single-user reads populate
+// User.CannotChangePassword. It lives in the DACL, which costs an additional
+// search per user, so searches never populate it.
+func (um *UserManager) SetReadCannotChangePassword(read bool) {
+	um.readCannotChangePassword = read
+}
+
 // -----------------------------------------------------------------------------
 // Read Operations
 // -----------------------------------------------------------------------------
@@ -446,8 +592,12 @@ func (um *UserManager) SearchUsersWithFilter(filter *UserSearchFilter) ([]*User,
 		return nil, WrapError("build_ldap_filter", err)
 	}
 
-	// Determine search base DN (container or baseDN)
+	// Determine search base DN (container or baseDN). A Global Catalog search
+	// without a container starts at the empty DN to cover the whole forest.
 	searchBaseDN := um.baseDN
+	if filter.GlobalCatalog {
+		searchBaseDN = ""
+	}
 	if filter.Container != "" {
 		searchBaseDN = filter.Container
 	}
@@ -460,7 +610,7 @@ func (um *UserManager) SearchUsersWithFilter(filter *UserSearchFilter) ([]*User,
 		searchScope = *filter.SearchScope
 	}
 
-	return um.searchUsersInContainer(searchBaseDN, ldapFilter, filter.Attributes, searchScope)
+	return um.searchUsersInContainer(searchBaseDN, ldapFilter, filter.Attributes, searchScope, filter.GlobalCatalog)
 }
 
 // -----------------------------------------------------------------------------
@@ -577,6 +727,26 @@ func (um *UserManager) CreateUser(req *CreateUserRequest) (*User, error) {
 	um.addOptionalAttribute(attributes, "profilePath", req.ProfilePath)
 	um.addOptionalAttribute(attributes, "scriptPath", req.LogonScript)
 
+	if req.AccountExpires != nil && !req.AccountExpires.IsZero() {
+		attributes["accountExpires"] = []string{formatAccountExpires(*req.AccountExpires)}
+	}
+	if req.LogonHours != nil && !req.LogonHours.AllowsAll() {
+		attributes["logonHours"] = []string{string(req.LogonHours.Bytes())}
+	}
+	if len(req.LogonWorkstations) > 0 {
+		attributes["userWorkstations"] = []string{strings.Join(req.LogonWorkstations, ",")}
+	}
+	if len(req.ServicePrincipalNames) > 0 {
+		attributes["servicePrincipalName"] = req.ServicePrincipalNames
+	}
+	if len(req.AllowedToDelegateTo) > 0 {
+		attributes["msDS-AllowedToDelegateTo"] = req.AllowedToDelegateTo
+	}
+	if req.SupportedEncryptionTypes != 0 {
+		attributes["msDS-SupportedEncryptionTypes"] = []string{strconv.FormatInt(int64(req.SupportedEncryptionTypes), 10)}
+	}
+	addExtraAttributes(attributes, req.ExtraAttributes)
+
 	// Create the user
 	addReq := &AddRequest{
 		DN:         userDN,
@@ -633,6 +803,22 @@ func (um *UserManager) CreateUser(req *CreateUserRequest) (*User, error) {
 		return nil, WrapError("apply_user_flags", err)
 	}
 
+	if req.CannotChangePassword != nil && *req.CannotChangePassword {
+		if err := um.setCannotChangePassword(userDN, true); err != nil {
+			return nil, WrapError("apply_cannot_change_password", err)
+		}
+	}
+
+	if req.PrimaryGroup != "" {
+		created, err := um.getUserByDN(userDN)
+		if err != nil {
+			return nil, WrapError("retrieve_created_user", err)
+		}
+		if err := um.setPrimaryGroup(created, req.PrimaryGroup); err != nil {
+			return nil, WrapError("apply_primary_group", err)
+		}
+	}
+
 	tflog.SubsystemDebug(um.ctx, "ldap", "User flags applied", map[string]any{
 		"user_dn":   userDN,
 		"final_uac": finalUAC,
@@ -751,6 +937,51 @@ func (um *UserManager) UpdateUser(guid string, req *UpdateUserRequest) (*User, e
 	hasChanges = um.addModifyAttribute(modReq, "profilePath", req.ProfilePath, currentUser.ProfilePath) || hasChanges
 	hasChanges = um.addModifyAttribute(modReq, "scriptPath", req.LogonScript, currentUser.LogonScript) || hasChanges
 
+	// Handle account expiration changes
+	if req.AccountExpires != nil && !accountExpiresEqual(*req.AccountExpires, currentUser.AccountExpires) {
+		modReq.ReplaceAttributes["accountExpires"] = []string{formatAccountExpires(*req.AccountExpires)}
+		hasChanges = true
+	}
+
+	// Handle logon restriction changes
+	hasChanges = um.calculateLogonRestrictionChanges(req, currentUser, modReq) || hasChanges
+
+	// Handle SPN changes
+	if req.ServicePrincipalNames != nil && !SPNsEqual(*req.ServicePrincipalNames, currentUser.ServicePrincipalNames) {
+		if len(*req.ServicePrincipalNames) == 0 {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, "servicePrincipalName")
+		} else {
+			modReq.ReplaceAttributes["servicePrincipalName"] = *req.ServicePrincipalNames
+		}
+		hasChanges = true
+	}
+
+	// Handle constrained delegation changes
+	if req.AllowedToDelegateTo != nil && !SPNsEqual(*req.AllowedToDelegateTo, currentUser.AllowedToDelegateTo) {
+		if len(*req.AllowedToDelegateTo) == 0 {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, "msDS-AllowedToDelegateTo")
+		} else {
+			modReq.ReplaceAttributes["msDS-AllowedToDelegateTo"] = *req.AllowedToDelegateTo
+		}
+		hasChanges = true
+	}
+
+	// Handle Kerberos encryption type changes
+	if req.SupportedEncryptionTypes != nil {
+		newEncTypes := currentUser.SupportedEncryptionTypes&^namedEncryptionTypes | *req.SupportedEncryptionTypes
+		if newEncTypes != currentUser.SupportedEncryptionTypes {
+			if newEncTypes == 0 {
+				modReq.DeleteAttributes = append(modReq.DeleteAttributes, "msDS-SupportedEncryptionTypes")
+			} else {
+				modReq.ReplaceAttributes["msDS-SupportedEncryptionTypes"] = []string{strconv.FormatInt(int64(newEncTypes), 10)}
+			}
+			hasChanges = true
+		}
+	}
+
+	// Handle extra attribute changes
+	hasChanges = calculateExtraAttributeChanges(modReq, req.ExtraAttributes, currentUser.ExtraAttributes) || hasChanges
+
 	// Handle UAC flag changes
 	uacChanged, newUAC := um.calculateUACChanges(req, currentUser)
 	if uacChanged {
@@ -778,6 +1009,21 @@ func (um *UserManager) UpdateUser(guid string, req *UpdateUserRequest) (*User, e
 		}
 	}
 
+	// "User cannot change password" lives in the DACL, not userAccountControl;
+	// setCannotChangePassword leaves a DACL already in the requested state alone
+	if req.CannotChangePassword != nil {
+		if err := um.setCannotChangePassword(currentUser.DistinguishedName, *req.CannotChangePassword); err != nil {
+			return nil, WrapError("modify_cannot_change_password", err)
+		}
+	}
+
+	// The primary group is written last, as it may add the user to the group
+	if req.PrimaryGroup != nil && !strings.EqualFold(*req.PrimaryGroup, currentUser.PrimaryGroup) {
+		if err := um.setPrimaryGroup(currentUser, *req.PrimaryGroup); err != nil {
+			return nil, WrapError("modify_primary_group", err)
+		}
+	}
+
 	// Retrieve final updated user
 	updatedUser, err := um.GetUserByGUID(guid)
 	if err != nil {
@@ -870,7 +1116,7 @@ func (um *UserManager) getUserByDN(dn string) (*User, error) {
 		return nil, WrapError("parse_user_entry", err)
 	}
 
-	return user, nil
+	return um.withCannotChangePassword(user)
 }
 
 // resolveGUIDToDN performs a narrow LDAP search to obtain only the DN of a
@@ -923,7 +1169,7 @@ func (um *UserManager) getUserByGUID(guid string) (*User, error) {
 		return nil, WrapError("parse_user_entry", err)
 	}
 
-	return user, nil
+	return um.withCannotChangePassword(user)
 }
 
 // getUserBySID is the internal implementation for SID-based user retrieval.
@@ -956,7 +1202,7 @@ func (um *UserManager) getUserBySID(sid string) (*User, error) {
 		return nil, WrapError("parse_user_entry", err)
 	}
 
-	return user, nil
+	return um.withCannotChangePassword(user)
 }
 
 // getUserByUPN is the internal implementation for UPN-based user retrieval.
@@ -984,7 +1230,7 @@ func (um *UserManager) getUserByUPN(upn string) (*User, error) {
 		return nil, WrapError("parse_user_entry", err)
 	}
 
-	return user, nil
+	return um.withCannotChangePassword(user)
 }
 
 // getUserBySAM is the internal implementation for SAM-based user retrieval.
@@ -1020,13 +1266,13 @@ func (um *UserManager) getUserBySAM(samAccountName string) (*User, error) {
 		return nil, WrapError("parse_user_entry", err)
 	}
 
-	return user, nil
+	return um.withCannotChangePassword(user)
 }
 
 // searchUsersInContainer searches for users in a specific container using LDAP filter.
 // The searchScope argument is passed verbatim; callers are responsible for
 // defaulting an unset scope before calling this helper.
-func (um *UserManager) searchUsersInContainer(baseDN, filter string, attributes []string, searchScope SearchScope) ([]*User, error) {
+func (um *UserManager) searchUsersInContainer(baseDN, filter string, attributes []string, searchScope SearchScope, globalCatalog bool) ([]*User, error) {
 	if filter == "" {
 		filter = "(&(objectClass=user)(!(objectClass=computer)))"
 	} else {
@@ -1039,11 +1285,12 @@ func (um *UserManager) searchUsersInContainer(baseDN, filter string, attributes
 	}
 
 	searchReq := &SearchRequest{
-		BaseDN:     baseDN,
-		Scope:      searchScope,
-		Filter:     filter,
-		Attributes: attributes,
-		TimeLimit:  um.timeout,
+		BaseDN:        baseDN,
+		Scope:         searchScope,
+		Filter:        filter,
+		Attributes:    attributes,
+		TimeLimit:     um.timeout,
+		GlobalCatalog: globalCatalog,
 	}
 
 	result, err := um.client.SearchWithPaging(um.ctx, searchReq)
@@ -1131,6 +1378,36 @@ func (um *UserManager) entryToUser(entry *ldap.Entry) (*User, error) {
 	user.ProfilePath = entry.GetAttributeValue("profilePath")
 	user.LogonScript = entry.GetAttributeValue("scriptPath")
 
+	// Logon restrictions
+	if logonHours := entry.GetRawAttributeValue("logonHours"); len(logonHours) > 0 {
+		if h, err := ParseLogonHours(logonHours); err == nil {
+			user.LogonHours = h
+		} else {
+			tflog.SubsystemWarn(um.ctx, "ldap", "Ignoring malformed logonHours", map[string]any{
+				"user_dn": entry.DN,
+				"error":   err.Error(),
+			})
+		}
+	}
+	if workstations := entry.GetAttributeValue("userWorkstations"); workstations != "" {
+		for name := range strings.SplitSeq(workstations, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				user.LogonWorkstations = append(user.LogonWorkstations, name)
+			}
+		}
+	}
+
+	// Kerberos
+	user.ServicePrincipalNames = entry.GetAttributeValues("servicePrincipalName")
+	user.AllowedToDelegateTo = entry.GetAttributeValues("msDS-AllowedToDelegateTo")
+	if encTypes := entry.GetAttributeValue("msDS-SupportedEncryptionTypes"); encTypes != "" {
+		if v, err := strconv.ParseInt(encTypes, 10, 32); err == nil {
+			user.SupportedEncryptionTypes = int32(v)
+		}
+	}
+
+	user.ExtraAttributes = readExtraAttributes(entry, um.extraAttributes)
+
 	// Parse userAccountControl flags
 	uacStr := entry.GetAttributeValue("userAccountControl")
 	if uacStr != "" {
@@ -1151,6 +1428,7 @@ func (um *UserManager) entryToUser(entry *ldap.Entry) (*User, error) {
 			if len(sidParts) >= 4 {
 				domainSID := strings.Join(sidParts[:len(sidParts)-1], "-")
 				primaryGroupSID := fmt.Sprintf("%s-%d", domainSID, pgid)
+				user.PrimaryGroupSID = primaryGroupSID
 				if dn, err := um.normalizer.ResolveSIDToDN(primaryGroupSID); err == nil {
 					user.PrimaryGroup = dn
 				} else {
@@ -1192,7 +1470,7 @@ func (um *UserManager) entryToUser(entry *ldap.Entry) (*User, error) {
 		}
 	}
 
-	if accountExpires := entry.GetAttributeValue("accountExpires"); accountExpires != "" && accountExpires != "0" && accountExpires != "9223372036854775807" {
+	if accountExpires := entry.GetAttributeValue("accountExpires"); accountExpires != "" && accountExpires != "0" && accountExpires != accountNeverExpires {
 		if t, err := um.parseADTimestamp(accountExpires); err == nil {
 			user.AccountExpires = &t
 		}
@@ -1215,10 +1493,20 @@ func (um *UserManager) parseUserAccountControl(user *User, uac int32) {
 	user.PasswordNotRequired = (uac & UACPasswordNotRequired) != 0
 	user.SmartCardLogonRequired = (uac & UACSmartCardRequired) != 0
 	user.TrustedForDelegation = (uac & UACTrustedForDelegation) != 0
+	user.TrustedToAuthForDeleg = (uac & UACTrustedToAuthForDeleg) != 0
+	user.AccountNotDelegated = (uac & UACNotDelegated) != 0
+	user.DoesNotRequirePreAuth = (uac & UACDontRequirePreauth) != 0
+	user.UseDESKeyOnly = (uac & UACUseDesKeyOnly) != 0
+	user.AllowReversiblePasswordEncryption = (uac & UACEncryptedTextPwdAllowed) != 0
 }
 
 // parseADTimestamp parses Active Directory timestamp format (100-nanosecond intervals since Jan 1, 1601).
 func (um *UserManager) parseADTimestamp(timestamp string) (time.Time, error) {
+	return parseADTimestamp(timestamp)
+}
+
+// parseADTimestamp parses Active Directory timestamp format (100-nanosecond intervals since Jan 1, 1601).
+func parseADTimestamp(timestamp string) (time.Time, error) {
 	if timestamp == "" || timestamp == "0" {
 		return time.Time{}, fmt.Errorf("empty or zero timestamp")
 	}
@@ -1229,10 +1517,19 @@ func (um *UserManager) parseADTimestamp(timestamp string) (time.Time, error) {
 		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
 	}
 
-	// AD timestamps are 100-nanosecond intervals since January 1, 1601 (UTC)
-	// Convert to Unix timestamp (nanoseconds since January 1, 1970)
-	const adEpoch = 116444736000000000 // 100-nanosecond intervals between 1601 and 1970
+	return fileTimeToTime(ticks)
+}
+
+// AD timestamps are 100-nanosecond intervals since January 1, 1601 (UTC).
+const adEpoch = 116444736000000000 // 100-nanosecond intervals between 1601 and 1970
+
+// accountNeverExpires is the accountExpires value AD uses for accounts
+// without an expiration date. A value of 0 means the same.
+const accountNeverExpires = "9223372036854775807"
 
+// fileTimeToTime converts a Windows FILETIME to a UTC time.
+func fileTimeToTime(ticks int64) (time.Time, error) {
+	// Convert to Unix timestamp (nanoseconds since January 1, 1970)
 	if ticks <= adEpoch {
 		return time.Time{}, fmt.Errorf("timestamp before Unix epoch")
 	}
@@ -1241,6 +1538,29 @@ func (um *UserManager) parseADTimestamp(timestamp string) (time.Time, error) {
 	return time.Unix(0, unixNanos).UTC(), nil
 }
 
+// timeToFileTime converts a time to a Windows FILETIME.
+func timeToFileTime(t time.Time) int64 {
+	return t.Unix()*10_000_000 + int64(t.Nanosecond())/100 + adEpoch
+}
+
+// formatAccountExpires converts an expiration time to an accountExpires
+// value. The zero time means the account never expires.
+func formatAccountExpires(t time.Time) string {
+	if t.IsZero() {
+		return accountNeverExpires
+	}
+	return strconv.FormatInt(timeToFileTime(t), 10)
+}
+
+// accountExpiresEqual reports whether a requested expiration time matches the
+// current one, where the zero time and nil both mean never.
+func accountExpiresEqual(requested time.Time, current *time.Time) bool {
+	if current == nil {
+		return requested.IsZero()
+	}
+	return requested.Equal(*current)
+}
+
 // DataSourceUsersAttributes is the narrow attribute set the ad_users data
 // source projects. Omitting attributes that the data source doesn't expose
 // (notably primaryGroupID, memberOf, address fields) keeps paged searches
@@ -1259,7 +1579,7 @@ func DataSourceUsersAttributes() []string {
 
 // getAllUserAttributes returns the complete list of user attributes to retrieve.
 func (um *UserManager) getAllUserAttributes() []string {
-	return []string{
+	return append([]string{
 		// Core identification
 		"objectGUID", "distinguishedName", "objectSid",
 		"sAMAccountName", "userPrincipalName", "cn",
@@ -1282,12 +1602,18 @@ func (um *UserManager) getAllUserAttributes() []string {
 		// System information
 		"homeDirectory", "homeDrive", "profilePath", "scriptPath",
 
+		// Logon restrictions
+		"logonHours", "userWorkstations",
+
+		// Kerberos
+		"servicePrincipalName", "msDS-AllowedToDelegateTo", "msDS-SupportedEncryptionTypes",
+
 		// Account control and membership
 		"userAccountControl", "memberOf", "primaryGroupID",
 
 		// Timestamps and lockout
 		"whenCreated", "whenChanged", "lastLogon", "pwdLastSet", "accountExpires", "lockoutTime",
-	}
+	}, um.extraAttributes...)
 }
 
 // validateSearchFilter validates the user-provided search filter.
@@ -1313,6 +1639,41 @@ func (um *UserManager) validateSearchFilter(filter *UserSearchFilter) error {
 	return nil
 }
 
+// FilterAttributes returns the LDAP attributes referenced by the filter
+// criteria. It is used to warn about Global Catalog searches on attributes
+// outside the partial attribute set.
+func (f *UserSearchFilter) FilterAttributes() []string {
+	var attrs []string
+	if f.NamePrefix != "" || f.NameSuffix != "" || f.NameContains != "" {
+		attrs = append(attrs, "cn")
+	}
+	if f.Department != "" {
+		attrs = append(attrs, "department")
+	}
+	if f.Title != "" {
+		attrs = append(attrs, "title")
+	}
+	if f.Company != "" {
+		attrs = append(attrs, "company")
+	}
+	if f.Office != "" {
+		attrs = append(attrs, "physicalDeliveryOfficeName")
+	}
+	if f.Manager != "" {
+		attrs = append(attrs, "manager")
+	}
+	if f.Enabled != nil {
+		attrs = append(attrs, "userAccountControl")
+	}
+	if f.HasEmail != nil || f.EmailDomain != "" {
+		attrs = append(attrs, "mail")
+	}
+	if f.MemberOf != "" {
+		attrs = append(attrs, "memberOf")
+	}
+	return attrs
+}
+
 // buildLDAPFilter converts a user-friendly filter to an LDAP filter string.
 func (um *UserManager) buildLDAPFilter(filter *UserSearchFilter) (string, error) {
 	if filter == nil {
@@ -1464,6 +1825,26 @@ func (um *UserManager) calculateUserAccountControl(req *CreateUserRequest) int32
 		uac |= UACTrustedForDelegation
 	}
 
+	if req.TrustedToAuthForDeleg != nil && *req.TrustedToAuthForDeleg {
+		uac |= UACTrustedToAuthForDeleg
+	}
+
+	if req.AccountNotDelegated != nil && *req.AccountNotDelegated {
+		uac |= UACNotDelegated
+	}
+
+	if req.DoesNotRequirePreAuth != nil && *req.DoesNotRequirePreAuth {
+		uac |= UACDontRequirePreauth
+	}
+
+	if req.UseDESKeyOnly != nil && *req.UseDESKeyOnly {
+		uac |= UACUseDesKeyOnly
+	}
+
+	if req.AllowReversiblePasswordEncryption != nil && *req.AllowReversiblePasswordEncryption {
+		uac |= UACEncryptedTextPwdAllowed
+	}
+
 	// Note: ChangePasswordAtLogon is handled separately via pwdLastSet attribute
 
 	return uac
@@ -1520,9 +1901,222 @@ func (um *UserManager) calculateUACChanges(req *UpdateUserRequest, currentUser *
 		}
 	}
 
+	// Handle TrustedToAuthForDeleg flag
+	if req.TrustedToAuthForDeleg != nil {
+		if *req.TrustedToAuthForDeleg && !currentUser.TrustedToAuthForDeleg {
+			newUAC |= UACTrustedToAuthForDeleg
+			changed = true
+		} else if !*req.TrustedToAuthForDeleg && currentUser.TrustedToAuthForDeleg {
+			newUAC &^= UACTrustedToAuthForDeleg
+			changed = true
+		}
+	}
+
+	// Handle the remaining flags that map directly to a single UAC bit
+	for _, f := range []struct {
+		requested *bool
+		current   bool
+		flag      int32
+	}{
+		{req.AccountNotDelegated, currentUser.AccountNotDelegated, UACNotDelegated},
+		{req.DoesNotRequirePreAuth, currentUser.DoesNotRequirePreAuth, UACDontRequirePreauth},
+		{req.UseDESKeyOnly, currentUser.UseDESKeyOnly, UACUseDesKeyOnly},
+		{req.AllowReversiblePasswordEncryption, currentUser.AllowReversiblePasswordEncryption, UACEncryptedTextPwdAllowed},
+	} {
+		if f.requested == nil || *f.requested == f.current {
+			continue
+		}
+		if *f.requested {
+			newUAC |= f.flag
+		} else {
+			newUAC &^= f.flag
+		}
+		changed = true
+	}
+
 	return changed, newUAC
 }
 
+// userDACLControl limits reads and writes of nTSecurityDescriptor to the
+// DACL (LDAP_SERVER_SD_FLAGS_OID), so the owner, group and SACL are neither
+// returned nor touched.
+func userDACLControl() ldap.Control {
+	return &ldap.ControlMicrosoftSDFlags{
+		Criticality:  true,
+		ControlValue: int32(SDFlagsDACLSecurityInformation),
+	}
+}
+
+// getUserDACL reads the DACL of the user at dn as a security descriptor.
+func (um *UserManager) getUserDACL(dn string) (*SecurityDescriptor, error) {
+	searchReq := &SearchRequest{
+		BaseDN:     dn,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=user)",
+		Attributes: []string{"nTSecurityDescriptor"},
+		SizeLimit:  1,
+		TimeLimit:  um.timeout,
+		Controls:   []ldap.Control{userDACLControl()},
+	}
+
+	result, err := um.client.Search(um.ctx, searchReq)
+	if err != nil {
+		return nil, WrapError("get_security_descriptor", err)
+	}
+	if len(result.Entries) == 0 {
+		return nil, NewNotFoundError("get_security_descriptor", "user not found at DN: %s", dn)
+	}
+
+	raw := readSecurityDescriptorBytes(result.Entries[0])
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("user %s has no nTSecurityDescriptor", dn)
+	}
+
+	sd, err := UnmarshalSecurityDescriptor(raw)
+	if err != nil {
+		return nil, WrapError("parse_security_descriptor", err)
+	}
+	return sd, nil
+}
+
+// withCannotChangePassword sets user.CannotChangePassword from the user's
+// DACL when the manager is configured to read it.
+func (um *UserManager) withCannotChangePassword(user *User) (*User, error) {
+	if !um.readCannotChangePassword {
+		return user, nil
+	}
+
+	// "User cannot change password" is a pair of deny ACEs in the DACL
+	sd, err := um.getUserDACL(user.DistinguishedName)
+	if err != nil {
+		return nil, err
+	}
+	user.CannotChangePassword = sd.HasDenyChangePasswordACE()
+	return user, nil
+}
+
+// setCannotChangePassword adds or removes the ACEs denying Everyone and
+// Principal Self the User-Change-Password extended right on the user at dn.
+// Only the DACL is read and written, so the owner, group and SACL are left
+// untouched.
+func (um *UserManager) setCannotChangePassword(dn string, cannotChange bool) error {
+	sd, err := um.getUserDACL(dn)
+	if err != nil {
+		return err
+	}
+
+	has := sd.HasDenyChangePasswordACE()
+	switch {
+	case cannotChange && !has:
+		if err := sd.AddDenyChangePasswordACEs(); err != nil {
+			return WrapError("build_change_password_aces", err)
+		}
+	case !cannotChange && has:
+		sd.RemoveDenyChangePasswordACEs()
+	default:
+		return nil // already in desired state
+	}
+
+	newRaw, err := sd.Marshal()
+	if err != nil {
+		return WrapError("marshal_security_descriptor", err)
+	}
+
+	modReq := &ModifyRequest{
+		DN: dn,
+		ReplaceAttributes: map[string][]string{
+			"nTSecurityDescriptor": {string(newRaw)},
+		},
+		Controls: []ldap.Control{userDACLControl()},
+	}
+	if err := um.client.Modify(um.ctx, modReq); err != nil {
+		return WrapError("write_security_descriptor", err)
+	}
+	return nil
+}
+
+// setPrimaryGroup makes the group at groupDN the primary group of user by
+// writing the group's RID to primaryGroupID. AD only accepts a group of the
+// user's own domain that the user is already a member of, so the user is
+// added to the group's member attribute first when needed. AD then drops the
+// user from member, as primary group membership is implicit, and adds it to
+// member of the previous primary group.
+func (um *UserManager) setPrimaryGroup(user *User, groupDN string) error {
+	searchReq := &SearchRequest{
+		BaseDN:     groupDN,
+		Scope:      ScopeBaseObject,
+		Filter:     "(objectClass=group)",
+		Attributes: []string{"objectSid"},
+		SizeLimit:  1,
+		TimeLimit:  um.timeout,
+	}
+
+	result, err := um.client.Search(um.ctx, searchReq)
+	if err != nil {
+		return WrapError("get_primary_group", err)
+	}
+	if len(result.Entries) == 0 {
+		return NewNotFoundError("set_primary_group", "group not found at DN: %s", groupDN)
+	}
+
+	groupSID, err := ParseSID(um.sidHandler.ExtractSIDSafe(result.Entries[0]))
+	if err != nil {
+		return fmt.Errorf("group %s has no valid objectSid: %w", groupDN, err)
+	}
+	userSID, err := ParseSID(user.ObjectSid)
+	if err != nil {
+		return fmt.Errorf("user %s has no valid objectSid: %w", user.DistinguishedName, err)
+	}
+	if !sameDomainSID(groupSID, userSID) {
+		return fmt.Errorf("group %s is not in the domain of user %s; the primary group must be a group of the user's own domain",
+			groupDN, user.DistinguishedName)
+	}
+	if strings.EqualFold(groupSID.String(), user.PrimaryGroupSID) {
+		return nil // already the primary group
+	}
+
+	isMember := false
+	for _, dn := range user.MemberOf {
+		if strings.EqualFold(dn, groupDN) {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		addReq := &ModifyRequest{
+			DN:            groupDN,
+			AddAttributes: map[string][]string{"member": {user.DistinguishedName}},
+		}
+		if err := um.client.Modify(um.ctx, addReq); err != nil {
+			return WrapError("add_primary_group_member", err)
+		}
+	}
+
+	modReq := &ModifyRequest{
+		DN: user.DistinguishedName,
+		ReplaceAttributes: map[string][]string{
+			"primaryGroupID": {strconv.FormatUint(uint64(groupSID.RID()), 10)},
+		},
+	}
+	if err := um.client.Modify(um.ctx, modReq); err != nil {
+		return WrapError("write_primary_group_id", err)
+	}
+
+	tflog.SubsystemDebug(um.ctx, "ldap", "Primary group set", map[string]any{
+		"user_dn":         user.DistinguishedName,
+		"group_dn":        groupDN,
+		"group_rid":       groupSID.RID(),
+		"added_to_member": !isMember,
+	})
+	return nil
+}
+
+// sameDomainSID reports whether a and b are account SIDs of the same domain.
+func sameDomainSID(a, b SID) bool {
+	return isDomainSID(a) && isDomainSID(b) &&
+		slices.Equal(a.SubAuthorities[:len(a.SubAuthorities)-1], b.SubAuthorities[:len(b.SubAuthorities)-1])
+}
+
 // renameAndMoveUser handles renaming and/or moving a user using ModifyDN operation.
 func (um *UserManager) renameAndMoveUser(currentUser *User, newName, newContainer string) error {
 	currentContainer, _ := GetDNParent(currentUser.DistinguishedName)
@@ -1587,6 +2181,47 @@ func (um *UserManager) addOptionalAttribute(attrs map[string][]string, name, val
 	}
 }
 
+// calculateLogonRestrictionChanges adds logonHours and userWorkstations
+// modifications to modReq where the request differs from the current user.
+// Returns true if any modification was added.
+func (um *UserManager) calculateLogonRestrictionChanges(req *UpdateUserRequest, currentUser *User, modReq *ModifyRequest) bool {
+	changed := false
+
+	if req.LogonHours != nil && !logonHoursEqual(req.LogonHours, currentUser.LogonHours) {
+		if req.LogonHours.AllowsAll() {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, "logonHours")
+		} else {
+			modReq.ReplaceAttributes["logonHours"] = []string{string(req.LogonHours.Bytes())}
+		}
+		changed = true
+	}
+
+	if req.LogonWorkstations != nil && !workstationsEqual(*req.LogonWorkstations, currentUser.LogonWorkstations) {
+		if len(*req.LogonWorkstations) == 0 {
+			modReq.DeleteAttributes = append(modReq.DeleteAttributes, "userWorkstations")
+		} else {
+			modReq.ReplaceAttributes["userWorkstations"] = []string{strings.Join(*req.LogonWorkstations, ",")}
+		}
+		changed = true
+	}
+
+	return changed
+}
+
+// workstationsEqual reports whether two workstation lists name the same
+// computers, ignoring order and case.
+func workstationsEqual(a, b []string) bool {
+	normalize := func(names []string) []string {
+		out := make([]string, len(names))
+		for i, name := range names {
+			out[i] = strings.ToUpper(name)
+		}
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
 // addModifyAttribute adds an attribute modification if the value differs from current.
 // Returns true if a change was added.
 func (um *UserManager) addModifyAttribute(modReq *ModifyRequest, ldapAttr string, newValue *string, currentValue string) bool {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -162,6 +163,9 @@ func mockPrimaryGroupSIDResolution(client *MockUserClient) {
 }
 
 // createMockUserEntry creates a mock LDAP entry for testing user operations.
+// testLogonHours allows logon on Monday 08:00-17:00 UTC.
+var testLogonHours = []byte{0, 0, 0, 0, 0xff, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
 func createMockUserEntry() *ldap.Entry {
 	entry := &ldap.Entry{
 		DN: "CN=John Doe,OU=Users,DC=example,DC=com",
@@ -202,6 +206,8 @@ func createMockUserEntry() *ldap.Entry {
 			{Name: "homeDrive", Values: []string{"H:"}},
 			{Name: "profilePath", Values: []string{"\\\\server\\profiles\\john.doe"}},
 			{Name: "scriptPath", Values: []string{"logon.bat"}},
+			{Name: "logonHours", Values: []string{string(testLogonHours)}, ByteValues: [][]byte{testLogonHours}},
+			{Name: "userWorkstations", Values: []string{"WS01, WS02"}},
 			{Name: "userAccountControl", Values: []string{"512"}}, // Normal account, enabled
 			{Name: "memberOf", Values: []string{
 				"CN=Engineers,OU=Groups,DC=example,DC=com",
@@ -1070,6 +1076,13 @@ func TestUserManager_entryToUser_ComprehensiveMapping(t *testing.T) {
 	assert.Equal(t, "\\\\server\\profiles\\john.doe", user.ProfilePath)
 	assert.Equal(t, "logon.bat", user.LogonScript)
 
+	// Logon restrictions
+	require.NotNil(t, user.LogonHours)
+	schedule, err := user.LogonHours.Schedule(0)
+	require.NoError(t, err)
+	assert.Equal(t, LogonSchedule{time.Monday: {{Start: 8, End: 17}}}, schedule)
+	assert.Equal(t, []string{"WS01", "WS02"}, user.LogonWorkstations)
+
 	// Account status
 	assert.Equal(t, int32(512), user.UserAccountControl)
 	assert.True(t, user.AccountEnabled)
@@ -1411,11 +1424,13 @@ func TestUserManager_CreateUser_WithAllOptionalAttributes(t *testing.T) {
 	um := NewUserManager(ctx, mockClient, baseDN, cacheManager)
 
 	enabled := true
+	expires := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
 	req := &CreateUserRequest{
 		Name:              "Test User",
 		UserPrincipalName: "testuser@example.com",
 		SAMAccountName:    "testuser",
 		Container:         "OU=Users,DC=example,DC=com",
+		AccountExpires:    &expires,
 		DisplayName:       "Test Display Name",
 		Description:       "Test description",
 		GivenName:         "Test",
@@ -1438,7 +1453,8 @@ func TestUserManager_CreateUser_WithAllOptionalAttributes(t *testing.T) {
 		// Verify optional attributes are included
 		return r.Attributes["displayName"] != nil &&
 			r.Attributes["description"] != nil &&
-			r.Attributes["givenName"] != nil
+			r.Attributes["givenName"] != nil &&
+			assert.ObjectsAreEqual([]string{"134483328000000000"}, r.Attributes["accountExpires"])
 	})).Return(nil).Once()
 
 	// Mock Modify
@@ -2000,6 +2016,405 @@ func TestUserManager_UpdateUser_NoChanges(t *testing.T) {
 	mockClient.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
 }
 
+func TestUserManager_UpdateUser_AccountExpires(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+	expires := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		expires  time.Time
+		expected string // Expected accountExpires value, empty for no modify
+	}{
+		{name: "set expiration", expires: expires, expected: "134483328000000000"},
+		{name: "never when already never", expires: time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+			mockClient.On("Search", mock.Anything, mock.Anything).Return(
+				makeUserSearchResult(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser"),
+				nil,
+			)
+			if tc.expected != "" {
+				mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+					return r.DN == userDN && assert.ObjectsAreEqual([]string{tc.expected}, r.ReplaceAttributes["accountExpires"])
+				})).Return(nil).Once()
+			}
+
+			_, err := um.UpdateUser(userGUID, &UpdateUserRequest{AccountExpires: &tc.expires})
+
+			require.NoError(t, err)
+			if tc.expected == "" {
+				mockClient.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserManager_UpdateUser_LogonRestrictions(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+
+	workdays, err := NewLogonHours(LogonSchedule{time.Monday: {{Start: 8, End: 17}}}, 0)
+	require.NoError(t, err)
+	unrestricted := UnrestrictedLogonHours()
+	workstations := []string{"KIOSK01", "KIOSK02"}
+	noWorkstations := []string{}
+
+	testCases := []struct {
+		name            string
+		req             *UpdateUserRequest
+		expectedReplace map[string][]string
+	}{
+		{
+			name: "set restrictions",
+			req:  &UpdateUserRequest{LogonHours: workdays, LogonWorkstations: &workstations},
+			expectedReplace: map[string][]string{
+				"logonHours":       {string(workdays.Bytes())},
+				"userWorkstations": {"KIOSK01,KIOSK02"},
+			},
+		},
+		{
+			name: "unrestricted when already unrestricted",
+			req:  &UpdateUserRequest{LogonHours: unrestricted, LogonWorkstations: &noWorkstations},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+			mockClient.On("Search", mock.Anything, mock.Anything).Return(
+				makeUserSearchResult(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser"),
+				nil,
+			)
+			if tc.expectedReplace != nil {
+				mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+					return r.DN == userDN && assert.ObjectsAreEqual(tc.expectedReplace, r.ReplaceAttributes)
+				})).Return(nil).Once()
+			}
+
+			_, err := um.UpdateUser(userGUID, tc.req)
+
+			require.NoError(t, err)
+			if tc.expectedReplace == nil {
+				mockClient.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserManager_UpdateUser_ServicePrincipalNames(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+
+	spns := []string{"HTTP/web.example.com", "HTTP/web"}
+	noSPNs := []string{}
+
+	testCases := []struct {
+		name            string
+		req             *UpdateUserRequest
+		expectedReplace map[string][]string
+	}{
+		{
+			name: "set SPNs",
+			req:  &UpdateUserRequest{ServicePrincipalNames: &spns},
+			expectedReplace: map[string][]string{
+				"servicePrincipalName": {"HTTP/web.example.com", "HTTP/web"},
+			},
+		},
+		{
+			name: "no SPNs when already none",
+			req:  &UpdateUserRequest{ServicePrincipalNames: &noSPNs},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+			mockClient.On("Search", mock.Anything, mock.Anything).Return(
+				makeUserSearchResult(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser"),
+				nil,
+			)
+			if tc.expectedReplace != nil {
+				mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+					return r.DN == userDN && assert.ObjectsAreEqual(tc.expectedReplace, r.ReplaceAttributes)
+				})).Return(nil).Once()
+			}
+
+			_, err := um.UpdateUser(userGUID, tc.req)
+
+			require.NoError(t, err)
+			if tc.expectedReplace == nil {
+				mockClient.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserManager_UpdateUser_ConstrainedDelegation(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+
+	targets := []string{"MSSQLSvc/db.example.com:1433", "cifs/fs.example.com"}
+	protocolTransition := true
+
+	mockClient := &MockClient{}
+	um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+	mockClient.On("Search", mock.Anything, mock.Anything).Return(
+		makeUserSearchResult(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser"),
+		nil,
+	)
+	mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+		return r.DN == userDN && assert.ObjectsAreEqual(map[string][]string{
+			"msDS-AllowedToDelegateTo": {"MSSQLSvc/db.example.com:1433", "cifs/fs.example.com"},
+			"userAccountControl":       {strconv.FormatInt(int64(UACNormalAccount|UACTrustedToAuthForDeleg), 10)},
+		}, r.ReplaceAttributes)
+	})).Return(nil).Once()
+
+	_, err := um.UpdateUser(userGUID, &UpdateUserRequest{
+		AllowedToDelegateTo:   &targets,
+		TrustedToAuthForDeleg: &protocolTransition,
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUserManager_UpdateUser_AccountControlFlagsAndEncryptionTypes(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+
+	// DES-CBC-CRC is not modeled and must survive the update; RC4 is dropped.
+	entry := makeUserEntry(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser")
+	entry.Attributes = append(entry.Attributes, &ldap.EntryAttribute{
+		Name: "msDS-SupportedEncryptionTypes", Values: []string{strconv.Itoa(int(EncTypeDESCBCCRC | EncTypeRC4HMAC))},
+	})
+
+	encTypes := EncTypeAES128 | EncTypeAES256
+	notDelegated := true
+	noPreauth := true
+	reversible := false
+
+	mockClient := &MockClient{}
+	um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+	mockClient.On("Search", mock.Anything, mock.Anything).Return(&SearchResult{Entries: []*ldap.Entry{entry}}, nil)
+	mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+		return r.DN == userDN && assert.ObjectsAreEqual(map[string][]string{
+			"msDS-SupportedEncryptionTypes": {strconv.Itoa(int(EncTypeDESCBCCRC | EncTypeAES128 | EncTypeAES256))},
+			"userAccountControl":            {strconv.FormatInt(int64(UACNormalAccount|UACNotDelegated|UACDontRequirePreauth), 10)},
+		}, r.ReplaceAttributes)
+	})).Return(nil).Once()
+
+	_, err := um.UpdateUser(userGUID, &UpdateUserRequest{
+		SupportedEncryptionTypes:          &encTypes,
+		AccountNotDelegated:               &notDelegated,
+		DoesNotRequirePreAuth:             &noPreauth,
+		AllowReversiblePasswordEncryption: &reversible,
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUserManager_UpdateUser_CannotChangePassword(t *testing.T) {
+	userGUID := "12345678-1234-1234-1234-123456789012"
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+
+	raw, err := buildSD(false).Marshal()
+	require.NoError(t, err)
+
+	mockClient := &MockClient{}
+	um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", NewCacheManager())
+
+	isSDSearch := func(r *SearchRequest) bool {
+		return slices.Equal(r.Attributes, []string{"nTSecurityDescriptor"}) && len(r.Controls) == 1
+	}
+	mockClient.On("Search", mock.Anything, mock.MatchedBy(isSDSearch)).Return(&SearchResult{Entries: []*ldap.Entry{{
+		DN:         userDN,
+		Attributes: []*ldap.EntryAttribute{{Name: "nTSecurityDescriptor", ByteValues: [][]byte{raw}}},
+	}}}, nil).Once()
+	mockClient.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool { return !isSDSearch(r) })).Return(
+		makeUserSearchResult(userDN, userGUID, "sid", "Test User", "testuser@example.com", "testuser"),
+		nil,
+	)
+	mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+		values := r.ReplaceAttributes["nTSecurityDescriptor"]
+		if r.DN != userDN || len(values) != 1 || len(r.Controls) != 1 {
+			return false
+		}
+		sd, err := UnmarshalSecurityDescriptor([]byte(values[0]))
+		return err == nil && sd.HasDenyChangePasswordACE()
+	})).Return(nil).Once()
+
+	cannotChange := true
+	_, err = um.UpdateUser(userGUID, &UpdateUserRequest{CannotChangePassword: &cannotChange})
+
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUserManager_GetUser_CannotChangePassword(t *testing.T) {
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+	sd := buildSD(false)
+	require.NoError(t, sd.AddDenyChangePasswordACEs())
+	raw, err := sd.Marshal()
+	require.NoError(t, err)
+
+	isSDSearch := func(r *SearchRequest) bool {
+		return slices.Equal(r.Attributes, []string{"nTSecurityDescriptor"}) && len(r.Controls) == 1
+	}
+	newClient := func() *MockClient {
+		mockClient := &MockClient{}
+		mockClient.On("Search", mock.Anything, mock.MatchedBy(isSDSearch)).Return(&SearchResult{Entries: []*ldap.Entry{{
+			DN:         userDN,
+			Attributes: []*ldap.EntryAttribute{{Name: "nTSecurityDescriptor", ByteValues: [][]byte{raw}}},
+		}}}, nil)
+		mockClient.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool {
+			return !isSDSearch(r) && !slices.Contains(r.Attributes, "nTSecurityDescriptor")
+		})).Return(makeUserSearchResult(userDN, "", "sid", "Test User", "testuser@example.com", "testuser"), nil)
+		return mockClient
+	}
+
+	t.Run("read with the DACL only when requested", func(t *testing.T) {
+		mockClient := newClient()
+		um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", nil)
+		um.SetReadCannotChangePassword(true)
+
+		user, err := um.GetUserByDN(userDN)
+		require.NoError(t, err)
+		assert.True(t, user.CannotChangePassword)
+		mockClient.AssertNumberOfCalls(t, "Search", 2)
+	})
+
+	t.Run("not read by default", func(t *testing.T) {
+		mockClient := newClient()
+		um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", nil)
+
+		user, err := um.GetUserByDN(userDN)
+		require.NoError(t, err)
+		assert.False(t, user.CannotChangePassword)
+		mockClient.AssertNumberOfCalls(t, "Search", 1)
+	})
+}
+
+func TestUserManager_setPrimaryGroup(t *testing.T) {
+	userDN := "CN=Test User,OU=Users,DC=example,DC=com"
+	groupDN := "CN=Linux Users,OU=Groups,DC=example,DC=com"
+	groupSIDBytes, err := NewSIDHandler().StringToSIDBytes("S-1-5-21-1-2-3-1105")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		user      *User
+		wantAdd   bool
+		wantWrite bool
+		wantErr   string
+	}{
+		"adds the user to the group first": {
+			user:      &User{DistinguishedName: userDN, ObjectSid: "S-1-5-21-1-2-3-1001", PrimaryGroupSID: "S-1-5-21-1-2-3-513"},
+			wantAdd:   true,
+			wantWrite: true,
+		},
+		"existing member": {
+			user:      &User{DistinguishedName: userDN, ObjectSid: "S-1-5-21-1-2-3-1001", PrimaryGroupSID: "S-1-5-21-1-2-3-513", MemberOf: []string{strings.ToLower(groupDN)}},
+			wantWrite: true,
+		},
+		"already the primary group": {
+			user: &User{DistinguishedName: userDN, ObjectSid: "S-1-5-21-1-2-3-1001", PrimaryGroupSID: "S-1-5-21-1-2-3-1105"},
+		},
+		"group of another domain": {
+			user:    &User{DistinguishedName: userDN, ObjectSid: "S-1-5-21-4-5-6-1001"},
+			wantErr: "not in the domain",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient := &MockClient{}
+			um := NewUserManager(t.Context(), mockClient, "DC=example,DC=com", nil)
+
+			mockClient.On("Search", mock.Anything, mock.MatchedBy(func(r *SearchRequest) bool {
+				return r.BaseDN == groupDN && r.Scope == ScopeBaseObject
+			})).Return(&SearchResult{Entries: []*ldap.Entry{{
+				DN:         groupDN,
+				Attributes: []*ldap.EntryAttribute{{Name: "objectSid", ByteValues: [][]byte{groupSIDBytes}}},
+			}}}, nil).Once()
+			if tc.wantAdd {
+				mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+					return r.DN == groupDN && slices.Equal(r.AddAttributes["member"], []string{userDN})
+				})).Return(nil).Once()
+			}
+			if tc.wantWrite {
+				mockClient.On("Modify", mock.Anything, mock.MatchedBy(func(r *ModifyRequest) bool {
+					return r.DN == userDN && slices.Equal(r.ReplaceAttributes["primaryGroupID"], []string{"1105"})
+				})).Return(nil).Once()
+			}
+
+			err := um.setPrimaryGroup(tc.user, groupDN)
+
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+			if !tc.wantAdd && !tc.wantWrite {
+				mockClient.AssertNotCalled(t, "Modify", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEncryptionTypeNames(t *testing.T) {
+	flags, err := EncryptionTypesFromNames([]string{"aes256", "RC4", "aes128"})
+	require.NoError(t, err)
+	assert.Equal(t, EncTypeRC4HMAC|EncTypeAES128|EncTypeAES256, flags)
+	assert.Equal(t, []string{"aes128", "aes256", "rc4"}, EncryptionTypeNames(flags))
+
+	// Unnamed flags are ignored.
+	assert.Equal(t, []string{"aes256"}, EncryptionTypeNames(EncTypeDESCBCMD5|EncTypeAES256|EncTypeAESSession))
+	assert.Nil(t, EncryptionTypeNames(0))
+
+	_, err = EncryptionTypesFromNames([]string{"des"})
+	assert.Error(t, err)
+}
+
+func TestUserManager_calculateLogonRestrictionChanges_Clear(t *testing.T) {
+	um := NewUserManager(t.Context(), &MockClient{}, "DC=example,DC=com", nil)
+	current := &User{LogonHours: &LogonHours{}, LogonWorkstations: []string{"KIOSK01"}}
+	unrestricted := UnrestrictedLogonHours()
+	noWorkstations := []string{}
+
+	modReq := &ModifyRequest{ReplaceAttributes: make(map[string][]string)}
+	changed := um.calculateLogonRestrictionChanges(&UpdateUserRequest{LogonHours: unrestricted, LogonWorkstations: &noWorkstations}, current, modReq)
+
+	assert.True(t, changed)
+	assert.Empty(t, modReq.ReplaceAttributes)
+	assert.ElementsMatch(t, []string{"logonHours", "userWorkstations"}, modReq.DeleteAttributes)
+}
+
+func TestFormatAccountExpires(t *testing.T) {
+	assert.Equal(t, accountNeverExpires, formatAccountExpires(time.Time{}))
+
+	expires := time.Date(2027, 3, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	value := formatAccountExpires(expires)
+	parsed, err := parseADTimestamp(value)
+	require.NoError(t, err)
+	assert.True(t, expires.Equal(parsed), "round trip %s -> %s -> %s", expires, value, parsed)
+}
+
 func TestUserManager_UpdateUser_UserNotFound(t *testing.T) {
 	ctx := context.Background()
 	mockClient := &MockClient{}
@@ -22,6 +22,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &GroupsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &GroupsDataSource{}
 
 func NewGroupsDataSource() datasource.DataSource {
 	return &GroupsDataSource{}
@@ -37,9 +38,10 @@ type GroupsDataSource struct {
 // GroupsDataSourceModel describes the data source data model.
 type GroupsDataSourceModel struct {
 	// Search configuration
-	Container types.String `tfsdk:"container"` // Optional container DN to search within
-	Scope     types.String `tfsdk:"scope"`     // Search scope: base, onelevel, subtree (default)
-	Filter    types.Object `tfsdk:"filter"`    // Filter block for search criteria
+	Container     types.String `tfsdk:"container"`      // Optional container DN to search within
+	Scope         types.String `tfsdk:"scope"`          // Search scope: base, onelevel, subtree (default)
+	GlobalCatalog types.Bool   `tfsdk:"global_catalog"` // Search a Global Catalog server (forest-wide)
+	Filter        types.Object `tfsdk:"filter"`         // Filter block for search criteria
 
 	// Output
 	Groups     types.List   `tfsdk:"groups"`      // List of groups found
@@ -101,6 +103,12 @@ func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					stringvalidator.OneOf("base", "onelevel", "subtree"),
 				},
 			},
+			"global_catalog": schema.BoolAttribute{
+				MarkdownDescription: "Search a Global Catalog server instead of the domain. Without `container`, the whole forest is searched. " +
+					"Only attributes in the Global Catalog partial attribute set are returned; membership of domain local groups " +
+					"in other domains is not visible. Defaults to `false`.",
+				Optional: true,
+			},
 
 			// Output attributes
 			"group_count": schema.Int64Attribute{
@@ -278,16 +286,17 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		searchScopeLog = searchFilter.SearchScope.String()
 	}
 	tflog.Debug(ctx, "Searching for AD groups", map[string]any{
-		"container":     searchFilter.Container,
-		"name_prefix":   searchFilter.NamePrefix,
-		"name_suffix":   searchFilter.NameSuffix,
-		"name_contains": searchFilter.NameContains,
-		"category":      searchFilter.Category,
-		"scope":         searchFilter.Scope,
-		"search_scope":  searchScopeLog,
-		"has_members":   searchFilter.HasMembers,
-		"member_of":     searchFilter.MemberOf,
-		"has_member":    searchFilter.HasMember,
+		"container":      searchFilter.Container,
+		"name_prefix":    searchFilter.NamePrefix,
+		"name_suffix":    searchFilter.NameSuffix,
+		"name_contains":  searchFilter.NameContains,
+		"category":       searchFilter.Category,
+		"scope":          searchFilter.Scope,
+		"search_scope":   searchScopeLog,
+		"has_members":    searchFilter.HasMembers,
+		"member_of":      searchFilter.MemberOf,
+		"has_member":     searchFilter.HasMember,
+		"global_catalog": searchFilter.GlobalCatalog,
 	})
 
 	// Perform the search
@@ -318,6 +327,25 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+func (d *GroupsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data GroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.GlobalCatalog.ValueBool() || data.Filter.IsUnknown() {
+		return
+	}
+
+	searchFilter, err := d.buildSearchFilter(ctx, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	addGlobalCatalogFilterWarning(&resp.Diagnostics, searchFilter.FilterAttributes())
+}
+
 // buildSearchFilter converts the Terraform configuration to a GroupSearchFilter.
 func (d *GroupsDataSource) buildSearchFilter(ctx context.Context, data *GroupsDataSourceModel, diags *diag.Diagnostics) (*ldapclient.GroupSearchFilter, error) {
 	searchFilter := &ldapclient.GroupSearchFilter{}
@@ -335,6 +363,8 @@ func (d *GroupsDataSource) buildSearchFilter(ctx context.Context, data *GroupsDa
 		searchFilter.SearchScope = helpers.MapSearchScope(data.Scope.ValueString())
 	}
 
+	searchFilter.GlobalCatalog = data.GlobalCatalog.ValueBool()
+
 	// Parse filter block if present
 	if !data.Filter.IsNull() {
 		var filterModel GroupFilterModel
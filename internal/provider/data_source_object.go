@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ObjectDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ObjectDataSource{}
+
+func NewObjectDataSource() datasource.DataSource {
+	return &ObjectDataSource{}
+}
+
+// ObjectDataSource defines the data source implementation.
+type ObjectDataSource struct {
+	client        ldapclient.Client
+	objectManager *ldapclient.ObjectManager
+}
+
+// ObjectDataSourceModel describes the data source data model.
+type ObjectDataSourceModel struct {
+	// Lookup methods (mutually exclusive)
+	ID types.String `tfsdk:"id"` // objectGUID lookup
+	DN types.String `tfsdk:"dn"` // Distinguished Name lookup
+
+	// Attributes to read
+	Attributes types.List `tfsdk:"attributes"` // Attribute names
+
+	// Computed outputs
+	ObjectClass  types.List   `tfsdk:"object_class"`  // objectClass hierarchy
+	RDNAttribute types.String `tfsdk:"rdn_attribute"` // Naming attribute type
+	Name         types.String `tfsdk:"name"`          // Naming attribute value
+	Container    types.String `tfsdk:"container"`     // Parent container DN
+	Values       types.Map    `tfsdk:"values"`        // Values of the requested attributes
+
+	// Timestamps
+	WhenCreated types.String `tfsdk:"when_created"` // When the object was created
+	WhenChanged types.String `tfsdk:"when_changed"` // When the object was last modified
+}
+
+func (d *ObjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+func (d *ObjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves an Active Directory object of any object class, and the values of selected attributes. " +
+			"Supports lookup by objectGUID or Distinguished Name.",
+
+		Attributes: map[string]schema.Attribute{
+			// Lookup methods (mutually exclusive)
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The objectGUID of the object to retrieve. Format: `550e8400-e29b-41d4-a716-446655440000`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"dn": schema.StringAttribute{
+				MarkdownDescription: "The Distinguished Name of the object to retrieve. " +
+					"Example: `CN=PRN-LON-01,OU=Printers,DC=example,DC=com`",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					validators.IsValidDN(),
+				},
+			},
+			"attributes": schema.ListAttribute{
+				MarkdownDescription: "The LDAP attributes to read into `values`. Each attribute must be defined in the schema.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(ldapNamePattern, "must be an LDAP attribute name")),
+				},
+			},
+
+			// Computed outputs
+			"object_class": schema.ListAttribute{
+				MarkdownDescription: "The objectClass values of the object, from the most general class to the most specific.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"rdn_attribute": schema.StringAttribute{
+				MarkdownDescription: "The naming attribute of the object, which forms the first component of its distinguished name.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The value of the naming attribute.",
+				Computed:            true,
+			},
+			"container": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the container holding the object.",
+				Computed:            true,
+			},
+			"values": schema.MapAttribute{
+				MarkdownDescription: "The values of the requested `attributes`, keyed by the requested names. Values of binary " +
+					"attributes are base64-encoded, as in the `attributes` argument of the `ad_object` resource. " +
+					"Attributes without values are omitted.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+
+			// Timestamps
+			"when_created": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the object was created (RFC3339 format).",
+				Computed:            true,
+			},
+			"when_changed": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the object was last modified (RFC3339 format).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ConfigValidators implements datasource.DataSourceWithConfigValidators.
+func (d *ObjectDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		// Exactly one lookup method must be specified
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("dn"),
+		),
+	}
+}
+
+func (d *ObjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+
+	// Initialize object manager
+	baseDN, err := d.client.GetBaseDN(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Get Base DN",
+			fmt.Sprintf("Could not retrieve base DN from LDAP server: %s", err.Error()),
+		)
+		return
+	}
+	d.objectManager = ldapclient.NewObjectManager(ctx, d.client, baseDN)
+}
+
+func (d *ObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ObjectDataSourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var attributes []string
+	resp.Diagnostics.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.objectManager.SetAttributes(attributes)
+
+	// Retrieve the object by objectGUID or DN
+	var object *ldapclient.Object
+	var err error
+	if !data.ID.IsNull() && data.ID.ValueString() != "" {
+		tflog.Debug(ctx, "Looking up object by objectGUID", map[string]any{
+			"guid": data.ID.ValueString(),
+		})
+		object, err = d.objectManager.GetObject(data.ID.ValueString())
+	} else {
+		tflog.Debug(ctx, "Looking up object by DN", map[string]any{
+			"dn": data.DN.ValueString(),
+		})
+		object, err = d.objectManager.GetObjectByDN(data.DN.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Object",
+			fmt.Sprintf("Could not read Active Directory object: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Successfully retrieved AD object", map[string]any{
+		"object_guid": object.ObjectGUID,
+		"object_dn":   object.DistinguishedName,
+	})
+
+	// Map object data to model
+	d.mapObjectToModel(ctx, object, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// mapObjectToModel maps the LDAP object data to the Terraform model.
+func (d *ObjectDataSource) mapObjectToModel(ctx context.Context, object *ldapclient.Object, data *ObjectDataSourceModel, diags *diag.Diagnostics) {
+	data.ID = types.StringValue(object.ObjectGUID)
+	data.DN = types.StringValue(helpers.NormalizeDN(ctx, object.DistinguishedName))
+	data.RDNAttribute = types.StringValue(object.RDNAttribute)
+	data.Name = types.StringValue(object.Name)
+	data.Container = types.StringValue(helpers.NormalizeDN(ctx, object.Container))
+
+	objectClass, listDiags := types.ListValueFrom(ctx, types.StringType, object.ObjectClass)
+	diags.Append(listDiags...)
+	data.ObjectClass = objectClass
+
+	data.Values = stringListMap(object.Attributes)
+
+	// Timestamps
+	if !object.WhenCreated.IsZero() {
+		data.WhenCreated = helpers.Timestamp(object.WhenCreated)
+	} else {
+		data.WhenCreated = types.StringNull()
+	}
+
+	if !object.WhenChanged.IsZero() {
+		data.WhenChanged = helpers.Timestamp(object.WhenChanged)
+	} else {
+		data.WhenChanged = types.StringNull()
+	}
+}
@@ -0,0 +1,96 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectDataSource_id(t *testing.T) {
+	name := GenerateTestName("tf-test-object-ds-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectDataSourceConfig(name, "id = ad_object.test.id"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ad_object.test", "dn", "ad_object.test", "dn"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "name", name),
+					resource.TestCheckResourceAttr("data.ad_object.test", "object_class.#", "4"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "object_class.3", "contact"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "values.%", "2"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "values.description.0", "Read by ad_object"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "values.thumbnailPhoto.0", "AAH+/w=="),
+					resource.TestCheckResourceAttrSet("data.ad_object.test", "when_created"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectDataSource_dn(t *testing.T) {
+	name := GenerateTestName("tf-test-object-ds-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectDataSourceConfig(name, "dn = ad_object.test.dn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ad_object.test", "id", "ad_object.test", "id"),
+					resource.TestCheckResourceAttr("data.ad_object.test", "rdn_attribute", "CN"),
+					resource.TestCheckResourceAttrPair("data.ad_object.test", "container", "ad_object.test", "container"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectDataSource_configValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "ad_object" "test" {
+  id = "12345678-1234-1234-1234-123456789012"
+  dn = "CN=test,DC=example,DC=com"
+}
+`, testProviderConfig()),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccObjectDataSourceConfig(name, lookup string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_object" "test" {
+  object_class = "contact"
+  name         = %[3]q
+  container    = data.ad_rootdse.test.default_naming_context
+
+  attributes = {
+    description    = ["Read by ad_object"]
+    thumbnailPhoto = ["AAH+/w=="]
+  }
+}
+
+data "ad_object" "test" {
+  %[4]s
+  attributes = ["description", "thumbnailPhoto", "info"]
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, lookup)
+}
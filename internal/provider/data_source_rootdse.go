@@ -47,6 +47,8 @@ type RootDSEDataSourceModel struct {
 	SupportedSASLMechanisms       types.List   `tfsdk:"supported_sasl_mechanisms"`
 	IsGlobalCatalogReady          types.Bool   `tfsdk:"is_global_catalog_ready"`
 	IsSynchronized                types.Bool   `tfsdk:"is_synchronized"`
+	SiteName                      types.String `tfsdk:"site_name"`
+	ClientSiteName                types.String `tfsdk:"client_site_name"`
 	Forest                        types.Object `tfsdk:"forest"`
 }
 
@@ -120,6 +122,17 @@ func (d *RootDSEDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 			},
 
+			// Sites
+			"site_name": schema.StringAttribute{
+				MarkdownDescription: "The Active Directory site of the connected domain controller, derived from `server_name`.",
+				Computed:            true,
+			},
+			"client_site_name": schema.StringAttribute{
+				MarkdownDescription: "The Active Directory site used to select domain controllers: the provider's `site` setting, or the site detected " +
+					"via CLDAP netlogon ping. Empty when servers were not selected by site (e.g. `ldap_url` is set or detection failed).",
+				Computed: true,
+			},
+
 			// Functional levels
 			"domain_functionality": schema.Int64Attribute{
 				MarkdownDescription: "The domain functional level. Common values: 0 (2000), 1 (2003 interim), 2 (2003), 3 (2008), 4 (2008 R2), 5 (2012), 6 (2012 R2), 7 (2016).",
@@ -256,6 +269,8 @@ func (d *RootDSEDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	tflog.Debug(ctx, "Successfully read RootDSE", map[string]any{
 		"dns_host_name":           result.DNSHostName,
+		"site_name":               result.ServerSiteName,
+		"client_site_name":        result.ClientSiteName,
 		"domain_name":             result.DomainName,
 		"forest_name":             result.Forest.Name,
 		"domain_functionality":    result.DomainFunctionality,
@@ -283,6 +298,8 @@ func (d *RootDSEDataSource) mapRootDSEToModel(ctx context.Context, info *ldapcli
 	data.DNSHostName = types.StringValue(info.DNSHostName)
 	data.ServerName = types.StringValue(info.ServerName)
 	data.LDAPServiceName = types.StringValue(info.LDAPServiceName)
+	data.SiteName = types.StringValue(info.ServerSiteName)
+	data.ClientSiteName = types.StringValue(info.ClientSiteName)
 	data.DomainFunctionality = types.Int64Value(info.DomainFunctionality)
 	data.ForestFunctionality = types.Int64Value(info.ForestFunctionality)
 	data.DomainControllerFunctionality = types.Int64Value(info.DomainControllerFunctionality)
@@ -45,6 +45,8 @@ func TestRootDSEDataSource_Schema(t *testing.T) {
 		"supported_sasl_mechanisms",
 		"is_global_catalog_ready",
 		"is_synchronized",
+		"site_name",
+		"client_site_name",
 		"forest",
 	}
 	for _, attr := range expectedAttrs {
@@ -120,6 +122,9 @@ func TestRootDSEDataSource_Read(t *testing.T) {
 		IsGlobalCatalogReady: true,
 		IsSynchronized:       true,
 
+		ServerSiteName: "Default-First-Site-Name",
+		ClientSiteName: "Default-First-Site-Name",
+
 		Forest: ldapclient.ForestInfo{
 			Name:             "example.com",
 			DefaultUPNSuffix: "example.com",
@@ -168,6 +173,8 @@ func TestRootDSEDataSource_Read(t *testing.T) {
 	assert.Equal(t, int64(7), data.DomainControllerFunctionality.ValueInt64())
 	assert.Equal(t, true, data.IsGlobalCatalogReady.ValueBool())
 	assert.Equal(t, true, data.IsSynchronized.ValueBool())
+	assert.Equal(t, "Default-First-Site-Name", data.SiteName.ValueString())
+	assert.Equal(t, "Default-First-Site-Name", data.ClientSiteName.ValueString())
 
 	// Verify forest nested object
 	assert.False(t, data.Forest.IsNull())
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// defaultSearchSizeLimit is the number of entries ad_search returns when
+// size_limit is not set.
+const defaultSearchSizeLimit = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SearchDataSource{}
+
+func NewSearchDataSource() datasource.DataSource {
+	return &SearchDataSource{}
+}
+
+// SearchDataSource defines the data source implementation.
+type SearchDataSource struct {
+	client ldapclient.Client
+}
+
+// SearchDataSourceModel describes the data source data model.
+type SearchDataSourceModel struct {
+	// Search configuration
+	BaseDN     types.String `tfsdk:"base_dn"`    // Optional base DN, defaults to the domain
+	Scope      types.String `tfsdk:"scope"`      // Search scope: base, onelevel, subtree (default)
+	Filter     types.String `tfsdk:"filter"`     // Raw RFC 4515 filter
+	Attributes types.List   `tfsdk:"attributes"` // Attributes to return
+	SizeLimit  types.Int64  `tfsdk:"size_limit"` // Maximum number of entries
+
+	// Output
+	Entries    types.List   `tfsdk:"entries"`     // Entries found
+	EntryCount types.Int64  `tfsdk:"entry_count"` // Number of entries found
+	Truncated  types.Bool   `tfsdk:"truncated"`   // Whether size_limit truncated the results
+	ID         types.String `tfsdk:"id"`          // Computed identifier for the data source
+}
+
+// searchEntryObjectType is the object type of an ad_search entry.
+var searchEntryObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"dn":     types.StringType,
+		"values": types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+	},
+}
+
+func (d *SearchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search"
+}
+
+func (d *SearchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches Active Directory with a raw LDAP filter and returns the matching entries with the " +
+			"requested attributes. Use this when the filter blocks of `ad_users` and `ad_groups` cannot express a query, " +
+			"or to query object classes that have no dedicated data source.",
+
+		Attributes: map[string]schema.Attribute{
+			// Search configuration
+			"base_dn": schema.StringAttribute{
+				MarkdownDescription: "The DN to search from. If not specified, searches from the base DN of the domain. " +
+					"Example: `OU=Users,DC=example,DC=com`",
+				Optional: true,
+				Validators: []validator.String{
+					validators.IsValidDN(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The search scope to use. Valid values: `base`, `onelevel`, `subtree`. Defaults to `subtree`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("base", "onelevel", "subtree"),
+				},
+			},
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "The LDAP search filter, in RFC 4515 string representation. " +
+					"Example: `(&(objectClass=user)(employeeType=contractor))`",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"attributes": schema.ListAttribute{
+				MarkdownDescription: "The LDAP attributes to return for each entry. If not specified, all user " +
+					"attributes are returned; operational and constructed attributes must be requested by name.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"size_limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of entries to return. Set to `0` to return all "+
+					"matching entries. Defaults to `%d`.", defaultSearchSizeLimit),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			// Output attributes
+			"entry_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of entries returned.",
+				Computed:            true,
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more entries matched than `size_limit` allowed to be returned.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "A computed identifier for this data source instance.",
+				Computed:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The entries matching the search, in the order returned by the server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"dn": schema.StringAttribute{
+							MarkdownDescription: "The Distinguished Name of the entry.",
+							Computed:            true,
+						},
+						"values": schema.MapAttribute{
+							MarkdownDescription: "The values of the returned attributes, keyed by attribute name as " +
+								"returned by the server. GUIDs such as `objectGUID` and SIDs such as `objectSid` are " +
+								"decoded to their string forms, and other binary values are base64-encoded.",
+							ElementType: types.ListType{ElemType: types.StringType},
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SearchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *SearchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SearchDataSourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	searchReq, err := d.buildSearchRequest(ctx, &data, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Building Search Request",
+			fmt.Sprintf("Could not build search request: %s", err.Error()),
+		)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Searching Active Directory", map[string]any{
+		"base_dn":    searchReq.BaseDN,
+		"scope":      searchReq.Scope.String(),
+		"filter":     searchReq.Filter,
+		"attributes": searchReq.Attributes,
+		"size_limit": searchReq.SizeLimit,
+	})
+
+	entries, truncated, err := ldapclient.SearchEntries(ctx, d.client, searchReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Searching Active Directory",
+			fmt.Sprintf("Could not search Active Directory: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Successfully searched Active Directory", map[string]any{
+		"entry_count": len(entries),
+		"truncated":   truncated,
+	})
+
+	// Convert results to Terraform model
+	entryElements := make([]attr.Value, len(entries))
+	for i, entry := range entries {
+		entryObj, objDiags := types.ObjectValue(searchEntryObjectType.AttrTypes, map[string]attr.Value{
+			"dn":     types.StringValue(entry.DN),
+			"values": stringListMap(entry.Attributes),
+		})
+		resp.Diagnostics.Append(objDiags...)
+		if objDiags.HasError() {
+			return
+		}
+		entryElements[i] = entryObj
+	}
+
+	entriesList, listDiags := types.ListValue(searchEntryObjectType, entryElements)
+	resp.Diagnostics.Append(listDiags...)
+	if listDiags.HasError() {
+		return
+	}
+
+	// Set computed values
+	data.Entries = entriesList
+	data.EntryCount = types.Int64Value(int64(len(entries)))
+	data.Truncated = types.BoolValue(truncated)
+	data.ID = types.StringValue(fmt.Sprintf("search-%d", len(entries)))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// buildSearchRequest converts the data source configuration into an LDAP
+// search request, defaulting the base DN to the domain.
+func (d *SearchDataSource) buildSearchRequest(ctx context.Context, data *SearchDataSourceModel, diags *diag.Diagnostics) (*ldapclient.SearchRequest, error) {
+	searchReq := &ldapclient.SearchRequest{
+		BaseDN:    data.BaseDN.ValueString(),
+		Scope:     ldapclient.ScopeWholeSubtree,
+		Filter:    data.Filter.ValueString(),
+		SizeLimit: defaultSearchSizeLimit,
+	}
+
+	if searchReq.BaseDN == "" {
+		baseDN, err := d.client.GetBaseDN(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not get base DN from LDAP server: %w", err)
+		}
+		searchReq.BaseDN = baseDN
+	}
+
+	if scope := helpers.MapSearchScope(data.Scope.ValueString()); scope != nil {
+		searchReq.Scope = *scope
+	}
+
+	if !data.SizeLimit.IsNull() {
+		searchReq.SizeLimit = int(data.SizeLimit.ValueInt64())
+	}
+
+	diags.Append(data.Attributes.ElementsAs(ctx, &searchReq.Attributes, false)...)
+
+	return searchReq, nil
+}
@@ -0,0 +1,116 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSearchDataSource_basic(t *testing.T) {
+	ouName := GenerateTestName(TestOUPrefix + "search-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSearchDataSourceConfig(ouName, `
+  base_dn    = ad_ou.test.dn
+  filter     = "(objectClass=contact)"
+  attributes = ["cn", "objectGUID", "description"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ad_search.test", "entry_count", "2"),
+					resource.TestCheckResourceAttr("data.ad_search.test", "truncated", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ad_search.test", "entries.*", map[string]string{
+						"values.cn.0":          "contact-a",
+						"values.description.0": "First contact",
+					}),
+					// objectGUID is decoded to the same form as resource IDs
+					resource.TestCheckTypeSetElemAttrPair("data.ad_search.test", "entries.*.values.objectGUID.0", "ad_object.a", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSearchDataSource_sizeLimitAndScope(t *testing.T) {
+	ouName := GenerateTestName(TestOUPrefix + "search-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSearchDataSourceConfig(ouName, `
+  base_dn    = ad_ou.test.dn
+  scope      = "onelevel"
+  filter     = "(objectClass=*)"
+  attributes = ["objectSid", "cn"]
+  size_limit = 1
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ad_search.test", "entry_count", "1"),
+					resource.TestCheckResourceAttr("data.ad_search.test", "truncated", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSearchDataSource_invalidFilter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+%s
+
+data "ad_search" "test" {
+  filter = "(objectClass=user"
+}
+`, testProviderConfig()),
+				ExpectError: regexp.MustCompile(`invalid search filter`),
+			},
+		},
+	})
+}
+
+// testAccSearchDataSourceConfig creates an OU holding two contacts and an
+// ad_search data source with the given arguments.
+func testAccSearchDataSourceConfig(ouName, search string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_ou" "test" {
+  name = %[3]q
+  path = data.ad_rootdse.test.default_naming_context
+}
+
+resource "ad_object" "a" {
+  object_class = "contact"
+  name         = "contact-a"
+  container    = ad_ou.test.dn
+
+  attributes = {
+    description = ["First contact"]
+  }
+}
+
+resource "ad_object" "b" {
+  object_class = "contact"
+  name         = "contact-b"
+  container    = ad_ou.test.dn
+}
+
+data "ad_search" "test" {
+%[4]s
+  depends_on = [ad_object.a, ad_object.b]
+}
+`, testProviderConfig(), testRootDSEDataSource(), ouName, search)
+}
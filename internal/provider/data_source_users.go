@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -23,6 +24,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &UsersDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &UsersDataSource{}
 
 func NewUsersDataSource() datasource.DataSource {
 	return &UsersDataSource{}
@@ -38,9 +40,10 @@ type UsersDataSource struct {
 // UsersDataSourceModel describes the data source data model.
 type UsersDataSourceModel struct {
 	// Search configuration
-	Container types.String `tfsdk:"container"` // Optional container DN to search within
-	Scope     types.String `tfsdk:"scope"`     // Search scope: base, onelevel, subtree (default)
-	Filter    types.Object `tfsdk:"filter"`    // Filter block for search criteria
+	Container     types.String `tfsdk:"container"`      // Optional container DN to search within
+	Scope         types.String `tfsdk:"scope"`          // Search scope: base, onelevel, subtree (default)
+	GlobalCatalog types.Bool   `tfsdk:"global_catalog"` // Search a Global Catalog server (forest-wide)
+	Filter        types.Object `tfsdk:"filter"`         // Filter block for search criteria
 
 	// Output
 	Users     types.List   `tfsdk:"users"`      // List of users found
@@ -127,6 +130,12 @@ func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaReque
 					stringvalidator.OneOf("base", "onelevel", "subtree"),
 				},
 			},
+			"global_catalog": schema.BoolAttribute{
+				MarkdownDescription: "Search a Global Catalog server instead of the domain. Without `container`, the whole forest is searched. " +
+					"Only attributes in the Global Catalog partial attribute set are returned; other attributes (e.g. `department`, `title`, `company`) are empty. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
 
 			// Output attributes
 			"user_count": schema.Int64Attribute{
@@ -340,20 +349,21 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		searchScopeLog = searchFilter.SearchScope.String()
 	}
 	tflog.Debug(ctx, "Searching for AD users", map[string]any{
-		"container":     searchFilter.Container,
-		"name_prefix":   searchFilter.NamePrefix,
-		"name_suffix":   searchFilter.NameSuffix,
-		"name_contains": searchFilter.NameContains,
-		"department":    searchFilter.Department,
-		"title":         searchFilter.Title,
-		"company":       searchFilter.Company,
-		"office":        searchFilter.Office,
-		"manager":       searchFilter.Manager,
-		"enabled":       searchFilter.Enabled,
-		"has_email":     searchFilter.HasEmail,
-		"email_domain":  searchFilter.EmailDomain,
-		"member_of":     searchFilter.MemberOf,
-		"search_scope":  searchScopeLog,
+		"container":      searchFilter.Container,
+		"name_prefix":    searchFilter.NamePrefix,
+		"name_suffix":    searchFilter.NameSuffix,
+		"name_contains":  searchFilter.NameContains,
+		"department":     searchFilter.Department,
+		"title":          searchFilter.Title,
+		"company":        searchFilter.Company,
+		"office":         searchFilter.Office,
+		"manager":        searchFilter.Manager,
+		"enabled":        searchFilter.Enabled,
+		"has_email":      searchFilter.HasEmail,
+		"email_domain":   searchFilter.EmailDomain,
+		"member_of":      searchFilter.MemberOf,
+		"search_scope":   searchScopeLog,
+		"global_catalog": searchFilter.GlobalCatalog,
 	})
 
 	// Narrow attribute list: data source only projects ~17 fields, so we save
@@ -386,6 +396,43 @@ func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+func (d *UsersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data UsersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.GlobalCatalog.ValueBool() || data.Filter.IsUnknown() {
+		return
+	}
+
+	searchFilter, err := d.buildSearchFilter(ctx, &data, &resp.Diagnostics)
+	if err != nil {
+		return
+	}
+
+	addGlobalCatalogFilterWarning(&resp.Diagnostics, searchFilter.FilterAttributes())
+}
+
+// addGlobalCatalogFilterWarning warns when a Global Catalog search filters on
+// attributes outside the partial attribute set. Such filters match nothing on
+// a GC because the values are not replicated there.
+func addGlobalCatalogFilterWarning(diags *diag.Diagnostics, filterAttributes []string) {
+	nonPAS := ldapclient.NonPartialAttributes(filterAttributes)
+	if len(nonPAS) == 0 {
+		return
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("filter"),
+		"Filter Uses Attributes Outside the Global Catalog",
+		fmt.Sprintf("global_catalog is enabled, but the filter references %s, which the Global Catalog does not "+
+			"replicate by default. The search may return no results. Set global_catalog = false to search "+
+			"the domain directly.", strings.Join(nonPAS, ", ")),
+	)
+}
+
 // parseFilterValue parses a filter value and returns the clean value and whether it should be negated.
 func parseFilterValue(value string) (cleanValue string, negate bool) {
 	if after, ok := strings.CutPrefix(value, "!"); ok {
@@ -411,6 +458,8 @@ func (d *UsersDataSource) buildSearchFilter(ctx context.Context, data *UsersData
 		searchFilter.SearchScope = helpers.MapSearchScope(data.Scope.ValueString())
 	}
 
+	searchFilter.GlobalCatalog = data.GlobalCatalog.ValueBool()
+
 	// Parse filter block if present
 	if !data.Filter.IsNull() {
 		var filterModel UserFilterModel
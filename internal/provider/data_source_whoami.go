@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
 	"github.com/isometry/terraform-provider-ad/internal/utils"
 )
 
@@ -28,7 +29,8 @@ type WhoAmIDataSource struct {
 
 // WhoAmIDataSourceModel describes the data source data model.
 type WhoAmIDataSourceModel struct {
-	ID types.String `tfsdk:"id"` // Raw authorization ID from server
+	ID                   types.String `tfsdk:"id"`                     // Raw authorization ID from server
+	KerberosTicketExpiry types.String `tfsdk:"kerberos_ticket_expiry"` // Expiry of the provider's Kerberos TGT
 }
 
 func (d *WhoAmIDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -46,6 +48,12 @@ func (d *WhoAmIDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 					"in various formats such as DN, UPN, SAM account name, or SID.",
 				Computed: true,
 			},
+			"kerberos_ticket_expiry": schema.StringAttribute{
+				MarkdownDescription: "When the provider's Kerberos ticket-granting ticket expires (RFC3339 format). " +
+					"The provider renews or re-acquires the ticket before this time where its credentials allow. " +
+					"Null unless Kerberos authentication is in use.",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -124,6 +132,7 @@ func (d *WhoAmIDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	})
 
 	data.ID = types.StringValue(result.AuthzID)
+	data.KerberosTicketExpiry = helpers.TimestampOrNull(result.KerberosTicketExpiry)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -3,6 +3,7 @@ package provider_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -41,7 +42,7 @@ func TestWhoAmIDataSource_Schema(t *testing.T) {
 	assert.NotNil(t, resp.Schema)
 
 	// Check that all expected attributes are present
-	expectedAttrs := []string{"id"}
+	expectedAttrs := []string{"id", "kerberos_ticket_expiry"}
 	for _, attr := range expectedAttrs {
 		assert.Contains(t, resp.Schema.Attributes, attr, "Schema should contain attribute %s", attr)
 		assert.True(t, resp.Schema.Attributes[attr].IsComputed(), "Attribute %s should be computed", attr)
@@ -121,6 +122,37 @@ func TestWhoAmIDataSource_Read(t *testing.T) {
 	resp.State.Get(t.Context(), &data)
 
 	assert.Equal(t, "u:CN=John Doe,CN=Users,DC=example,DC=com", data.ID.ValueString())
+	assert.True(t, data.KerberosTicketExpiry.IsNull())
+}
+
+func TestWhoAmIDataSource_Read_KerberosTicketExpiry(t *testing.T) {
+	dataSource := &provider.WhoAmIDataSource{}
+	mockClient := NewMockLDAPClient()
+	providerData := &ldapclient.ProviderData{Client: mockClient, CacheManager: nil}
+	configResp := &datasource.ConfigureResponse{}
+	dataSource.Configure(t.Context(), datasource.ConfigureRequest{ProviderData: providerData}, configResp)
+
+	expiry := time.Date(2026, 10, 18, 20, 30, 0, 0, time.UTC)
+	mockClient.SetWhoAmIResult(&ldapclient.WhoAmIResult{
+		AuthzID:              "u:EXAMPLE\\svc-terraform",
+		KerberosTicketExpiry: &expiry,
+	})
+
+	schemaResp := &datasource.SchemaResponse{}
+	dataSource.Schema(t.Context(), datasource.SchemaRequest{}, schemaResp)
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil),
+		},
+	}
+
+	dataSource.Read(t.Context(), createReadRequest(dataSource), resp)
+	assert.False(t, resp.Diagnostics.HasError())
+
+	var data provider.WhoAmIDataSourceModel
+	resp.State.Get(t.Context(), &data)
+	assert.Equal(t, "2026-10-18T20:30:00Z", data.KerberosTicketExpiry.ValueString())
 }
 
 func TestWhoAmIDataSource_Read_WhoAmI_Error(t *testing.T) {
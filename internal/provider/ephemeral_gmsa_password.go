@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &GMSAPasswordEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &GMSAPasswordEphemeralResource{}
+
+func NewGMSAPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &GMSAPasswordEphemeralResource{}
+}
+
+// GMSAPasswordEphemeralResource defines the ephemeral resource implementation.
+type GMSAPasswordEphemeralResource struct {
+	client       ldapclient.Client
+	cacheManager *ldapclient.CacheManager
+	baseDN       string
+	encrypted    bool
+}
+
+// GMSAPasswordEphemeralResourceModel describes the ephemeral resource data model.
+type GMSAPasswordEphemeralResourceModel struct {
+	Account          types.String `tfsdk:"account"`
+	DN               types.String `tfsdk:"dn"`
+	SAMAccountName   types.String `tfsdk:"sam_account_name"`
+	Password         types.String `tfsdk:"password"`
+	NTHash           types.String `tfsdk:"nt_hash"`
+	PreviousPassword types.String `tfsdk:"previous_password"`
+	PreviousNTHash   types.String `tfsdk:"previous_nt_hash"`
+	ExpirationTime   types.String `tfsdk:"expiration_time"`
+	UnchangedUntil   types.String `tfsdk:"unchanged_until"`
+}
+
+func (e *GMSAPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gmsa_password"
+}
+
+func (e *GMSAPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the current password of a group managed service account (gMSA) from `msDS-ManagedPassword`, " +
+			"for services that run as the gMSA on platforms that cannot retrieve it themselves. The password is never stored in plan or state.\n\n" +
+			"Active Directory only returns the password over an encrypted connection (LDAPS, StartTLS or a Kerberos `seal` SASL security layer) " +
+			"to principals listed in the gMSA's `PrincipalsAllowedToRetrieveManagedPassword`.",
+
+		Attributes: map[string]schema.Attribute{
+			"account": schema.StringAttribute{
+				MarkdownDescription: "The gMSA to read the password of, identified by DN, GUID, SID or SAM account name (e.g., `svc-web` or `svc-web$`).",
+				Required:            true,
+			},
+			"dn": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the gMSA.",
+				Computed:            true,
+			},
+			"sam_account_name": schema.StringAttribute{
+				MarkdownDescription: "The SAM account name of the gMSA, including the trailing `$`.",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The current password as base64-encoded UTF-16LE bytes. gMSA passwords are random and are not valid text, " +
+					"so they are returned as the raw bytes a Kerberos keytab or NTLM client expects.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"nt_hash": schema.StringAttribute{
+				MarkdownDescription: "The NT hash (MD4) of the current password, hex-encoded.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"previous_password": schema.StringAttribute{
+				MarkdownDescription: "The previous password as base64-encoded UTF-16LE bytes. Null until the password has been changed once.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"previous_nt_hash": schema.StringAttribute{
+				MarkdownDescription: "The NT hash (MD4) of the previous password, hex-encoded. Null until the password has been changed once.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration_time": schema.StringAttribute{
+				MarkdownDescription: "When the password will have been changed and must be read again (RFC3339 format).",
+				Computed:            true,
+			},
+			"unchanged_until": schema.StringAttribute{
+				MarkdownDescription: "The time before which the password will not change (RFC3339 format).",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *GMSAPasswordEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = providerData.Client
+	e.cacheManager = providerData.CacheManager
+	e.encrypted = providerData.EncryptedConnection
+
+	baseDN, err := e.client.GetBaseDN(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"LDAP Configuration Error",
+			fmt.Sprintf("Could not determine base DN from LDAP server: %s", err.Error()),
+		)
+		return
+	}
+	e.baseDN = baseDN
+}
+
+func (e *GMSAPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data GMSAPasswordEphemeralResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Set up entry/exit logging
+	start := time.Now()
+	tflog.Debug(ctx, "Starting ephemeral resource operation", map[string]any{
+		"operation":          "open",
+		"ephemeral_resource": "ad_gmsa_password",
+	})
+	defer func() {
+		duration := time.Since(start)
+		if resp.Diagnostics.HasError() {
+			tflog.Error(ctx, "Ephemeral resource operation failed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_gmsa_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		} else {
+			tflog.Info(ctx, "Ephemeral resource operation completed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_gmsa_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		}
+	}()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !e.encrypted {
+		resp.Diagnostics.AddError(
+			"Encrypted Connection Required",
+			"Active Directory only returns gMSA passwords over an encrypted connection. "+
+				"Enable use_tls, use an ldaps:// URL, or set sasl_security_layer to \"seal\" with Kerberos authentication.",
+		)
+		return
+	}
+
+	gmsaManager := ldapclient.NewGMSAManager(ctx, e.client, e.baseDN, e.cacheManager)
+	password, err := gmsaManager.GetManagedPassword(data.Account.ValueString())
+	if err != nil {
+		if ldapclient.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"gMSA Password Not Found",
+				fmt.Sprintf("Could not read the password of gMSA %q: %s. "+
+					"Check that the gMSA exists and that the provider's account is allowed to retrieve its password.",
+					data.Account.ValueString(), err.Error()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading gMSA Password",
+			fmt.Sprintf("Could not read the password of gMSA %q: %s", data.Account.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Read gMSA password", map[string]any{
+		"dn":              password.DN,
+		"has_previous":    password.PreviousPassword != nil,
+		"expiration_time": password.ExpiresAt().Format(time.RFC3339),
+	})
+
+	data.DN = types.StringValue(password.DN)
+	data.SAMAccountName = types.StringValue(password.SAMAccountName)
+	data.Password = types.StringValue(base64.StdEncoding.EncodeToString(password.CurrentPassword))
+	data.NTHash = types.StringValue(hex.EncodeToString(ldapclient.NTHash(password.CurrentPassword)))
+	data.PreviousPassword = types.StringNull()
+	data.PreviousNTHash = types.StringNull()
+	if password.PreviousPassword != nil {
+		data.PreviousPassword = types.StringValue(base64.StdEncoding.EncodeToString(password.PreviousPassword))
+		data.PreviousNTHash = types.StringValue(hex.EncodeToString(ldapclient.NTHash(password.PreviousPassword)))
+	}
+	data.ExpirationTime = helpers.Timestamp(password.ExpiresAt())
+	data.UnchangedUntil = helpers.Timestamp(password.UnchangedUntil())
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
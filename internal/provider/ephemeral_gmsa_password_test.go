@@ -0,0 +1,68 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider"
+)
+
+func TestGMSAPasswordEphemeralResource_Schema(t *testing.T) {
+	e := provider.NewGMSAPasswordEphemeralResource()
+	resp := &ephemeral.SchemaResponse{}
+	e.Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.Schema.Attributes["account"].IsRequired())
+	for _, attr := range []string{"dn", "sam_account_name", "expiration_time", "unchanged_until"} {
+		assert.True(t, resp.Schema.Attributes[attr].IsComputed(), "Attribute %s should be computed", attr)
+	}
+	for _, attr := range []string{"password", "nt_hash", "previous_password", "previous_nt_hash"} {
+		assert.True(t, resp.Schema.Attributes[attr].IsComputed(), "Attribute %s should be computed", attr)
+		assert.True(t, resp.Schema.Attributes[attr].IsSensitive(), "Attribute %s should be sensitive", attr)
+	}
+}
+
+func TestGMSAPasswordEphemeralResource_Open(t *testing.T) {
+	testCases := []struct {
+		name          string
+		encrypted     bool
+		expectedError string
+	}{
+		{name: "unencrypted connection", expectedError: "Encrypted Connection Required"},
+		{name: "gMSA not found", encrypted: true, expectedError: "gMSA Password Not Found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &provider.GMSAPasswordEphemeralResource{}
+			providerData := &ldapclient.ProviderData{Client: NewMockLDAPClient(), EncryptedConnection: tc.encrypted}
+			configResp := &ephemeral.ConfigureResponse{}
+			e.Configure(t.Context(), ephemeral.ConfigureRequest{ProviderData: providerData}, configResp)
+			assert.False(t, configResp.Diagnostics.HasError())
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			e.Schema(t.Context(), ephemeral.SchemaRequest{}, schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(t.Context())
+			values := map[string]tftypes.Value{}
+			for name, attr := range schemaResp.Schema.Attributes {
+				values[name] = tftypes.NewValue(attr.GetType().TerraformType(t.Context()), nil)
+			}
+			values["account"] = tftypes.NewValue(tftypes.String, "svc-web")
+
+			resp := &ephemeral.OpenResponse{
+				Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			req := ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+			e.Open(t.Context(), req, resp)
+
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tc.expectedError)
+		})
+	}
+}
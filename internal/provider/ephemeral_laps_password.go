@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &LAPSPasswordEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &LAPSPasswordEphemeralResource{}
+
+func NewLAPSPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &LAPSPasswordEphemeralResource{}
+}
+
+// LAPSPasswordEphemeralResource defines the ephemeral resource implementation.
+type LAPSPasswordEphemeralResource struct {
+	client       ldapclient.Client
+	cacheManager *ldapclient.CacheManager
+	baseDN       string
+}
+
+// LAPSPasswordEphemeralResourceModel describes the ephemeral resource data model.
+type LAPSPasswordEphemeralResourceModel struct {
+	Computer       types.String `tfsdk:"computer"`
+	DN             types.String `tfsdk:"dn"`
+	AccountName    types.String `tfsdk:"account_name"`
+	Password       types.String `tfsdk:"password"`
+	ExpirationTime types.String `tfsdk:"expiration_time"`
+	UpdateTime     types.String `tfsdk:"update_time"`
+	Source         types.String `tfsdk:"source"`
+}
+
+func (e *LAPSPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_laps_password"
+}
+
+func (e *LAPSPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the local administrator password of a computer managed by Windows LAPS or legacy Microsoft LAPS. " +
+			"The password is read from `msLAPS-EncryptedPassword`, `msLAPS-Password` or `ms-Mcs-AdmPwd` and is never stored in plan or state. " +
+			"The bind identity needs the extended right to read the password attribute on the computer object.\n\n" +
+			"Passwords encrypted by Windows LAPS (`msLAPS-EncryptedPassword`) are decrypted with the KDS root key they were protected with, " +
+			"so the bind identity must also be able to read `msKds-RootKeyData` (by default only Domain Admins and Enterprise Admins can). " +
+			"Decryption through the Group Key Distribution Service RPC interface is not supported; when the root key cannot be read, " +
+			"the provider falls back to a clear-text password if the computer has one, with a warning.",
+
+		Attributes: map[string]schema.Attribute{
+			"computer": schema.StringAttribute{
+				MarkdownDescription: "The computer to read the password of, identified by DN, GUID, SID, DNS host name " +
+					"(e.g., `ws01.example.com`) or SAM account name (e.g., `WS01` or `WS01$`).",
+				Required: true,
+			},
+			"dn": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the computer.",
+				Computed:            true,
+			},
+			"account_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the managed local account. Null for legacy LAPS, which does not record it.",
+				Computed:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The local administrator password.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiration_time": schema.StringAttribute{
+				MarkdownDescription: "When the password expires and will be rotated (RFC3339 format).",
+				Computed:            true,
+			},
+			"update_time": schema.StringAttribute{
+				MarkdownDescription: "When the password was last set (RFC3339 format). Null for legacy LAPS.",
+				Computed:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The attribute the password was read from: `msLAPS-EncryptedPassword`, `msLAPS-Password` or `ms-Mcs-AdmPwd`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *LAPSPasswordEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = providerData.Client
+	e.cacheManager = providerData.CacheManager
+
+	baseDN, err := e.client.GetBaseDN(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"LDAP Configuration Error",
+			fmt.Sprintf("Could not determine base DN from LDAP server: %s", err.Error()),
+		)
+		return
+	}
+	e.baseDN = baseDN
+}
+
+func (e *LAPSPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data LAPSPasswordEphemeralResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Set up entry/exit logging
+	start := time.Now()
+	tflog.Debug(ctx, "Starting ephemeral resource operation", map[string]any{
+		"operation":          "open",
+		"ephemeral_resource": "ad_laps_password",
+	})
+	defer func() {
+		duration := time.Since(start)
+		if resp.Diagnostics.HasError() {
+			tflog.Error(ctx, "Ephemeral resource operation failed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_laps_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		} else {
+			tflog.Info(ctx, "Ephemeral resource operation completed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_laps_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		}
+	}()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lapsManager := ldapclient.NewLAPSManager(ctx, e.client, e.baseDN, e.cacheManager)
+	password, err := lapsManager.GetLAPSPassword(data.Computer.ValueString())
+	if err != nil {
+		if errors.Is(err, ldapclient.ErrKDSRootKeyUnreadable) {
+			resp.Diagnostics.AddError(
+				"KDS Root Key Not Readable",
+				fmt.Sprintf("Computer %q only has a password encrypted by Windows LAPS (msLAPS-EncryptedPassword). "+
+					"Decrypting it requires read access to msKds-RootKeyData on the KDS root keys under "+
+					"CN=Master Root Keys,CN=Group Key Distribution Service,CN=Services in the configuration partition, "+
+					"which by default only Domain Admins and Enterprise Admins have. Grant the provider's account that access, "+
+					"or have LAPS store the password unencrypted: %s", data.Computer.ValueString(), err.Error()),
+			)
+			return
+		}
+		if ldapclient.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"LAPS Password Not Found",
+				fmt.Sprintf("No readable LAPS password was found for computer %q: %s. "+
+					"Check that the computer exists, is managed by LAPS, and that the provider's account may read its password.",
+					data.Computer.ValueString(), err.Error()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading LAPS Password",
+			fmt.Sprintf("Could not read the LAPS password for computer %q: %s", data.Computer.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if password.EncryptedUnreadable {
+		resp.Diagnostics.AddWarning(
+			"Encrypted LAPS Password Not Decrypted",
+			fmt.Sprintf("Computer %s has an encrypted LAPS password that cannot be decrypted, so the clear-text password from %s was returned. "+
+				"It may be older than the encrypted password. Decryption requires read access to msKds-RootKeyData, "+
+				"which by default only Domain Admins and Enterprise Admins have.", password.ComputerDN, password.Source),
+		)
+	}
+
+	tflog.Debug(ctx, "Read LAPS password", map[string]any{
+		"computer_dn": password.ComputerDN,
+		"source":      password.Source,
+	})
+
+	data.DN = types.StringValue(password.ComputerDN)
+	data.AccountName = types.StringNull()
+	if password.Account != "" {
+		data.AccountName = types.StringValue(password.Account)
+	}
+	data.Password = types.StringValue(password.Password)
+	data.ExpirationTime = helpers.TimestampOrNull(password.Expiration)
+	data.UpdateTime = helpers.TimestampOrNull(password.UpdatedAt)
+	data.Source = types.StringValue(password.Source)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
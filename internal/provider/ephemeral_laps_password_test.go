@@ -0,0 +1,69 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider"
+)
+
+func TestLAPSPasswordEphemeralResource_Schema(t *testing.T) {
+	e := provider.NewLAPSPasswordEphemeralResource()
+	resp := &ephemeral.SchemaResponse{}
+	e.Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.Schema.Attributes["computer"].IsRequired())
+	for _, attr := range []string{"dn", "account_name", "password", "expiration_time", "update_time", "source"} {
+		assert.True(t, resp.Schema.Attributes[attr].IsComputed(), "Attribute %s should be computed", attr)
+	}
+	assert.True(t, resp.Schema.Attributes["password"].IsSensitive())
+}
+
+func TestLAPSPasswordEphemeralResource_Open(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clientErr     error
+		expectedError string
+	}{
+		{name: "computer not found", expectedError: "LAPS Password Not Found"},
+		{name: "search error", clientErr: assert.AnError, expectedError: "Error Reading LAPS Password"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &provider.LAPSPasswordEphemeralResource{}
+			mockClient := NewMockLDAPClient()
+			configResp := &ephemeral.ConfigureResponse{}
+			e.Configure(t.Context(), ephemeral.ConfigureRequest{ProviderData: &ldapclient.ProviderData{Client: mockClient}}, configResp)
+			assert.False(t, configResp.Diagnostics.HasError())
+			mockClient.SetError(tc.clientErr)
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			e.Schema(t.Context(), ephemeral.SchemaRequest{}, schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(t.Context())
+			config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"computer":        tftypes.NewValue(tftypes.String, "WS01"),
+				"dn":              tftypes.NewValue(tftypes.String, nil),
+				"account_name":    tftypes.NewValue(tftypes.String, nil),
+				"password":        tftypes.NewValue(tftypes.String, nil),
+				"expiration_time": tftypes.NewValue(tftypes.String, nil),
+				"update_time":     tftypes.NewValue(tftypes.String, nil),
+				"source":          tftypes.NewValue(tftypes.String, nil),
+			})
+
+			resp := &ephemeral.OpenResponse{
+				Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			e.Open(t.Context(), ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}, resp)
+
+			assert.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// defaultGeneratedPasswordLength is the length of a generated password when
+// neither the configuration nor the policy asks for a longer one.
+const defaultGeneratedPasswordLength = 24
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &PasswordEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &PasswordEphemeralResource{}
+
+func NewPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &PasswordEphemeralResource{}
+}
+
+// PasswordEphemeralResource defines the ephemeral resource implementation.
+type PasswordEphemeralResource struct {
+	client       ldapclient.Client
+	cacheManager *ldapclient.CacheManager
+	baseDN       string
+}
+
+// PasswordEphemeralResourceModel describes the ephemeral resource data model.
+type PasswordEphemeralResourceModel struct {
+	Target            types.String `tfsdk:"target"`
+	Length            types.Int64  `tfsdk:"length"`
+	Result            types.String `tfsdk:"result"`
+	MinLength         types.Int64  `tfsdk:"min_length"`
+	ComplexityEnabled types.Bool   `tfsdk:"complexity_enabled"`
+	PolicySource      types.String `tfsdk:"policy_source"`
+}
+
+func (e *PasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password"
+}
+
+func (e *PasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a random password that satisfies the password policy in effect for a user, an OU or the domain. " +
+			"The policy is read from the password settings object named by the user's `msDS-ResultantPSO`, or from the domain's " +
+			"`minPwdLength` and `pwdProperties`. The password is never stored in plan or state, so it can be passed to " +
+			"`ad_user.password`.",
+
+		Attributes: map[string]schema.Attribute{
+			"target": schema.StringAttribute{
+				MarkdownDescription: "The user whose effective password policy applies, identified by DN, GUID, SID, UPN or SAM account name, " +
+					"or the DN of the OU the user will be created in. Fine-grained password policies apply to users and groups, not OUs, " +
+					"so an OU DN or an unset target uses the domain password policy.",
+				Optional: true,
+			},
+			"length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The length of the generated password. Raised to the policy's minimum length when shorter. "+
+					"Defaults to `%d`.", defaultGeneratedPasswordLength),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, ldapclient.MaxGeneratedPasswordLength),
+				},
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "The generated password. It contains at least one uppercase letter, lowercase letter, digit and special character " +
+					"and, when the policy enforces complexity, none of the target user's account or display name tokens.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"min_length": schema.Int64Attribute{
+				MarkdownDescription: "The minimum password length required by the effective policy.",
+				Computed:            true,
+			},
+			"complexity_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the effective policy enforces password complexity.",
+				Computed:            true,
+			},
+			"policy_source": schema.StringAttribute{
+				MarkdownDescription: "The DN of the password settings object or domain the effective policy was read from.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *PasswordEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = providerData.Client
+	e.cacheManager = providerData.CacheManager
+
+	baseDN, err := e.client.GetBaseDN(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"LDAP Configuration Error",
+			fmt.Sprintf("Could not determine base DN from LDAP server: %s", err.Error()),
+		)
+		return
+	}
+	e.baseDN = baseDN
+}
+
+func (e *PasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data PasswordEphemeralResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Set up entry/exit logging
+	start := time.Now()
+	tflog.Debug(ctx, "Starting ephemeral resource operation", map[string]any{
+		"operation":          "open",
+		"ephemeral_resource": "ad_password",
+	})
+	defer func() {
+		duration := time.Since(start)
+		if resp.Diagnostics.HasError() {
+			tflog.Error(ctx, "Ephemeral resource operation failed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		} else {
+			tflog.Info(ctx, "Ephemeral resource operation completed", map[string]any{
+				"operation":          "open",
+				"ephemeral_resource": "ad_password",
+				"duration_ms":        duration.Milliseconds(),
+			})
+		}
+	}()
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyManager := ldapclient.NewPasswordPolicyManager(ctx, e.client, e.baseDN, e.cacheManager)
+	policy, err := policyManager.GetPasswordPolicy(data.Target.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Password Policy",
+			fmt.Sprintf("Could not read the password policy for %q: %s", data.Target.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if policy.UnreadablePSO != "" {
+		resp.Diagnostics.AddWarning(
+			"Password Settings Object Not Readable",
+			fmt.Sprintf("The password settings object %s applies to %q but could not be read, so the domain password policy was used instead. "+
+				"Grant the provider's account read access to the Password Settings Container to honour fine-grained password policies.",
+				policy.UnreadablePSO, data.Target.ValueString()),
+		)
+	}
+
+	length := defaultGeneratedPasswordLength
+	if !data.Length.IsNull() {
+		length = int(data.Length.ValueInt64())
+	}
+
+	password, err := ldapclient.GeneratePassword(length, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Generating Password",
+			fmt.Sprintf("Could not generate a password satisfying the policy from %s: %s", policy.Source, err.Error()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Generated password", map[string]any{
+		"policy_source":      policy.Source,
+		"min_length":         policy.MinLength,
+		"complexity_enabled": policy.ComplexityEnabled,
+	})
+
+	data.Result = types.StringValue(password)
+	data.MinLength = types.Int64Value(int64(policy.MinLength))
+	data.ComplexityEnabled = types.BoolValue(policy.ComplexityEnabled)
+	data.PolicySource = types.StringValue(policy.Source)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
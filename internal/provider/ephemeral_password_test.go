@@ -0,0 +1,70 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider"
+)
+
+func TestPasswordEphemeralResource_Schema(t *testing.T) {
+	e := provider.NewPasswordEphemeralResource()
+	resp := &ephemeral.SchemaResponse{}
+	e.Schema(t.Context(), ephemeral.SchemaRequest{}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	for _, attr := range []string{"target", "length"} {
+		assert.True(t, resp.Schema.Attributes[attr].IsOptional(), "Attribute %s should be optional", attr)
+	}
+	for _, attr := range []string{"result", "min_length", "complexity_enabled", "policy_source"} {
+		assert.True(t, resp.Schema.Attributes[attr].IsComputed(), "Attribute %s should be computed", attr)
+	}
+	assert.True(t, resp.Schema.Attributes["result"].IsSensitive())
+}
+
+func TestPasswordEphemeralResource_Configure_WrongType(t *testing.T) {
+	e := &provider.PasswordEphemeralResource{}
+	resp := &ephemeral.ConfigureResponse{}
+	e.Configure(t.Context(), ephemeral.ConfigureRequest{ProviderData: "not a client"}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Unexpected Ephemeral Resource Configure Type")
+}
+
+func TestPasswordEphemeralResource_Open_PolicyError(t *testing.T) {
+	e := &provider.PasswordEphemeralResource{}
+	mockClient := NewMockLDAPClient()
+	configResp := &ephemeral.ConfigureResponse{}
+	e.Configure(t.Context(), ephemeral.ConfigureRequest{ProviderData: &ldapclient.ProviderData{Client: mockClient}}, configResp)
+	assert.False(t, configResp.Diagnostics.HasError())
+
+	mockClient.SetError(assert.AnError)
+
+	schemaResp := &ephemeral.SchemaResponse{}
+	e.Schema(t.Context(), ephemeral.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(t.Context())
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"target":             tftypes.NewValue(tftypes.String, "CN=Jane Doe,OU=Users,DC=example,DC=com"),
+		"length":             tftypes.NewValue(tftypes.Number, nil),
+		"result":             tftypes.NewValue(tftypes.String, nil),
+		"min_length":         tftypes.NewValue(tftypes.Number, nil),
+		"complexity_enabled": tftypes.NewValue(tftypes.Bool, nil),
+		"policy_source":      tftypes.NewValue(tftypes.String, nil),
+	})
+
+	req := ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+	}
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	e.Open(t.Context(), req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "Error Reading Password Policy")
+}
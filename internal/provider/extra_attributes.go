@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+// userModeledAttributes are the LDAP attributes managed or reported by
+// ad_user arguments, which extra_attributes may not manage.
+var userModeledAttributes = []string{
+	"objectClass", "objectGUID", "objectSid", "distinguishedName", "cn", "name",
+	"sAMAccountName", "userPrincipalName", "unicodePwd", "userAccountControl", "pwdLastSet",
+	"displayName", "description", "givenName", "sn", "initials",
+	"mail", "homePhone", "mobile", "telephoneNumber", "facsimileTelephoneNumber", "wWWHomePage",
+	"streetAddress", "l", "st", "postalCode", "co", "postOfficeBox",
+	"title", "department", "company", "manager", "employeeID", "employeeNumber",
+	"physicalDeliveryOfficeName", "division", "o",
+	"homeDirectory", "homeDrive", "profilePath", "scriptPath",
+	"logonHours", "userWorkstations", "accountExpires", "servicePrincipalName", "msDS-AllowedToDelegateTo",
+	"msDS-SupportedEncryptionTypes", "nTSecurityDescriptor",
+	"memberOf", "primaryGroupID", "whenCreated", "whenChanged", "lastLogon", "lockoutTime",
+}
+
+// groupModeledAttributes are the LDAP attributes managed or reported by
+// ad_group arguments, which extra_attributes may not manage. Membership is
+// managed by ad_group_membership.
+var groupModeledAttributes = []string{
+	"objectClass", "objectGUID", "objectSid", "distinguishedName", "cn", "name",
+	"sAMAccountName", "groupType", "description", "managedBy", "member",
+}
+
+// ouModeledAttributes are the LDAP attributes managed or reported by ad_ou
+// arguments, which extra_attributes may not manage.
+var ouModeledAttributes = []string{
+	"objectClass", "objectGUID", "distinguishedName", "ou", "name",
+	"description", "managedBy", "nTSecurityDescriptor",
+}
+
+// extraAttributesSchema returns the extra_attributes attribute shared by the
+// object resources.
+func extraAttributesSchema() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "Additional LDAP attributes to manage that are not modeled by other arguments, such as " +
+			"`extensionAttribute1`-`extensionAttribute15`, `employeeType` or custom schema extensions. Keys are LDAP " +
+			"attribute names and values are lists of string values. Only the listed attributes are managed: removing " +
+			"an attribute from the map deletes it from the object, and attributes that are not listed are ignored. " +
+			"Attributes modeled by other arguments of this resource, and attributes that the schema marks as " +
+			"system-only or constructed, are rejected at plan time.",
+		Optional:    true,
+		ElementType: types.ListType{ElemType: types.StringType},
+		Validators: []validator.Map{
+			mapvalidator.SizeAtLeast(1),
+			mapvalidator.KeysAre(stringvalidator.RegexMatches(
+				regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`),
+				"must be an LDAP attribute name",
+			)),
+			mapvalidator.ValueListsAre(
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+			),
+		},
+	}
+}
+
+// validateExtraAttributeNames refuses extra attributes that the resource
+// already models, and attribute names that differ only in case.
+func validateExtraAttributeNames(extra types.Map, modeled []string, diags *diag.Diagnostics) {
+	validateAttributeNames(path.Root("extra_attributes"), extra, modeled, diags)
+}
+
+// validateAttributeNames refuses attributes of the map at attrsPath that the
+// resource already models, and attribute names that differ only in case.
+func validateAttributeNames(attrsPath path.Path, attrs types.Map, modeled []string, diags *diag.Diagnostics) {
+	if attrs.IsNull() || attrs.IsUnknown() {
+		return
+	}
+
+	argument := attrsPath.String()
+	seen := make(map[string]string, len(attrs.Elements()))
+	for _, name := range sortedKeys(attrs) {
+		attrPath := attrsPath.AtMapKey(name)
+
+		if i := slices.IndexFunc(modeled, func(m string) bool { return strings.EqualFold(m, name) }); i >= 0 {
+			diags.AddAttributeError(
+				attrPath,
+				"Modeled Attribute in "+argument,
+				fmt.Sprintf("The %q attribute is managed by this resource and cannot be set through %s.", modeled[i], argument),
+			)
+			continue
+		}
+
+		if previous, ok := seen[strings.ToLower(name)]; ok {
+			diags.AddAttributeError(
+				attrPath,
+				"Duplicate Attribute in "+argument,
+				fmt.Sprintf("LDAP attribute names are case-insensitive: %q and %q name the same attribute.", previous, name),
+			)
+			continue
+		}
+		seen[strings.ToLower(name)] = name
+	}
+}
+
+// validateExtraAttributeSchema checks extra attributes against the directory
+// schema, refusing attributes that are not defined, that only the system may
+// write, or that are constructed, and multiple values for single-valued
+// attributes. It is skipped when the provider is not yet configured.
+func validateExtraAttributeSchema(ctx context.Context, client ldapclient.Client, extra types.Map, diags *diag.Diagnostics) {
+	validateAttributeSchema(ctx, client, path.Root("extra_attributes"), extra, diags)
+}
+
+// validateAttributeSchema performs the checks of validateExtraAttributeSchema
+// on the attribute map at attrsPath. It returns the schema definitions of the
+// attributes, or nil if they could not be read.
+func validateAttributeSchema(ctx context.Context, client ldapclient.Client, attrsPath path.Path, attrs types.Map, diags *diag.Diagnostics) map[string]ldapclient.AttributeSchema {
+	if client == nil || attrs.IsNull() || attrs.IsUnknown() {
+		return nil
+	}
+
+	argument := attrsPath.String()
+	names := sortedKeys(attrs)
+	schemas, err := ldapclient.LookupAttributeSchema(ctx, client, names)
+	if err != nil {
+		diags.AddAttributeWarning(
+			attrsPath,
+			"Could Not Verify "+argument,
+			fmt.Sprintf("The attribute definitions could not be read from the schema: %s", err.Error()),
+		)
+		return nil
+	}
+
+	for _, name := range names {
+		attrPath := attrsPath.AtMapKey(name)
+
+		attrSchema, ok := schemas[strings.ToLower(name)]
+		switch {
+		case !ok:
+			diags.AddAttributeError(attrPath, "Unknown Attribute in "+argument,
+				fmt.Sprintf("The %q attribute is not defined in the Active Directory schema.", name))
+		case attrSchema.SystemOnly:
+			diags.AddAttributeError(attrPath, "System-Only Attribute in "+argument,
+				fmt.Sprintf("The %q attribute is system-only and can only be written by Active Directory itself.", attrSchema.LDAPDisplayName))
+		case attrSchema.Constructed:
+			diags.AddAttributeError(attrPath, "Constructed Attribute in "+argument,
+				fmt.Sprintf("The %q attribute is constructed by Active Directory and cannot be written.", attrSchema.LDAPDisplayName))
+		case attrSchema.SingleValued:
+			if values, ok := attrs.Elements()[name].(types.List); ok && !values.IsUnknown() && len(values.Elements()) > 1 {
+				diags.AddAttributeError(attrPath, "Multiple Values for Single-Valued Attribute",
+					fmt.Sprintf("The %q attribute is single-valued but %d values are configured.", attrSchema.LDAPDisplayName, len(values.Elements())))
+			}
+		}
+	}
+	return schemas
+}
+
+// extraAttributeValues converts extra_attributes to LDAP attribute values. A
+// null or unknown map converts to nil.
+func extraAttributeValues(extra types.Map) map[string][]string {
+	if extra.IsNull() || extra.IsUnknown() {
+		return nil
+	}
+
+	values := make(map[string][]string, len(extra.Elements()))
+	for name, element := range extra.Elements() {
+		list, ok := element.(types.List)
+		if !ok {
+			continue
+		}
+		attrValues := make([]string, 0, len(list.Elements()))
+		for _, value := range list.Elements() {
+			if s, ok := value.(types.String); ok {
+				attrValues = append(attrValues, s.ValueString())
+			}
+		}
+		values[name] = attrValues
+	}
+	return values
+}
+
+// extraAttributeChanges returns the extra attributes to write when updating
+// from state to plan: the planned attributes, plus an empty list for each
+// attribute no longer planned so that it is deleted. It returns nil when
+// extra_attributes is unchanged.
+func extraAttributeChanges(plan, state types.Map) map[string][]string {
+	if plan.Equal(state) {
+		return nil
+	}
+
+	changes := extraAttributeValues(plan)
+	if changes == nil {
+		changes = make(map[string][]string)
+	}
+	for name := range extraAttributeValues(state) {
+		if !slices.ContainsFunc(sortedKeys(plan), func(p string) bool { return strings.EqualFold(p, name) }) {
+			changes[name] = []string{}
+		}
+	}
+	return changes
+}
+
+// extraAttributeNames returns the attribute names of the given
+// extra_attributes maps, for reading them back from the directory.
+func extraAttributeNames(maps ...types.Map) []string {
+	var names []string
+	for _, extra := range maps {
+		for _, name := range sortedKeys(extra) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// extraAttributesToModel converts the extra attributes read from the
+// directory to extra_attributes for the attributes listed in prior. A null
+// prior value stays null. The prior order of values is kept when the
+// directory holds the same values, as AD does not preserve value order.
+func extraAttributesToModel(prior types.Map, values map[string][]string) types.Map {
+	return attributesToModel(prior, values, func(_ string, a, b []string) bool { return ldapclient.SameAttributeValues(a, b) })
+}
+
+// attributesToModel converts attributes read from the directory like
+// extraAttributesToModel, keeping the prior values of an attribute whenever
+// equal reports them equal to the values read.
+func attributesToModel(prior types.Map, values map[string][]string, equal func(name string, a, b []string) bool) types.Map {
+	elemType := types.ListType{ElemType: types.StringType}
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.MapNull(elemType)
+	}
+
+	priorValues := extraAttributeValues(prior)
+	modelValues := make(map[string][]string, len(priorValues))
+	for name, priorList := range priorValues {
+		current, ok := values[name]
+		if !ok {
+			continue
+		}
+		if equal(name, priorList, current) {
+			current = priorList
+		}
+		modelValues[name] = current
+	}
+	return stringListMap(modelValues)
+}
+
+// stringListMap converts attribute values to a map of string lists.
+func stringListMap(values map[string][]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for name, attrValues := range values {
+		list := make([]attr.Value, len(attrValues))
+		for i, value := range attrValues {
+			list[i] = types.StringValue(value)
+		}
+		elements[name] = types.ListValueMust(types.StringType, list)
+	}
+	return types.MapValueMust(types.ListType{ElemType: types.StringType}, elements)
+}
+
+// sortedKeys returns the keys of a known map in sorted order.
+func sortedKeys(m types.Map) []string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	keys := make([]string, 0, len(m.Elements()))
+	for key := range m.Elements() {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
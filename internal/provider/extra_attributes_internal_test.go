@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// extraAttributesMap builds an extra_attributes value from attribute values.
+func extraAttributesMap(values map[string][]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for name, attrValues := range values {
+		list := make([]attr.Value, len(attrValues))
+		for i, value := range attrValues {
+			list[i] = types.StringValue(value)
+		}
+		elements[name] = types.ListValueMust(types.StringType, list)
+	}
+	return types.MapValueMust(types.ListType{ElemType: types.StringType}, elements)
+}
+
+func TestValidateExtraAttributeNames(t *testing.T) {
+	t.Parallel()
+
+	var diags diag.Diagnostics
+	validateExtraAttributeNames(extraAttributesMap(map[string][]string{
+		"EmployeeType":        {"contractor"},
+		"employeetype":        {"contractor"},
+		"extensionAttribute1": {"finance"},
+		"MAIL":                {"jdoe@example.com"},
+	}), userModeledAttributes, &diags)
+
+	var paths []path.Path
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path())
+		}
+	}
+	assert.ElementsMatch(t, []path.Path{
+		path.Root("extra_attributes").AtMapKey("MAIL"),
+		path.Root("extra_attributes").AtMapKey("employeetype"),
+	}, paths)
+
+	diags = nil
+	validateExtraAttributeNames(extraAttributesMap(map[string][]string{"mail": {"group@example.com"}}), groupModeledAttributes, &diags)
+	assert.False(t, diags.HasError(), "mail is not modeled by ad_group")
+
+	validateExtraAttributeNames(types.MapNull(types.ListType{ElemType: types.StringType}), userModeledAttributes, &diags)
+	assert.False(t, diags.HasError())
+}
+
+func TestExtraAttributeChanges(t *testing.T) {
+	t.Parallel()
+
+	null := types.MapNull(types.ListType{ElemType: types.StringType})
+	state := extraAttributesMap(map[string][]string{
+		"employeeType":        {"contractor"},
+		"extensionAttribute1": {"finance"},
+	})
+
+	assert.Nil(t, extraAttributeChanges(state, state))
+	assert.Nil(t, extraAttributeChanges(null, null))
+
+	assert.Equal(t, map[string][]string{
+		"employeeType":        {"employee"},
+		"extensionAttribute1": {},
+	}, extraAttributeChanges(extraAttributesMap(map[string][]string{"employeeType": {"employee"}}), state))
+
+	assert.Equal(t, map[string][]string{
+		"employeeType":        {},
+		"extensionAttribute1": {},
+	}, extraAttributeChanges(null, state))
+
+	// Renaming the key's case replaces the attribute rather than deleting it.
+	assert.Equal(t, map[string][]string{
+		"employeetype": {"contractor"},
+	}, extraAttributeChanges(extraAttributesMap(map[string][]string{"employeetype": {"contractor"}}),
+		extraAttributesMap(map[string][]string{"employeeType": {"contractor"}})))
+}
+
+func TestExtraAttributesToModel(t *testing.T) {
+	t.Parallel()
+
+	prior := extraAttributesMap(map[string][]string{
+		"otherTelephone":      {"+1 555 0101", "+1 555 0100"},
+		"extensionAttribute1": {"finance"},
+		"employeeType":        {"contractor"},
+	})
+
+	got := extraAttributesToModel(prior, map[string][]string{
+		"otherTelephone":      {"+1 555 0100", "+1 555 0101"},
+		"extensionAttribute1": {"sales"},
+	})
+
+	assert.True(t, got.Equal(extraAttributesMap(map[string][]string{
+		"otherTelephone":      {"+1 555 0101", "+1 555 0100"},
+		"extensionAttribute1": {"sales"},
+	})), "got %s", got)
+
+	null := types.MapNull(types.ListType{ElemType: types.StringType})
+	assert.True(t, extraAttributesToModel(null, map[string][]string{"info": {"x"}}).IsNull())
+	assert.True(t, extraAttributesToModel(types.Map{}, nil).Equal(null))
+}
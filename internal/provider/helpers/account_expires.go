@@ -0,0 +1,80 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+)
+
+// AccountExpiresNever is the account_expires value for accounts that never expire.
+const AccountExpiresNever = "never"
+
+// ParseAccountExpires parses an account_expires value, either an RFC3339
+// timestamp or "never". The zero time means the account never expires.
+func ParseAccountExpires(value string) (time.Time, error) {
+	if value == AccountExpiresNever {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be an RFC3339 timestamp or %q: %w", AccountExpiresNever, err)
+	}
+	if !t.After(time.Unix(0, 0)) {
+		return time.Time{}, fmt.Errorf("must be after %s", time.Unix(0, 0).UTC().Format(time.RFC3339))
+	}
+	return t, nil
+}
+
+// AccountExpiresEquivalent reports whether a configured account_expires value
+// describes the expiration stored in AD. Active Directory Users and Computers
+// and Set-ADAccountExpiration store the end of the chosen day, i.e. midnight at
+// the start of the following day, so a configured midnight (in its own offset)
+// and a stored value exactly 24 hours later are treated as equivalent. Any
+// other configured time must match the stored value.
+func AccountExpiresEquivalent(configured, stored string) bool {
+	configuredTime, err := ParseAccountExpires(configured)
+	if err != nil {
+		return false
+	}
+	storedTime, err := ParseAccountExpires(stored)
+	if err != nil {
+		return false
+	}
+
+	if configuredTime.IsZero() || storedTime.IsZero() {
+		return configuredTime.IsZero() && storedTime.IsZero()
+	}
+	if configuredTime.Truncate(time.Second).Equal(storedTime.Truncate(time.Second)) {
+		return true
+	}
+
+	hour, minute, second := configuredTime.Clock()
+	if hour != 0 || minute != 0 || second != 0 || configuredTime.Nanosecond() != 0 {
+		return false
+	}
+	return storedTime.Equal(configuredTime.Add(24 * time.Hour))
+}
+
+// AccountExpiresChanged reports whether a planned account_expires value must
+// be written. The current value is the one read from AD, or a configured value
+// found equivalent to it, so only a different instant needs writing.
+func AccountExpiresChanged(planned, current string) bool {
+	plannedTime, err := ParseAccountExpires(planned)
+	if err != nil {
+		return false
+	}
+	currentTime, err := ParseAccountExpires(current)
+	if err != nil {
+		return true
+	}
+	return !plannedTime.Equal(currentTime)
+}
+
+// FormatAccountExpires formats an account expiration for Terraform state as
+// an RFC3339 timestamp, or "never" if the pointer is nil.
+func FormatAccountExpires(expires *time.Time) string {
+	if expires == nil {
+		return AccountExpiresNever
+	}
+	return expires.Format(time.RFC3339)
+}
@@ -0,0 +1,91 @@
+package helpers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+)
+
+func TestParseAccountExpires(t *testing.T) {
+	t.Parallel()
+
+	never, err := helpers.ParseAccountExpires("never")
+	require.NoError(t, err)
+	assert.True(t, never.IsZero())
+
+	expires, err := helpers.ParseAccountExpires("2027-02-28T17:00:00+01:00")
+	require.NoError(t, err)
+	assert.True(t, expires.Equal(time.Date(2027, 2, 28, 16, 0, 0, 0, time.UTC)))
+
+	for _, value := range []string{"", "2027-02-28", "Never", "1969-12-31T00:00:00Z"} {
+		_, err := helpers.ParseAccountExpires(value)
+		assert.Error(t, err, "value %q", value)
+	}
+}
+
+func TestAccountExpiresEquivalent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		configured string
+		stored     string
+		want       bool
+	}{
+		"both never":                    {"never", "never", true},
+		"never and timestamp":           {"never", "2027-03-01T00:00:00Z", false},
+		"same instant":                  {"2027-03-01T00:00:00Z", "2027-03-01T00:00:00Z", true},
+		"same instant other offset":     {"2027-03-01T01:00:00+01:00", "2027-03-01T00:00:00Z", true},
+		"end of configured day":         {"2027-02-28T00:00:00Z", "2027-03-01T00:00:00Z", true},
+		"end of configured day, offset": {"2027-02-28T00:00:00+01:00", "2027-02-28T23:00:00Z", true},
+		"time of day before midnight":   {"2027-02-28T12:00:00Z", "2027-03-01T00:00:00Z", false},
+		"midnight in another offset":    {"2027-02-28T17:00:00+01:00", "2027-02-28T23:00:00Z", false},
+		"end of following day":          {"2027-02-28T00:00:00Z", "2027-03-02T00:00:00Z", false},
+		"earlier instant":               {"2027-02-28T12:00:00Z", "2027-02-28T00:00:00Z", false},
+		"invalid configured value":      {"tomorrow", "2027-03-01T00:00:00Z", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, helpers.AccountExpiresEquivalent(tt.configured, tt.stored))
+		})
+	}
+}
+
+func TestAccountExpiresChanged(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		planned string
+		current string
+		want    bool
+	}{
+		"unchanged":                    {"2027-03-01T00:00:00Z", "2027-03-01T00:00:00Z", false},
+		"same instant other offset":    {"2027-03-01T01:00:00+01:00", "2027-03-01T00:00:00Z", false},
+		"both never":                   {"never", "never", false},
+		"earlier time on previous day": {"2027-02-28T12:00:00Z", "2027-03-01T00:00:00Z", true},
+		"end of day equivalent":        {"2027-03-01T00:00:00Z", "2027-02-28T00:00:00Z", true},
+		"never to timestamp":           {"2027-03-01T00:00:00Z", "never", true},
+		"invalid current value":        {"2027-03-01T00:00:00Z", "", true},
+		"invalid planned value":        {"tomorrow", "never", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, helpers.AccountExpiresChanged(tt.planned, tt.current))
+		})
+	}
+}
+
+func TestFormatAccountExpires(t *testing.T) {
+	t.Parallel()
+
+	stored := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "never", helpers.FormatAccountExpires(nil))
+	assert.Equal(t, "2027-03-01T00:00:00Z", helpers.FormatAccountExpires(&stored))
+}
@@ -199,6 +199,21 @@ func GetString(v types.String) string {
 	return v.ValueString()
 }
 
+// GetStringSet returns the known string elements of a Terraform types.Set.
+// Returns nil if the set is null or unknown.
+func GetStringSet(v types.Set) []string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var values []string
+	for _, elem := range v.Elements() {
+		if s, ok := elem.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			values = append(values, s.ValueString())
+		}
+	}
+	return values
+}
+
 // =============================================================================
 // Construction Helpers (Go → Terraform)
 // =============================================================================
@@ -341,6 +356,21 @@ func StringList(values []string, diags *diag.Diagnostics) types.List {
 	return list
 }
 
+// StringSetOrNull converts a string slice to a Terraform Set of strings.
+// Returns types.SetNull() if the slice is empty.
+func StringSetOrNull(values []string, diags *diag.Diagnostics) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	elems := make([]attr.Value, len(values))
+	for i, v := range values {
+		elems[i] = types.StringValue(v)
+	}
+	set, d := types.SetValue(types.StringType, elems)
+	diags.Append(d...)
+	return set
+}
+
 // Int64List converts an int64 slice to a Terraform List of numbers.
 // Returns an empty list (not null) if the slice is empty.
 func Int64List(values []int64, diags *diag.Diagnostics) types.List {
@@ -655,6 +655,31 @@ func TestStringList(t *testing.T) {
 	})
 }
 
+// ===========================================================================
+// StringSetOrNull / GetStringSet
+// ===========================================================================
+
+func TestStringSetOrNull(t *testing.T) {
+	t.Run("empty slice returns null set", func(t *testing.T) {
+		var diags diag.Diagnostics
+		got := helpers.StringSetOrNull(nil, &diags)
+		assert.True(t, got.IsNull())
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("populated slice round-trips", func(t *testing.T) {
+		var diags diag.Diagnostics
+		got := helpers.StringSetOrNull([]string{"a", "b"}, &diags)
+		require.False(t, diags.HasError())
+		assert.ElementsMatch(t, []string{"a", "b"}, helpers.GetStringSet(got))
+	})
+
+	t.Run("null and unknown sets have no elements", func(t *testing.T) {
+		assert.Nil(t, helpers.GetStringSet(types.SetNull(types.StringType)))
+		assert.Nil(t, helpers.GetStringSet(types.SetUnknown(types.StringType)))
+	})
+}
+
 // ===========================================================================
 // Int64List
 // ===========================================================================
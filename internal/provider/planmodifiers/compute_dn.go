@@ -21,6 +21,7 @@ var _ planmodifier.String = computeDN{}
 // errors when a resource is moved.
 type computeDN struct {
 	rdnPrefix  string // "CN" or "OU"
+	rdnAttr    string // attribute holding the RDN type, overrides rdnPrefix
 	parentAttr string // "container" or "path"
 }
 
@@ -35,6 +36,16 @@ func ComputeDN(rdnPrefix string, parentAttr string) planmodifier.String {
 	}
 }
 
+// ComputeDNFromAttribute returns a plan modifier like ComputeDN that reads
+// the RDN attribute type from the planned rdnAttr attribute rather than
+// using a fixed prefix, for resources whose naming attribute is configurable.
+func ComputeDNFromAttribute(rdnAttr string, parentAttr string) planmodifier.String {
+	return computeDN{
+		rdnAttr:    rdnAttr,
+		parentAttr: parentAttr,
+	}
+}
+
 func (m computeDN) Description(_ context.Context) string {
 	return fmt.Sprintf("computes the DN from the planned name and %s attributes", m.parentAttr)
 }
@@ -65,19 +76,29 @@ func (m computeDN) PlanModifyString(ctx context.Context, req planmodifier.String
 		return
 	}
 
-	// If either value is unknown, we can't compute the DN — mark it unknown
-	if name.IsUnknown() || parent.IsUnknown() {
+	// Get the planned RDN attribute type, if configurable
+	rdnPrefix := types.StringValue(m.rdnPrefix)
+	if m.rdnAttr != "" {
+		diags = req.Plan.GetAttribute(ctx, path.Root(m.rdnAttr), &rdnPrefix)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// If any value is unknown, we can't compute the DN — mark it unknown
+	if name.IsUnknown() || parent.IsUnknown() || rdnPrefix.IsUnknown() {
 		resp.PlanValue = types.StringUnknown()
 		return
 	}
 
-	// If either value is null, leave the plan value unchanged
-	if name.IsNull() || parent.IsNull() {
+	// If any value is null, leave the plan value unchanged
+	if name.IsNull() || parent.IsNull() || rdnPrefix.IsNull() {
 		return
 	}
 
 	// Compute the expected DN and normalize case
-	computedDN := fmt.Sprintf("%s=%s,%s", m.rdnPrefix, ldap.EscapeDN(name.ValueString()), parent.ValueString())
+	computedDN := fmt.Sprintf("%s=%s,%s", rdnPrefix.ValueString(), ldap.EscapeDN(name.ValueString()), parent.ValueString())
 	normalizedDN, err := ldapclient.NormalizeDNCase(computedDN)
 	if err != nil {
 		// Fall back to raw computed DN if normalization fails
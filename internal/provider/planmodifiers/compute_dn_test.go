@@ -363,3 +363,56 @@ func TestComputeDN_Description(t *testing.T) {
 		t.Error("expected non-empty markdown description")
 	}
 }
+
+func TestComputeDNFromAttribute(t *testing.T) {
+	modifier := ComputeDNFromAttribute("rdn_attribute", "container")
+
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":          tftypes.String,
+			"rdn_attribute": tftypes.String,
+			"container":     tftypes.String,
+			"dn":            tftypes.String,
+		},
+	}
+	objectSchema := testSchema("container")
+	objectSchema.Attributes["rdn_attribute"] = schema.StringAttribute{Optional: true, Computed: true}
+
+	tests := []struct {
+		name         string
+		rdnAttribute tftypes.Value
+		expected     types.String
+	}{
+		{"known", tftypes.NewValue(tftypes.String, "ou"), types.StringValue("OU=Printers,OU=Site1,DC=example,DC=com")},
+		{"unknown", tftypes.NewValue(tftypes.String, tftypes.UnknownValue), types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: objectSchema,
+				Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+					"name":          tftypes.NewValue(tftypes.String, "Printers"),
+					"rdn_attribute": tt.rdnAttribute,
+					"container":     tftypes.NewValue(tftypes.String, "OU=Site1,DC=example,DC=com"),
+					"dn":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			}
+
+			req := planmodifier.StringRequest{
+				Plan:      plan,
+				PlanValue: types.StringUnknown(),
+			}
+
+			var resp planmodifier.StringResponse
+			modifier.PlanModifyString(t.Context(), req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tt.expected) {
+				t.Errorf("expected DN %s, got %s", tt.expected, resp.PlanValue)
+			}
+		})
+	}
+}
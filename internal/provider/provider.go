@@ -3,13 +3,17 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"math"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -49,9 +53,21 @@ type ActiveDirectoryProviderModel struct {
 	LdapURL types.String `tfsdk:"ldap_url"`
 	BaseDN  types.String `tfsdk:"base_dn"`
 
+	// Site settings
+	Site          types.String `tfsdk:"site"`
+	SiteDetection types.Bool   `tfsdk:"site_detection"`
+
 	// Authentication settings
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordFile      types.String `tfsdk:"password_file"`
+	CredentialCommand types.List   `tfsdk:"credential_command"`
+
+	CredentialRefreshInterval types.Int64 `tfsdk:"credential_refresh_interval"`
+
+	// NTLM settings (optional)
+	NTLMDomain types.String `tfsdk:"ntlm_domain"`
+	NTLMHash   types.String `tfsdk:"ntlm_hash"`
 
 	// Kerberos settings (optional)
 	KerberosRealm          types.String `tfsdk:"kerberos_realm"`
@@ -61,6 +77,7 @@ type ActiveDirectoryProviderModel struct {
 	KerberosSPN            types.String `tfsdk:"kerberos_spn"`
 	KerberosDNSLookupKDC   types.Bool   `tfsdk:"kerberos_dns_lookup_kdc"`
 	KerberosDNSLookupRealm types.Bool   `tfsdk:"kerberos_dns_lookup_realm"`
+	SASLSecurityLayer      types.String `tfsdk:"sasl_security_layer"`
 
 	// TLS settings
 	UseTLS            types.Bool   `tfsdk:"use_tls"`
@@ -69,6 +86,7 @@ type ActiveDirectoryProviderModel struct {
 	TLSCACert         types.String `tfsdk:"tls_ca_cert"`
 	TLSClientCertFile types.String `tfsdk:"tls_client_cert_file"`
 	TLSClientKeyFile  types.String `tfsdk:"tls_client_key_file"`
+	ChannelBinding    types.String `tfsdk:"channel_binding"`
 
 	// Connection pool settings
 	MaxConnections types.Int64 `tfsdk:"max_connections"`
@@ -123,6 +141,29 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 				Optional: true,
 			},
 
+			// Site settings
+			"site": schema.StringAttribute{
+				MarkdownDescription: "Active Directory site whose domain controllers are preferred (e.g., `London`). " +
+					"Domain controllers are discovered from `_ldap._tcp.<site>._sites.<domain>` first, falling back to the domain-wide records. " +
+					"If not specified, the site is detected automatically unless `site_detection` is `false`. " +
+					"Ignored when `ldap_url` is set. Can be set via the `AD_SITE` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 63),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?$`),
+						"must be a single DNS label of letters, digits, hyphens and underscores",
+					),
+				},
+			},
+			"site_detection": schema.BoolAttribute{
+				MarkdownDescription: "Detect the client's Active Directory site with a CLDAP netlogon ping (UDP port 389) when `site` is not set. " +
+					"Detection adds an SRV lookup and a ping of up to 2 seconds to provider startup; when the ping fails, " +
+					"domain controllers are discovered from the domain-wide SRV records instead. " +
+					"Defaults to `true`. Can be set via the `AD_SITE_DETECTION` environment variable.",
+				Optional: true,
+			},
+
 			// Authentication settings
 			"username": schema.StringAttribute{
 				MarkdownDescription: "Username for LDAP authentication. Supports DN, UPN, or SAM account name formats. " +
@@ -135,6 +176,64 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 				Optional:  true,
 				Sensitive: true,
 			},
+			"password_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the password for LDAP authentication; trailing line breaks are ignored. " +
+					"The file is read again when it changes, so a password rotated by a secrets agent is used for new binds without restarting the provider. " +
+					"Mutually exclusive with `password`, `ntlm_hash` and `credential_command`. " +
+					"Can be set via the `AD_PASSWORD_FILE` environment variable.",
+				Optional: true,
+			},
+			"credential_command": schema.ListAttribute{
+				MarkdownDescription: "Executable and arguments of a credential helper that supplies the bind credentials, " +
+					"in the style of a git credential helper. The command is run directly, not through a shell. " +
+					"It receives a JSON request with `action`, `domain`, `username` and `kerberos_realm` on standard input " +
+					"and must print a JSON object with `password` and optionally `username`, or a `keytab` path for Kerberos. " +
+					"The command runs when the provider is configured and again, at most once a minute, when pooled connections re-authenticate, " +
+					"when a new connection would bind with credentials older than `credential_refresh_interval`, " +
+					"and when a bind is rejected for invalid credentials, in which case the bind is retried once if the credentials changed. " +
+					"Rotated credentials are therefore picked up without restarting the provider. Mutually exclusive with `password` and `ntlm_hash`. " +
+					"Can be set via the `AD_CREDENTIAL_COMMAND` environment variable, either as the path of an executable taking no arguments " +
+					"or as a list such as `[\"/usr/local/bin/ad-credentials\", \"--vault-path\", \"secret/ad\"]`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"credential_refresh_interval": schema.Int64Attribute{
+				MarkdownDescription: "Age in seconds after which the credentials from `credential_command` are fetched again before a new connection binds. " +
+					"Defaults to `300` (5 minutes). Valid range: 60–2147483647 seconds. " +
+					"Can be set via the `AD_CREDENTIAL_REFRESH_INTERVAL` environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(minCredentialRefreshInterval, math.MaxInt32),
+				},
+			},
+
+			// NTLM settings
+			"ntlm_domain": schema.StringAttribute{
+				MarkdownDescription: "Domain for NTLM authentication (e.g., `EXAMPLE`). Setting this selects an NTLM bind with `username` and `password` " +
+					"instead of a simple bind, for clients that are not domain-joined and cannot use Kerberos. " +
+					"A down-level `DOMAIN\\user` username alone does not select NTLM; it is sent as a simple bind unless this or `ntlm_hash` is set. " +
+					"When only `ntlm_hash` is set, the domain is taken from a down-level username. " +
+					"Can be set via the `AD_NTLM_DOMAIN` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"ntlm_hash": schema.StringAttribute{
+				MarkdownDescription: "NT hash of the account password as 32 hexadecimal characters, used for NTLM authentication instead of `password`. " +
+					"Setting this selects an NTLM bind, with the domain from `ntlm_domain` or a down-level `DOMAIN\\user` username. " +
+					"Mutually exclusive with `password`, `password_file`, `credential_command` and `kerberos_realm`. " +
+					"Can be set via the `AD_NTLM_HASH` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]{32}$`), "must be 32 hexadecimal characters"),
+				},
+			},
 
 			// Kerberos settings
 			"kerberos_realm": schema.StringAttribute{
@@ -178,6 +277,16 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 					"Can be set via the `AD_KERBEROS_DNS_LOOKUP_REALM` environment variable.",
 				Optional: true,
 			},
+			"sasl_security_layer": schema.StringAttribute{
+				MarkdownDescription: "SASL security layer to negotiate after a Kerberos bind on a connection without TLS: " +
+					"`none`, `sign` (integrity, satisfies domain controllers that require LDAP signing) or " +
+					"`seal` (integrity and encryption). Ignored when TLS or StartTLS is in use. Defaults to `none`. " +
+					"Can be set via the `AD_SASL_SECURITY_LAYER` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ldapclient.SASLSecurityLayerNone, ldapclient.SASLSecurityLayerSign, ldapclient.SASLSecurityLayerSeal),
+				},
+			},
 
 			// TLS settings
 			"use_tls": schema.BoolAttribute{
@@ -191,7 +300,7 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 				Optional: true,
 			},
 			"tls_ca_cert_file": schema.StringAttribute{
-				MarkdownDescription: "Path to custom CA certificate file for TLS verification. " +
+				MarkdownDescription: "Path to custom CA certificate file for TLS verification. The file is read again when it changes. " +
 					"Can be set via the `AD_TLS_CA_CERT_FILE` environment variable.",
 				Optional: true,
 			},
@@ -203,6 +312,7 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 			},
 			"tls_client_cert_file": schema.StringAttribute{
 				MarkdownDescription: "Path to client certificate file for mutual TLS authentication. " +
+					"The certificate and key are read again when either file changes, so rotated certificates are used for new connections. " +
 					"Can be set via the `AD_TLS_CLIENT_CERT_FILE` environment variable.",
 				Optional: true,
 			},
@@ -212,6 +322,16 @@ func (p *ActiveDirectoryProvider) Schema(ctx context.Context, req provider.Schem
 				Optional:  true,
 				Sensitive: true,
 			},
+			"channel_binding": schema.StringAttribute{
+				MarkdownDescription: "Channel binding for NTLM and Kerberos binds over TLS, as required by domain controllers " +
+					"enforcing LDAP channel binding: `auto` (bind TLS connections, send no token otherwise), " +
+					"`required` (fail connections that cannot be bound) or `disabled`. Defaults to `auto`. " +
+					"Can be set via the `AD_CHANNEL_BINDING` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ldapclient.ChannelBindingAuto, ldapclient.ChannelBindingRequired, ldapclient.ChannelBindingDisabled),
+				},
+			},
 
 			// Connection pool settings
 			"max_connections": schema.Int64Attribute{
@@ -308,6 +428,42 @@ func (p *ActiveDirectoryProvider) ConfigValidators(ctx context.Context) []provid
 			path.MatchRoot("tls_ca_cert_file"),
 			path.MatchRoot("tls_ca_cert"),
 		),
+		// NTLM authenticates with either the password or its NT hash
+		providervalidator.Conflicting(
+			path.MatchRoot("password"),
+			path.MatchRoot("ntlm_hash"),
+		),
+		// The credential command supplies the password
+		providervalidator.Conflicting(
+			path.MatchRoot("credential_command"),
+			path.MatchRoot("password"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("credential_command"),
+			path.MatchRoot("ntlm_hash"),
+		),
+		// The password file supplies the password
+		providervalidator.Conflicting(
+			path.MatchRoot("password_file"),
+			path.MatchRoot("password"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("password_file"),
+			path.MatchRoot("ntlm_hash"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("password_file"),
+			path.MatchRoot("credential_command"),
+		),
+		// Kerberos takes precedence, so NTLM settings would be ignored
+		providervalidator.Conflicting(
+			path.MatchRoot("kerberos_realm"),
+			path.MatchRoot("ntlm_domain"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("kerberos_realm"),
+			path.MatchRoot("ntlm_hash"),
+		),
 	}
 }
 
@@ -328,7 +484,7 @@ func (p *ActiveDirectoryProvider) Configure(ctx context.Context, req provider.Co
 	})
 
 	// Build configuration from provider config and environment variables
-	config := p.buildLDAPConfig(&data, &resp.Diagnostics)
+	config := p.buildLDAPConfig(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -451,10 +607,12 @@ func (p *ActiveDirectoryProvider) Configure(ctx context.Context, req provider.Co
 
 	// Create provider data wrapper with both client and cache manager
 	providerData := ldapclient.NewProviderData(client, p.cacheManager, ignoreMissingMembers)
+	providerData.EncryptedConnection = config.IsEncrypted()
 
 	// Make provider data available to resources and data sources
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
 }
 
 // configureLogging sets up logging configuration based on environment variables.
@@ -469,7 +627,7 @@ func (p *ActiveDirectoryProvider) configureLogging(ctx context.Context) context.
 }
 
 // buildLDAPConfig constructs the LDAP client configuration from provider config and environment variables.
-func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderModel, diags *diag.Diagnostics) *ldapclient.ConnectionConfig {
+func (p *ActiveDirectoryProvider) buildLDAPConfig(ctx context.Context, data *ActiveDirectoryProviderModel, diags *diag.Diagnostics) *ldapclient.ConnectionConfig {
 	config := ldapclient.DefaultConfig()
 
 	// Connection settings
@@ -485,17 +643,76 @@ func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderM
 		config.BaseDN = baseDN
 	}
 
+	// Site settings
+	config.Site = p.getStringValue(data.Site, "AD_SITE")
+	config.SiteDetection = p.getBoolValue(data.SiteDetection, "AD_SITE_DETECTION", true)
+
 	// Authentication settings - validate that we have credentials
 	username := p.getStringValue(data.Username, "AD_USERNAME", "AD_USER")
 	password := p.getStringValue(data.Password, "AD_PASSWORD")
+	ntlmDomain := p.getStringValue(data.NTLMDomain, "AD_NTLM_DOMAIN")
+	ntlmHash := p.getStringValue(data.NTLMHash, "AD_NTLM_HASH")
 	kerberosRealm := p.getStringValue(data.KerberosRealm, "AD_KERBEROS_REALM")
 	kerberosKeytab := p.getStringValue(data.KerberosKeytab, "AD_KERBEROS_KEYTAB")
 	kerberosConfig := p.getStringValue(data.KerberosConfig, "AD_KERBEROS_CONFIG")
 	kerberosCCache := p.getStringValue(data.KerberosCCache, "AD_KERBEROS_CCACHE")
 	kerberosSPN := p.getStringValue(data.KerberosSPN, "AD_KERBEROS_SPN")
 
+	passwordFile := p.getStringValue(data.PasswordFile, "AD_PASSWORD_FILE")
+	credentialCommand := p.getCredentialCommand(ctx, data.CredentialCommand, diags)
+
+	// The schema validators only see configured attributes, so conflicts
+	// involving environment variables are checked on the effective values
+	validateNTLMSettings(ntlmSettings{
+		password:          password,
+		passwordFile:      passwordFile,
+		credentialCommand: credentialCommand,
+		ntlmDomain:        ntlmDomain,
+		ntlmHash:          ntlmHash,
+		kerberosRealm:     kerberosRealm,
+	}, diags)
+	if diags.HasError() {
+		return config
+	}
+
+	// A password file is read now and again whenever it changes
+	if passwordFile != "" {
+		filePassword, err := ldapclient.ReadPasswordFile(passwordFile)
+		if err != nil {
+			diags.AddError(
+				"Invalid Password File",
+				fmt.Sprintf("Unable to read password_file (or AD_PASSWORD_FILE): %s", err),
+			)
+			return config
+		}
+		password = filePassword
+	}
+
+	// A credential command supplies the password or keytab at runtime
+	if len(credentialCommand) > 0 {
+		result, err := ldapclient.RunCredentialCommand(ctx, credentialCommand, ldapclient.CredentialRequest{
+			Domain:        config.Domain,
+			Username:      username,
+			KerberosRealm: kerberosRealm,
+		})
+		if err != nil {
+			diags.AddError(
+				"Credential Command Failed",
+				fmt.Sprintf("The credential_command (or AD_CREDENTIAL_COMMAND) did not return credentials: %s", err),
+			)
+			return config
+		}
+		if result.Username != "" {
+			username = result.Username
+		}
+		password = result.Password
+		if result.KerberosKeytab != "" {
+			kerberosKeytab = result.KerberosKeytab
+		}
+	}
+
 	// Check that we have some form of authentication
-	hasPasswordAuth := username != "" && password != ""
+	hasPasswordAuth := username != "" && (password != "" || ntlmHash != "")
 	hasKerberosAuth := kerberosRealm != ""
 
 	if !hasPasswordAuth && !hasKerberosAuth {
@@ -503,6 +720,7 @@ func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderM
 			"Missing Authentication Configuration",
 			"Either username/password authentication or Kerberos authentication must be configured. "+
 				"For username/password: provide 'username' and 'password' attributes or set AD_USERNAME|AD_USER and AD_PASSWORD environment variables. "+
+				"For NTLM: additionally provide 'ntlm_domain', or replace 'password' with 'ntlm_hash'. "+
 				"For Kerberos: provide 'kerberos_realm' and optionally 'username'/'password' (for password auth), 'kerberos_keytab' (for keytab auth), or 'kerberos_ccache' (for credential cache auth).",
 		)
 		return config
@@ -511,11 +729,17 @@ func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderM
 	// Set authentication fields in ConnectionConfig
 	config.Username = username
 	config.Password = password
+	config.NTLMDomain = ntlmDomain
+	config.NTLMHash = ntlmHash
 	config.KerberosRealm = kerberosRealm
 	config.KerberosKeytab = kerberosKeytab
 	config.KerberosConfig = kerberosConfig
 	config.KerberosCCache = kerberosCCache
 	config.KerberosSPN = kerberosSPN
+	config.PasswordFile = passwordFile
+	config.CredentialCommand = credentialCommand
+	config.CredentialRefreshInterval = time.Duration(p.getInt64Bounded(data.CredentialRefreshInterval, "AD_CREDENTIAL_REFRESH_INTERVAL", "credential_refresh_interval",
+		defaultCredentialRefreshInterval, minCredentialRefreshInterval, math.MaxInt32, diags)) * time.Second
 
 	// Set Kerberos DNS lookup settings
 	config.KerberosDNSLookupKDC = p.getBoolValue(data.KerberosDNSLookupKDC, "AD_KERBEROS_DNS_LOOKUP_KDC", false)
@@ -531,6 +755,15 @@ func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderM
 		}
 	}
 
+	// SASL security layer for Kerberos binds without TLS
+	config.SASLSecurityLayer = p.getStringValue(data.SASLSecurityLayer, "AD_SASL_SECURITY_LAYER")
+	if err := ldapclient.ValidateSASLSecurityLayer(config.SASLSecurityLayer); err != nil {
+		diags.AddError(
+			"Invalid SASL Security Layer",
+			fmt.Sprintf("Value for sasl_security_layer (or AD_SASL_SECURITY_LAYER) is not supported: %s.", err),
+		)
+	}
+
 	// TLS settings
 	if useTLS := p.getBoolValue(data.UseTLS, "AD_USE_TLS", true); !useTLS {
 		config.UseTLS = false
@@ -549,6 +782,15 @@ func (p *ActiveDirectoryProvider) buildLDAPConfig(data *ActiveDirectoryProviderM
 	config.TLSClientCertFile = p.getStringValue(data.TLSClientCertFile, "AD_TLS_CLIENT_CERT_FILE")
 	config.TLSClientKeyFile = p.getStringValue(data.TLSClientKeyFile, "AD_TLS_CLIENT_KEY_FILE")
 
+	// Channel binding for NTLM and Kerberos binds over TLS
+	config.ChannelBinding = p.getStringValue(data.ChannelBinding, "AD_CHANNEL_BINDING")
+	if err := ldapclient.ValidateChannelBinding(config.ChannelBinding); err != nil {
+		diags.AddError(
+			"Invalid Channel Binding",
+			fmt.Sprintf("Value for channel_binding (or AD_CHANNEL_BINDING) is not supported: %s.", err),
+		)
+	}
+
 	// Connection pool settings
 	config.MaxConnections = p.getIntBounded(data.MaxConnections, "AD_MAX_CONNECTIONS", "max_connections",
 		defaultMaxConnections, minMaxConnections, int64(ldapclient.MaxConnectionPoolLimit), diags)
@@ -613,6 +855,92 @@ func (p *ActiveDirectoryProvider) getInt64Value(configValue types.Int64, envVar
 	return defaultValue
 }
 
+// ntlmSettings holds the effective values, from configuration or
+// environment variables, that determine whether an NTLM bind is consistent.
+type ntlmSettings struct {
+	password          string
+	passwordFile      string
+	credentialCommand []string
+	ntlmDomain        string
+	ntlmHash          string
+	kerberosRealm     string
+}
+
+// validateNTLMSettings reports NTLM settings that conflict with other
+// credentials. It mirrors the NTLM-related ConfigValidators for values set
+// through environment variables.
+func validateNTLMSettings(s ntlmSettings, diags *diag.Diagnostics) {
+	if s.ntlmHash != "" {
+		if err := ldapclient.ValidateNTHash(s.ntlmHash); err != nil {
+			diags.AddError(
+				"Invalid NT Hash",
+				fmt.Sprintf("Value for ntlm_hash (or AD_NTLM_HASH) is invalid: %s.", err),
+			)
+		}
+	}
+
+	conflicts := []struct {
+		set          bool
+		first, other string
+	}{
+		{s.ntlmHash != "" && s.password != "", "ntlm_hash (or AD_NTLM_HASH)", "password (or AD_PASSWORD)"},
+		{s.ntlmHash != "" && s.passwordFile != "", "ntlm_hash (or AD_NTLM_HASH)", "password_file (or AD_PASSWORD_FILE)"},
+		{s.ntlmHash != "" && len(s.credentialCommand) > 0, "ntlm_hash (or AD_NTLM_HASH)", "credential_command (or AD_CREDENTIAL_COMMAND)"},
+		{s.kerberosRealm != "" && s.ntlmDomain != "", "kerberos_realm (or AD_KERBEROS_REALM)", "ntlm_domain (or AD_NTLM_DOMAIN)"},
+		{s.kerberosRealm != "" && s.ntlmHash != "", "kerberos_realm (or AD_KERBEROS_REALM)", "ntlm_hash (or AD_NTLM_HASH)"},
+	}
+	for _, c := range conflicts {
+		if c.set {
+			diags.AddError(
+				"Conflicting Authentication Configuration",
+				fmt.Sprintf("%s cannot be combined with %s.", c.first, c.other),
+			)
+		}
+	}
+}
+
+// getCredentialCommand returns the credential command from the provider
+// configuration, falling back to AD_CREDENTIAL_COMMAND.
+func (p *ActiveDirectoryProvider) getCredentialCommand(ctx context.Context, configValue types.List, diags *diag.Diagnostics) []string {
+	if !configValue.IsNull() && !configValue.IsUnknown() {
+		var command []string
+		diags.Append(configValue.ElementsAs(ctx, &command, false)...)
+		return command
+	}
+	command, err := parseCredentialCommandEnv(os.Getenv("AD_CREDENTIAL_COMMAND"))
+	if err != nil {
+		diags.AddError(
+			"Invalid Credential Command",
+			fmt.Sprintf("AD_CREDENTIAL_COMMAND %s.", err),
+		)
+		return nil
+	}
+	return command
+}
+
+// parseCredentialCommandEnv parses AD_CREDENTIAL_COMMAND. A value starting
+// with "[" is a list of strings as in HCL, such as
+// ["/usr/local/bin/helper", "--vault-path", "secret/ad"]; any other value is
+// the path of an executable taking no arguments, which may contain spaces.
+func parseCredentialCommandEnv(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(value, "[") {
+		return []string{value}, nil
+	}
+
+	var command []string
+	if err := json.Unmarshal([]byte(value), &command); err != nil {
+		return nil, fmt.Errorf("is not a list of strings: %w", err)
+	}
+	if len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("must name an executable")
+	}
+	return command, nil
+}
+
 // Per-field defaults and lower bounds for the provider's numeric configuration
 // attributes. Schema validators (Int64Attribute.Validators) and runtime helpers
 // (getInt64Bounded / getIntBounded) read these so the bounds are declared once.
@@ -626,6 +954,10 @@ const (
 	defaultMaxIdleTime = 300
 	minMaxIdleTime     = 1
 
+	// The credential command runs at most once a minute however often it is asked to
+	defaultCredentialRefreshInterval = 300
+	minCredentialRefreshInterval     = 60
+
 	defaultConnectTimeout = 30
 	minConnectTimeout     = 1
 
@@ -676,6 +1008,9 @@ func (p *ActiveDirectoryProvider) Resources(ctx context.Context) []func() resour
 	return []func() resource.Resource{
 		NewGroupResource,
 		NewGroupMembershipResource,
+		NewObjectResource,
+		NewServicePrincipalNameResource,
+		NewResourceBasedDelegationResource,
 		NewOUResource,
 		NewUserResource,
 	}
@@ -683,7 +1018,9 @@ func (p *ActiveDirectoryProvider) Resources(ctx context.Context) []func() resour
 
 func (p *ActiveDirectoryProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
-		// No ephemeral resources defined yet
+		NewPasswordEphemeralResource,
+		NewLAPSPasswordEphemeralResource,
+		NewGMSAPasswordEphemeralResource,
 	}
 }
 
@@ -691,8 +1028,10 @@ func (p *ActiveDirectoryProvider) DataSources(ctx context.Context) []func() data
 	return []func() datasource.DataSource{
 		NewGroupDataSource,
 		NewGroupsDataSource,
+		NewObjectDataSource,
 		NewOUDataSource,
 		NewRootDSEDataSource,
+		NewSearchDataSource,
 		NewUserDataSource,
 		NewUsersDataSource,
 		NewWhoAmIDataSource,
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"math"
+	"slices"
 	"strconv"
 	"testing"
 
@@ -282,3 +283,110 @@ func TestSchema_Int64ValidatorsBoundsLowerEdge(t *testing.T) {
 // when other tests don't reference it. Removing a validator from the schema
 // would not break this assertion, but the wiring tests above would catch it.
 var _ = int64validator.Between(0, 1)
+
+// --- buildLDAPConfig: NTLM settings --------------------------------------
+
+func TestBuildLDAPConfig_NTLMConflictsFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		data ActiveDirectoryProviderModel
+	}{
+		{
+			name: "password from env with configured hash",
+			env:  map[string]string{"AD_PASSWORD": "secret"},
+			data: ActiveDirectoryProviderModel{NTLMHash: types.StringValue("8846f7eaee8fb117ad06bdd830b7586c")},
+		},
+		{
+			name: "hash from env with configured password",
+			env:  map[string]string{"AD_NTLM_HASH": "8846f7eaee8fb117ad06bdd830b7586c"},
+			data: ActiveDirectoryProviderModel{Password: types.StringValue("secret")},
+		},
+		{
+			name: "realm from env with configured NTLM domain",
+			env:  map[string]string{"AD_KERBEROS_REALM": "EXAMPLE.COM", "AD_PASSWORD": "secret"},
+			data: ActiveDirectoryProviderModel{NTLMDomain: types.StringValue("EXAMPLE")},
+		},
+		{
+			name: "malformed hash from env",
+			env:  map[string]string{"AD_NTLM_HASH": "not-a-hash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AD_DOMAIN", "example.com")
+			t.Setenv("AD_USERNAME", `EXAMPLE\svc`)
+			for _, name := range []string{"AD_PASSWORD", "AD_NTLM_HASH", "AD_NTLM_DOMAIN", "AD_KERBEROS_REALM", "AD_PASSWORD_FILE", "AD_CREDENTIAL_COMMAND"} {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			p := &ActiveDirectoryProvider{}
+			var diags diag.Diagnostics
+			p.buildLDAPConfig(t.Context(), &tt.data, &diags)
+
+			if !diags.HasError() {
+				t.Fatal("expected error diagnostics, got none")
+			}
+		})
+	}
+}
+
+func TestBuildLDAPConfig_DownLevelUsernameDoesNotSelectNTLM(t *testing.T) {
+	t.Setenv("AD_DOMAIN", "example.com")
+	for _, name := range []string{"AD_NTLM_HASH", "AD_NTLM_DOMAIN", "AD_KERBEROS_REALM", "AD_PASSWORD_FILE", "AD_CREDENTIAL_COMMAND"} {
+		t.Setenv(name, "")
+	}
+
+	data := ActiveDirectoryProviderModel{
+		Username: types.StringValue(`EXAMPLE\svc`),
+		Password: types.StringValue("secret"),
+	}
+
+	p := &ActiveDirectoryProvider{}
+	var diags diag.Diagnostics
+	config := p.buildLDAPConfig(t.Context(), &data, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", diags)
+	}
+	if got := config.GetAuthMethod(); got != ldapclient.AuthMethodSimpleBind {
+		t.Errorf("GetAuthMethod() = %v, want simple bind", got)
+	}
+}
+
+// --- parseCredentialCommandEnv ----------------------------------------------
+
+func TestParseCredentialCommandEnv(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{value: "", want: nil},
+		{value: "/usr/local/bin/ad-credentials", want: []string{"/usr/local/bin/ad-credentials"}},
+		{value: "/opt/My Tools/ad-credentials", want: []string{"/opt/My Tools/ad-credentials"}},
+		{
+			value: `["/opt/My Tools/ad-credentials", "--vault-path", "secret/ad svc"]`,
+			want:  []string{"/opt/My Tools/ad-credentials", "--vault-path", "secret/ad svc"},
+		},
+		{value: `["/usr/local/bin/ad-credentials",`, wantErr: true},
+		{value: `[1, 2]`, wantErr: true},
+		{value: `[]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCredentialCommandEnv(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCredentialCommandEnv(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseCredentialCommandEnv(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
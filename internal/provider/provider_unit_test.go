@@ -45,11 +45,11 @@ func TestProviderSchema(t *testing.T) {
 
 	// Test that required attributes are present
 	requiredAttributes := []string{
-		"domain", "ldap_url", "base_dn",
-		"username", "password",
-		"kerberos_realm", "kerberos_keytab", "kerberos_config",
+		"domain", "ldap_url", "base_dn", "site", "site_detection",
+		"username", "password", "password_file", "credential_command", "credential_refresh_interval", "ntlm_domain", "ntlm_hash",
+		"kerberos_realm", "kerberos_keytab", "kerberos_config", "sasl_security_layer",
 		"use_tls", "skip_tls_verify", "tls_ca_cert_file", "tls_ca_cert",
-		"tls_client_cert_file", "tls_client_key_file",
+		"tls_client_cert_file", "tls_client_key_file", "channel_binding",
 		"max_connections", "max_idle_time", "connect_timeout",
 		"max_retries", "initial_backoff", "max_backoff",
 	}
@@ -70,7 +70,10 @@ func TestProviderResources(t *testing.T) {
 	expectedResources := []string{
 		"ad_group",
 		"ad_group_membership",
+		"ad_object",
 		"ad_ou",
+		"ad_resource_based_delegation",
+		"ad_service_principal_name",
 		"ad_user",
 	}
 
@@ -96,8 +99,10 @@ func TestProviderDataSources(t *testing.T) {
 	expectedDataSources := []string{
 		"ad_group",
 		"ad_groups",
+		"ad_object",
 		"ad_ou",
 		"ad_rootdse",
+		"ad_search",
 		"ad_user",
 		"ad_users",
 		"ad_whoami",
@@ -160,9 +165,15 @@ func TestProviderEphemeralResources(t *testing.T) {
 
 	ephemeralResources := p.EphemeralResources(t.Context())
 
-	// AD provider currently has no ephemeral resources defined
-	if len(ephemeralResources) != 0 {
-		t.Errorf("Expected 0 ephemeral resources, got %d", len(ephemeralResources))
+	// AD provider has 3 ephemeral resources: password, laps_password, gmsa_password
+	if len(ephemeralResources) != 3 {
+		t.Errorf("Expected 3 ephemeral resources, got %d", len(ephemeralResources))
+	}
+
+	for i, factory := range ephemeralResources {
+		if factory() == nil {
+			t.Errorf("Ephemeral resource factory %d returned nil", i)
+		}
 	}
 }
 
@@ -325,15 +336,22 @@ func TestProviderEnvironmentVariables(t *testing.T) {
 		"AD_BASE_DN",
 		"AD_USERNAME",
 		"AD_PASSWORD",
+		"AD_PASSWORD_FILE",
+		"AD_CREDENTIAL_COMMAND",
+		"AD_CREDENTIAL_REFRESH_INTERVAL",
+		"AD_NTLM_DOMAIN",
+		"AD_NTLM_HASH",
 		"AD_KERBEROS_REALM",
 		"AD_KERBEROS_KEYTAB",
 		"AD_KERBEROS_CONFIG",
+		"AD_SASL_SECURITY_LAYER",
 		"AD_USE_TLS",
 		"AD_SKIP_TLS_VERIFY",
 		"AD_TLS_CA_CERT_FILE",
 		"AD_TLS_CA_CERT",
 		"AD_TLS_CLIENT_CERT_FILE",
 		"AD_TLS_CLIENT_KEY_FILE",
+		"AD_CHANNEL_BINDING",
 	}
 
 	// Test that environment variables are documented
@@ -29,6 +29,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &GroupResource{}
 var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithValidateConfig = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
 
 // NewGroupResource creates a new instance of the group resource.
 func NewGroupResource() resource.Resource {
@@ -43,14 +45,15 @@ type GroupResource struct {
 
 // GroupResourceModel describes the resource data model.
 type GroupResourceModel struct {
-	ID             types.String              `tfsdk:"id"`               // objectGUID (computed)
-	Name           types.String              `tfsdk:"name"`             // Required - cn attribute
-	SAMAccountName types.String              `tfsdk:"sam_account_name"` // Required - sAMAccountName
-	Container      customtypes.DNStringValue `tfsdk:"container"`        // Required - parent container DN
-	Scope          types.String              `tfsdk:"scope"`            // Optional+Computed+Default: "global"
-	Category       types.String              `tfsdk:"category"`         // Optional+Computed+Default: "security"
-	Description    types.String              `tfsdk:"description"`      // Optional
-	ManagedBy      types.String              `tfsdk:"managed_by"`       // Optional+Computed - managedBy attribute
+	ID              types.String              `tfsdk:"id"`               // objectGUID (computed)
+	Name            types.String              `tfsdk:"name"`             // Required - cn attribute
+	SAMAccountName  types.String              `tfsdk:"sam_account_name"` // Required - sAMAccountName
+	Container       customtypes.DNStringValue `tfsdk:"container"`        // Required - parent container DN
+	Scope           types.String              `tfsdk:"scope"`            // Optional+Computed+Default: "global"
+	Category        types.String              `tfsdk:"category"`         // Optional+Computed+Default: "security"
+	Description     types.String              `tfsdk:"description"`      // Optional
+	ManagedBy       types.String              `tfsdk:"managed_by"`       // Optional+Computed - managedBy attribute
+	ExtraAttributes types.Map                 `tfsdk:"extra_attributes"` // Optional - attributes not otherwise modeled
 	// Computed attributes
 	DistinguishedName customtypes.DNStringValue `tfsdk:"dn"`  // Computed
 	SID               types.String              `tfsdk:"sid"` // Computed
@@ -143,6 +146,7 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					validators.IsValidDN(),
 				},
 			},
+			"extra_attributes": extraAttributesSchema(),
 			"dn": schema.StringAttribute{
 				MarkdownDescription: "The distinguished name of the group. This is automatically generated based on the name and container.",
 				Computed:            true,
@@ -162,6 +166,24 @@ func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *GroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var extraAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_attributes"), &extraAttributes)...)
+	validateExtraAttributeNames(extraAttributes, groupModeledAttributes, &resp.Diagnostics)
+}
+
+// ModifyPlan refuses extra attributes that the directory schema does not
+// allow to be written.
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var extraAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_attributes"), &extraAttributes)...)
+	validateExtraAttributeSchema(ctx, r.client, extraAttributes, &resp.Diagnostics)
+}
+
 func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -235,6 +257,7 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
+	groupManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Normalize scope and category to canonical form
 	normalizedScope, err := ldapclient.NormalizeGroupScope(data.Scope.ValueString())
@@ -274,6 +297,8 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 		createReq.ManagedBy = data.ManagedBy.ValueString()
 	}
 
+	createReq.ExtraAttributes = extraAttributeValues(data.ExtraAttributes)
+
 	// Create the group
 	group, err := groupManager.CreateGroup(createReq)
 	if err != nil {
@@ -322,6 +347,7 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		)
 		return
 	}
+	groupManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Get the group by GUID
 	group, err := groupManager.GetGroup(data.ID.ValueString())
@@ -385,6 +411,7 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	groupManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes, currentData.ExtraAttributes))
 
 	if !data.Name.Equal(currentData.Name) {
 		name := data.Name.ValueString()
@@ -450,6 +477,12 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		hasChanges = true
 	}
 
+	// Check for extra attribute changes; attributes removed from the map are deleted
+	if changes := extraAttributeChanges(data.ExtraAttributes, currentData.ExtraAttributes); changes != nil {
+		updateReq.ExtraAttributes = changes
+		hasChanges = true
+	}
+
 	// If no changes at all, return current state
 	if !hasChanges {
 		tflog.Debug(ctx, "No changes detected for AD group")
@@ -608,6 +641,9 @@ func (r *GroupResource) updateModelFromGroup(ctx context.Context, model *GroupRe
 	// Handle optional description and managedBy
 	model.Description = helpers.StringOrNull(group.Description)
 	model.ManagedBy = helpers.StringOrNull(group.ManagedBy)
+
+	// Extra attributes are read back only for the names already in the model
+	model.ExtraAttributes = extraAttributesToModel(model.ExtraAttributes, group.ExtraAttributes)
 }
 
 // getGroupManager creates a GroupManager instance with base DN lookup.
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
 	"github.com/isometry/terraform-provider-ad/internal/utils"
 )
 
@@ -79,7 +81,9 @@ func (r *GroupMembershipResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "Set of group member identifiers. Members can be specified using any supported identifier format: " +
 					"Distinguished Name (DN), Object GUID, User Principal Name (UPN), SAM Account Name, or Security Identifier (SID). " +
 					"This attribute preserves your original configuration exactly as specified. " +
-					"**Note**: This resource manages the complete membership set - members not listed here will be removed from the group.",
+					"**Note**: This resource manages the complete membership set - members not listed here will be removed from the group. " +
+					"Accounts whose primary group is this group are members through their `primaryGroupID` rather than the `member` attribute: " +
+					"listing them causes no drift, but omitting them does not remove them; change their primary group instead.",
 				Required:    true,
 				ElementType: types.StringType,
 			},
@@ -651,6 +655,23 @@ func (r *GroupMembershipResource) refreshMembershipState(ctx context.Context, me
 		return fmt.Errorf("could not get current group members: %w", err)
 	}
 
+	// Accounts whose primary group is this group are not listed in member.
+	// Keep those already in state, so they do not show as drift.
+	var missing []string
+	for _, dn := range helpers.GetStringSet(model.MembersNormalized) {
+		if !slices.ContainsFunc(currentMembers, func(m string) bool { return ldapclient.DNEqual(m, dn) }) {
+			missing = append(missing, dn)
+		}
+	}
+	if len(missing) > 0 {
+		primaryMembers, err := membershipManager.PrimaryGroupMembers(model.GroupID.ValueString(), missing)
+		if err != nil {
+			return fmt.Errorf("could not get primary group members: %w", err)
+		}
+		currentMembers = append(currentMembers, primaryMembers...)
+		slices.Sort(currentMembers)
+	}
+
 	// DO NOT touch model.Members - preserve user's original configuration!
 
 	// Only update MembersNormalized with current AD state
@@ -1202,3 +1202,51 @@ func groupUniqueSuffix() string {
 }
 
 // Note: testAccPreCheck is defined in provider_test.go
+
+func TestAccGroupResource_extraAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupResourceConfig_withExtraAttributes("tf-test-group-extra", "TFTestGroupExtra", `{
+    info = ["Owned by the finance team"]
+    mail = ["finance@example.com"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_group.test", "extra_attributes.info.0", "Owned by the finance team"),
+					resource.TestCheckResourceAttr("ad_group.test", "extra_attributes.mail.0", "finance@example.com"),
+				),
+			},
+			{
+				Config: testAccGroupResourceConfig_basic("tf-test-group-extra", "TFTestGroupExtra"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ad_group.test", "extra_attributes.%"),
+				),
+			},
+			{
+				Config: testAccGroupResourceConfig_withExtraAttributes("tf-test-group-extra", "TFTestGroupExtra", `{
+    member = ["CN=Administrator,CN=Users,DC=example,DC=com"]
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Modeled Attribute in extra_attributes`),
+			},
+		},
+	})
+}
+
+func testAccGroupResourceConfig_withExtraAttributes(name, samName, extraAttributes string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_group" "test" {
+  name             = %[3]q
+  sam_account_name = %[4]q
+  container        = "%[5]s,${data.ad_rootdse.test.default_naming_context}"
+
+  extra_attributes = %[6]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, samName, DefaultTestContainer, extraAttributes)
+}
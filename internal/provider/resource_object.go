@@ -0,0 +1,562 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/provider/planmodifiers"
+	customtypes "github.com/isometry/terraform-provider-ad/internal/provider/types"
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectResource{}
+var _ resource.ResourceWithImportState = &ObjectResource{}
+var _ resource.ResourceWithValidateConfig = &ObjectResource{}
+var _ resource.ResourceWithModifyPlan = &ObjectResource{}
+
+// objectModeledAttributes are the LDAP attributes managed or reported by
+// ad_object arguments, which attributes may not manage. The RDN attribute
+// is added from the configuration.
+var objectModeledAttributes = []string{
+	"objectClass", "objectGUID", "distinguishedName", "name",
+}
+
+// ldapNamePattern matches LDAP attribute and object class names.
+var ldapNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+func NewObjectResource() resource.Resource {
+	return &ObjectResource{}
+}
+
+// ObjectResource defines the resource implementation.
+type ObjectResource struct {
+	client ldapclient.Client
+}
+
+// ObjectResourceModel describes the resource data model.
+type ObjectResourceModel struct {
+	ID           types.String              `tfsdk:"id"`            // objectGUID (computed)
+	ObjectClass  types.String              `tfsdk:"object_class"`  // Required - Structural object class
+	RDNAttribute types.String              `tfsdk:"rdn_attribute"` // Optional+Computed+Default: cn
+	Name         types.String              `tfsdk:"name"`          // Required - RDN value
+	Container    customtypes.DNStringValue `tfsdk:"container"`     // Required - Parent container DN
+	Attributes   types.Map                 `tfsdk:"attributes"`    // Optional - Managed attributes
+	// Computed attributes
+	DN customtypes.DNStringValue `tfsdk:"dn"` // Computed - Full Distinguished Name
+}
+
+func (r *ObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages an Active Directory object of any object class. This is an escape hatch for object " +
+			"classes that no dedicated resource models, such as printers, Exchange objects or custom schema classes. " +
+			"Prefer the dedicated resources where they exist.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The objectGUID of the object. This is automatically assigned by Active Directory and used as the unique identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"object_class": schema.StringAttribute{
+				MarkdownDescription: "The structural object class of the object (e.g., `printQueue` or `contact`). Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ldapNamePattern, "must be an LDAP object class name"),
+				},
+			},
+			"rdn_attribute": schema.StringAttribute{
+				MarkdownDescription: "The naming attribute of the object class, which forms the first component of the " +
+					"distinguished name (e.g., `cn` or `ou`). Defaults to `cn`. Changing this forces a new resource to be created.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("cn"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ldapNamePattern, "must be an LDAP attribute name"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The value of the naming attribute. Changing this renames the object in place.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"container": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the container where the object will be created. Changing this moves the object in place.",
+				Required:            true,
+				CustomType:          customtypes.DNStringType{},
+				Validators: []validator.String{
+					validators.IsValidDN(),
+				},
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "LDAP attributes to manage. Keys are LDAP attribute names and values are lists of " +
+					"string values. Values of binary attributes (octet string, SID and security descriptor syntax) are " +
+					"base64-encoded, and distinguished names are compared case-insensitively. Only the listed attributes " +
+					"are managed: removing an attribute from the map deletes it from the object, and attributes that are " +
+					"not listed are ignored. Attributes that the schema does not define, or marks as system-only or " +
+					"constructed, are rejected at plan time.",
+				Optional:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(ldapNamePattern, "must be an LDAP attribute name")),
+					mapvalidator.ValueListsAre(
+						listvalidator.SizeAtLeast(1),
+						listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					),
+				},
+			},
+			"dn": schema.StringAttribute{
+				MarkdownDescription: "The distinguished name of the object. This is automatically generated based on the rdn_attribute, name and container.",
+				Computed:            true,
+				CustomType:          customtypes.DNStringType{},
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.ComputeDNFromAttribute("rdn_attribute", "container"),
+				},
+			},
+		},
+	}
+}
+
+func (r *ObjectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modeled := objectModeledAttributes
+	switch {
+	case data.RDNAttribute.IsNull():
+		modeled = append(slices.Clone(modeled), "cn")
+	case !data.RDNAttribute.IsUnknown():
+		modeled = append(slices.Clone(modeled), data.RDNAttribute.ValueString())
+	}
+	validateAttributeNames(path.Root("attributes"), data.Attributes, modeled, &resp.Diagnostics)
+}
+
+// ModifyPlan refuses attributes that the directory schema does not allow to
+// be written, and values of binary attributes that are not base64-encoded.
+func (r *ObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var attributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("attributes"), &attributes)...)
+	schemas := validateAttributeSchema(ctx, r.client, path.Root("attributes"), attributes, &resp.Diagnostics)
+
+	for name, values := range extraAttributeValues(attributes) {
+		if !schemas[strings.ToLower(name)].IsBinary() {
+			continue
+		}
+		for _, value := range values {
+			if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("attributes").AtMapKey(name),
+					"Invalid Binary Attribute Value",
+					fmt.Sprintf("The %q attribute is binary and its values must be base64-encoded: %s", name, err.Error()),
+				)
+				break
+			}
+		}
+	}
+}
+
+func (r *ObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ObjectResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating AD object", map[string]any{
+		"object_class": data.ObjectClass.ValueString(),
+		"name":         data.Name.ValueString(),
+		"container":    data.Container.ValueString(),
+	})
+
+	// Create ObjectManager
+	objectManager, err := r.getObjectManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object Manager",
+			err.Error(),
+		)
+		return
+	}
+	objectManager.SetAttributes(extraAttributeNames(data.Attributes))
+
+	// Normalize container DN case before creating
+	normalizedContainer, err := ldapclient.NormalizeDNCase(data.Container.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Container DN",
+			fmt.Sprintf("Could not normalize container DN case: %s", err.Error()),
+		)
+		return
+	}
+
+	// Create the object
+	object, err := objectManager.CreateObject(&ldapclient.CreateObjectRequest{
+		ObjectClass:  data.ObjectClass.ValueString(),
+		RDNAttribute: data.RDNAttribute.ValueString(),
+		Name:         data.Name.ValueString(),
+		Container:    normalizedContainer,
+		Attributes:   extraAttributeValues(data.Attributes),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object",
+			"Could not create object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Created AD object", map[string]any{
+		"guid": object.ObjectGUID,
+		"dn":   object.DistinguishedName,
+	})
+
+	// Update the model with the created object data
+	r.updateModelFromObject(ctx, &data, object, objectManager)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ObjectResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading AD object", map[string]any{
+		"guid": data.ID.ValueString(),
+	})
+
+	// Create ObjectManager
+	objectManager, err := r.getObjectManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object Manager",
+			err.Error(),
+		)
+		return
+	}
+	objectManager.SetAttributes(extraAttributeNames(data.Attributes))
+
+	// Get the object by GUID
+	object, err := objectManager.GetObject(data.ID.ValueString())
+	if err != nil {
+		if ldapclient.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Object",
+			fmt.Sprintf("Could not read object with ID %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Update the model with the current object data
+	r.updateModelFromObject(ctx, &data, object, objectManager)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ObjectResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating AD object", map[string]any{
+		"guid": data.ID.ValueString(),
+	})
+
+	// Create ObjectManager
+	objectManager, err := r.getObjectManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	// Create update request
+	updateReq := &ldapclient.UpdateObjectRequest{}
+	hasChanges := false
+
+	// Check for changes by comparing with current state
+	var currentData ObjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &currentData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	objectManager.SetAttributes(extraAttributeNames(data.Attributes, currentData.Attributes))
+
+	// Check for name changes (triggers rename)
+	if !data.Name.Equal(currentData.Name) {
+		name := data.Name.ValueString()
+		updateReq.Name = &name
+		hasChanges = true
+	}
+
+	// Check for container changes (triggers move)
+	if !data.Container.Equal(currentData.Container) {
+		container := data.Container.ValueString()
+		updateReq.Container = &container
+		hasChanges = true
+	}
+
+	// Check for attribute changes; attributes removed from the map are deleted
+	if changes := extraAttributeChanges(data.Attributes, currentData.Attributes); changes != nil {
+		updateReq.Attributes = changes
+		hasChanges = true
+	}
+
+	// If no changes at all, return current state
+	if !hasChanges {
+		tflog.Debug(ctx, "No changes detected for AD object")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Update the object
+	object, err := objectManager.UpdateObject(data.ID.ValueString(), updateReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Object",
+			"Could not update object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updated AD object", map[string]any{
+		"guid": object.ObjectGUID,
+	})
+
+	// Update the model with the updated object data
+	r.updateModelFromObject(ctx, &data, object, objectManager)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ObjectResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting AD object", map[string]any{
+		"guid": data.ID.ValueString(),
+	})
+
+	// Create ObjectManager
+	objectManager, err := r.getObjectManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	// Delete the object
+	if err := objectManager.DeleteObject(data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Object",
+			"Could not delete object, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted AD object", map[string]any{
+		"guid": data.ID.ValueString(),
+	})
+}
+
+func (r *ObjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Support import by GUID or DN
+	importID := strings.TrimSpace(req.ID)
+
+	tflog.Debug(ctx, "Importing AD object", map[string]any{
+		"import_id": importID,
+	})
+
+	// Create ObjectManager
+	objectManager, err := r.getObjectManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Object Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	var object *ldapclient.Object
+
+	// Check if the import ID looks like a GUID
+	if ldapclient.NewGUIDHandler().IsValidGUID(importID) {
+		// Import by GUID
+		object, err = objectManager.GetObject(importID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Object by GUID",
+				fmt.Sprintf("Could not import object with GUID %s: %s", importID, err.Error()),
+			)
+			return
+		}
+	} else {
+		// Import by DN
+		object, err = objectManager.GetObjectByDN(importID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Object by DN",
+				fmt.Sprintf("Could not import object with DN %s: %s", importID, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Create model from the imported object; attributes are not imported,
+	// as only the configured attributes are managed
+	data := ObjectResourceModel{
+		ObjectClass:  types.StringNull(),
+		RDNAttribute: types.StringNull(),
+		Attributes:   types.MapNull(types.ListType{ElemType: types.StringType}),
+	}
+	r.updateModelFromObject(ctx, &data, object, objectManager)
+
+	tflog.Debug(ctx, "Imported AD object", map[string]any{
+		"guid": object.ObjectGUID,
+		"dn":   object.DistinguishedName,
+	})
+
+	// Set the resource state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updateModelFromObject updates the Terraform model with data from an LDAP
+// object.
+func (r *ObjectResource) updateModelFromObject(ctx context.Context, model *ObjectResourceModel, object *ldapclient.Object, objectManager *ldapclient.ObjectManager) {
+	model.ID = types.StringValue(object.ObjectGUID)
+	model.Name = types.StringValue(object.Name)
+
+	// Keep the configured object class while the object still has it; AD
+	// lists the whole class hierarchy, with the most specific class last
+	if !slices.ContainsFunc(object.ObjectClass, func(c string) bool { return strings.EqualFold(c, model.ObjectClass.ValueString()) }) &&
+		len(object.ObjectClass) > 0 {
+		model.ObjectClass = types.StringValue(object.ObjectClass[len(object.ObjectClass)-1])
+	}
+
+	// AD reports the RDN attribute type in upper case, so keep the configured case
+	if !strings.EqualFold(model.RDNAttribute.ValueString(), object.RDNAttribute) {
+		model.RDNAttribute = types.StringValue(strings.ToLower(object.RDNAttribute))
+	}
+
+	// Normalize DN and container
+	model.DN = customtypes.DNString(helpers.NormalizeDN(ctx, object.DistinguishedName))
+	model.Container = customtypes.DNString(helpers.NormalizeDN(ctx, object.Container))
+
+	// Attributes are read back only for the names already in the model
+	model.Attributes = attributesToModel(model.Attributes, object.Attributes, objectManager.AttributeValuesEqual)
+}
+
+// getObjectManager creates an ObjectManager instance with base DN lookup.
+func (r *ObjectResource) getObjectManager(ctx context.Context) (*ldapclient.ObjectManager, error) {
+	// Get base DN from client
+	baseDN, err := r.client.GetBaseDN(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get base DN from LDAP server: %w", err)
+	}
+
+	// Create ObjectManager
+	return ldapclient.NewObjectManager(ctx, r.client, baseDN), nil
+}
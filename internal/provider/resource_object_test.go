@@ -0,0 +1,160 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccObjectResource_basic(t *testing.T) {
+	name := GenerateTestName("tf-test-object-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccObjectResourceConfig_contact(name, `{
+    description    = ["Managed by ad_object"]
+    otherTelephone = ["+1 555 0100", "+1 555 0101"]
+    thumbnailPhoto = ["AAH+/w=="]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_object.test", "object_class", "contact"),
+					resource.TestCheckResourceAttr("ad_object.test", "rdn_attribute", "cn"),
+					resource.TestCheckResourceAttr("ad_object.test", "name", name),
+					resource.TestCheckResourceAttrSet("ad_object.test", "id"),
+					resource.TestCheckResourceAttr("ad_object.test", "attributes.description.0", "Managed by ad_object"),
+					resource.TestCheckResourceAttr("ad_object.test", "attributes.otherTelephone.#", "2"),
+					resource.TestCheckResourceAttr("ad_object.test", "attributes.thumbnailPhoto.0", "AAH+/w=="),
+					resource.TestCheckResourceAttrWith("ad_object.test", "dn", func(value string) error {
+						if !strings.HasPrefix(strings.ToUpper(value), "CN="+strings.ToUpper(name)+",") {
+							return fmt.Errorf("expected DN to start with CN=%s, got: %s", name, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// ImportState testing; attributes are not imported
+			{
+				ResourceName:            "ad_object.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"attributes"},
+			},
+			// Update attributes; removed attributes are deleted
+			{
+				Config: testAccObjectResourceConfig_contact(name, `{
+    description = ["Updated by ad_object"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_object.test", "attributes.%", "1"),
+					resource.TestCheckResourceAttr("ad_object.test", "attributes.description.0", "Updated by ad_object"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectResource_renameAndMove(t *testing.T) {
+	name1 := GenerateTestName("tf-test-object-")
+	name2 := GenerateTestName("tf-test-object-renamed-")
+	ouName := GenerateTestName(TestOUPrefix + "object-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig_inContainer(ouName, name1, "data.ad_rootdse.test.default_naming_context"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_object.test", "name", name1),
+					resource.TestCheckResourceAttrPair("ad_object.test", "container", "data.ad_rootdse.test", "default_naming_context"),
+				),
+			},
+			// The objectGUID must survive a rename and move via ModifyDN
+			{
+				Config: testAccObjectResourceConfig_inContainer(ouName, name2, "ad_ou.test.dn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_object.test", "name", name2),
+					resource.TestCheckResourceAttrPair("ad_object.test", "container", "ad_ou.test", "dn"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ad_object.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config:             testAccObjectResourceConfig_inContainer(ouName, name2, "ad_ou.test.dn"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccObjectResource_invalidAttributes(t *testing.T) {
+	name := GenerateTestName("tf-test-object-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig_contact(name, `{
+    cn = ["other"]
+  }`),
+				ExpectError: regexp.MustCompile(`Modeled Attribute in attributes`),
+			},
+			{
+				Config: testAccObjectResourceConfig_contact(name, `{
+    thumbnailPhoto = ["not base64!"]
+  }`),
+				ExpectError: regexp.MustCompile(`Invalid Binary Attribute Value`),
+			},
+		},
+	})
+}
+
+func testAccObjectResourceConfig_contact(name, attributes string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_object" "test" {
+  object_class = "contact"
+  name         = %[3]q
+  container    = data.ad_rootdse.test.default_naming_context
+
+  attributes = %[4]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, attributes)
+}
+
+// testAccObjectResourceConfig_inContainer creates an OU and a contact whose
+// container is the Terraform expression containerRef.
+func testAccObjectResourceConfig_inContainer(ouName, name, containerRef string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_ou" "test" {
+  name = %[3]q
+  path = data.ad_rootdse.test.default_naming_context
+}
+
+resource "ad_object" "test" {
+  object_class = "contact"
+  name         = %[4]q
+  container    = %[5]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), ouName, name, containerRef)
+}
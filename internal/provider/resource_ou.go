@@ -28,6 +28,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OUResource{}
 var _ resource.ResourceWithImportState = &OUResource{}
+var _ resource.ResourceWithValidateConfig = &OUResource{}
+var _ resource.ResourceWithModifyPlan = &OUResource{}
 
 func NewOUResource() resource.Resource {
 	return &OUResource{}
@@ -40,12 +42,13 @@ type OUResource struct {
 
 // OUResourceModel describes the resource data model.
 type OUResourceModel struct {
-	ID          types.String              `tfsdk:"id"`          // objectGUID (computed)
-	Name        types.String              `tfsdk:"name"`        // Required - OU name
-	Path        customtypes.DNStringValue `tfsdk:"path"`        // Required - Parent container DN
-	Description types.String              `tfsdk:"description"` // Optional - OU description
-	Protected   types.Bool                `tfsdk:"protected"`   // Optional+Computed+Default: false
-	ManagedBy   types.String              `tfsdk:"managed_by"`  // Optional+Computed - managedBy attribute
+	ID              types.String              `tfsdk:"id"`               // objectGUID (computed)
+	Name            types.String              `tfsdk:"name"`             // Required - OU name
+	Path            customtypes.DNStringValue `tfsdk:"path"`             // Required - Parent container DN
+	Description     types.String              `tfsdk:"description"`      // Optional - OU description
+	Protected       types.Bool                `tfsdk:"protected"`        // Optional+Computed+Default: false
+	ManagedBy       types.String              `tfsdk:"managed_by"`       // Optional+Computed - managedBy attribute
+	ExtraAttributes types.Map                 `tfsdk:"extra_attributes"` // Optional - attributes not otherwise modeled
 	// Computed attributes
 	DN   customtypes.DNStringValue `tfsdk:"dn"`   // Computed - Full Distinguished Name
 	GUID types.String              `tfsdk:"guid"` // Computed - GUID string (same as ID)
@@ -109,6 +112,7 @@ func (r *OUResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 					validators.IsValidDN(),
 				},
 			},
+			"extra_attributes": extraAttributesSchema(),
 			"dn": schema.StringAttribute{
 				MarkdownDescription: "The distinguished name of the OU. This is automatically generated based on the name and path.",
 				Computed:            true,
@@ -128,6 +132,24 @@ func (r *OUResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 	}
 }
 
+func (r *OUResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var extraAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_attributes"), &extraAttributes)...)
+	validateExtraAttributeNames(extraAttributes, ouModeledAttributes, &resp.Diagnostics)
+}
+
+// ModifyPlan refuses extra attributes that the directory schema does not
+// allow to be written.
+func (r *OUResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var extraAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_attributes"), &extraAttributes)...)
+	validateExtraAttributeSchema(ctx, r.client, extraAttributes, &resp.Diagnostics)
+}
+
 func (r *OUResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -173,6 +195,7 @@ func (r *OUResource) Create(ctx context.Context, req resource.CreateRequest, res
 		)
 		return
 	}
+	ouManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Normalize parent DN case before creating
 	normalizedParentDN, err := ldapclient.NormalizeDNCase(data.Path.ValueString())
@@ -201,6 +224,8 @@ func (r *OUResource) Create(ctx context.Context, req resource.CreateRequest, res
 		createReq.ManagedBy = data.ManagedBy.ValueString()
 	}
 
+	createReq.ExtraAttributes = extraAttributeValues(data.ExtraAttributes)
+
 	// Create the OU
 	ou, err := ouManager.CreateOU(createReq)
 	if err != nil {
@@ -249,6 +274,7 @@ func (r *OUResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		)
 		return
 	}
+	ouManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Get the OU by GUID
 	ou, err := ouManager.GetOU(data.ID.ValueString())
@@ -312,6 +338,7 @@ func (r *OUResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ouManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes, currentData.ExtraAttributes))
 
 	// Check for name changes (triggers OU rename)
 	if !data.Name.Equal(currentData.Name) {
@@ -350,6 +377,12 @@ func (r *OUResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		hasChanges = true
 	}
 
+	// Check for extra attribute changes; attributes removed from the map are deleted
+	if changes := extraAttributeChanges(data.ExtraAttributes, currentData.ExtraAttributes); changes != nil {
+		updateReq.ExtraAttributes = changes
+		hasChanges = true
+	}
+
 	// If no changes at all, return current state
 	if !hasChanges {
 		tflog.Debug(ctx, "No changes detected for AD OU")
@@ -502,6 +535,9 @@ func (r *OUResource) updateModelFromOU(ctx context.Context, model *OUResourceMod
 	// Handle optional description and managedBy
 	model.Description = helpers.StringOrNull(ou.Description)
 	model.ManagedBy = helpers.StringOrNull(ou.ManagedBy)
+
+	// Extra attributes are read back only for the names already in the model
+	model.ExtraAttributes = extraAttributesToModel(model.ExtraAttributes, ou.ExtraAttributes)
 }
 
 // getOUManager creates an OUManager instance with base DN lookup.
@@ -1141,3 +1141,46 @@ resource "ad_group" "inside" {
 }
 `, testProviderConfig(), testRootDSEDataSource(), grandparent, parent, child, groupName, groupSAM, parentPath)
 }
+
+func TestAccOUResource_extraAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOUResourceConfig_withExtraAttributes("tf-test-ou-extra", `{
+    l  = ["London"]
+    st = ["England"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_ou.test", "extra_attributes.l.0", "London"),
+					resource.TestCheckResourceAttr("ad_ou.test", "extra_attributes.st.0", "England"),
+				),
+			},
+			{
+				Config: testAccOUResourceConfig_withExtraAttributes("tf-test-ou-extra", `{
+    l = ["Manchester"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_ou.test", "extra_attributes.%", "1"),
+					resource.TestCheckResourceAttr("ad_ou.test", "extra_attributes.l.0", "Manchester"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOUResourceConfig_withExtraAttributes(name, extraAttributes string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_ou" "test" {
+  name = %[3]q
+  path = "${data.ad_rootdse.test.default_naming_context}"
+
+  extra_attributes = %[4]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, extraAttributes)
+}
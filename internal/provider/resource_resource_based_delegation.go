@@ -0,0 +1,423 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ResourceBasedDelegationResource{}
+var _ resource.ResourceWithImportState = &ResourceBasedDelegationResource{}
+var _ resource.ResourceWithModifyPlan = &ResourceBasedDelegationResource{}
+
+func NewResourceBasedDelegationResource() resource.Resource {
+	return &ResourceBasedDelegationResource{}
+}
+
+// ResourceBasedDelegationResource defines the resource implementation.
+type ResourceBasedDelegationResource struct {
+	client       ldapclient.Client
+	cacheManager *ldapclient.CacheManager
+}
+
+// ResourceBasedDelegationResourceModel describes the resource data model.
+type ResourceBasedDelegationResourceModel struct {
+	ID            types.String `tfsdk:"id"`             // Account objectGUID (same as account_id)
+	AccountID     types.String `tfsdk:"account_id"`     // Required - objectGUID of the account delegated to
+	Principals    types.Set    `tfsdk:"principals"`     // Required - identifiers of the accounts allowed to delegate
+	PrincipalSIDs types.Set    `tfsdk:"principal_sids"` // Computed - SIDs written to the security descriptor
+}
+
+func (r *ResourceBasedDelegationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_based_delegation"
+}
+
+func (r *ResourceBasedDelegationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages resource-based constrained delegation (RBCD) to an Active Directory account, such as a " +
+			"computer, user or managed service account. The listed principals may obtain Kerberos service tickets to the " +
+			"services of the account on behalf of other users.\n\n" +
+			"The principals are written to msDS-AllowedToActOnBehalfOfOtherIdentity of the account as a security descriptor, " +
+			"in the same form as `Set-ADComputer -PrincipalsAllowedToDelegateToAccount`. This resource manages the complete " +
+			"set of principals: principals not listed here are removed, and destroying the resource removes them all.\n\n" +
+			"**Supported Identifier Formats**:\n" +
+			"- Distinguished Name (DN): `CN=WEB01,OU=Servers,DC=example,DC=com`\n" +
+			"- Object GUID: `550e8400-e29b-41d4-a716-446655440000`\n" +
+			"- User Principal Name (UPN): `svc-web@example.com`\n" +
+			"- SAM Account Name: `DOMAIN\\WEB01$` or `WEB01$`\n" +
+			"- Security Identifier (SID): `S-1-5-21-123456789-123456789-123456789-1001`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier, which is the objectGUID of the account. This is the same value as `account_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The objectGUID of the account that the principals may delegate to. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.SetAttribute{
+				MarkdownDescription: "The accounts allowed to delegate to the account. Principals can be specified using any supported " +
+					"identifier format: Distinguished Name (DN), Object GUID, User Principal Name (UPN), SAM Account Name, or " +
+					"Security Identifier (SID). This attribute preserves your original configuration exactly as specified.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"principal_sids": schema.SetAttribute{
+				MarkdownDescription: "The security identifiers (SIDs) of the principals, as written to msDS-AllowedToActOnBehalfOfOtherIdentity.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (r *ResourceBasedDelegationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.cacheManager = providerData.CacheManager
+}
+
+// ModifyPlan resolves the principals to SIDs during planning, populating
+// principal_sids so that changes to the resolved principals are shown in the
+// plan.
+func (r *ResourceBasedDelegationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only process if we have a plan (not during destroy)
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ResourceBasedDelegationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Principals that depend on resources not yet created are resolved on apply
+	var principals []string
+	if plan.Principals.IsUnknown() || plan.Principals.ElementsAs(ctx, &principals, false).HasError() {
+		plan.PrincipalSIDs = types.SetUnknown(types.StringType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+		return
+	}
+
+	delegationManager, err := r.getDelegationManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Delegation Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	sids := resolvePrincipalSIDs(delegationManager, principals, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principalSIDs, diags := types.SetValueFrom(ctx, types.StringType, sids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.PrincipalSIDs = principalSIDs
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *ResourceBasedDelegationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ResourceBasedDelegationResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Configuring resource-based constrained delegation", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+	})
+
+	r.setAllowedPrincipals(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.AccountID
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceBasedDelegationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ResourceBasedDelegationResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delegationManager, err := r.getDelegationManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Delegation Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	account, err := delegationManager.GetAccount(data.AccountID.ValueString())
+	if err != nil {
+		if ldapclient.IsNotFoundError(err) {
+			tflog.Debug(ctx, "Account not found, removing delegation from state", map[string]any{
+				"account_id": data.AccountID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Resource-Based Delegation",
+			fmt.Sprintf("Could not read account %s: %s", data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// DO NOT touch data.Principals - preserve the configured identifiers.
+	// Drift is detected by comparing principal_sids with the resolved plan.
+	if !ldapclient.SIDsEqual(account.AllowedPrincipals, helpers.GetStringSet(data.PrincipalSIDs)) {
+		principalSIDs, diags := types.SetValueFrom(ctx, types.StringType, sortedSIDs(account.AllowedPrincipals))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.PrincipalSIDs = principalSIDs
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceBasedDelegationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ResourceBasedDelegationResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating resource-based constrained delegation", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+	})
+
+	r.setAllowedPrincipals(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ResourceBasedDelegationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ResourceBasedDelegationResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Removing resource-based constrained delegation", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+	})
+
+	delegationManager, err := r.getDelegationManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Delegation Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := delegationManager.ClearAllowedPrincipals(data.AccountID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Removing Resource-Based Delegation",
+			fmt.Sprintf("Could not remove the principals allowed to delegate to account %s: %s", data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ResourceBasedDelegationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by account objectGUID
+	accountID := strings.TrimSpace(req.ID)
+	if !ldapclient.NewGUIDHandler().IsValidGUID(accountID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the objectGUID of the account, such as 550e8400-e29b-41d4-a716-446655440000, got: %s", req.ID),
+		)
+		return
+	}
+
+	delegationManager, err := r.getDelegationManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Delegation Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	account, err := delegationManager.GetAccount(accountID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Resource-Based Delegation",
+			fmt.Sprintf("Could not read account %s: %s", accountID, err.Error()),
+		)
+		return
+	}
+
+	// For import, set both principals and principal_sids to the SIDs from AD.
+	// Users can then update their configuration to use their preferred
+	// identifier format.
+	principalSIDs, diags := types.SetValueFrom(ctx, types.StringType, sortedSIDs(account.AllowedPrincipals))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := ResourceBasedDelegationResourceModel{
+		ID:            types.StringValue(account.ObjectGUID),
+		AccountID:     types.StringValue(account.ObjectGUID),
+		Principals:    principalSIDs,
+		PrincipalSIDs: principalSIDs,
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setAllowedPrincipals resolves the configured principals and writes them to
+// the account, updating principal_sids.
+func (r *ResourceBasedDelegationResource) setAllowedPrincipals(ctx context.Context, data *ResourceBasedDelegationResourceModel, diags *diag.Diagnostics) {
+	delegationManager, err := r.getDelegationManager(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Creating Delegation Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	var principals []string
+	diags.Append(data.Principals.ElementsAs(ctx, &principals, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	sids := resolvePrincipalSIDs(delegationManager, principals, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if err := delegationManager.SetAllowedPrincipals(data.AccountID.ValueString(), sids); err != nil {
+		diags.AddError(
+			"Error Setting Resource-Based Delegation",
+			fmt.Sprintf("Could not set the principals allowed to delegate to account %s: %s", data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	principalSIDs, d := types.SetValueFrom(ctx, types.StringType, sids)
+	diags.Append(d...)
+	data.PrincipalSIDs = principalSIDs
+}
+
+// getDelegationManager creates a DelegationManager instance with base DN lookup.
+func (r *ResourceBasedDelegationResource) getDelegationManager(ctx context.Context) (*ldapclient.DelegationManager, error) {
+	baseDN, err := r.client.GetBaseDN(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get base DN from LDAP server: %w", err)
+	}
+
+	return ldapclient.NewDelegationManager(ctx, r.client, baseDN, r.cacheManager), nil
+}
+
+// resolvePrincipalSIDs resolves principal identifiers to SIDs, adding an
+// error for each principal that cannot be resolved. The SIDs are returned
+// sorted and without duplicates.
+func resolvePrincipalSIDs(delegationManager *ldapclient.DelegationManager, principals []string, diags *diag.Diagnostics) []string {
+	resolved, failures := delegationManager.ResolvePrincipalSIDs(principals)
+	for identifier, err := range failures {
+		diags.AddAttributeError(
+			path.Root("principals"),
+			"Principal could not be resolved",
+			fmt.Sprintf("Principal '%s' could not be resolved: %s", identifier, err.Error()),
+		)
+	}
+
+	sids := make([]string, 0, len(resolved))
+	for _, sid := range resolved {
+		sids = append(sids, sid)
+	}
+	return sortedSIDs(sids)
+}
+
+// sortedSIDs returns sids sorted and without duplicates.
+func sortedSIDs(sids []string) []string {
+	out := slices.Clone(sids)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
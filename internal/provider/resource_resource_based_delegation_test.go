@@ -0,0 +1,102 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccResourceBasedDelegationResource_basic(t *testing.T) {
+	targetName := GenerateTestName(TestUserPrefix)
+	targetSAM := GenerateTestSAMName("rbcd")
+	targetUPN := fmt.Sprintf("%s@%s", targetSAM, GetTestConfig().Domain)
+	principalName := GenerateTestName(TestUserPrefix)
+	principalSAM := GenerateTestSAMName("rbcd")
+	principalUPN := fmt.Sprintf("%s@%s", principalSAM, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing, with the principal identified by UPN
+			{
+				Config: testAccResourceBasedDelegationResourceConfig(targetName, targetUPN, targetSAM, principalName, principalUPN, principalSAM, "ad_user.principal.principal_name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ad_resource_based_delegation.test", "id", "ad_user.target", "id"),
+					resource.TestCheckResourceAttr("ad_resource_based_delegation.test", "principals.#", "1"),
+					resource.TestCheckResourceAttr("ad_resource_based_delegation.test", "principal_sids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("ad_resource_based_delegation.test", "principal_sids.*", "ad_user.principal", "sid"),
+				),
+			},
+			// The same principal identified by SID resolves to the same SID
+			{
+				Config: testAccResourceBasedDelegationResourceConfig(targetName, targetUPN, targetSAM, principalName, principalUPN, principalSAM, "ad_user.principal.sid"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_resource_based_delegation.test", "principal_sids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("ad_resource_based_delegation.test", "principal_sids.*", "ad_user.principal", "sid"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ad_resource_based_delegation.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"principals"},
+			},
+		},
+	})
+}
+
+func TestAccResourceBasedDelegationResource_unresolvablePrincipal(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig() + `
+resource "ad_resource_based_delegation" "test" {
+  account_id = "550e8400-e29b-41d4-a716-446655440000"
+  principals = ["CN=Does Not Exist,DC=example,DC=invalid"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Principal could not be resolved`),
+			},
+		},
+	})
+}
+
+// testAccResourceBasedDelegationResourceConfig creates a target and a
+// principal user and allows the principal, referenced by principalRef, to
+// delegate to the target.
+func testAccResourceBasedDelegationResourceConfig(targetName, targetUPN, targetSAM, principalName, principalUPN, principalSAM, principalRef string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "target" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[9]s,${data.ad_rootdse.test.default_naming_context}"
+}
+
+resource "ad_user" "principal" {
+  name             = %[6]q
+  principal_name   = %[7]q
+  sam_account_name = %[8]q
+  container        = "%[9]s,${data.ad_rootdse.test.default_naming_context}"
+}
+
+resource "ad_resource_based_delegation" "test" {
+  account_id = ad_user.target.id
+  principals = [%[10]s]
+}
+`, testProviderConfig(), testRootDSEDataSource(), targetName, targetUPN, targetSAM, principalName, principalUPN, principalSAM, DefaultTestContainer, principalRef)
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+	"github.com/isometry/terraform-provider-ad/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServicePrincipalNameResource{}
+var _ resource.ResourceWithImportState = &ServicePrincipalNameResource{}
+var _ resource.ResourceWithModifyPlan = &ServicePrincipalNameResource{}
+
+func NewServicePrincipalNameResource() resource.Resource {
+	return &ServicePrincipalNameResource{}
+}
+
+// ServicePrincipalNameResource defines the resource implementation.
+type ServicePrincipalNameResource struct {
+	client ldapclient.Client
+}
+
+// ServicePrincipalNameResourceModel describes the resource data model.
+type ServicePrincipalNameResourceModel struct {
+	ID        types.String `tfsdk:"id"`         // <account_id>/<spn> (computed)
+	AccountID types.String `tfsdk:"account_id"` // Required - objectGUID of the account
+	SPN       types.String `tfsdk:"spn"`        // Required - Service principal name
+}
+
+func (r *ServicePrincipalNameResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_principal_name"
+}
+
+func (r *ServicePrincipalNameResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers a single service principal name (SPN) to an Active Directory account, such as a user, " +
+			"computer or managed service account. Use this resource when the SPN and the account are managed by different " +
+			"configurations; other SPNs of the account are left untouched.\n\n" +
+			"SPNs must be unique in the forest. When the SPN is created, it is checked against a Global Catalog server and " +
+			"the plan fails if another object already holds it.\n\n" +
+			"**Note**: Do not use this resource for a user whose SPNs are managed by the `service_principal_names` argument " +
+			"of `ad_user`, as each would remove the SPNs of the other.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource identifier, in the format `<account_id>/<spn>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The objectGUID of the account to register the SPN to. Changing this forces a new resource to be created.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"spn": schema.StringAttribute{
+				MarkdownDescription: "The service principal name, in the format `serviceclass/host[:port][/name]`. " +
+					"Example: `MSSQLSvc/db.example.com:1433`. Changing this forces a new resource to be created.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.IsValidSPN(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ServicePrincipalNameResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ldapclient.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ldapclient.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+// ModifyPlan refuses an SPN that another object in the forest already holds.
+// Both arguments force replacement, so the check only runs on create.
+func (r *ServicePrincipalNameResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan ServicePrincipalNameResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.SPN.IsUnknown() || plan.AccountID.IsUnknown() {
+		return
+	}
+
+	baseDN, err := r.client.GetBaseDN(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Getting Base DN During Planning",
+			fmt.Sprintf("Could not get base DN from LDAP client: %s", err.Error()),
+		)
+		return
+	}
+
+	validateSPNUniqueness(ctx, r.client, baseDN, path.Root("spn"), []string{plan.SPN.ValueString()}, plan.AccountID.ValueString(), &resp.Diagnostics)
+}
+
+func (r *ServicePrincipalNameResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServicePrincipalNameResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Registering service principal name", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+		"spn":        data.SPN.ValueString(),
+	})
+
+	spnManager, err := r.getSPNManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SPN Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := spnManager.AddSPN(data.AccountID.ValueString(), data.SPN.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Registering Service Principal Name",
+			fmt.Sprintf("Could not register SPN %s to account %s: %s", data.SPN.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(data.AccountID.ValueString() + "/" + data.SPN.ValueString())
+
+	tflog.Debug(ctx, "Registered service principal name", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServicePrincipalNameResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServicePrincipalNameResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spnManager, err := r.getSPNManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SPN Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	account, err := spnManager.GetAccount(data.AccountID.ValueString())
+	if err != nil {
+		if ldapclient.IsNotFoundError(err) {
+			tflog.Debug(ctx, "Account not found, removing service principal name from state", map[string]any{
+				"account_id": data.AccountID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Service Principal Name",
+			fmt.Sprintf("Could not read account %s: %s", data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// The SPN was removed outside of Terraform. AD compares SPNs
+	// case-insensitively, so the configured case is kept.
+	if !slices.ContainsFunc(account.ServicePrincipalNames, func(s string) bool { return ldapclient.SPNEqual(s, data.SPN.ValueString()) }) {
+		tflog.Debug(ctx, "Service principal name no longer registered, removing from state", map[string]any{
+			"account_id": data.AccountID.ValueString(),
+			"spn":        data.SPN.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(data.AccountID.ValueString() + "/" + data.SPN.ValueString())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called with changes, as every argument forces replacement.
+func (r *ServicePrincipalNameResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ServicePrincipalNameResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServicePrincipalNameResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServicePrincipalNameResourceModel
+
+	// Initialize logging subsystem for consistent logging
+	ctx = utils.InitializeLogging(ctx)
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Removing service principal name", map[string]any{
+		"account_id": data.AccountID.ValueString(),
+		"spn":        data.SPN.ValueString(),
+	})
+
+	spnManager, err := r.getSPNManager(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating SPN Manager",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := spnManager.RemoveSPN(data.AccountID.ValueString(), data.SPN.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Removing Service Principal Name",
+			fmt.Sprintf("Could not remove SPN %s from account %s: %s", data.SPN.ValueString(), data.AccountID.ValueString(), err.Error()),
+		)
+		return
+	}
+}
+
+func (r *ServicePrincipalNameResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by <account_id>/<spn>; the SPN itself contains slashes
+	accountID, spn, ok := strings.Cut(strings.TrimSpace(req.ID), "/")
+	if !ok || !ldapclient.NewGUIDHandler().IsValidGUID(accountID) || ldapclient.ValidateSPN(spn) != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the format <account_id>/<spn>, such as "+
+				"550e8400-e29b-41d4-a716-446655440000/HTTP/web.example.com, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), accountID+"/"+spn)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("spn"), spn)...)
+}
+
+// getSPNManager creates an SPNManager instance with base DN lookup.
+func (r *ServicePrincipalNameResource) getSPNManager(ctx context.Context) (*ldapclient.SPNManager, error) {
+	baseDN, err := r.client.GetBaseDN(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not get base DN from LDAP server: %w", err)
+	}
+
+	return ldapclient.NewSPNManager(ctx, r.client, baseDN), nil
+}
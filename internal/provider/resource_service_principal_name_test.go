@@ -0,0 +1,104 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccServicePrincipalNameResource_basic(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("spn")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+	spn := fmt.Sprintf("HTTP/%s.example.com", strings.ToLower(samName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServicePrincipalNameResourceConfig(name, upn, samName, spn),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_service_principal_name.test", "spn", spn),
+					resource.TestCheckResourceAttrPair("ad_service_principal_name.test", "account_id", "ad_user.test", "id"),
+					resource.TestCheckResourceAttrWith("ad_service_principal_name.test", "id", func(value string) error {
+						if !strings.HasSuffix(value, "/"+spn) {
+							return fmt.Errorf("expected ID to end with /%s, got: %s", spn, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ad_service_principal_name.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The SPN is already registered to the user, so it cannot be
+			// registered to another account
+			{
+				Config: testAccServicePrincipalNameResourceConfig(name, upn, samName, spn) + fmt.Sprintf(`
+resource "ad_service_principal_name" "duplicate" {
+  account_id = data.ad_user.administrator.id
+  spn        = %q
+}
+
+data "ad_user" "administrator" {
+  sam_account_name = "Administrator"
+}
+`, spn),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Duplicate Service Principal Name`),
+			},
+		},
+	})
+}
+
+func TestAccServicePrincipalNameResource_invalidSPN(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig() + `
+resource "ad_service_principal_name" "test" {
+  account_id = "550e8400-e29b-41d4-a716-446655440000"
+  spn        = "web.example.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Service Principal Name`),
+			},
+		},
+	})
+}
+
+// testAccServicePrincipalNameResourceConfig creates a user whose SPNs are
+// not managed by ad_user, and registers spn to it.
+func testAccServicePrincipalNameResourceConfig(name, upn, sam, spn string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+}
+
+resource "ad_service_principal_name" "test" {
+  account_id = ad_user.test.id
+  spn        = %[7]q
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, spn)
+}
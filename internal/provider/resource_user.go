@@ -7,7 +7,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -71,11 +74,19 @@ type UserResourceModel struct {
 	Password        types.String `tfsdk:"password"`
 	PasswordVersion types.Int64  `tfsdk:"password_version"`
 
-	Enabled                types.Bool `tfsdk:"enabled"`
-	PasswordNeverExpires   types.Bool `tfsdk:"password_never_expires"`
-	SmartCardLogonRequired types.Bool `tfsdk:"smart_card_logon_required"`
-	TrustedForDelegation   types.Bool `tfsdk:"trusted_for_delegation"`
-	ChangePasswordAtLogon  types.Bool `tfsdk:"change_password_at_logon"`
+	Enabled                           types.Bool `tfsdk:"enabled"`
+	PasswordNeverExpires              types.Bool `tfsdk:"password_never_expires"`
+	SmartCardLogonRequired            types.Bool `tfsdk:"smart_card_logon_required"`
+	TrustedForDelegation              types.Bool `tfsdk:"trusted_for_delegation"`
+	TrustedToAuthForDelegation        types.Bool `tfsdk:"trusted_to_auth_for_delegation"`
+	AccountNotDelegated               types.Bool `tfsdk:"account_not_delegated"`
+	DoesNotRequirePreAuth             types.Bool `tfsdk:"does_not_require_preauth"`
+	UseDESKeyOnly                     types.Bool `tfsdk:"use_des_key_only"`
+	AllowReversiblePasswordEncryption types.Bool `tfsdk:"allow_reversible_password_encryption"`
+	ChangePasswordAtLogon             types.Bool `tfsdk:"change_password_at_logon"`
+	CannotChangePassword              types.Bool `tfsdk:"cannot_change_password"`
+
+	Tier0Hardening types.String `tfsdk:"tier0_hardening"`
 
 	PasswordNotRequired types.Bool  `tfsdk:"password_not_required"`
 	AccountLockedOut    types.Bool  `tfsdk:"account_locked_out"`
@@ -116,14 +127,23 @@ type UserResourceModel struct {
 	ProfilePath   types.String `tfsdk:"profile_path"`
 	LogonScript   types.String `tfsdk:"logon_script"`
 
+	LogonHours        customtypes.LogonHoursValue `tfsdk:"logon_hours"`
+	LogonWorkstations types.Set                   `tfsdk:"logon_workstations"`
+
+	ServicePrincipalNames   types.Set `tfsdk:"service_principal_names"`
+	AllowedToDelegateTo     types.Set `tfsdk:"allowed_to_delegate_to"`
+	KerberosEncryptionTypes types.Set `tfsdk:"kerberos_encryption_types"`
+
+	ExtraAttributes types.Map `tfsdk:"extra_attributes"`
+
 	MemberOf     types.List   `tfsdk:"member_of"`
 	PrimaryGroup types.String `tfsdk:"primary_group"`
 
-	WhenCreated     types.String `tfsdk:"when_created"`
-	WhenChanged     types.String `tfsdk:"when_changed"`
-	LastLogon       types.String `tfsdk:"last_logon"`
-	PasswordLastSet types.String `tfsdk:"password_last_set"`
-	AccountExpires  types.String `tfsdk:"account_expires"`
+	WhenCreated     types.String                    `tfsdk:"when_created"`
+	WhenChanged     types.String                    `tfsdk:"when_changed"`
+	LastLogon       types.String                    `tfsdk:"last_logon"`
+	PasswordLastSet types.String                    `tfsdk:"password_last_set"`
+	AccountExpires  customtypes.AccountExpiresValue `tfsdk:"account_expires"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -215,7 +235,8 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 			// Password (write-only with version trigger)
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The password for the user. This is **write-only** and never stored in state. " +
+				MarkdownDescription: "The password for the user. This is a **write-only** attribute: it is never stored in plan or state, " +
+					"and it accepts ephemeral values such as the result of `ad_password`. " +
 					"When `password_version` is 0 or omitted, the password is only set on create. " +
 					"When `password_version` > 0, the password is set whenever the version changes. " +
 					"Requires LDAPS connection. **Note**: Requires Terraform 1.11+.",
@@ -260,6 +281,42 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"trusted_to_auth_for_delegation": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user may use protocol transition (S4U2Self) with constrained delegation, " +
+					"obtaining tickets to the services in `allowed_to_delegate_to` on behalf of users that did not authenticate " +
+					"with Kerberos (TRUSTED_TO_AUTH_FOR_DELEGATION). Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"account_not_delegated": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is sensitive and cannot be delegated, so no service may impersonate " +
+					"it through Kerberos delegation (NOT_DELEGATED). Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"does_not_require_preauth": schema.BoolAttribute{
+				MarkdownDescription: "Whether Kerberos pre-authentication is not required for the user (DONT_REQ_PREAUTH). " +
+					"This exposes the account to AS-REP roasting and should only be enabled for legacy clients. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"use_des_key_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is restricted to DES encryption types for Kerberos (USE_DES_KEY_ONLY). " +
+					"DES is disabled by default in current Windows versions. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"allow_reversible_password_encryption": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user's password is stored using reversible encryption (ENCRYPTED_TEXT_PWD_ALLOWED). " +
+					"Takes effect the next time the password is set. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"change_password_at_logon": schema.BoolAttribute{
 				MarkdownDescription: "Whether the user must change their password at next logon. On Create, defaults to `true` when no `password` is set and `false` otherwise. On Update, the prior state value is preserved when this attribute is omitted from configuration.",
 				Optional:            true,
@@ -268,6 +325,29 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					planmodifiers.DefaultChangePasswordAtLogon(),
 				},
 			},
+			"cannot_change_password": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user is prevented from changing their own password. Active Directory has no " +
+					"userAccountControl bit for this: it is set by denying the Change Password right to Everyone and SELF in the " +
+					"user's security descriptor, as Active Directory Users and Computers does. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"tier0_hardening": schema.StringAttribute{
+				MarkdownDescription: "Checks the user against a tier 0 hardening baseline at plan time when it is a member, " +
+					"directly or through nested groups, of a privileged group such as Domain Admins, Enterprise Admins, " +
+					"Schema Admins or the BUILTIN Administrators and operator groups. A privileged user is expected to " +
+					"have `account_not_delegated` set, be a member of Protected Users and not allow RC4 in " +
+					"`kerberos_encryption_types`. Set to `warn` to report gaps as warnings or `error` to fail the plan. " +
+					"Membership is read from the directory together with the planned `primary_group`; when the user is " +
+					"created only the primary group and the planned attributes are checked, as Protected Users membership " +
+					"is not known until the user exists. Omit this attribute to disable the check.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tier0HardeningWarn, tier0HardeningError),
+				},
+			},
 
 			// Computed security (read-only)
 			"password_not_required": schema.BoolAttribute{
@@ -512,6 +592,68 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 
+			// Logon restrictions
+			"logon_hours": schema.SingleNestedAttribute{
+				MarkdownDescription: "The hours during which the user may log on, as a list of `HH-HH` hour ranges per day " +
+					"(e.g., `monday = [\"08-17\"]`, where the end hour is exclusive). Days that are omitted deny logon all day. " +
+					"Active Directory stores logon hours in UTC with hourly resolution; set `utc_offset` to express the ranges in local time. " +
+					"Omit this attribute to allow logon at any time.",
+				CustomType: customtypes.NewLogonHoursType(),
+				Optional:   true,
+				Attributes: logonHoursAttributes(),
+			},
+			"logon_workstations": schema.SetAttribute{
+				MarkdownDescription: "The NetBIOS names of the computers the user may log on to (userWorkstations). Omit this attribute to allow logon to any computer.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 15),
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[^,\s]+$`), "must be a NetBIOS computer name without commas or spaces"),
+					),
+				},
+			},
+
+			// Kerberos
+			"service_principal_names": schema.SetAttribute{
+				MarkdownDescription: "The service principal names (SPNs) registered to the user (servicePrincipalName), such as " +
+					"`HTTP/web.example.com` or `MSSQLSvc/db.example.com:1433`. SPNs must be unique in the forest: SPNs being added " +
+					"are checked against a Global Catalog server, and the plan fails if another object already holds one. " +
+					"When set, this is the complete set of SPNs of the user and an empty set removes them all. Omit this " +
+					"attribute to leave the user's SPNs unmanaged, for example when they are managed with `ad_service_principal_name`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.IsValidSPN()),
+				},
+			},
+			"allowed_to_delegate_to": schema.SetAttribute{
+				MarkdownDescription: "The service principal names of the services the user may delegate to with Kerberos " +
+					"constrained delegation (msDS-AllowedToDelegateTo), such as `cifs/fs.example.com`. Set " +
+					"`trusted_to_auth_for_delegation` to also allow protocol transition. Omit this attribute to disable " +
+					"constrained delegation.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(validators.IsValidSPN()),
+				},
+			},
+			"kerberos_encryption_types": schema.SetAttribute{
+				MarkdownDescription: "The Kerberos encryption types the user supports (msDS-SupportedEncryptionTypes), any of " +
+					"`aes128`, `aes256` and `rc4`. Other flags of the attribute, such as the DES types, are preserved. An empty " +
+					"set removes these types, so the domain default applies. Omit this attribute to leave the encryption " +
+					"types unmanaged.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("aes128", "aes256", "rc4")),
+				},
+			},
+
+			"extra_attributes": extraAttributesSchema(),
+
 			// Computed memberships
 			"member_of": schema.ListAttribute{
 				MarkdownDescription: "A list of Distinguished Names of groups this user is a member of.",
@@ -522,8 +664,12 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"primary_group": schema.StringAttribute{
-				MarkdownDescription: "The primary group of the user (typically 'Domain Users').",
-				Computed:            true,
+				MarkdownDescription: "The primary group of the user, by Distinguished Name, GUID, SID or SAM account name. " +
+					"Defaults to Domain Users; when not configured, the primary group is read from the directory but not managed. " +
+					"The group must be a global or universal group of the user's domain. The user is added to the group before " +
+					"`primaryGroupID` is set to the group's RID, and Active Directory then lists the previous primary group in `member_of`.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -559,8 +705,18 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"account_expires": schema.StringAttribute{
-				MarkdownDescription: "When the user account expires (RFC3339 format).",
-				Computed:            true,
+				MarkdownDescription: "When the user account expires, as an RFC3339 timestamp (e.g., `2027-02-28T00:00:00Z`), " +
+					"or `never` for an account without an expiration date. " +
+					"A configured midnight (`T00:00:00` in its offset) is not reported as a change when AD stores the end of that day, 24 hours later, " +
+					"as Active Directory Users and Computers does. " +
+					"Accounts that never expire read back as `never` rather than null. " +
+					"Defaults to the current value when not configured.",
+				CustomType: customtypes.AccountExpiresType{},
+				Optional:   true,
+				Computed:   true,
+				Validators: []validator.String{
+					validators.IsValidAccountExpires(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
@@ -576,6 +732,49 @@ func (r *UserResource) ValidateConfig(ctx context.Context, req resource.Validate
 		return
 	}
 
+	// Active Directory removes logonHours that allow every hour, so such a
+	// schedule could never be read back.
+	if !data.LogonHours.IsNull() && !data.LogonHours.IsUnknown() {
+		if logonHours, err := data.LogonHours.LogonHours(); err == nil && logonHours.AllowsAll() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("logon_hours"),
+				"Unrestricted Logon Hours",
+				"The configured logon_hours allow logon at every hour of the week. Omit logon_hours instead.",
+			)
+		}
+	}
+
+	validateExtraAttributeNames(data.ExtraAttributes, userModeledAttributes, &resp.Diagnostics)
+
+	// Unconstrained delegation lets the user delegate to any service, which
+	// makes the constrained delegation list meaningless.
+	if data.TrustedForDelegation.ValueBool() && !data.AllowedToDelegateTo.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("allowed_to_delegate_to"),
+			"Unconstrained Delegation Enabled",
+			"trusted_for_delegation allows the user to delegate to any service, so allowed_to_delegate_to does not restrict delegation. "+
+				"Set trusted_for_delegation to false to use constrained delegation.",
+		)
+	}
+	if data.TrustedToAuthForDelegation.ValueBool() && data.AllowedToDelegateTo.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("trusted_to_auth_for_delegation"),
+			"Protocol Transition Without Constrained Delegation",
+			"trusted_to_auth_for_delegation has no effect unless allowed_to_delegate_to lists the services the user may delegate to.",
+		)
+	}
+
+	// Accounts without pre-authentication hand out material that can be
+	// cracked offline to anyone who asks (AS-REP roasting).
+	if data.DoesNotRequirePreAuth.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("does_not_require_preauth"),
+			"Kerberos Pre-Authentication Disabled",
+			"does_not_require_preauth lets anyone request an AS-REP for this user and attack its password offline. "+
+				"Only disable pre-authentication for clients that cannot perform it.",
+		)
+	}
+
 	// Warn if enabled is true (or default) but no password is set.
 	// Active Directory requires a password before an account can be enabled.
 	if (data.Enabled.IsNull() || data.Enabled.ValueBool()) && (data.Password.IsNull() || data.Password.ValueString() == "") {
@@ -634,25 +833,60 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 //   - when_changed as Unknown when any tracked attribute differs between state
 //     and plan (i.e. an Update will occur).
 //
-// On create (state is null) and destroy (plan is null) it is a no-op: the
-// framework's Unknown default for Computed attributes applies on create, and
-// destroy leaves computed values untouched.
+// On create (state is null) and destroy (plan is null) no computed values are
+// changed: the framework's Unknown default for Computed attributes applies on
+// create, and destroy leaves computed values untouched.
 func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// Destroy: nothing to do.
 	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	// Create: leave framework Unknown defaults in place.
-	if req.State.Raw.IsNull() {
+	// Refuse extra attributes the directory will not let us write, on create
+	// as well as update.
+	var extraAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("extra_attributes"), &extraAttributes)...)
+	validateExtraAttributeSchema(ctx, r.client, extraAttributes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan, state, config UserResourceModel
+	// Refuse SPNs that another object in the forest already holds. Only SPNs
+	// being added are checked, so an unchanged plan does not search the GC.
+	var spns types.Set
+	stateSPNs, userID := types.SetNull(types.StringType), types.StringNull()
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("service_principal_names"), &spns)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("service_principal_names"), &stateSPNs)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &userID)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateSPNUniqueness(ctx, r.client, r.baseDN, path.Root("service_principal_names"), addedSPNs(spns, stateSPNs), userID.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan UserResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Check privileged users against the tier 0 hardening baseline, which
+	// does not need the user's DACL. A user being created is checked
+	// against its planned values only.
+	if r.client != nil {
+		validateTier0Hardening(ctx, ldapclient.NewUserManager(ctx, r.client, r.baseDN, r.cacheManager), plan, r.resolvePrimaryGroup, &resp.Diagnostics)
+	}
+
+	// Create: leave framework Unknown defaults in place.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state, config UserResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -689,6 +923,12 @@ func (r *UserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRe
 		plan.WhenChanged = types.StringUnknown()
 	}
 
+	// Changing the primary group moves the user between the member lists of
+	// the old and new groups, so member_of is only known after apply.
+	if !plan.PrimaryGroup.IsUnknown() && !plan.PrimaryGroup.Equal(state.PrimaryGroup) {
+		plan.MemberOf = types.ListUnknown(types.StringType)
+	}
+
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
@@ -743,6 +983,11 @@ func userAccountControlDriversDiffer(plan, state UserResourceModel) bool {
 		{plan.PasswordNeverExpires, state.PasswordNeverExpires},
 		{plan.SmartCardLogonRequired, state.SmartCardLogonRequired},
 		{plan.TrustedForDelegation, state.TrustedForDelegation},
+		{plan.TrustedToAuthForDelegation, state.TrustedToAuthForDelegation},
+		{plan.AccountNotDelegated, state.AccountNotDelegated},
+		{plan.DoesNotRequirePreAuth, state.DoesNotRequirePreAuth},
+		{plan.UseDESKeyOnly, state.UseDESKeyOnly},
+		{plan.AllowReversiblePasswordEncryption, state.AllowReversiblePasswordEncryption},
 	}
 	for _, d := range drivers {
 		if d.plan.IsUnknown() {
@@ -796,9 +1041,22 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}()
 
 	userManager := r.getUserManager(ctx)
+	userManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Convert Terraform model to LDAP create request
 	createReq := r.modelToCreateRequest(&data)
+	if createReq.PrimaryGroup != "" {
+		groupDN, err := r.resolvePrimaryGroup(createReq.PrimaryGroup)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("primary_group"),
+				"Error Resolving Primary Group",
+				fmt.Sprintf("Could not resolve primary group %q: %s", createReq.PrimaryGroup, err.Error()),
+			)
+			return
+		}
+		createReq.PrimaryGroup = groupDN
+	}
 
 	// Set password from config (WriteOnly attributes are only available in config)
 	if !config.Password.IsNull() && config.Password.ValueString() != "" {
@@ -841,6 +1099,7 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	})
 
 	userManager := r.getUserManager(ctx)
+	userManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes))
 
 	// Get the user by GUID
 	user, err := userManager.GetUserByGUID(data.ID.ValueString())
@@ -885,6 +1144,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	userManager.SetExtraAttributes(extraAttributeNames(data.ExtraAttributes, currentData.ExtraAttributes))
 
 	// Check if password should be reset (version > 0 AND version changed).
 	// evaluatePasswordRotation is reused here as a defence-in-depth check:
@@ -976,6 +1236,19 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	if updateReq.PrimaryGroup != nil {
+		groupDN, err := r.resolvePrimaryGroup(*updateReq.PrimaryGroup)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("primary_group"),
+				"Error Resolving Primary Group",
+				fmt.Sprintf("Could not resolve primary group %q: %s", *updateReq.PrimaryGroup, err.Error()),
+			)
+			return
+		}
+		updateReq.PrimaryGroup = &groupDN
+	}
+
 	// Update the user
 	user, err := userManager.UpdateUser(data.ID.ValueString(), updateReq)
 	if err != nil {
@@ -1076,8 +1349,11 @@ func (r *UserResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 // getUserManager creates a UserManager instance using the cached base DN.
+// Users are read with their DACL, which cannot_change_password is read from.
 func (r *UserResource) getUserManager(ctx context.Context) *ldapclient.UserManager {
-	return ldapclient.NewUserManager(ctx, r.client, r.baseDN, r.cacheManager)
+	userManager := ldapclient.NewUserManager(ctx, r.client, r.baseDN, r.cacheManager)
+	userManager.SetReadCannotChangePassword(true)
+	return userManager
 }
 
 // modelToCreateRequest converts the Terraform model to an LDAP CreateUserRequest.
@@ -1107,10 +1383,41 @@ func (r *UserResource) modelToCreateRequest(model *UserResourceModel) *ldapclien
 		val := model.TrustedForDelegation.ValueBool()
 		req.TrustedForDelegation = &val
 	}
+	if !model.TrustedToAuthForDelegation.IsNull() {
+		val := model.TrustedToAuthForDelegation.ValueBool()
+		req.TrustedToAuthForDeleg = &val
+	}
+	if !model.AccountNotDelegated.IsNull() {
+		val := model.AccountNotDelegated.ValueBool()
+		req.AccountNotDelegated = &val
+	}
+	if !model.DoesNotRequirePreAuth.IsNull() {
+		val := model.DoesNotRequirePreAuth.ValueBool()
+		req.DoesNotRequirePreAuth = &val
+	}
+	if !model.UseDESKeyOnly.IsNull() {
+		val := model.UseDESKeyOnly.ValueBool()
+		req.UseDESKeyOnly = &val
+	}
+	if !model.AllowReversiblePasswordEncryption.IsNull() {
+		val := model.AllowReversiblePasswordEncryption.ValueBool()
+		req.AllowReversiblePasswordEncryption = &val
+	}
 	if !model.ChangePasswordAtLogon.IsNull() {
 		val := model.ChangePasswordAtLogon.ValueBool()
 		req.ChangePasswordAtLogon = &val
 	}
+	if !model.CannotChangePassword.IsNull() {
+		val := model.CannotChangePassword.ValueBool()
+		req.CannotChangePassword = &val
+	}
+
+	// Account expiration (validated by the schema)
+	if !model.AccountExpires.IsNull() && !model.AccountExpires.IsUnknown() {
+		if expires, err := helpers.ParseAccountExpires(model.AccountExpires.ValueString()); err == nil {
+			req.AccountExpires = &expires
+		}
+	}
 
 	// Personal information
 	req.DisplayName = helpers.GetString(model.DisplayName)
@@ -1152,6 +1459,26 @@ func (r *UserResource) modelToCreateRequest(model *UserResourceModel) *ldapclien
 	req.ProfilePath = helpers.GetString(model.ProfilePath)
 	req.LogonScript = helpers.GetString(model.LogonScript)
 
+	// Logon restrictions (validated by the schema)
+	if !model.LogonHours.IsNull() && !model.LogonHours.IsUnknown() {
+		if logonHours, err := model.LogonHours.LogonHours(); err == nil {
+			req.LogonHours = logonHours
+		}
+	}
+	req.LogonWorkstations = helpers.GetStringSet(model.LogonWorkstations)
+
+	// Kerberos (checked for duplicates by ModifyPlan)
+	req.ServicePrincipalNames = helpers.GetStringSet(model.ServicePrincipalNames)
+	req.AllowedToDelegateTo = helpers.GetStringSet(model.AllowedToDelegateTo)
+	if encTypes, err := ldapclient.EncryptionTypesFromNames(helpers.GetStringSet(model.KerberosEncryptionTypes)); err == nil {
+		req.SupportedEncryptionTypes = encTypes
+	}
+
+	req.ExtraAttributes = extraAttributeValues(model.ExtraAttributes)
+
+	// Primary group identifier, resolved to a DN by the caller
+	req.PrimaryGroup = helpers.GetString(model.PrimaryGroup)
+
 	return req
 }
 
@@ -1186,7 +1513,22 @@ func (r *UserResource) buildUpdateRequest(plan, state *UserResourceModel) *ldapc
 	hasChanges = helpers.BoolChanged(plan.PasswordNeverExpires, state.PasswordNeverExpires, &updateReq.PasswordNeverExpires) || hasChanges
 	hasChanges = helpers.BoolChanged(plan.SmartCardLogonRequired, state.SmartCardLogonRequired, &updateReq.SmartCardLogonRequired) || hasChanges
 	hasChanges = helpers.BoolChanged(plan.TrustedForDelegation, state.TrustedForDelegation, &updateReq.TrustedForDelegation) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.TrustedToAuthForDelegation, state.TrustedToAuthForDelegation, &updateReq.TrustedToAuthForDeleg) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.AccountNotDelegated, state.AccountNotDelegated, &updateReq.AccountNotDelegated) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.DoesNotRequirePreAuth, state.DoesNotRequirePreAuth, &updateReq.DoesNotRequirePreAuth) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.UseDESKeyOnly, state.UseDESKeyOnly, &updateReq.UseDESKeyOnly) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.AllowReversiblePasswordEncryption, state.AllowReversiblePasswordEncryption, &updateReq.AllowReversiblePasswordEncryption) || hasChanges
 	hasChanges = helpers.BoolChanged(plan.ChangePasswordAtLogon, state.ChangePasswordAtLogon, &updateReq.ChangePasswordAtLogon) || hasChanges
+	hasChanges = helpers.BoolChanged(plan.CannotChangePassword, state.CannotChangePassword, &updateReq.CannotChangePassword) || hasChanges
+
+	// Check account expiration change against the value read from AD
+	if !plan.AccountExpires.IsNull() && !plan.AccountExpires.IsUnknown() &&
+		helpers.AccountExpiresChanged(plan.AccountExpires.ValueString(), state.AccountExpires.ValueString()) {
+		if expires, err := helpers.ParseAccountExpires(plan.AccountExpires.ValueString()); err == nil {
+			updateReq.AccountExpires = &expires
+			hasChanges = true
+		}
+	}
 
 	// Check string attribute changes
 	hasChanges = helpers.StringChanged(plan.DisplayName, state.DisplayName, &updateReq.DisplayName) || hasChanges
@@ -1220,6 +1562,62 @@ func (r *UserResource) buildUpdateRequest(plan, state *UserResourceModel) *ldapc
 	hasChanges = helpers.StringChanged(plan.ProfilePath, state.ProfilePath, &updateReq.ProfilePath) || hasChanges
 	hasChanges = helpers.StringChanged(plan.LogonScript, state.LogonScript, &updateReq.LogonScript) || hasChanges
 
+	// Check logon restriction changes; removing them lifts the restriction
+	if !plan.LogonHours.Equal(state.LogonHours) {
+		if plan.LogonHours.IsNull() {
+			updateReq.LogonHours = ldapclient.UnrestrictedLogonHours()
+			hasChanges = true
+		} else if logonHours, err := plan.LogonHours.LogonHours(); err == nil {
+			updateReq.LogonHours = logonHours
+			hasChanges = true
+		}
+	}
+	if !plan.LogonWorkstations.Equal(state.LogonWorkstations) {
+		workstations := helpers.GetStringSet(plan.LogonWorkstations)
+		updateReq.LogonWorkstations = &workstations
+		hasChanges = true
+	}
+
+	// Check SPN changes; removing the attribute leaves the SPNs unmanaged
+	if !plan.ServicePrincipalNames.IsNull() && !plan.ServicePrincipalNames.Equal(state.ServicePrincipalNames) {
+		spns := helpers.GetStringSet(plan.ServicePrincipalNames)
+		if spns == nil {
+			spns = []string{}
+		}
+		updateReq.ServicePrincipalNames = &spns
+		hasChanges = true
+	}
+
+	// Check constrained delegation changes; removing the attribute disables it
+	if !plan.AllowedToDelegateTo.Equal(state.AllowedToDelegateTo) {
+		targets := helpers.GetStringSet(plan.AllowedToDelegateTo)
+		if targets == nil {
+			targets = []string{}
+		}
+		updateReq.AllowedToDelegateTo = &targets
+		hasChanges = true
+	}
+
+	// Check Kerberos encryption type changes; omitting the attribute leaves them unmanaged
+	if !plan.KerberosEncryptionTypes.IsNull() && !plan.KerberosEncryptionTypes.Equal(state.KerberosEncryptionTypes) {
+		if encTypes, err := ldapclient.EncryptionTypesFromNames(helpers.GetStringSet(plan.KerberosEncryptionTypes)); err == nil {
+			updateReq.SupportedEncryptionTypes = &encTypes
+			hasChanges = true
+		}
+	}
+
+	// Check extra attribute changes; attributes removed from the map are deleted
+	if changes := extraAttributeChanges(plan.ExtraAttributes, state.ExtraAttributes); changes != nil {
+		updateReq.ExtraAttributes = changes
+		hasChanges = true
+	}
+
+	// Check primary group changes; the identifier is resolved to a DN by the caller
+	if !plan.PrimaryGroup.IsNull() && !plan.PrimaryGroup.IsUnknown() && !plan.PrimaryGroup.Equal(state.PrimaryGroup) {
+		updateReq.PrimaryGroup = plan.PrimaryGroup.ValueStringPointer()
+		hasChanges = true
+	}
+
 	if !hasChanges {
 		return nil
 	}
@@ -1227,6 +1625,57 @@ func (r *UserResource) buildUpdateRequest(plan, state *UserResourceModel) *ldapc
 	return updateReq
 }
 
+// logonHoursAttributes returns the attributes of the logon_hours object: a
+// utc_offset and a list of hour ranges for each day of the week.
+func logonHoursAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"utc_offset": schema.StringAttribute{
+			MarkdownDescription: "The UTC offset the hour ranges are expressed in, as a whole number of hours (e.g., `+01:00`). " +
+				"Offsets are fixed, so ranges do not follow daylight saving time changes. Defaults to UTC.",
+			Optional: true,
+			Validators: []validator.String{
+				validators.IsValidUTCOffset(),
+			},
+		},
+	}
+	for _, day := range []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"} {
+		attributes[day] = schema.ListAttribute{
+			MarkdownDescription: fmt.Sprintf("The hour ranges during which logon is allowed on %s, in `HH-HH` format.", strings.ToUpper(day[:1])+day[1:]),
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(validators.IsValidHourRange()),
+			},
+		}
+	}
+	return attributes
+}
+
+// resolvePrimaryGroup resolves a primary_group identifier to the group's DN.
+func (r *UserResource) resolvePrimaryGroup(identifier string) (string, error) {
+	normalizer := ldapclient.NewMemberNormalizer(r.client, r.baseDN, r.cacheManager)
+	return normalizer.NormalizeToDN(identifier)
+}
+
+// primaryGroupToModel returns the primary group DN read from the directory,
+// unless prior is another identifier of the same group, which is kept so a
+// group configured by GUID, SID or SAM account name does not show as drift.
+func (r *UserResource) primaryGroupToModel(prior types.String, current string) types.String {
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.StringValue(current)
+	}
+	if ldapclient.DNEqual(prior.ValueString(), current) {
+		return prior
+	}
+	if r.client == nil {
+		return types.StringValue(current)
+	}
+	if groupDN, err := r.resolvePrimaryGroup(prior.ValueString()); err == nil && ldapclient.DNEqual(groupDN, current) {
+		return prior
+	}
+	return types.StringValue(current)
+}
+
 // userToModel maps an LDAP User to the Terraform model.
 func (r *UserResource) userToModel(ctx context.Context, user *ldapclient.User, model *UserResourceModel, diags *diag.Diagnostics) {
 	// Identity
@@ -1248,7 +1697,13 @@ func (r *UserResource) userToModel(ctx context.Context, user *ldapclient.User, m
 	model.PasswordNeverExpires = types.BoolValue(user.PasswordNeverExpires)
 	model.SmartCardLogonRequired = types.BoolValue(user.SmartCardLogonRequired)
 	model.TrustedForDelegation = types.BoolValue(user.TrustedForDelegation)
+	model.TrustedToAuthForDelegation = types.BoolValue(user.TrustedToAuthForDeleg)
+	model.AccountNotDelegated = types.BoolValue(user.AccountNotDelegated)
+	model.DoesNotRequirePreAuth = types.BoolValue(user.DoesNotRequirePreAuth)
+	model.UseDESKeyOnly = types.BoolValue(user.UseDESKeyOnly)
+	model.AllowReversiblePasswordEncryption = types.BoolValue(user.AllowReversiblePasswordEncryption)
 	model.ChangePasswordAtLogon = types.BoolValue(user.ChangePasswordAtLogon)
+	model.CannotChangePassword = types.BoolValue(user.CannotChangePassword)
 
 	// Computed security
 	model.PasswordNotRequired = types.BoolValue(user.PasswordNotRequired)
@@ -1295,8 +1750,18 @@ func (r *UserResource) userToModel(ctx context.Context, user *ldapclient.User, m
 	model.ProfilePath = helpers.StringOrNull(user.ProfilePath)
 	model.LogonScript = helpers.StringOrNull(user.LogonScript)
 
+	// Logon restrictions
+	logonHours, logonHoursDiags := customtypes.LogonHoursFromLDAP(user.LogonHours)
+	diags.Append(logonHoursDiags...)
+	model.LogonHours = logonHours
+	model.LogonWorkstations = helpers.StringSetOrNull(user.LogonWorkstations, diags)
+	model.ServicePrincipalNames = servicePrincipalNamesToModel(model.ServicePrincipalNames, user.ServicePrincipalNames)
+	model.AllowedToDelegateTo = helpers.StringSetOrNull(user.AllowedToDelegateTo, diags)
+	model.KerberosEncryptionTypes = kerberosEncryptionTypesToModel(model.KerberosEncryptionTypes, user.SupportedEncryptionTypes)
+	model.ExtraAttributes = extraAttributesToModel(model.ExtraAttributes, user.ExtraAttributes)
+
 	// Group memberships
-	model.PrimaryGroup = types.StringValue(user.PrimaryGroup)
+	model.PrimaryGroup = r.primaryGroupToModel(model.PrimaryGroup, user.PrimaryGroup)
 	model.MemberOf = helpers.DNListOrNull(ctx, user.MemberOf, diags)
 
 	// Timestamps - convert to RFC3339 format using shared helpers
@@ -1304,5 +1769,19 @@ func (r *UserResource) userToModel(ctx context.Context, user *ldapclient.User, m
 	model.WhenChanged = helpers.Timestamp(user.WhenChanged)
 	model.LastLogon = helpers.TimestampOrNull(user.LastLogon)
 	model.PasswordLastSet = helpers.TimestampOrNull(user.PasswordLastSet)
-	model.AccountExpires = helpers.TimestampOrNull(user.AccountExpires)
+	model.AccountExpires = customtypes.AccountExpires(helpers.FormatAccountExpires(user.AccountExpires))
+}
+
+// kerberosEncryptionTypesToModel converts msDS-SupportedEncryptionTypes to
+// the kerberos_encryption_types set. The set stays null while unmanaged.
+func kerberosEncryptionTypesToModel(prior types.Set, encTypes int32) types.Set {
+	if prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	names := ldapclient.EncryptionTypeNames(encTypes)
+	elems := make([]attr.Value, len(names))
+	for i, name := range names {
+		elems[i] = types.StringValue(name)
+	}
+	return types.SetValueMust(types.StringType, elems)
 }
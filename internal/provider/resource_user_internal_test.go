@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	customtypes "github.com/isometry/terraform-provider-ad/internal/provider/types"
 )
 
 func TestEvaluatePasswordRotation(t *testing.T) {
@@ -126,13 +131,25 @@ func TestBuildUpdateRequest_ChangePasswordAtLogonAfterRefresh(t *testing.T) {
 // exercised on the single attribute each test mutates.
 func newUserModelForUpdateDiff() UserResourceModel {
 	return UserResourceModel{
-		PrincipalName:          types.StringValue("alice@example.com"),
-		SAMAccountName:         types.StringValue("alice"),
-		Enabled:                types.BoolValue(true),
-		PasswordNeverExpires:   types.BoolValue(false),
-		SmartCardLogonRequired: types.BoolValue(false),
-		TrustedForDelegation:   types.BoolValue(false),
-		ChangePasswordAtLogon:  types.BoolValue(false),
+		PrincipalName:                     types.StringValue("alice@example.com"),
+		SAMAccountName:                    types.StringValue("alice"),
+		Enabled:                           types.BoolValue(true),
+		PasswordNeverExpires:              types.BoolValue(false),
+		SmartCardLogonRequired:            types.BoolValue(false),
+		TrustedForDelegation:              types.BoolValue(false),
+		TrustedToAuthForDelegation:        types.BoolValue(false),
+		AccountNotDelegated:               types.BoolValue(false),
+		DoesNotRequirePreAuth:             types.BoolValue(false),
+		UseDESKeyOnly:                     types.BoolValue(false),
+		AllowReversiblePasswordEncryption: types.BoolValue(false),
+		ChangePasswordAtLogon:             types.BoolValue(false),
+		CannotChangePassword:              types.BoolValue(false),
+		LogonHours:                        customtypes.LogonHoursNull(),
+		LogonWorkstations:                 types.SetNull(types.StringType),
+		ServicePrincipalNames:             types.SetNull(types.StringType),
+		AllowedToDelegateTo:               types.SetNull(types.StringType),
+		KerberosEncryptionTypes:           types.SetNull(types.StringType),
+		ExtraAttributes:                   types.MapNull(types.ListType{ElemType: types.StringType}),
 	}
 }
 
@@ -152,3 +169,366 @@ func TestBuildUpdateRequest_NilWhenPlanMatchesRefreshedState(t *testing.T) {
 		t.Fatalf("expected nil update request when plan and refreshed state agree on tracked attrs, got %+v", req)
 	}
 }
+
+func TestBuildUpdateRequest_AccountExpires(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+
+	tests := map[string]struct {
+		plan      customtypes.AccountExpiresValue
+		state     customtypes.AccountExpiresValue
+		wantSet   bool
+		wantNever bool
+	}{
+		"new expiration":               {plan: customtypes.AccountExpires("2027-02-28T00:00:00Z"), state: customtypes.AccountExpires("never"), wantSet: true},
+		"cleared expiration":           {plan: customtypes.AccountExpires("never"), state: customtypes.AccountExpires("2027-03-01T00:00:00Z"), wantSet: true, wantNever: true},
+		"earlier time on previous day": {plan: customtypes.AccountExpires("2027-02-28T12:00:00Z"), state: customtypes.AccountExpires("2027-03-01T00:00:00Z"), wantSet: true},
+		"end of day equivalent":        {plan: customtypes.AccountExpires("2027-02-28T00:00:00Z"), state: customtypes.AccountExpires("2027-03-01T00:00:00Z"), wantSet: true},
+		"same instant other offset":    {plan: customtypes.AccountExpires("2027-03-01T01:00:00+01:00"), state: customtypes.AccountExpires("2027-03-01T00:00:00Z")},
+		"unconfigured keeps state":     {plan: customtypes.AccountExpiresUnknown(), state: customtypes.AccountExpires("2027-03-01T00:00:00Z")},
+		"unchanged never is no change": {plan: customtypes.AccountExpires("never"), state: customtypes.AccountExpires("never")},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := newUserModelForUpdateDiff()
+			plan.AccountExpires = tt.plan
+			state := newUserModelForUpdateDiff()
+			state.AccountExpires = tt.state
+
+			req := r.buildUpdateRequest(&plan, &state)
+			if !tt.wantSet {
+				if req != nil {
+					t.Fatalf("expected nil update request, got %+v", req)
+				}
+				return
+			}
+			if req == nil || req.AccountExpires == nil {
+				t.Fatalf("expected update request with AccountExpires set; got %+v", req)
+			}
+			if req.AccountExpires.IsZero() != tt.wantNever {
+				t.Errorf("expected never=%t, got AccountExpires=%s", tt.wantNever, req.AccountExpires)
+			}
+		})
+	}
+}
+
+func TestBuildUpdateRequest_LogonRestrictions(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	ctx := t.Context()
+
+	weekdays, diags := customtypes.NewLogonHoursType().ValueFromObject(ctx, types.ObjectValueMust(customtypes.LogonHoursAttrTypes(), map[string]attr.Value{
+		"utc_offset": types.StringValue("+01:00"),
+		"sunday":     types.ListNull(types.StringType),
+		"monday":     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("08-17")}),
+		"tuesday":    types.ListNull(types.StringType),
+		"wednesday":  types.ListNull(types.StringType),
+		"thursday":   types.ListNull(types.StringType),
+		"friday":     types.ListNull(types.StringType),
+		"saturday":   types.ListNull(types.StringType),
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+	restricted, ok := weekdays.(customtypes.LogonHoursValue)
+	if !ok {
+		t.Fatalf("expected LogonHoursValue, got %T", weekdays)
+	}
+	kiosks := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("KIOSK01")})
+
+	t.Run("restrictions_added", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		plan.LogonHours = restricted
+		plan.LogonWorkstations = kiosks
+		state := newUserModelForUpdateDiff()
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.LogonHours == nil || req.LogonWorkstations == nil {
+			t.Fatalf("expected update request with logon restrictions set; got %+v", req)
+		}
+		if req.LogonHours.AllowsAll() {
+			t.Errorf("expected restricted logon hours")
+		}
+		if len(*req.LogonWorkstations) != 1 || (*req.LogonWorkstations)[0] != "KIOSK01" {
+			t.Errorf("expected [KIOSK01], got %v", *req.LogonWorkstations)
+		}
+	})
+
+	t.Run("restrictions_removed", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		state := newUserModelForUpdateDiff()
+		state.LogonHours = restricted
+		state.LogonWorkstations = kiosks
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.LogonHours == nil || req.LogonWorkstations == nil {
+			t.Fatalf("expected update request clearing logon restrictions; got %+v", req)
+		}
+		if !req.LogonHours.AllowsAll() {
+			t.Errorf("expected unrestricted logon hours")
+		}
+		if len(*req.LogonWorkstations) != 0 {
+			t.Errorf("expected no workstations, got %v", *req.LogonWorkstations)
+		}
+	})
+}
+
+func TestBuildUpdateRequest_ServicePrincipalNames(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	spns := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("HTTP/web.example.com")})
+	noSPNs := types.SetValueMust(types.StringType, []attr.Value{})
+
+	tests := map[string]struct {
+		plan    types.Set
+		state   types.Set
+		wantSet bool
+		want    []string
+	}{
+		"spns_added":     {plan: spns, state: types.SetNull(types.StringType), wantSet: true, want: []string{"HTTP/web.example.com"}},
+		"spns_cleared":   {plan: noSPNs, state: spns, wantSet: true, want: []string{}},
+		"spns_unmanaged": {plan: types.SetNull(types.StringType), state: spns},
+		"spns_unchanged": {plan: spns, state: spns},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := newUserModelForUpdateDiff()
+			plan.ServicePrincipalNames = tt.plan
+			state := newUserModelForUpdateDiff()
+			state.ServicePrincipalNames = tt.state
+
+			req := r.buildUpdateRequest(&plan, &state)
+			if !tt.wantSet {
+				if req != nil {
+					t.Fatalf("expected nil update request, got %+v", req)
+				}
+				return
+			}
+			if req == nil || req.ServicePrincipalNames == nil {
+				t.Fatalf("expected update request with ServicePrincipalNames set; got %+v", req)
+			}
+			if !slices.Equal(*req.ServicePrincipalNames, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, *req.ServicePrincipalNames)
+			}
+		})
+	}
+}
+
+func TestBuildUpdateRequest_ConstrainedDelegation(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	targets := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("cifs/fs.example.com")})
+
+	t.Run("targets_added_with_protocol_transition", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		plan.AllowedToDelegateTo = targets
+		plan.TrustedToAuthForDelegation = types.BoolValue(true)
+		state := newUserModelForUpdateDiff()
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.AllowedToDelegateTo == nil || req.TrustedToAuthForDeleg == nil {
+			t.Fatalf("expected update request with AllowedToDelegateTo and TrustedToAuthForDeleg set; got %+v", req)
+		}
+		if !slices.Equal(*req.AllowedToDelegateTo, []string{"cifs/fs.example.com"}) {
+			t.Errorf("expected [cifs/fs.example.com], got %v", *req.AllowedToDelegateTo)
+		}
+		if !*req.TrustedToAuthForDeleg {
+			t.Error("expected TrustedToAuthForDeleg to be true")
+		}
+	})
+
+	t.Run("targets_removed", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		state := newUserModelForUpdateDiff()
+		state.AllowedToDelegateTo = targets
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.AllowedToDelegateTo == nil {
+			t.Fatalf("expected update request with AllowedToDelegateTo set; got %+v", req)
+		}
+		if len(*req.AllowedToDelegateTo) != 0 {
+			t.Errorf("expected empty list, got %v", *req.AllowedToDelegateTo)
+		}
+	})
+}
+
+func TestBuildUpdateRequest_AccountControlFlags(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	plan := newUserModelForUpdateDiff()
+	plan.AccountNotDelegated = types.BoolValue(true)
+	plan.CannotChangePassword = types.BoolValue(true)
+	state := newUserModelForUpdateDiff()
+
+	req := r.buildUpdateRequest(&plan, &state)
+	if req == nil || req.AccountNotDelegated == nil || req.CannotChangePassword == nil {
+		t.Fatalf("expected update request with AccountNotDelegated and CannotChangePassword set; got %+v", req)
+	}
+	if !*req.AccountNotDelegated || !*req.CannotChangePassword {
+		t.Errorf("expected both flags to be true; got %v and %v", *req.AccountNotDelegated, *req.CannotChangePassword)
+	}
+	if req.DoesNotRequirePreAuth != nil || req.UseDESKeyOnly != nil || req.AllowReversiblePasswordEncryption != nil {
+		t.Errorf("expected unchanged flags to stay nil; got %+v", req)
+	}
+	if !userAccountControlDriversDiffer(plan, state) {
+		t.Error("expected account_not_delegated to mark user_account_control unknown")
+	}
+}
+
+func TestBuildUpdateRequest_KerberosEncryptionTypes(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	aesOnly := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("aes128"), types.StringValue("aes256")})
+
+	t.Run("types_changed", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		plan.KerberosEncryptionTypes = aesOnly
+		state := newUserModelForUpdateDiff()
+		state.KerberosEncryptionTypes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("rc4")})
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.SupportedEncryptionTypes == nil {
+			t.Fatalf("expected update request with SupportedEncryptionTypes set; got %+v", req)
+		}
+		if want := ldapclient.EncTypeAES128 | ldapclient.EncTypeAES256; *req.SupportedEncryptionTypes != want {
+			t.Errorf("expected %#x, got %#x", want, *req.SupportedEncryptionTypes)
+		}
+	})
+
+	t.Run("unmanaged", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		state := newUserModelForUpdateDiff()
+		state.KerberosEncryptionTypes = aesOnly
+
+		if req := r.buildUpdateRequest(&plan, &state); req != nil {
+			t.Errorf("expected no update when kerberos_encryption_types is omitted; got %+v", req)
+		}
+	})
+}
+
+func TestBuildUpdateRequest_PrimaryGroup(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+
+	t.Run("group_changed", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		plan.PrimaryGroup = types.StringValue("linux-users")
+		state := newUserModelForUpdateDiff()
+		state.PrimaryGroup = types.StringValue("CN=Domain Users,CN=Users,DC=example,DC=com")
+
+		req := r.buildUpdateRequest(&plan, &state)
+		if req == nil || req.PrimaryGroup == nil || *req.PrimaryGroup != "linux-users" {
+			t.Fatalf("expected update request with PrimaryGroup linux-users; got %+v", req)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		plan := newUserModelForUpdateDiff()
+		plan.PrimaryGroup = types.StringUnknown()
+		state := newUserModelForUpdateDiff()
+		state.PrimaryGroup = types.StringValue("CN=Domain Users,CN=Users,DC=example,DC=com")
+
+		if req := r.buildUpdateRequest(&plan, &state); req != nil {
+			t.Errorf("expected no update when primary_group is unknown; got %+v", req)
+		}
+	})
+}
+
+func TestPrimaryGroupToModel(t *testing.T) {
+	t.Parallel()
+
+	r := &UserResource{}
+	current := "CN=Linux Users,OU=Groups,DC=example,DC=com"
+
+	if got := r.primaryGroupToModel(types.StringUnknown(), current); got.ValueString() != current {
+		t.Errorf("expected %q for an unconfigured primary group, got %s", current, got)
+	}
+	prior := types.StringValue("cn=linux users,ou=groups,dc=example,dc=com")
+	if got := r.primaryGroupToModel(prior, current); !got.Equal(prior) {
+		t.Errorf("expected prior case to be kept, got %s", got)
+	}
+	// Without a client other identifiers cannot be resolved
+	if got := r.primaryGroupToModel(types.StringValue("linux-users"), current); got.ValueString() != current {
+		t.Errorf("expected %q, got %s", current, got)
+	}
+}
+
+func TestKerberosEncryptionTypesToModel(t *testing.T) {
+	t.Parallel()
+
+	if got := kerberosEncryptionTypesToModel(types.SetNull(types.StringType), ldapclient.EncTypeAES256); !got.IsNull() {
+		t.Errorf("expected unmanaged encryption types to stay null, got %s", got)
+	}
+
+	prior := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("aes256")})
+	got := kerberosEncryptionTypesToModel(prior, ldapclient.EncTypeDESCBCMD5|ldapclient.EncTypeRC4HMAC|ldapclient.EncTypeAES256)
+	want := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("aes256"), types.StringValue("rc4")})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if got := kerberosEncryptionTypesToModel(prior, 0); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected empty set when no named types are set, got %s", got)
+	}
+}
+
+func TestServicePrincipalNamesToModel(t *testing.T) {
+	t.Parallel()
+
+	prior := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("HTTP/Web.example.com")})
+
+	if got := servicePrincipalNamesToModel(types.SetNull(types.StringType), []string{"HTTP/web.example.com"}); !got.IsNull() {
+		t.Errorf("expected unmanaged SPNs to stay null, got %s", got)
+	}
+	if got := servicePrincipalNamesToModel(prior, []string{"http/web.example.com"}); !got.Equal(prior) {
+		t.Errorf("expected prior case to be kept, got %s", got)
+	}
+	if got := servicePrincipalNamesToModel(prior, nil); got.IsNull() || len(got.Elements()) != 0 {
+		t.Errorf("expected empty set when SPNs were removed, got %s", got)
+	}
+}
+
+func TestAddedSPNs(t *testing.T) {
+	t.Parallel()
+
+	planned := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("HTTP/web.example.com"),
+		types.StringValue("HTTP/api.example.com"),
+	})
+	prior := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("http/WEB.example.com")})
+
+	if got := addedSPNs(planned, prior); !slices.Equal(got, []string{"HTTP/api.example.com"}) {
+		t.Errorf("expected [HTTP/api.example.com], got %v", got)
+	}
+	if got := addedSPNs(types.SetUnknown(types.StringType), prior); got != nil {
+		t.Errorf("expected no SPNs for an unknown plan, got %v", got)
+	}
+}
@@ -953,6 +953,467 @@ resource "ad_user" "test" {
 `, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, password, version, DefaultTestContainer)
 }
 
+func TestAccUserResource_accountExpires(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withAccountExpires(name, upn, samName, "2030-06-30T17:00:00+02:00"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "account_expires", "2030-06-30T17:00:00+02:00"),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_withAccountExpires(name, upn, samName, "never"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "account_expires", "never"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_withAccountExpires(name, upn, sam, accountExpires string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[7]s,${data.ad_rootdse.test.default_naming_context}"
+  account_expires  = %[6]q
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, accountExpires, DefaultTestContainer)
+}
+
+func TestAccUserResource_logonRestrictions(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withLogonRestrictions(name, upn, samName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "logon_hours.utc_offset", "+01:00"),
+					resource.TestCheckResourceAttr("ad_user.test", "logon_hours.monday.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ad_user.test", "logon_workstations.*", "KIOSK01"),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_basic(name, upn, samName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ad_user.test", "logon_hours.%"),
+					resource.TestCheckNoResourceAttr("ad_user.test", "logon_workstations.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_withLogonRestrictions(name, upn, sam string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+
+  logon_hours = {
+    utc_offset = "+01:00"
+    monday     = ["08-12", "13-17"]
+    friday     = ["08-12"]
+  }
+  logon_workstations = ["KIOSK01", "KIOSK02"]
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer)
+}
+
+func TestAccUserResource_extraAttributes(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withExtraAttributes(name, upn, samName, `{
+    extensionAttribute1 = ["finance"]
+    otherTelephone      = ["+1 555 0100", "+1 555 0101"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "extra_attributes.extensionAttribute1.0", "finance"),
+					resource.TestCheckResourceAttr("ad_user.test", "extra_attributes.otherTelephone.#", "2"),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_withExtraAttributes(name, upn, samName, `{
+    employeeType   = ["contractor"]
+    otherTelephone = ["+1 555 0101"]
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "extra_attributes.%", "2"),
+					resource.TestCheckResourceAttr("ad_user.test", "extra_attributes.employeeType.0", "contractor"),
+					resource.TestCheckNoResourceAttr("ad_user.test", "extra_attributes.extensionAttribute1.#"),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_withExtraAttributes(name, upn, samName, `{
+    mail = ["someone@example.com"]
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Modeled Attribute in extra_attributes`),
+			},
+			{
+				Config: testAccUserResourceConfig_withExtraAttributes(name, upn, samName, `{
+    objectSid = ["S-1-5-21-0-0-0-500"]
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`System-Only Attribute in extra_attributes`),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_withExtraAttributes(name, upn, sam, extraAttributes string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+
+  extra_attributes = %[7]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, extraAttributes)
+}
+
+func TestAccUserResource_servicePrincipalNames(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+	otherName := GenerateTestName(TestUserPrefix)
+	otherSAMName := GenerateTestSAMName("u")
+	otherUPN := fmt.Sprintf("%s@%s", otherSAMName, GetTestConfig().Domain)
+	host := strings.ToLower(samName) + ".example.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withSPNs(name, upn, samName, fmt.Sprintf(`["HTTP/%[1]s", "MSSQLSvc/%[1]s:1433"]`, host)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "service_principal_names.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ad_user.test", "service_principal_names.*", "HTTP/"+host),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_withSPNs(name, upn, samName, fmt.Sprintf(`["HTTP/%s"]`, host)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "service_principal_names.#", "1"),
+				),
+			},
+			// A second account may not claim an SPN the first already holds
+			{
+				Config: testAccUserResourceConfig_withSPNs(name, upn, samName, fmt.Sprintf(`["HTTP/%s"]`, host)) + fmt.Sprintf(`
+resource "ad_user" "other" {
+  name                    = %[1]q
+  principal_name          = %[2]q
+  sam_account_name        = %[3]q
+  container               = "%[4]s,${data.ad_rootdse.test.default_naming_context}"
+  service_principal_names = ["http/%[5]s"]
+}
+`, otherName, otherUPN, otherSAMName, DefaultTestContainer, strings.ToUpper(host)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Duplicate Service Principal Name`),
+			},
+			{
+				Config: testAccUserResourceConfig_withSPNs(name, upn, samName, `[]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "service_principal_names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_withSPNs(name, upn, sam, spns string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+
+  service_principal_names = %[7]s
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, spns)
+}
+
+func TestAccUserResource_constrainedDelegation(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withDelegation(name, upn, samName, `
+  allowed_to_delegate_to         = ["cifs/fs.example.com", "MSSQLSvc/db.example.com:1433"]
+  trusted_to_auth_for_delegation = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "allowed_to_delegate_to.#", "2"),
+					resource.TestCheckTypeSetElemAttr("ad_user.test", "allowed_to_delegate_to.*", "cifs/fs.example.com"),
+					resource.TestCheckResourceAttr("ad_user.test", "trusted_to_auth_for_delegation", "true"),
+				),
+			},
+			{
+				ResourceName:            "ad_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+			{
+				Config: testAccUserResourceConfig_withDelegation(name, upn, samName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ad_user.test", "allowed_to_delegate_to"),
+					resource.TestCheckResourceAttr("ad_user.test", "trusted_to_auth_for_delegation", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserResource_accountControlFlags(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_withDelegation(name, upn, samName, `
+  account_not_delegated     = true
+  cannot_change_password    = true
+  kerberos_encryption_types = ["aes128", "aes256"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "account_not_delegated", "true"),
+					resource.TestCheckResourceAttr("ad_user.test", "cannot_change_password", "true"),
+					resource.TestCheckResourceAttr("ad_user.test", "kerberos_encryption_types.#", "2"),
+					resource.TestCheckResourceAttr("ad_user.test", "user_account_control", "1049090"), // NORMAL_ACCOUNT | ACCOUNTDISABLE | NOT_DELEGATED
+				),
+			},
+			{
+				ResourceName:            "ad_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "kerberos_encryption_types"},
+			},
+			{
+				Config: testAccUserResourceConfig_withDelegation(name, upn, samName, `
+  does_not_require_preauth  = true
+  kerberos_encryption_types = ["aes256", "rc4"]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "account_not_delegated", "false"),
+					resource.TestCheckResourceAttr("ad_user.test", "cannot_change_password", "false"),
+					resource.TestCheckResourceAttr("ad_user.test", "does_not_require_preauth", "true"),
+					resource.TestCheckTypeSetElemAttr("ad_user.test", "kerberos_encryption_types.*", "rc4"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccUserResource_tier0Hardening adds the user to Print Operators, a
+// privileged BUILTIN group that is empty in a fresh domain, and expects the
+// unhardened account to fail the plan.
+func TestAccUserResource_tier0Hardening(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceConfig_tier0Hardening(name, upn, samName, "warn"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttr("ad_user.test", "tier0_hardening", "warn"),
+				),
+			},
+			{
+				Config:      testAccUserResourceConfig_tier0Hardening(name, upn, samName, "error"),
+				ExpectError: regexp.MustCompile(`Privileged Account Can Be Delegated`),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_tier0Hardening(name, upn, sam, mode string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+data "ad_group" "print_operators" {
+  sam_account_name = "Print Operators"
+}
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+  tier0_hardening  = %[7]q
+}
+
+resource "ad_group_membership" "test" {
+  group_id = data.ad_group.print_operators.id
+  members  = [ad_user.test.id]
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, mode)
+}
+
+func TestAccUserResource_primaryGroup(t *testing.T) {
+	name := GenerateTestName(TestUserPrefix)
+	samName := GenerateTestSAMName("u")
+	upn := fmt.Sprintf("%s@%s", samName, GetTestConfig().Domain)
+	groupName := GenerateTestName(TestGroupPrefix)
+	groupSAM := GenerateTestSAMName("g")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckUserDestroy(t.Context(), s)
+		},
+		Steps: []resource.TestStep{
+			{
+				// The group is configured by sAMAccountName and the
+				// membership lists the user, neither of which may drift.
+				Config: testAccUserResourceConfig_primaryGroup(name, upn, samName, groupName, groupSAM, "ad_group.test.sam_account_name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckUserExists(t.Context(), "ad_user.test"),
+					resource.TestCheckResourceAttrPair("ad_user.test", "primary_group", "ad_group.test", "sam_account_name"),
+					resource.TestCheckResourceAttr("ad_group_membership.test", "members_normalized.#", "1"),
+				),
+			},
+			{
+				Config: testAccUserResourceConfig_primaryGroup(name, upn, samName, groupName, groupSAM, `"Domain Users"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ad_user.test", "primary_group", "Domain Users"),
+					// The previous primary group is now an explicit membership
+					resource.TestCheckResourceAttr("ad_user.test", "member_of.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserResourceConfig_primaryGroup(name, upn, sam, groupName, groupSAM, primaryGroup string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_group" "test" {
+  name             = %[7]q
+  sam_account_name = %[8]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+}
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+  primary_group    = %[9]s
+}
+
+resource "ad_group_membership" "test" {
+  group_id = ad_group.test.id
+  members  = [ad_user.test.id]
+}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, groupName, groupSAM, primaryGroup)
+}
+
+func testAccUserResourceConfig_withDelegation(name, upn, sam, delegation string) string {
+	return fmt.Sprintf(`
+%s
+
+%s
+
+resource "ad_user" "test" {
+  name             = %[3]q
+  principal_name   = %[4]q
+  sam_account_name = %[5]q
+  container        = "%[6]s,${data.ad_rootdse.test.default_naming_context}"
+%[7]s}
+`, testProviderConfig(), testRootDSEDataSource(), name, upn, sam, DefaultTestContainer, delegation)
+}
+
 // TestAccUserResource_duplicateSAMAccountName confirms the provider surfaces
 // a clear error when two ad_user resources in the same plan share a
 // sAMAccountName. Active Directory requires sAMAccountName to be unique
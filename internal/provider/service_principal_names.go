@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+)
+
+// addedSPNs returns the known SPNs of planned that are not in prior,
+// comparing case-insensitively as Active Directory does.
+func addedSPNs(planned, prior types.Set) []string {
+	priorSPNs := helpers.GetStringSet(prior)
+	var added []string
+	for _, spn := range helpers.GetStringSet(planned) {
+		if !slices.ContainsFunc(priorSPNs, func(p string) bool { return ldapclient.SPNEqual(p, spn) }) {
+			added = append(added, spn)
+		}
+	}
+	return added
+}
+
+// validateSPNUniqueness refuses SPNs that are already registered to an
+// object other than the account with objectGUID ownerGUID anywhere in the
+// forest, as Kerberos cannot issue tickets for a duplicated SPN. The check
+// runs against a Global Catalog server and is skipped when the provider is
+// not yet configured.
+func validateSPNUniqueness(ctx context.Context, client ldapclient.Client, baseDN string, attrPath path.Path, spns []string, ownerGUID string, diags *diag.Diagnostics) {
+	if client == nil || len(spns) == 0 {
+		return
+	}
+
+	duplicates, err := ldapclient.NewSPNManager(ctx, client, baseDN).FindDuplicateSPNs(spns, ownerGUID)
+	if err != nil {
+		diags.AddAttributeWarning(
+			attrPath,
+			"Could Not Verify Service Principal Names",
+			fmt.Sprintf("The forest could not be searched for duplicate service principal names: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, spn := range spns {
+		owners, ok := duplicates[spn]
+		if !ok {
+			continue
+		}
+		ownerDNs := make([]string, len(owners))
+		for i, owner := range owners {
+			ownerDNs[i] = owner.DistinguishedName
+		}
+		diags.AddAttributeError(
+			attrPath,
+			"Duplicate Service Principal Name",
+			fmt.Sprintf("The SPN %q is already registered to %s. Kerberos requires each SPN to be registered to a "+
+				"single account in the forest; remove it from the other account first.", spn, strings.Join(ownerDNs, "; ")),
+		)
+	}
+}
+
+// servicePrincipalNamesToModel converts the SPNs read from the directory to
+// service_principal_names. A null prior value stays null, as SPNs are only
+// managed when configured. The prior value is kept when it names the same
+// SPNs, ignoring case.
+func servicePrincipalNamesToModel(prior types.Set, spns []string) types.Set {
+	if prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	if !prior.IsUnknown() && ldapclient.SPNsEqual(helpers.GetStringSet(prior), spns) {
+		return prior
+	}
+
+	elems := make([]attr.Value, len(spns))
+	for i, spn := range spns {
+		elems[i] = types.StringValue(spn)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
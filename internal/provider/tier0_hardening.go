@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+)
+
+// tier0Hardening modes accepted by the tier0_hardening attribute.
+const (
+	tier0HardeningWarn  = "warn"
+	tier0HardeningError = "error"
+)
+
+// tier0Finding is a hardening gap of a privileged account.
+type tier0Finding struct {
+	path    path.Path
+	summary string
+	detail  string
+}
+
+// tier0HardeningFindings compares a privileged user, whose transitive group
+// memberships are groups, against the tier 0 baseline: not delegable, a
+// member of Protected Users and not allowing RC4. Planned values are used
+// where the configuration decides the outcome and the directory otherwise;
+// unknown planned values are not reported. user is nil for a user still to
+// be created, whose Protected Users membership cannot be known yet.
+func tier0HardeningFindings(plan UserResourceModel, user *ldapclient.User, groups []ldapclient.GroupRef) []tier0Finding {
+	var findings []tier0Finding
+
+	if !plan.AccountNotDelegated.IsUnknown() && !plan.AccountNotDelegated.ValueBool() {
+		findings = append(findings, tier0Finding{
+			path:    path.Root("account_not_delegated"),
+			summary: "Privileged Account Can Be Delegated",
+			detail: "account_not_delegated is false, so any service trusted for delegation can impersonate this account. " +
+				"Set account_not_delegated to true.",
+		})
+	}
+
+	if user != nil && !slices.ContainsFunc(groups, func(g ldapclient.GroupRef) bool { return ldapclient.IsProtectedUsersSID(g.SID) }) {
+		findings = append(findings, tier0Finding{
+			path:    path.Root("tier0_hardening"),
+			summary: "Privileged Account Outside Protected Users",
+			detail: "The user is not a member of Protected Users, so its credentials may be cached and it may " +
+				"authenticate with NTLM, DES or RC4. Add the user to Protected Users, for example with ad_group_membership.",
+		})
+	}
+
+	allowsRC4 := false
+	switch {
+	case plan.KerberosEncryptionTypes.IsUnknown():
+	case plan.KerberosEncryptionTypes.IsNull():
+		// An account without any of the named types, such as a new one, uses
+		// the domain default, which includes RC4.
+		var supported int32
+		if user != nil {
+			supported = user.SupportedEncryptionTypes
+		}
+		allowsRC4 = supported&(ldapclient.EncTypeRC4HMAC|ldapclient.EncTypeAES128|ldapclient.EncTypeAES256) == 0 ||
+			supported&ldapclient.EncTypeRC4HMAC != 0
+	default:
+		allowsRC4 = slices.Contains(helpers.GetStringSet(plan.KerberosEncryptionTypes), "rc4")
+	}
+	if allowsRC4 {
+		findings = append(findings, tier0Finding{
+			path:    path.Root("kerberos_encryption_types"),
+			summary: "Privileged Account Allows RC4",
+			detail: "The user's Kerberos encryption types include RC4, which exposes service tickets to offline cracking. " +
+				"Set kerberos_encryption_types to [\"aes128\", \"aes256\"].",
+		})
+	}
+
+	return findings
+}
+
+// validateTier0Hardening reports hardening gaps of a user that is, directly
+// or through nested groups, a member of a privileged group, as warnings or
+// errors according to mode. The memberships of an existing user are read
+// from the directory; the planned primary group, resolved to a DN with
+// resolvePrimaryGroup, and the groups it is nested in are added, so a user
+// being created is checked against its primary group alone. The check is
+// skipped when the provider is not yet configured.
+func validateTier0Hardening(ctx context.Context, um *ldapclient.UserManager, plan UserResourceModel, resolvePrimaryGroup func(string) (string, error), diags *diag.Diagnostics) {
+	mode := plan.Tier0Hardening.ValueString()
+	if um == nil || (mode != tier0HardeningWarn && mode != tier0HardeningError) {
+		return
+	}
+
+	var user *ldapclient.User
+	var groups []ldapclient.GroupRef
+	var err error
+	if id := plan.ID.ValueString(); id != "" {
+		user, err = um.GetUserByGUID(id)
+		if err == nil {
+			groups, err = um.GetTransitiveGroups(user)
+		}
+	}
+	if err == nil && !plan.PrimaryGroup.IsNull() && !plan.PrimaryGroup.IsUnknown() {
+		var groupDN string
+		var primary []ldapclient.GroupRef
+		groupDN, err = resolvePrimaryGroup(plan.PrimaryGroup.ValueString())
+		if err == nil {
+			primary, err = um.GetGroupAndParents(groupDN)
+		}
+		groups = append(groups, primary...)
+	}
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("tier0_hardening"),
+			"Could Not Verify Tier 0 Hardening",
+			fmt.Sprintf("The group memberships of the user could not be read: %s", err.Error()),
+		)
+		return
+	}
+
+	addTier0HardeningDiagnostics(mode, user, groups, plan, diags)
+}
+
+// addTier0HardeningDiagnostics adds a diagnostic per hardening gap when the
+// user is a member of a privileged group.
+func addTier0HardeningDiagnostics(mode string, user *ldapclient.User, groups []ldapclient.GroupRef, plan UserResourceModel, diags *diag.Diagnostics) {
+	var privileged []string
+	for _, g := range groups {
+		if ldapclient.IsPrivilegedGroupSID(g.SID) {
+			privileged = append(privileged, cmp.Or(g.DistinguishedName, g.SID))
+		}
+	}
+	if len(privileged) == 0 {
+		return
+	}
+
+	membership := fmt.Sprintf("The user is a member of the privileged groups %s. ", strings.Join(privileged, "; "))
+	for _, f := range tier0HardeningFindings(plan, user, groups) {
+		if mode == tier0HardeningError {
+			diags.AddAttributeError(f.path, f.summary, membership+f.detail)
+		} else {
+			diags.AddAttributeWarning(f.path, f.summary, membership+f.detail)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+var (
+	testDomainAdmins   = ldapclient.GroupRef{DistinguishedName: "CN=Domain Admins,CN=Users,DC=example,DC=com", SID: "S-1-5-21-1-2-3-512"}
+	testDomainUsers    = ldapclient.GroupRef{DistinguishedName: "CN=Domain Users,CN=Users,DC=example,DC=com", SID: "S-1-5-21-1-2-3-513"}
+	testProtectedUsers = ldapclient.GroupRef{DistinguishedName: "CN=Protected Users,CN=Users,DC=example,DC=com", SID: "S-1-5-21-1-2-3-525"}
+)
+
+func TestAddTier0HardeningDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	aesOnly := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("aes128"), types.StringValue("aes256")})
+
+	hardened := newUserModelForUpdateDiff()
+	hardened.AccountNotDelegated = types.BoolValue(true)
+	hardened.KerberosEncryptionTypes = aesOnly
+
+	tests := map[string]struct {
+		mode      string
+		plan      UserResourceModel
+		user      *ldapclient.User
+		groups    []ldapclient.GroupRef
+		wantPaths []path.Path
+		wantError bool
+	}{
+		"unprivileged user is not checked": {
+			mode:   tier0HardeningError,
+			plan:   newUserModelForUpdateDiff(),
+			user:   &ldapclient.User{},
+			groups: []ldapclient.GroupRef{testDomainUsers},
+		},
+		"hardened privileged user": {
+			mode:   tier0HardeningError,
+			plan:   hardened,
+			user:   &ldapclient.User{},
+			groups: []ldapclient.GroupRef{testDomainAdmins, testProtectedUsers, testDomainUsers},
+		},
+		"unhardened privileged user warns": {
+			mode:   tier0HardeningWarn,
+			plan:   newUserModelForUpdateDiff(),
+			user:   &ldapclient.User{},
+			groups: []ldapclient.GroupRef{testDomainAdmins},
+			wantPaths: []path.Path{
+				path.Root("account_not_delegated"),
+				path.Root("tier0_hardening"),
+				path.Root("kerberos_encryption_types"),
+			},
+		},
+		"unmanaged encryption types are read from the directory": {
+			mode: tier0HardeningError,
+			plan: func() UserResourceModel {
+				m := hardened
+				m.KerberosEncryptionTypes = types.SetNull(types.StringType)
+				return m
+			}(),
+			user:      &ldapclient.User{SupportedEncryptionTypes: ldapclient.EncTypeRC4HMAC | ldapclient.EncTypeAES256},
+			groups:    []ldapclient.GroupRef{{SID: "S-1-5-32-544"}, testProtectedUsers},
+			wantPaths: []path.Path{path.Root("kerberos_encryption_types")},
+			wantError: true,
+		},
+		"unmanaged AES-only encryption types pass": {
+			mode: tier0HardeningError,
+			plan: func() UserResourceModel {
+				m := hardened
+				m.KerberosEncryptionTypes = types.SetNull(types.StringType)
+				return m
+			}(),
+			user:   &ldapclient.User{SupportedEncryptionTypes: ldapclient.EncTypeAES128 | ldapclient.EncTypeAES256},
+			groups: []ldapclient.GroupRef{testDomainAdmins, testProtectedUsers},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var diags diag.Diagnostics
+			addTier0HardeningDiagnostics(tc.mode, tc.user, tc.groups, tc.plan, &diags)
+
+			var paths []path.Path
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					paths = append(paths, withPath.Path())
+				}
+			}
+			assert.Equal(t, tc.wantPaths, paths)
+			assert.Equal(t, tc.wantError, diags.HasError())
+			if len(diags) > 0 {
+				assert.Contains(t, diags[0].Detail(), "privileged groups")
+			}
+		})
+	}
+}
+
+// tier0GroupsClient answers group searches with fixed groups. Any other
+// search, such as a user lookup, fails.
+type tier0GroupsClient struct {
+	stubMembershipClient
+	groups  []ldapclient.GroupRef
+	filters []string
+}
+
+func (c *tier0GroupsClient) SearchWithPaging(ctx context.Context, req *ldapclient.SearchRequest) (*ldapclient.SearchResult, error) {
+	c.filters = append(c.filters, req.Filter)
+	result := &ldapclient.SearchResult{}
+	for _, g := range c.groups {
+		sid, err := ldapclient.NewSIDHandler().StringToSIDBytes(g.SID)
+		if err != nil {
+			return nil, err
+		}
+		result.Entries = append(result.Entries, &ldap.Entry{
+			DN:         g.DistinguishedName,
+			Attributes: []*ldap.EntryAttribute{{Name: "objectSid", ByteValues: [][]byte{sid}}},
+		})
+	}
+	return result, nil
+}
+
+func TestValidateTier0Hardening_PlannedPrimaryGroup(t *testing.T) {
+	t.Parallel()
+
+	tier0Admins := ldapclient.GroupRef{DistinguishedName: "CN=Tier0 Admins,OU=Groups,DC=example,DC=com", SID: "S-1-5-21-1-2-3-1105"}
+	resolve := func(identifier string) (string, error) {
+		if identifier != "Tier0 Admins" {
+			return "", errors.New("group not found")
+		}
+		return tier0Admins.DistinguishedName, nil
+	}
+
+	// A user being created, with no ID yet, whose primary group is nested in
+	// Domain Admins.
+	plan := newUserModelForUpdateDiff()
+	plan.Tier0Hardening = types.StringValue(tier0HardeningError)
+	plan.PrimaryGroup = types.StringValue("Tier0 Admins")
+
+	t.Run("privileged primary group on create", func(t *testing.T) {
+		t.Parallel()
+
+		client := &tier0GroupsClient{groups: []ldapclient.GroupRef{tier0Admins, testDomainAdmins}}
+		um := ldapclient.NewUserManager(t.Context(), client, "DC=example,DC=com", nil)
+
+		var diags diag.Diagnostics
+		validateTier0Hardening(t.Context(), um, plan, resolve, &diags)
+
+		require.Len(t, client.filters, 1, "only the primary group is looked up")
+		assert.Contains(t, client.filters[0], "(distinguishedName="+tier0Admins.DistinguishedName+")")
+
+		var paths []path.Path
+		for _, d := range diags {
+			paths = append(paths, d.(diag.DiagnosticWithPath).Path())
+		}
+		// Protected Users membership is not known before the user exists
+		assert.Equal(t, []path.Path{path.Root("account_not_delegated"), path.Root("kerberos_encryption_types")}, paths)
+		assert.True(t, diags.HasError())
+	})
+
+	t.Run("unprivileged primary group", func(t *testing.T) {
+		t.Parallel()
+
+		client := &tier0GroupsClient{groups: []ldapclient.GroupRef{testDomainUsers}}
+		um := ldapclient.NewUserManager(t.Context(), client, "DC=example,DC=com", nil)
+
+		var diags diag.Diagnostics
+		validateTier0Hardening(t.Context(), um, plan, resolve, &diags)
+		assert.Empty(t, diags)
+	})
+
+	t.Run("unresolvable primary group warns", func(t *testing.T) {
+		t.Parallel()
+
+		unknown := plan
+		unknown.PrimaryGroup = types.StringValue("Missing")
+		um := ldapclient.NewUserManager(t.Context(), &tier0GroupsClient{}, "DC=example,DC=com", nil)
+
+		var diags diag.Diagnostics
+		validateTier0Hardening(t.Context(), um, unknown, resolve, &diags)
+		require.Len(t, diags, 1)
+		assert.Equal(t, "Could Not Verify Tier 0 Hardening", diags[0].Summary())
+		assert.False(t, diags.HasError())
+	})
+}
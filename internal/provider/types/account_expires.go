@@ -0,0 +1,144 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = AccountExpiresType{}
+	_ basetypes.StringValuable                   = AccountExpiresValue{}
+	_ basetypes.StringValuableWithSemanticEquals = AccountExpiresValue{}
+)
+
+// AccountExpiresType is a custom string type for account expiration times
+// (an RFC3339 timestamp or "never") that treats the value Active Directory
+// stores as equal to the configured one when they describe the same
+// expiration. This prevents drift from time zone normalization and from the
+// end-of-day rounding applied by Active Directory Users and Computers.
+type AccountExpiresType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t AccountExpiresType) String() string {
+	return "AccountExpiresType"
+}
+
+// ValueType returns the Value type.
+func (t AccountExpiresType) ValueType(ctx context.Context) attr.Value {
+	return AccountExpiresValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t AccountExpiresType) Equal(o attr.Type) bool {
+	other, ok := o.(AccountExpiresType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t AccountExpiresType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	value := AccountExpiresValue{
+		StringValue: in,
+	}
+
+	return value, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t AccountExpiresType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("expected basetypes.StringValue, got: %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not create AccountExpiresValue: %v", diags.Errors())
+	}
+
+	return stringValuable, nil
+}
+
+// AccountExpiresValue is an account expiration value with semantic equality
+// between configured and stored expirations.
+type AccountExpiresValue struct {
+	basetypes.StringValue
+}
+
+// Equal returns true if the given value is equivalent.
+func (v AccountExpiresValue) Equal(o attr.Value) bool {
+	other, ok := o.(AccountExpiresValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Type returns the type of the value.
+func (v AccountExpiresValue) Type(ctx context.Context) attr.Type {
+	return AccountExpiresType{}
+}
+
+// StringSemanticEquals reports whether the new value, as read back from
+// Active Directory, describes the same expiration as the prior value.
+func (v AccountExpiresValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(AccountExpiresValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while attempting to perform semantic equality checks. "+
+				"This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+				fmt.Sprintf("Expected AccountExpiresValue, but got: %T", newValuable),
+		)
+		return false, diags
+	}
+
+	// If either value is null or unknown, they can only be equal if both are the same state
+	if v.IsNull() || v.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return v.Equal(newValue), diags
+	}
+
+	return helpers.AccountExpiresEquivalent(v.ValueString(), newValue.ValueString()), diags
+}
+
+// AccountExpires is a helper function to create an AccountExpiresValue.
+func AccountExpires(value string) AccountExpiresValue {
+	return AccountExpiresValue{
+		StringValue: basetypes.NewStringValue(value),
+	}
+}
+
+// AccountExpiresNull is a helper function to create a null AccountExpiresValue.
+func AccountExpiresNull() AccountExpiresValue {
+	return AccountExpiresValue{
+		StringValue: basetypes.NewStringNull(),
+	}
+}
+
+// AccountExpiresUnknown is a helper function to create an unknown AccountExpiresValue.
+func AccountExpiresUnknown() AccountExpiresValue {
+	return AccountExpiresValue{
+		StringValue: basetypes.NewStringUnknown(),
+	}
+}
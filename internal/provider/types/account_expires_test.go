@@ -0,0 +1,88 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountExpiresType_ValueFromTerraform round-trips a string through the framework value layer.
+func TestAccountExpiresType_ValueFromTerraform(t *testing.T) {
+	t.Parallel()
+
+	got, err := AccountExpiresType{}.ValueFromTerraform(context.Background(), tftypes.NewValue(tftypes.String, "never"))
+	require.NoError(t, err)
+
+	v, ok := got.(AccountExpiresValue)
+	require.True(t, ok, "expected AccountExpiresValue, got %T", got)
+	assert.Equal(t, "never", v.ValueString())
+	assert.True(t, AccountExpiresType{}.Equal(v.Type(context.Background())))
+	assert.False(t, AccountExpiresType{}.Equal(basetypes.StringType{}))
+}
+
+// TestAccountExpiresValue_StringSemanticEquals exercises the drift-prevention contract.
+func TestAccountExpiresValue_StringSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		old           AccountExpiresValue
+		new           basetypes.StringValuable
+		expectedEqual bool
+		expectErrDiag bool
+	}{
+		"identical": {
+			old:           AccountExpires("never"),
+			new:           AccountExpires("never"),
+			expectedEqual: true,
+		},
+		"time_zone_normalized": {
+			old:           AccountExpires("2027-03-01T01:00:00+01:00"),
+			new:           AccountExpires("2027-03-01T00:00:00Z"),
+			expectedEqual: true,
+		},
+		"end_of_day_rounding": {
+			old:           AccountExpires("2027-02-28T00:00:00Z"),
+			new:           AccountExpires("2027-03-01T00:00:00Z"),
+			expectedEqual: true,
+		},
+		"time_of_day_before_end_of_day": {
+			old:           AccountExpires("2027-02-28T12:00:00Z"),
+			new:           AccountExpires("2027-03-01T00:00:00Z"),
+			expectedEqual: false,
+		},
+		"changed_expiration": {
+			old:           AccountExpires("2027-02-28T00:00:00Z"),
+			new:           AccountExpires("never"),
+			expectedEqual: false,
+		},
+		"null_and_value": {
+			old:           AccountExpiresNull(),
+			new:           AccountExpires("never"),
+			expectedEqual: false,
+		},
+		"unknown_and_unknown": {
+			old:           AccountExpiresUnknown(),
+			new:           AccountExpiresUnknown(),
+			expectedEqual: true,
+		},
+		"wrong_type": {
+			old:           AccountExpires("never"),
+			new:           basetypes.NewStringValue("never"),
+			expectErrDiag: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			equal, diags := tc.old.StringSemanticEquals(context.Background(), tc.new)
+			assert.Equal(t, tc.expectErrDiag, diags.HasError(), "diagnostics: %s", diags)
+			assert.Equal(t, tc.expectedEqual, equal)
+		})
+	}
+}
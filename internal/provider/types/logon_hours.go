@@ -0,0 +1,258 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.ObjectTypable                    = LogonHoursType{}
+	_ basetypes.ObjectValuable                   = LogonHoursValue{}
+	_ basetypes.ObjectValuableWithSemanticEquals = LogonHoursValue{}
+)
+
+// logonHoursDays maps the day attributes of a logon_hours object to weekdays.
+var logonHoursDays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// LogonHoursAttrTypes returns the attribute types of a logon_hours object:
+// a utc_offset string and a list of "HH-HH" hour ranges for each day.
+func LogonHoursAttrTypes() map[string]attr.Type {
+	attrTypes := map[string]attr.Type{
+		"utc_offset": basetypes.StringType{},
+	}
+	for name := range logonHoursDays {
+		attrTypes[name] = basetypes.ListType{ElemType: basetypes.StringType{}}
+	}
+	return attrTypes
+}
+
+// LogonHoursType is a custom object type for weekly logon schedules that
+// compares schedules by the logonHours bitmap they encode, so that ranges
+// split or ordered differently, or expressed in a different UTC offset,
+// are semantically equal.
+type LogonHoursType struct {
+	basetypes.ObjectType
+}
+
+// NewLogonHoursType creates a new LogonHoursType with its attribute types initialized.
+func NewLogonHoursType() LogonHoursType {
+	return LogonHoursType{
+		ObjectType: basetypes.ObjectType{
+			AttrTypes: LogonHoursAttrTypes(),
+		},
+	}
+}
+
+// String returns a human readable string of the type name.
+func (t LogonHoursType) String() string {
+	return "LogonHoursType"
+}
+
+// ValueType returns the Value type.
+func (t LogonHoursType) ValueType(ctx context.Context) attr.Value {
+	return LogonHoursValue{
+		ObjectValue: basetypes.NewObjectNull(LogonHoursAttrTypes()),
+	}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t LogonHoursType) Equal(o attr.Type) bool {
+	other, ok := o.(LogonHoursType)
+	if !ok {
+		return false
+	}
+
+	return t.ObjectType.Equal(other.ObjectType)
+}
+
+// ValueFromObject returns an ObjectValuable type given an ObjectValue.
+func (t LogonHoursType) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	value := LogonHoursValue{
+		ObjectValue: in,
+	}
+
+	return value, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t LogonHoursType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.ObjectType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	objectValue, ok := attrValue.(basetypes.ObjectValue)
+	if !ok {
+		return nil, fmt.Errorf("expected basetypes.ObjectValue, got: %T", attrValue)
+	}
+
+	objectValuable, diags := t.ValueFromObject(ctx, objectValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not create LogonHoursValue: %v", diags.Errors())
+	}
+
+	return objectValuable, nil
+}
+
+// LogonHoursValue is a weekly logon schedule with semantic equality by bitmap.
+type LogonHoursValue struct {
+	basetypes.ObjectValue
+}
+
+// Equal returns true if the given value is equivalent.
+func (v LogonHoursValue) Equal(o attr.Value) bool {
+	other, ok := o.(LogonHoursValue)
+	if !ok {
+		return false
+	}
+
+	return v.ObjectValue.Equal(other.ObjectValue)
+}
+
+// Type returns the type of the value.
+func (v LogonHoursValue) Type(ctx context.Context) attr.Type {
+	return NewLogonHoursType()
+}
+
+// ObjectSemanticEquals reports whether both schedules encode the same
+// logonHours bitmap.
+func (v LogonHoursValue) ObjectSemanticEquals(ctx context.Context, newValuable basetypes.ObjectValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(LogonHoursValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while attempting to perform semantic equality checks. "+
+				"This is always an error in the provider. Please report the following to the provider developer:\n\n"+
+				fmt.Sprintf("Expected LogonHoursValue, but got: %T", newValuable),
+		)
+		return false, diags
+	}
+
+	// If either value is null or unknown, they can only be equal if both are the same state
+	if v.IsNull() || v.IsUnknown() || newValue.IsNull() || newValue.IsUnknown() {
+		return v.Equal(newValue), diags
+	}
+
+	oldHours, err1 := v.LogonHours()
+	newHours, err2 := newValue.LogonHours()
+	if err1 != nil || err2 != nil {
+		return v.Equal(newValue), diags
+	}
+
+	return *oldHours == *newHours, diags
+}
+
+// LogonHours encodes the schedule into the UTC logonHours bitmap. A null
+// utc_offset means the hours are in UTC.
+func (v LogonHoursValue) LogonHours() (*ldapclient.LogonHours, error) {
+	if v.IsNull() || v.IsUnknown() {
+		return nil, fmt.Errorf("logon hours are null or unknown")
+	}
+	attrs := v.Attributes()
+
+	var offset time.Duration
+	if utcOffset, ok := attrs["utc_offset"].(basetypes.StringValue); ok && !utcOffset.IsNull() {
+		if utcOffset.IsUnknown() {
+			return nil, fmt.Errorf("utc_offset is unknown")
+		}
+		var err error
+		if offset, err = ldapclient.ParseUTCOffset(utcOffset.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule := ldapclient.LogonSchedule{}
+	for name, day := range logonHoursDays {
+		hours, ok := attrs[name].(basetypes.ListValue)
+		if !ok || hours.IsNull() {
+			continue
+		}
+		if hours.IsUnknown() {
+			return nil, fmt.Errorf("%s is unknown", name)
+		}
+		for _, element := range hours.Elements() {
+			hourRange, ok := element.(basetypes.StringValue)
+			if !ok || hourRange.IsUnknown() {
+				return nil, fmt.Errorf("%s contains an unknown hour range", name)
+			}
+			r, err := ldapclient.ParseHourRange(hourRange.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			schedule[day] = append(schedule[day], r)
+		}
+	}
+
+	return ldapclient.NewLogonHours(schedule, offset)
+}
+
+// LogonHoursFromLDAP converts a logonHours bitmap to a schedule in UTC with a
+// null utc_offset. A nil bitmap, or one allowing every hour, is null.
+func LogonHoursFromLDAP(h *ldapclient.LogonHours) (LogonHoursValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if h == nil || h.AllowsAll() {
+		return LogonHoursNull(), diags
+	}
+
+	schedule, err := h.Schedule(0)
+	if err != nil {
+		diags.AddError("Logon Hours Conversion Error", err.Error())
+		return LogonHoursNull(), diags
+	}
+
+	attrs := map[string]attr.Value{
+		"utc_offset": basetypes.NewStringNull(),
+	}
+	for name, day := range logonHoursDays {
+		ranges, ok := schedule[day]
+		if !ok {
+			attrs[name] = basetypes.NewListNull(basetypes.StringType{})
+			continue
+		}
+		elements := make([]attr.Value, len(ranges))
+		for i, r := range ranges {
+			elements[i] = basetypes.NewStringValue(r.String())
+		}
+		list, listDiags := basetypes.NewListValue(basetypes.StringType{}, elements)
+		diags.Append(listDiags...)
+		attrs[name] = list
+	}
+	if diags.HasError() {
+		return LogonHoursNull(), diags
+	}
+
+	object, objectDiags := basetypes.NewObjectValue(LogonHoursAttrTypes(), attrs)
+	diags.Append(objectDiags...)
+	if diags.HasError() {
+		return LogonHoursNull(), diags
+	}
+
+	return LogonHoursValue{ObjectValue: object}, diags
+}
+
+// LogonHoursNull is a helper function to create a null LogonHoursValue.
+func LogonHoursNull() LogonHoursValue {
+	return LogonHoursValue{
+		ObjectValue: basetypes.NewObjectNull(LogonHoursAttrTypes()),
+	}
+}
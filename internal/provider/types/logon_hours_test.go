@@ -0,0 +1,145 @@
+package types
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+// logonHours builds a LogonHoursValue from a UTC offset ("" for null) and
+// the hour ranges of each configured day.
+func logonHours(offset string, days map[string][]string) LogonHoursValue {
+	attrs := map[string]attr.Value{"utc_offset": basetypes.NewStringNull()}
+	if offset != "" {
+		attrs["utc_offset"] = basetypes.NewStringValue(offset)
+	}
+	for name := range logonHoursDays {
+		ranges, ok := days[name]
+		if !ok {
+			attrs[name] = basetypes.NewListNull(basetypes.StringType{})
+			continue
+		}
+		elements := make([]attr.Value, len(ranges))
+		for i, r := range ranges {
+			elements[i] = basetypes.NewStringValue(r)
+		}
+		attrs[name] = basetypes.NewListValueMust(basetypes.StringType{}, elements)
+	}
+	return LogonHoursValue{ObjectValue: basetypes.NewObjectValueMust(LogonHoursAttrTypes(), attrs)}
+}
+
+// TestLogonHoursType_ValueFromTerraform verifies null round-trips through the framework value layer.
+func TestLogonHoursType_ValueFromTerraform(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	got, err := NewLogonHoursType().ValueFromTerraform(ctx, tftypes.NewValue(NewLogonHoursType().TerraformType(ctx), nil))
+	require.NoError(t, err)
+
+	v, ok := got.(LogonHoursValue)
+	require.True(t, ok, "expected LogonHoursValue, got %T", got)
+	assert.True(t, v.IsNull())
+	assert.True(t, NewLogonHoursType().Equal(v.Type(ctx)))
+}
+
+// TestLogonHoursValue_LogonHours verifies encoding honours utc_offset.
+func TestLogonHoursValue_LogonHours(t *testing.T) {
+	t.Parallel()
+
+	h, err := logonHours("+02:00", map[string][]string{"monday": {"08-17"}}).LogonHours()
+	require.NoError(t, err)
+
+	schedule, err := h.Schedule(0)
+	require.NoError(t, err)
+	assert.Equal(t, ldapclient.LogonSchedule{time.Monday: {{Start: 6, End: 15}}}, schedule)
+
+	_, err = logonHours("", map[string][]string{"monday": {"17-08"}}).LogonHours()
+	assert.ErrorContains(t, err, "monday")
+
+	_, err = LogonHoursNull().LogonHours()
+	assert.Error(t, err)
+}
+
+// TestLogonHoursFromLDAP verifies decoding to a UTC schedule.
+func TestLogonHoursFromLDAP(t *testing.T) {
+	t.Parallel()
+
+	h, err := ldapclient.NewLogonHours(ldapclient.LogonSchedule{time.Monday: {{Start: 8, End: 17}}, time.Friday: {{Start: 8, End: 12}}}, 0)
+	require.NoError(t, err)
+
+	v, diags := LogonHoursFromLDAP(h)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %s", diags)
+	assert.True(t, v.Equal(logonHours("", map[string][]string{"monday": {"08-17"}, "friday": {"08-12"}})))
+
+	for name, h := range map[string]*ldapclient.LogonHours{"nil": nil, "unrestricted": ldapclient.UnrestrictedLogonHours()} {
+		v, diags := LogonHoursFromLDAP(h)
+		require.False(t, diags.HasError(), name)
+		assert.True(t, v.IsNull(), name)
+	}
+}
+
+// TestLogonHoursValue_ObjectSemanticEquals exercises the drift-prevention contract.
+func TestLogonHoursValue_ObjectSemanticEquals(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		old           LogonHoursValue
+		new           basetypes.ObjectValuable
+		expectedEqual bool
+		expectErrDiag bool
+	}{
+		"identical": {
+			old:           logonHours("", map[string][]string{"monday": {"08-17"}}),
+			new:           logonHours("", map[string][]string{"monday": {"08-17"}}),
+			expectedEqual: true,
+		},
+		"split_and_reordered_ranges": {
+			old:           logonHours("", map[string][]string{"monday": {"12-17", "08-12"}}),
+			new:           logonHours("", map[string][]string{"monday": {"08-17"}}),
+			expectedEqual: true,
+		},
+		"local_time_read_back_as_utc": {
+			old:           logonHours("+01:00", map[string][]string{"monday": {"08-17"}}),
+			new:           logonHours("", map[string][]string{"monday": {"07-16"}}),
+			expectedEqual: true,
+		},
+		"empty_day_list_and_null_day": {
+			old:           logonHours("", map[string][]string{"monday": {"08-17"}, "sunday": {}}),
+			new:           logonHours("", map[string][]string{"monday": {"08-17"}}),
+			expectedEqual: true,
+		},
+		"different_hours": {
+			old:           logonHours("", map[string][]string{"monday": {"08-17"}}),
+			new:           logonHours("", map[string][]string{"monday": {"08-18"}}),
+			expectedEqual: false,
+		},
+		"null_and_value": {
+			old:           LogonHoursNull(),
+			new:           logonHours("", map[string][]string{}),
+			expectedEqual: false,
+		},
+		"wrong_type": {
+			old:           LogonHoursNull(),
+			new:           basetypes.NewObjectNull(LogonHoursAttrTypes()),
+			expectErrDiag: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			equal, diags := tc.old.ObjectSemanticEquals(context.Background(), tc.new)
+			assert.Equal(t, tc.expectErrDiag, diags.HasError(), "diagnostics: %s", diags)
+			assert.Equal(t, tc.expectedEqual, equal)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/helpers"
+)
+
+// Ensure the implementation satisfies the expected interface.
+var _ validator.String = accountExpiresValidator{}
+
+// accountExpiresValidator validates that a string is an RFC3339 timestamp or "never".
+type accountExpiresValidator struct{}
+
+// Description describes the validation in plain text.
+func (v accountExpiresValidator) Description(_ context.Context) string {
+	return "value must be an RFC3339 timestamp or \"never\""
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v accountExpiresValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be an RFC3339 timestamp or `never`"
+}
+
+// ValidateString performs the validation.
+func (v accountExpiresValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	// Skip validation for unknown or null values
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if _, err := helpers.ParseAccountExpires(value); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Account Expiration",
+			fmt.Sprintf("The value %q is not a valid account expiration: %s", value, err.Error()),
+		)
+	}
+}
+
+// IsValidAccountExpires returns a validator which ensures that any configured
+// attribute value is an RFC3339 timestamp or "never".
+//
+// Unknown values and null values are skipped from validation.
+func IsValidAccountExpires() validator.String {
+	return accountExpiresValidator{}
+}
@@ -0,0 +1,51 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+)
+
+func TestAccountExpiresValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		val         types.String
+		expectError bool
+	}{
+		"never":                 {val: types.StringValue("never")},
+		"utc timestamp":         {val: types.StringValue("2027-03-01T00:00:00Z")},
+		"offset timestamp":      {val: types.StringValue("2027-02-28T17:00:00+01:00")},
+		"date only":             {val: types.StringValue("2027-03-01"), expectError: true},
+		"capitalised never":     {val: types.StringValue("Never"), expectError: true},
+		"before the Unix epoch": {val: types.StringValue("1900-01-01T00:00:00Z"), expectError: true},
+		"empty":                 {val: types.StringValue(""), expectError: true},
+		"null value":            {val: types.StringNull()},
+		"unknown value":         {val: types.StringUnknown()},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := validator.StringRequest{
+				Path:        path.Root("account_expires"),
+				ConfigValue: test.val,
+			}
+			response := validator.StringResponse{}
+
+			validators.IsValidAccountExpires().ValidateString(t.Context(), request, &response)
+
+			if response.Diagnostics.HasError() != test.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %s", test.expectError, response.Diagnostics)
+			}
+			if test.expectError && response.Diagnostics[0].Summary() != "Invalid Account Expiration" {
+				t.Errorf("unexpected summary %q", response.Diagnostics[0].Summary())
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+// Ensure the implementations satisfy the expected interface.
+var (
+	_ validator.String = hourRangeValidator{}
+	_ validator.String = utcOffsetValidator{}
+)
+
+// hourRangeValidator validates that a string is an "HH-HH" range of whole hours.
+type hourRangeValidator struct{}
+
+// Description describes the validation in plain text.
+func (v hourRangeValidator) Description(_ context.Context) string {
+	return "value must be an hour range in HH-HH format, such as 08-17"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v hourRangeValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be an hour range in `HH-HH` format, such as `08-17`"
+}
+
+// ValidateString performs the validation.
+func (v hourRangeValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	// Skip validation for unknown or null values
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ldapclient.ParseHourRange(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Hour Range",
+			fmt.Sprintf("%s. The end hour is exclusive, so 08-17 allows logon from 08:00 until 17:00.", err.Error()),
+		)
+	}
+}
+
+// IsValidHourRange returns a validator which ensures that any configured
+// attribute value is an "HH-HH" range of whole hours within a day.
+//
+// Unknown values and null values are skipped from validation.
+func IsValidHourRange() validator.String {
+	return hourRangeValidator{}
+}
+
+// utcOffsetValidator validates that a string is a UTC offset of whole hours.
+type utcOffsetValidator struct{}
+
+// Description describes the validation in plain text.
+func (v utcOffsetValidator) Description(_ context.Context) string {
+	return "value must be a UTC offset of whole hours, such as +01:00 or -05:00"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v utcOffsetValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a UTC offset of whole hours, such as `+01:00` or `-05:00`"
+}
+
+// ValidateString performs the validation.
+func (v utcOffsetValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	// Skip validation for unknown or null values
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	offset, err := ldapclient.ParseUTCOffset(value)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid UTC Offset", err.Error()+".")
+		return
+	}
+	if offset%time.Hour != 0 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid UTC Offset",
+			fmt.Sprintf("The UTC offset %q is not a whole number of hours. Logon hours are stored with hourly resolution.", value),
+		)
+	}
+}
+
+// IsValidUTCOffset returns a validator which ensures that any configured
+// attribute value is a UTC offset of a whole number of hours.
+//
+// Unknown values and null values are skipped from validation.
+func IsValidUTCOffset() validator.String {
+	return utcOffsetValidator{}
+}
@@ -0,0 +1,51 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+)
+
+func TestLogonHoursValidators(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		validator   validator.String
+		val         types.String
+		expectError bool
+	}{
+		"hour range":          {validator: validators.IsValidHourRange(), val: types.StringValue("08-17")},
+		"whole day":           {validator: validators.IsValidHourRange(), val: types.StringValue("00-24")},
+		"reversed hour range": {validator: validators.IsValidHourRange(), val: types.StringValue("17-08"), expectError: true},
+		"clock time range":    {validator: validators.IsValidHourRange(), val: types.StringValue("08:00-17:00"), expectError: true},
+		"unknown hour range":  {validator: validators.IsValidHourRange(), val: types.StringUnknown()},
+		"utc":                 {validator: validators.IsValidUTCOffset(), val: types.StringValue("Z")},
+		"positive offset":     {validator: validators.IsValidUTCOffset(), val: types.StringValue("+01:00")},
+		"negative offset":     {validator: validators.IsValidUTCOffset(), val: types.StringValue("-05:00")},
+		"half hour offset":    {validator: validators.IsValidUTCOffset(), val: types.StringValue("+05:30"), expectError: true},
+		"time zone name":      {validator: validators.IsValidUTCOffset(), val: types.StringValue("Europe/London"), expectError: true},
+		"null offset":         {validator: validators.IsValidUTCOffset(), val: types.StringNull()},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: test.val,
+			}
+			response := validator.StringResponse{}
+
+			test.validator.ValidateString(t.Context(), request, &response)
+
+			if response.Diagnostics.HasError() != test.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %s", test.expectError, response.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	ldapclient "github.com/isometry/terraform-provider-ad/internal/ldap"
+)
+
+// Ensure the implementation satisfies the expected interface.
+var _ validator.String = spnValidator{}
+
+// spnValidator validates that a string is a Kerberos service principal name.
+type spnValidator struct{}
+
+// Description describes the validation in plain text.
+func (v spnValidator) Description(_ context.Context) string {
+	return "value must be a service principal name in serviceclass/host[:port][/name] format, such as HTTP/web.example.com"
+}
+
+// MarkdownDescription describes the validation in Markdown.
+func (v spnValidator) MarkdownDescription(_ context.Context) string {
+	return "value must be a service principal name in `serviceclass/host[:port][/name]` format, such as `HTTP/web.example.com`"
+}
+
+// ValidateString performs the validation.
+func (v spnValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	// Skip validation for unknown or null values
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	if err := ldapclient.ValidateSPN(value); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Service Principal Name",
+			fmt.Sprintf("The value %q is not a valid service principal name: %s.", value, err.Error()),
+		)
+	}
+}
+
+// IsValidSPN returns a validator which ensures that any configured attribute
+// value is a service principal name of the form serviceclass/host[:port][/name].
+//
+// Unknown values and null values are skipped from validation.
+func IsValidSPN() validator.String {
+	return spnValidator{}
+}
@@ -0,0 +1,47 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/isometry/terraform-provider-ad/internal/provider/validators"
+)
+
+func TestSPNValidator(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		val         types.String
+		expectError bool
+	}{
+		"host":          {val: types.StringValue("HTTP/web.example.com")},
+		"host and port": {val: types.StringValue("MSSQLSvc/db.example.com:1433")},
+		"service name":  {val: types.StringValue("ldap/dc01.example.com/example.com")},
+		"no host":       {val: types.StringValue("HTTP"), expectError: true},
+		"invalid port":  {val: types.StringValue("MSSQLSvc/db.example.com:sql"), expectError: true},
+		"whitespace":    {val: types.StringValue("HTTP/web example.com"), expectError: true},
+		"unknown":       {val: types.StringUnknown()},
+		"null":          {val: types.StringNull()},
+	}
+
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: test.val,
+			}
+			response := validator.StringResponse{}
+
+			validators.IsValidSPN().ValidateString(t.Context(), request, &response)
+
+			if response.Diagnostics.HasError() != test.expectError {
+				t.Fatalf("expected error %t, got diagnostics: %s", test.expectError, response.Diagnostics)
+			}
+		})
+	}
+}